@@ -18,6 +18,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 )
 
 var _ = Describe("Auth", func() {
@@ -31,9 +32,9 @@ var _ = Describe("Auth", func() {
 		dbConn = postgresRunner.Open()
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener)
-		sqlDB = db.NewSQL(logger, dbConn, bus)
+		sqlDB = db.NewSQL(logger, dbConn, bus, encryption.NoEncryption{})
 
-		_, err := sqlDB.SaveConfig(atc.DefaultPipelineName, atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		_, err := sqlDB.SaveConfig(atc.DefaultTeamName, atc.DefaultPipelineName, atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
 		Ω(err).ShouldNot(HaveOccurred())
 
 		atcBin, err := gexec.Build("github.com/concourse/atc/cmd/atc")
@@ -18,6 +18,7 @@ import (
 	"github.com/cloudfoundry/gunk/urljoiner"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/event"
 )
 
@@ -36,8 +37,8 @@ var _ = Describe("One-off Builds", func() {
 		dbConn = postgresRunner.Open()
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener)
-		sqlDB = db.NewSQL(dbLogger, dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB)
+		sqlDB = db.NewSQL(dbLogger, dbConn, bus, encryption.NoEncryption{})
+		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB, encryption.NoEncryption{})
 
 		atcProcess, atcPort = startATC(atcBin, 1)
 	})
@@ -88,14 +89,14 @@ var _ = Describe("One-off Builds", func() {
 				location := event.OriginLocation{ID: 1, ParentID: 0, ParallelGroup: 0}
 
 				// job build data
-				_, err := sqlDB.SaveConfig(atc.DefaultPipelineName, atc.Config{
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, atc.DefaultPipelineName, atc.Config{
 					Jobs: []atc.JobConfig{
 						{Name: "job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipelineDB, err = pipelineDBFactory.BuildWithName(atc.DefaultPipelineName)
+				pipelineDB, err = pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, atc.DefaultPipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				build, err = pipelineDB.CreateJobBuild("job-name")
@@ -115,7 +116,7 @@ var _ = Describe("One-off Builds", func() {
 				})
 
 				// One off build data
-				oneOffBuild, err = sqlDB.CreateOneOffBuild()
+				oneOffBuild, err = sqlDB.CreateOneOffBuild(atc.DefaultTeamName)
 				Ω(err).ShouldNot(HaveOccurred())
 				_, err = sqlDB.StartBuild(oneOffBuild.ID, "", "")
 				Ω(err).ShouldNot(HaveOccurred())
@@ -20,6 +20,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 )
 
 var _ = Describe("Multiple ATCs", func() {
@@ -42,7 +43,7 @@ var _ = Describe("Multiple ATCs", func() {
 		dbConn = postgresRunner.Open()
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener)
-		sqlDB = db.NewSQL(dbLogger, dbConn, bus)
+		sqlDB = db.NewSQL(dbLogger, dbConn, bus, encryption.NoEncryption{})
 
 		atcOneProcess, atcOnePort = startATC(atcBin, 1)
 		atcTwoProcess, atcTwoPort = startATC(atcBin, 2)
@@ -18,6 +18,7 @@ import (
 	"github.com/cloudfoundry/gunk/urljoiner"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 )
 
 var _ = Describe("Pipeline Pausing", func() {
@@ -38,8 +39,8 @@ var _ = Describe("Pipeline Pausing", func() {
 		dbConn = postgresRunner.Open()
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener)
-		sqlDB = db.NewSQL(dbLogger, dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB)
+		sqlDB = db.NewSQL(dbLogger, dbConn, bus, encryption.NoEncryption{})
+		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB, encryption.NoEncryption{})
 		atcProcess, atcPort = startATC(atcBin, 1)
 	})
 
@@ -76,24 +77,24 @@ var _ = Describe("Pipeline Pausing", func() {
 		Context("with a job in the configuration", func() {
 
 			BeforeEach(func() {
-				_, err := sqlDB.SaveConfig("some-pipeline", atc.Config{
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, "some-pipeline", atc.Config{
 					Jobs: []atc.JobConfig{
 						{Name: "some-job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				_, err = sqlDB.SaveConfig("another-pipeline", atc.Config{
+				_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "another-pipeline", atc.Config{
 					Jobs: []atc.JobConfig{
 						{Name: "another-job-name"},
 					},
 				}, db.ConfigVersion(1), db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipelineDB, err = pipelineDBFactory.BuildWithName("some-pipeline")
+				pipelineDB, err = pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, "some-pipeline")
 				Ω(err).ShouldNot(HaveOccurred())
 
-				otherPipelineDB, err = pipelineDBFactory.BuildWithName("another-pipeline")
+				otherPipelineDB, err = pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, "another-pipeline")
 				Ω(err).ShouldNot(HaveOccurred())
 
 			})
@@ -19,6 +19,7 @@ import (
 	"github.com/cloudfoundry/gunk/urljoiner"
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 )
 
 var _ = Describe("Resource Pausing", func() {
@@ -37,10 +38,10 @@ var _ = Describe("Resource Pausing", func() {
 		dbListener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
 		bus := db.NewNotificationsBus(dbListener)
 
-		sqlDB = db.NewSQL(dbLogger, dbConn, bus)
+		sqlDB = db.NewSQL(dbLogger, dbConn, bus, encryption.NoEncryption{})
 		Ω(err).ShouldNot(HaveOccurred())
 
-		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB)
+		pipelineDBFactory = db.NewPipelineDBFactory(dbLogger, dbConn, bus, sqlDB, encryption.NoEncryption{})
 
 		atcProcess, atcPort = startATC(atcBin, 1)
 	})
@@ -80,7 +81,7 @@ var _ = Describe("Resource Pausing", func() {
 
 			BeforeEach(func() {
 				// job build data
-				_, err := sqlDB.SaveConfig(atc.DefaultPipelineName, atc.Config{
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, atc.DefaultPipelineName, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "job-name",
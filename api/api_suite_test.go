@@ -18,33 +18,47 @@ import (
 	buildfakes "github.com/concourse/atc/api/buildserver/fakes"
 	pipeserverfakes "github.com/concourse/atc/api/pipes/fakes"
 	workerserverfakes "github.com/concourse/atc/api/workerserver/fakes"
+	"github.com/concourse/atc/auth"
 	authfakes "github.com/concourse/atc/auth/fakes"
 	dbfakes "github.com/concourse/atc/db/fakes"
 	enginefakes "github.com/concourse/atc/engine/fakes"
+	pipelinesfakes "github.com/concourse/atc/pipelines/fakes"
 	workerfakes "github.com/concourse/atc/worker/fakes"
 )
 
 var (
 	sink *lager.ReconfigurableSink
 
-	authValidator       *authfakes.FakeValidator
-	fakeEngine          *enginefakes.FakeEngine
-	fakeWorkerClient    *workerfakes.FakeClient
-	buildsDB            *buildfakes.FakeBuildsDB
-	configDB            *dbfakes.FakeConfigDB
-	workerDB            *workerserverfakes.FakeWorkerDB
-	pipeDB              *pipeserverfakes.FakePipeDB
-	pipelineDBFactory   *dbfakes.FakePipelineDBFactory
-	pipelinesDB         *dbfakes.FakePipelinesDB
-	configValidationErr error
-	peerAddr            string
-	drain               chan struct{}
-	cliDownloadsDir     string
+	authValidator        *authfakes.FakeValidator
+	fakeTokenGenerator   *authfakes.FakeTokenGenerator
+	providers            auth.Providers
+	basicAuthEnabled     bool
+	fakeEngine           *enginefakes.FakeEngine
+	fakeWorkerClient     *workerfakes.FakeClient
+	fakeWorkerHealth     *workerfakes.FakeWorkerHealth
+	fakeSchedulerFactory *pipelinesfakes.FakeRadarSchedulerFactory
+	buildsDB             *buildfakes.FakeBuildsDB
+	configDB             *dbfakes.FakeConfigDB
+	workerDB             *workerserverfakes.FakeWorkerDB
+	pipeDB               *pipeserverfakes.FakePipeDB
+	pipelineDBFactory    *dbfakes.FakePipelineDBFactory
+	pipelinesDB          *dbfakes.FakePipelinesDB
+	teamsDB              *dbfakes.FakeTeamsDB
+	configValidationErr  error
+	peerAddr             string
+	externalURL          string
+	drain                chan struct{}
+	cliDownloadsDir      string
+
+	workerRegistrationKey string
 
 	constructedEventHandler *fakeEventHandlerFactory
 
 	server *httptest.Server
 	client *http.Client
+
+	// serves the API behind authentication, as when it is not publicly viewable
+	protectedServer *httptest.Server
 )
 
 type fakeEventHandlerFactory struct {
@@ -79,14 +93,25 @@ var _ = BeforeEach(func() {
 	workerDB = new(workerserverfakes.FakeWorkerDB)
 	pipeDB = new(pipeserverfakes.FakePipeDB)
 	pipelinesDB = new(dbfakes.FakePipelinesDB)
+	teamsDB = new(dbfakes.FakeTeamsDB)
 
 	authValidator = new(authfakes.FakeValidator)
+	authValidator.TeamNameReturns(atc.DefaultTeamName)
+	authValidator.RoleReturns(auth.RoleAdmin)
+	fakeTokenGenerator = new(authfakes.FakeTokenGenerator)
+	providers = auth.Providers{}
+	basicAuthEnabled = true
+	workerRegistrationKey = "some-worker-key"
 	configValidationErr = nil
 	peerAddr = "127.0.0.1:1234"
+	externalURL = "https://example.com"
 	drain = make(chan struct{})
 
 	fakeEngine = new(enginefakes.FakeEngine)
 	fakeWorkerClient = new(workerfakes.FakeClient)
+	fakeWorkerHealth = new(workerfakes.FakeWorkerHealth)
+	fakeWorkerHealth.HealthyReturns(true)
+	fakeSchedulerFactory = new(pipelinesfakes.FakeRadarSchedulerFactory)
 
 	var err error
 
@@ -105,20 +130,29 @@ var _ = BeforeEach(func() {
 		authValidator,
 		pipelineDBFactory,
 
+		providers,
+		basicAuthEnabled,
+		fakeTokenGenerator,
+		workerRegistrationKey,
+
 		configDB,
 
 		buildsDB,
 		workerDB,
 		pipeDB,
 		pipelinesDB,
+		teamsDB,
 
 		func(atc.Config) error { return configValidationErr },
 		peerAddr,
+		externalURL,
 		constructedEventHandler.Construct,
 		drain,
 
 		fakeEngine,
 		fakeWorkerClient,
+		fakeWorkerHealth,
+		fakeSchedulerFactory,
 
 		sink,
 
@@ -128,6 +162,12 @@ var _ = BeforeEach(func() {
 
 	server = httptest.NewServer(handler)
 
+	protectedServer = httptest.NewServer(auth.Handler{
+		Handler:   handler,
+		Validator: authValidator,
+		Exempt:    api.SelfAuthenticatedRoutes(),
+	})
+
 	client = &http.Client{
 		Transport: &http.Transport{},
 	}
@@ -135,6 +175,7 @@ var _ = BeforeEach(func() {
 
 var _ = AfterEach(func() {
 	server.Close()
+	protectedServer.Close()
 })
 
 func TestAPI(t *testing.T) {
@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	authfakes "github.com/concourse/atc/auth/fakes"
+)
+
+var _ = Describe("Auth API", func() {
+	Describe("GET /api/v1/auth/methods", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/auth/methods")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when providers are configured", func() {
+			BeforeEach(func() {
+				fakeProviderB := new(authfakes.FakeProvider)
+				fakeProviderB.DisplayNameReturns("OAuth Provider B")
+
+				fakeProviderA := new(authfakes.FakeProvider)
+				fakeProviderA.DisplayNameReturns("OAuth Provider A")
+
+				providers["b"] = fakeProviderB
+				providers["a"] = fakeProviderA
+			})
+
+			It("returns 200", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+			})
+
+			It("returns the configured providers in order, followed by basic auth", func() {
+				var methods []atc.AuthMethod
+				err := json.NewDecoder(response.Body).Decode(&methods)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(methods).Should(Equal([]atc.AuthMethod{
+					{
+						Type:        atc.AuthTypeOAuth,
+						DisplayName: "OAuth Provider A",
+						AuthURL:     "https://example.com/auth/a",
+					},
+					{
+						Type:        atc.AuthTypeOAuth,
+						DisplayName: "OAuth Provider B",
+						AuthURL:     "https://example.com/auth/b",
+					},
+					{
+						Type:        atc.AuthTypeBasic,
+						DisplayName: "Basic Auth",
+						AuthURL:     "https://example.com/login/basic",
+					},
+				}))
+			})
+		})
+
+		Context("when no providers are configured", func() {
+			It("returns only basic auth", func() {
+				var methods []atc.AuthMethod
+				err := json.NewDecoder(response.Body).Decode(&methods)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(methods).Should(Equal([]atc.AuthMethod{
+					{
+						Type:        atc.AuthTypeBasic,
+						DisplayName: "Basic Auth",
+						AuthURL:     "https://example.com/login/basic",
+					},
+				}))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/auth/token", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/auth/token", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			req.SetBasicAuth("some-user", "some-password")
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				authValidator.TeamNameReturns("some-team")
+				authValidator.RoleReturns(auth.RoleOperator)
+			})
+
+			Context("when generating the token succeeds", func() {
+				BeforeEach(func() {
+					fakeTokenGenerator.GenerateTokenReturns("some-type", "some-value", nil)
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+
+				It("returns the token", func() {
+					var token atc.AuthToken
+					err := json.NewDecoder(response.Body).Decode(&token)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(token).Should(Equal(atc.AuthToken{
+						Type:  "some-type",
+						Value: "some-value",
+					}))
+				})
+
+				It("generates a token for the user that expires after a day", func() {
+					Ω(fakeTokenGenerator.GenerateTokenCallCount()).Should(Equal(1))
+
+					subject, teamName, role, expiration := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Ω(subject).Should(Equal("some-user"))
+					Ω(teamName).Should(Equal("some-team"))
+					Ω(role).Should(Equal(auth.RoleOperator))
+					Ω(expiration).Should(BeTemporally("~", time.Now().Add(auth.CookieAge), time.Minute))
+				})
+			})
+
+			Context("when generating the token fails", func() {
+				BeforeEach(func() {
+					fakeTokenGenerator.GenerateTokenReturns("", "", errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not generate a token", func() {
+				Ω(fakeTokenGenerator.GenerateTokenCallCount()).Should(BeZero())
+			})
+		})
+	})
+})
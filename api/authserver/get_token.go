@@ -0,0 +1,33 @@
+package authserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+)
+
+func (s *Server) GetAuthToken(w http.ResponseWriter, r *http.Request) {
+	var subject string
+
+	username, _, err := auth.ExtractUsernameAndPassword(r.Header.Get("Authorization"))
+	if err == nil {
+		subject = username
+	}
+
+	tokenType, tokenValue, err := s.tokenGenerator.GenerateToken(subject, s.validator.TeamName(r), s.validator.Role(r), time.Now().Add(auth.CookieAge))
+	if err != nil {
+		s.logger.Error("failed-to-generate-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(atc.AuthToken{
+		Type:  string(tokenType),
+		Value: string(tokenValue),
+	})
+}
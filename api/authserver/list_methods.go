@@ -0,0 +1,52 @@
+package authserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) ListAuthMethods(w http.ResponseWriter, r *http.Request) {
+	methods := []atc.AuthMethod{}
+
+	providerNames := []string{}
+	for name := range s.providers {
+		providerNames = append(providerNames, name)
+	}
+
+	sort.Strings(providerNames)
+
+	for _, name := range providerNames {
+		path, err := auth.OAuthRoutes.CreatePathForRoute(
+			auth.OAuthBegin,
+			rata.Params{"provider": name},
+		)
+		if err != nil {
+			s.logger.Error("failed-to-create-oauth-begin-path", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeOAuth,
+			DisplayName: s.providers[name].DisplayName(),
+			AuthURL:     s.externalURL + path,
+		})
+	}
+
+	if s.basicAuth {
+		methods = append(methods, atc.AuthMethod{
+			Type:        atc.AuthTypeBasic,
+			DisplayName: "Basic Auth",
+			AuthURL:     s.externalURL + "/login/basic",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	json.NewEncoder(w).Encode(methods)
+}
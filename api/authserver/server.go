@@ -0,0 +1,34 @@
+package authserver
+
+import (
+	"github.com/pivotal-golang/lager"
+
+	"github.com/concourse/atc/auth"
+)
+
+type Server struct {
+	logger         lager.Logger
+	externalURL    string
+	providers      auth.Providers
+	basicAuth      bool
+	tokenGenerator auth.TokenGenerator
+	validator      auth.Validator
+}
+
+func NewServer(
+	logger lager.Logger,
+	externalURL string,
+	providers auth.Providers,
+	basicAuth bool,
+	tokenGenerator auth.TokenGenerator,
+	validator auth.Validator,
+) *Server {
+	return &Server{
+		logger:         logger,
+		externalURL:    externalURL,
+		providers:      providers,
+		basicAuth:      basicAuth,
+		tokenGenerator: tokenGenerator,
+		validator:      validator,
+	}
+}
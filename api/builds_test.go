@@ -107,6 +107,7 @@ var _ = Describe("Builds API", func() {
 
 					It("creates a one-off build and runs it asynchronously", func() {
 						Ω(buildsDB.CreateOneOffBuildCallCount()).Should(Equal(1))
+						Ω(buildsDB.CreateOneOffBuildArgsForCall(0)).Should(Equal(atc.DefaultTeamName))
 
 						Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(1))
 						oneOff, builtPlan := fakeEngine.CreateBuildArgsForCall(0)
@@ -163,17 +164,22 @@ var _ = Describe("Builds API", func() {
 
 	Describe("GET /api/v1/builds", func() {
 		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/builds")
+			response, err = client.Get(server.URL + "/api/v1/builds" + queryParams)
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 
 		Context("when getting all builds succeeds", func() {
 			BeforeEach(func() {
-				buildsDB.GetAllBuildsReturns([]db.Build{
+				buildsDB.GetBuildsReturns([]db.Build{
 					{
 						ID:           3,
 						Name:         "2",
@@ -188,13 +194,21 @@ var _ = Describe("Builds API", func() {
 						PipelineName: "some-pipeline",
 						Status:       db.StatusSucceeded,
 					},
-				}, nil)
+				}, db.Pagination{}, nil)
 			})
 
 			It("returns 200 OK", func() {
 				Ω(response.StatusCode).Should(Equal(http.StatusOK))
 			})
 
+			It("fetches the most recent page of builds of every team", func() {
+				Ω(buildsDB.GetBuildsCallCount()).Should(Equal(1))
+
+				teamName, page := buildsDB.GetBuildsArgsForCall(0)
+				Ω(teamName).Should(BeEmpty())
+				Ω(page).Should(Equal(db.Page{Limit: 100}))
+			})
+
 			It("returns all builds", func() {
 				body, err := ioutil.ReadAll(response.Body)
 				Ω(err).ShouldNot(HaveOccurred())
@@ -216,11 +230,48 @@ var _ = Describe("Builds API", func() {
 					}
 				]`))
 			})
+
+			Context("when a page is requested", func() {
+				BeforeEach(func() {
+					queryParams = "?until=1&limit=2"
+				})
+
+				It("fetches the requested page", func() {
+					Ω(buildsDB.GetBuildsCallCount()).Should(Equal(1))
+
+					_, page := buildsDB.GetBuildsArgsForCall(0)
+					Ω(page).Should(Equal(db.Page{Until: 1, Limit: 2}))
+				})
+			})
+
+			Context("when there is a next page", func() {
+				BeforeEach(func() {
+					buildsDB.GetBuildsReturns([]db.Build{}, db.Pagination{
+						Next: &db.Page{Since: 1, Limit: 2},
+					}, nil)
+				})
+
+				It("returns a Link header for it", func() {
+					Ω(response.Header["Link"]).Should(Equal([]string{
+						`</api/v1/builds?limit=2&since=1>; rel="next"`,
+					}))
+				})
+			})
+		})
+
+		Context("when the page is invalid", func() {
+			BeforeEach(func() {
+				queryParams = "?since=-1"
+			})
+
+			It("returns 400 Bad Request", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
 		})
 
 		Context("when getting all builds fails", func() {
 			BeforeEach(func() {
-				buildsDB.GetAllBuildsReturns(nil, errors.New("oh no!"))
+				buildsDB.GetBuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
 			})
 
 			It("returns 500 Internal Server Error", func() {
@@ -229,6 +280,55 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/builds", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			buildsDB.GetBuildsReturns([]db.Build{
+				{
+					ID:           3,
+					Name:         "2",
+					JobName:      "job2",
+					PipelineName: "some-pipeline",
+					TeamName:     "some-team",
+					Status:       db.StatusStarted,
+				},
+			}, db.Pagination{}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/builds")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("fetches only the team's builds", func() {
+			Ω(buildsDB.GetBuildsCallCount()).Should(Equal(1))
+
+			teamName, _ := buildsDB.GetBuildsArgsForCall(0)
+			Ω(teamName).Should(Equal("some-team"))
+		})
+
+		It("returns the team's builds", func() {
+			Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(body).Should(MatchJSON(`[
+				{
+					"id": 3,
+					"name": "2",
+					"job_name": "job2",
+					"status": "started",
+					"url": "/teams/some-team/pipelines/some-pipeline/jobs/job2/builds/2",
+					"team_name": "some-team"
+				}
+			]`))
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/events", func() {
 		var (
 			request  *http.Request
@@ -407,6 +507,26 @@ var _ = Describe("Builds API", func() {
 					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
 				})
 			})
+
+			Context("and the build belongs to another team", func() {
+				BeforeEach(func() {
+					authValidator.TeamNameReturns("some-team")
+
+					buildsDB.GetBuildReturns(db.Build{
+						ID:       128,
+						Status:   db.StatusStarted,
+						TeamName: "some-other-team",
+					}, nil)
+				})
+
+				It("returns 403", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+				})
+
+				It("does not abort the build", func() {
+					Ω(fakeEngine.LookupBuildCallCount()).Should(BeZero())
+				})
+			})
 		})
 
 		Context("when not authenticated", func() {
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -25,6 +26,11 @@ func (s *Server) AbortBuild(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !auth.IsAuthorizedForTeam(s.fallback, r, build.TeamName) {
+		auth.Forbidden(w, build.TeamName)
+		return
+	}
+
 	engineBuild, err := s.engine.LookupBuild(build)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
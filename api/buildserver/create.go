@@ -6,6 +6,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/auth"
 )
 
 func (s *Server) CreateBuild(w http.ResponseWriter, r *http.Request) {
@@ -16,7 +17,7 @@ func (s *Server) CreateBuild(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	build, err := s.db.CreateOneOffBuild()
+	build, err := s.db.CreateOneOffBuild(auth.RequestedTeamName(r))
 	if err != nil {
 		s.logger.Error("failed-to-create-one-off-build", err)
 		w.WriteHeader(http.StatusInternalServerError)
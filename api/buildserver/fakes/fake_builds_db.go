@@ -29,16 +29,22 @@ type FakeBuildsDB struct {
 		result1 db.EventSource
 		result2 error
 	}
-	GetAllBuildsStub        func() ([]db.Build, error)
-	getAllBuildsMutex       sync.RWMutex
-	getAllBuildsArgsForCall []struct{}
-	getAllBuildsReturns struct {
+	GetBuildsStub        func(teamName string, page db.Page) ([]db.Build, db.Pagination, error)
+	getBuildsMutex       sync.RWMutex
+	getBuildsArgsForCall []struct {
+		teamName string
+		page     db.Page
+	}
+	getBuildsReturns struct {
 		result1 []db.Build
-		result2 error
+		result2 db.Pagination
+		result3 error
 	}
-	CreateOneOffBuildStub        func() (db.Build, error)
+	CreateOneOffBuildStub        func(teamName string) (db.Build, error)
 	createOneOffBuildMutex       sync.RWMutex
-	createOneOffBuildArgsForCall []struct{}
+	createOneOffBuildArgsForCall []struct {
+		teamName string
+	}
 	createOneOffBuildReturns struct {
 		result1 db.Build
 		result2 error
@@ -122,37 +128,49 @@ func (fake *FakeBuildsDB) GetBuildEventsReturns(result1 db.EventSource, result2
 	}{result1, result2}
 }
 
-func (fake *FakeBuildsDB) GetAllBuilds() ([]db.Build, error) {
-	fake.getAllBuildsMutex.Lock()
-	fake.getAllBuildsArgsForCall = append(fake.getAllBuildsArgsForCall, struct{}{})
-	fake.getAllBuildsMutex.Unlock()
-	if fake.GetAllBuildsStub != nil {
-		return fake.GetAllBuildsStub()
+func (fake *FakeBuildsDB) GetBuilds(teamName string, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getBuildsMutex.Lock()
+	fake.getBuildsArgsForCall = append(fake.getBuildsArgsForCall, struct {
+		teamName string
+		page     db.Page
+	}{teamName, page})
+	fake.getBuildsMutex.Unlock()
+	if fake.GetBuildsStub != nil {
+		return fake.GetBuildsStub(teamName, page)
 	} else {
-		return fake.getAllBuildsReturns.result1, fake.getAllBuildsReturns.result2
+		return fake.getBuildsReturns.result1, fake.getBuildsReturns.result2, fake.getBuildsReturns.result3
 	}
 }
 
-func (fake *FakeBuildsDB) GetAllBuildsCallCount() int {
-	fake.getAllBuildsMutex.RLock()
-	defer fake.getAllBuildsMutex.RUnlock()
-	return len(fake.getAllBuildsArgsForCall)
+func (fake *FakeBuildsDB) GetBuildsCallCount() int {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return len(fake.getBuildsArgsForCall)
 }
 
-func (fake *FakeBuildsDB) GetAllBuildsReturns(result1 []db.Build, result2 error) {
-	fake.GetAllBuildsStub = nil
-	fake.getAllBuildsReturns = struct {
+func (fake *FakeBuildsDB) GetBuildsArgsForCall(i int) (string, db.Page) {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return fake.getBuildsArgsForCall[i].teamName, fake.getBuildsArgsForCall[i].page
+}
+
+func (fake *FakeBuildsDB) GetBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetBuildsStub = nil
+	fake.getBuildsReturns = struct {
 		result1 []db.Build
-		result2 error
-	}{result1, result2}
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
 }
 
-func (fake *FakeBuildsDB) CreateOneOffBuild() (db.Build, error) {
+func (fake *FakeBuildsDB) CreateOneOffBuild(teamName string) (db.Build, error) {
 	fake.createOneOffBuildMutex.Lock()
-	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct{}{})
+	fake.createOneOffBuildArgsForCall = append(fake.createOneOffBuildArgsForCall, struct {
+		teamName string
+	}{teamName})
 	fake.createOneOffBuildMutex.Unlock()
 	if fake.CreateOneOffBuildStub != nil {
-		return fake.CreateOneOffBuildStub()
+		return fake.CreateOneOffBuildStub(teamName)
 	} else {
 		return fake.createOneOffBuildReturns.result1, fake.createOneOffBuildReturns.result2
 	}
@@ -164,6 +182,12 @@ func (fake *FakeBuildsDB) CreateOneOffBuildCallCount() int {
 	return len(fake.createOneOffBuildArgsForCall)
 }
 
+func (fake *FakeBuildsDB) CreateOneOffBuildArgsForCall(i int) string {
+	fake.createOneOffBuildMutex.RLock()
+	defer fake.createOneOffBuildMutex.RUnlock()
+	return fake.createOneOffBuildArgsForCall[i].teamName
+}
+
 func (fake *FakeBuildsDB) CreateOneOffBuildReturns(result1 db.Build, result2 error) {
 	fake.CreateOneOffBuildStub = nil
 	fake.createOneOffBuildReturns = struct {
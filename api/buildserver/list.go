@@ -5,16 +5,25 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/pagination"
 	"github.com/concourse/atc/api/present"
 )
 
 func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
-	builds, err := s.db.GetAllBuilds()
+	page, err := pagination.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	builds, pages, err := s.db.GetBuilds(r.FormValue(":team_name"), page)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	pagination.AddLinkHeaders(w, r, pages)
+
 	w.WriteHeader(http.StatusOK)
 
 	atc := make([]atc.Build, len(builds))
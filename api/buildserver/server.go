@@ -33,9 +33,9 @@ type BuildsDB interface {
 	GetBuild(buildID int) (db.Build, error)
 	GetBuildEvents(buildID int, from uint) (db.EventSource, error)
 
-	GetAllBuilds() ([]db.Build, error)
+	GetBuilds(teamName string, page db.Page) ([]db.Build, db.Pagination, error)
 
-	CreateOneOffBuild() (db.Build, error)
+	CreateOneOffBuild(teamName string) (db.Build, error)
 	GetConfigByBuildID(buildID int) (atc.Config, db.ConfigVersion, error)
 }
 
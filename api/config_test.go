@@ -166,7 +166,8 @@ var _ = Describe("Config API", func() {
 				})
 
 				It("calls get config with the correct arguments", func() {
-					name := configDB.GetConfigArgsForCall(0)
+					teamName, name := configDB.GetConfigArgsForCall(0)
+					Ω(teamName).Should(Equal(atc.DefaultTeamName))
 					Ω(name).Should(Equal("something-else"))
 				})
 			})
@@ -241,7 +242,8 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
 
-							name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+							teamName, name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+							Ω(teamName).Should(Equal(atc.DefaultTeamName))
 							Ω(name).Should(Equal("a-pipeline"))
 							Ω(config).Should(Equal(config))
 							Ω(id).Should(Equal(db.ConfigVersion(42)))
@@ -308,7 +310,8 @@ var _ = Describe("Config API", func() {
 						It("saves it", func() {
 							Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
 
-							name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+							teamName, name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+							Ω(teamName).Should(Equal(atc.DefaultTeamName))
 							Ω(name).Should(Equal("a-pipeline"))
 							Ω(config).Should(Equal(config))
 							Ω(id).Should(Equal(db.ConfigVersion(42)))
@@ -318,7 +321,7 @@ var _ = Describe("Config API", func() {
 						It("does not give the DB a map of empty interfaces to empty interfaces", func() {
 							Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
 
-							_, config, _, _ := configDB.SaveConfigArgsForCall(0)
+							_, _, config, _, _ := configDB.SaveConfigArgsForCall(0)
 							Ω(config).Should(Equal(config))
 
 							_, err := json.Marshal(config)
@@ -348,7 +351,8 @@ jobs:
 							It("saves it", func() {
 								Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
 
-								name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+								teamName, name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+								Ω(teamName).Should(Equal(atc.DefaultTeamName))
 								Ω(name).Should(Equal("a-pipeline"))
 								Ω(config).Should(Equal(atc.Config{
 									Jobs: atc.JobConfigs{
@@ -373,6 +377,126 @@ jobs:
 							})
 						})
 
+						Context("when the payload contains durations", func() {
+							BeforeEach(func() {
+								payload := `
+resources:
+- name: some-resource
+  type: git
+  check_every: 30s
+  check_timeout: 1h
+  source:
+    interval: 10m
+jobs:
+- name: some-job
+  config:
+    run:
+      path: ls
+    params:
+      TIMEOUT: 5s`
+
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+							})
+
+							It("returns 200", func() {
+								Ω(response.StatusCode).Should(Equal(http.StatusOK))
+							})
+
+							It("saves them as they were written", func() {
+								Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
+
+								_, _, config, _, _ := configDB.SaveConfigArgsForCall(0)
+								Ω(config).Should(Equal(atc.Config{
+									Resources: atc.ResourceConfigs{
+										{
+											Name:         "some-resource",
+											Type:         "git",
+											CheckEvery:   "30s",
+											CheckTimeout: "1h",
+											Source: atc.Source{
+												"interval": "10m",
+											},
+										},
+									},
+									Jobs: atc.JobConfigs{
+										{
+											Name: "some-job",
+											TaskConfig: &atc.TaskConfig{
+												Run: atc.TaskRunConfig{
+													Path: "ls",
+												},
+
+												Params: map[string]string{
+													"TIMEOUT": "5s",
+												},
+											},
+										},
+									},
+								}))
+							})
+						})
+
+						Context("when the payload specifies versions for get steps", func() {
+							BeforeEach(func() {
+								payload := `
+jobs:
+- name: some-job
+  plan:
+  - get: some-latest-resource
+    version: latest
+  - get: some-every-resource
+    version: every
+  - get: some-pinned-resource
+    version:
+      ref: abc
+  - aggregate:
+    - get: some-nested-resource
+      version:
+        version: "1"`
+
+								request.Body = ioutil.NopCloser(bytes.NewBufferString(payload))
+							})
+
+							It("returns 200", func() {
+								Ω(response.StatusCode).Should(Equal(http.StatusOK))
+							})
+
+							It("saves them", func() {
+								Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
+
+								_, _, config, _, _ := configDB.SaveConfigArgsForCall(0)
+								Ω(config).Should(Equal(atc.Config{
+									Jobs: atc.JobConfigs{
+										{
+											Name: "some-job",
+											Plan: atc.PlanSequence{
+												{
+													Get:     "some-latest-resource",
+													Version: &atc.VersionConfig{Latest: true},
+												},
+												{
+													Get:     "some-every-resource",
+													Version: &atc.VersionConfig{Every: true},
+												},
+												{
+													Get:     "some-pinned-resource",
+													Version: &atc.VersionConfig{Pinned: atc.Version{"ref": "abc"}},
+												},
+												{
+													Aggregate: &atc.PlanSequence{
+														{
+															Get:     "some-nested-resource",
+															Version: &atc.VersionConfig{Pinned: atc.Version{"version": "1"}},
+														},
+													},
+												},
+											},
+										},
+									},
+								}))
+							})
+						})
+
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								configDB.SaveConfigReturns(true, nil)
@@ -457,7 +581,8 @@ jobs:
 							It("saves it", func() {
 								Ω(configDB.SaveConfigCallCount()).Should(Equal(1))
 
-								name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+								teamName, name, config, id, pipelineState := configDB.SaveConfigArgsForCall(0)
+								Ω(teamName).Should(Equal(atc.DefaultTeamName))
 								Ω(name).Should(Equal("a-pipeline"))
 								Ω(config).Should(Equal(config))
 								Ω(id).Should(Equal(db.ConfigVersion(42)))
@@ -6,12 +6,14 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/tedsuo/rata"
 )
 
 func (s *Server) GetConfig(w http.ResponseWriter, r *http.Request) {
+	teamName := auth.RequestedTeamName(r)
 	pipelineName := rata.Param(r, "pipeline_name")
-	config, id, err := s.db.GetConfig(pipelineName)
+	config, id, err := s.db.GetConfig(teamName, pipelineName)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pivotal-golang/lager"
@@ -114,8 +115,15 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 
 	session.Info("saving")
 
+	teamName := auth.RequestedTeamName(r)
 	pipelineName := rata.Param(r, "pipeline_name")
-	created, err := s.db.SaveConfig(pipelineName, config, version, pausedState)
+	created, err := s.db.SaveConfig(teamName, pipelineName, config, version, pausedState)
+	if err == db.ErrNoTeam {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "team '%s' does not exist", teamName)
+		return
+	}
+
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -190,6 +198,9 @@ func requestToConfig(contentType string, requestBody io.ReadCloser) (interface{}
 	return configStructure, pausedState, nil
 }
 
+var durationType = reflect.TypeOf(time.Duration(0))
+var versionConfigType = reflect.TypeOf(atc.VersionConfig{})
+
 func saveConfigRequestUnmarshler(r *http.Request) (atc.Config, db.PipelinePausedState, error) {
 	configStructure, pausedState, err := requestToConfig(r.Header.Get("Content-Type"), r.Body)
 	if err != nil {
@@ -203,17 +214,24 @@ func saveConfigRequestUnmarshler(r *http.Request) (atc.Config, db.PipelinePaused
 		Result:           &config,
 		WeaklyTypedInput: true,
 		DecodeHook: func(
-			dataKind reflect.Kind,
-			valKind reflect.Kind,
+			dataType reflect.Type,
+			valType reflect.Type,
 			data interface{},
 		) (interface{}, error) {
+			dataKind := dataType.Kind()
+			valKind := valType.Kind()
+
 			if valKind == reflect.Map {
 				if dataKind == reflect.Map {
 					return sanitize(data)
 				}
 			}
 
-			if dataKind == reflect.String {
+			if valType == versionConfigType {
+				return expandVersionConfig(data)
+			}
+
+			if dataKind == reflect.String && (valType == durationType || valKind == reflect.Int64) {
 				val, err := time.ParseDuration(data.(string))
 				if err == nil {
 					return val, nil
@@ -248,6 +266,30 @@ func saveConfigRequestUnmarshler(r *http.Request) (atc.Config, db.PipelinePaused
 	return config, pausedState, nil
 }
 
+// get steps specify `version: latest | every | {...}`; spell it out as the
+// fields of atc.VersionConfig so that it decodes like any other struct
+func expandVersionConfig(data interface{}) (interface{}, error) {
+	sanitized, err := sanitize(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch version := sanitized.(type) {
+	case string:
+		if version != atc.VersionLatest && version != atc.VersionEvery {
+			return version, nil
+		}
+
+		return map[string]interface{}{version: true}, nil
+
+	case map[string]interface{}:
+		return map[string]interface{}{"pinned": version}, nil
+
+	default:
+		return version, nil
+	}
+}
+
 func sanitize(root interface{}) (interface{}, error) {
 	switch rootVal := root.(type) {
 	case map[interface{}]interface{}:
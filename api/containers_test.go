@@ -0,0 +1,227 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	workerfakes "github.com/concourse/atc/worker/fakes"
+)
+
+var _ = Describe("Containers API", func() {
+	Describe("GET /api/v1/containers", func() {
+		var (
+			query url.Values
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = url.Values{}
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/containers", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			req.URL.RawQuery = query.Encode()
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when containers are found", func() {
+				var (
+					fakeContainerA *workerfakes.FakeContainer
+					fakeContainerB *workerfakes.FakeContainer
+				)
+
+				BeforeEach(func() {
+					fakeContainerA = new(workerfakes.FakeContainer)
+					fakeContainerA.HandleReturns("some-handle")
+					fakeContainerA.WorkerNameReturns("some-worker")
+					fakeContainerA.WorkerAddrReturns("1.2.3.4:7777")
+					fakeContainerA.TTLReturns(4*time.Minute, nil)
+					fakeContainerA.IdentifierFromPropertiesReturns(worker.Identifier{
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						BuildID:      42,
+						Type:         worker.ContainerTypeTask,
+						Name:         "some-task",
+						StepLocation: 3,
+					}, nil)
+
+					fakeContainerB = new(workerfakes.FakeContainer)
+					fakeContainerB.HandleReturns("some-other-handle")
+					fakeContainerB.WorkerNameReturns("some-other-worker")
+					fakeContainerB.WorkerAddrReturns("1.2.3.4:8888")
+					fakeContainerB.TTLReturns(5*time.Minute, nil)
+					fakeContainerB.IdentifierFromPropertiesReturns(worker.Identifier{
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						Type:         worker.ContainerTypeCheck,
+						Name:         "some-resource",
+						CheckType:    "git",
+						CheckSource:  atc.Source{"private_key": "secret"},
+					}, nil)
+
+					fakeWorkerClient.FindContainersForIdentifierReturns([]worker.Container{
+						fakeContainerA,
+						fakeContainerB,
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+
+				It("returns the containers, without their check sources", func() {
+					var containers []atc.Container
+					err := json.NewDecoder(response.Body).Decode(&containers)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(containers).Should(Equal([]atc.Container{
+						{
+							ID:           "some-handle",
+							WorkerName:   "some-worker",
+							WorkerAddr:   "1.2.3.4:7777",
+							TTLInSeconds: 240,
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      42,
+							Type:         "task",
+							Name:         "some-task",
+							StepLocation: 3,
+						},
+						{
+							ID:           "some-other-handle",
+							WorkerName:   "some-other-worker",
+							WorkerAddr:   "1.2.3.4:8888",
+							TTLInSeconds: 300,
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							Type:         "check",
+							Name:         "some-resource",
+							CheckType:    "git",
+						},
+					}))
+				})
+
+				It("releases the containers", func() {
+					Ω(fakeContainerA.ReleaseCallCount()).Should(Equal(1))
+					Ω(fakeContainerB.ReleaseCallCount()).Should(Equal(1))
+				})
+
+				It("finds all containers", func() {
+					Ω(fakeWorkerClient.FindContainersForIdentifierCallCount()).Should(Equal(1))
+					Ω(fakeWorkerClient.FindContainersForIdentifierArgsForCall(0)).Should(BeZero())
+				})
+
+				Context("when filters are given", func() {
+					BeforeEach(func() {
+						query = url.Values{
+							"pipeline": []string{"some-pipeline"},
+							"job":      []string{"some-job"},
+							"build-id": []string{"42"},
+							"type":     []string{"task"},
+							"name":     []string{"some-task"},
+						}
+					})
+
+					It("finds the containers matching the filters", func() {
+						Ω(fakeWorkerClient.FindContainersForIdentifierArgsForCall(0)).Should(Equal(worker.Identifier{
+							PipelineName: "some-pipeline",
+							JobName:      "some-job",
+							BuildID:      42,
+							Type:         worker.ContainerTypeTask,
+							Name:         "some-task",
+						}))
+					})
+				})
+
+				Context("when the user belongs to a team other than main", func() {
+					BeforeEach(func() {
+						authValidator.TeamNameReturns("some-team")
+					})
+
+					It("only finds the team's containers", func() {
+						Ω(fakeWorkerClient.FindContainersForIdentifierArgsForCall(0)).Should(Equal(worker.Identifier{
+							TeamName: "some-team",
+						}))
+					})
+				})
+
+				Context("when a container's properties cannot be read", func() {
+					BeforeEach(func() {
+						fakeContainerA.IdentifierFromPropertiesReturns(worker.Identifier{}, errors.New("nope"))
+					})
+
+					It("omits it", func() {
+						var containers []atc.Container
+						err := json.NewDecoder(response.Body).Decode(&containers)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						Ω(containers).Should(HaveLen(1))
+						Ω(containers[0].ID).Should(Equal("some-other-handle"))
+					})
+
+					It("still releases it", func() {
+						Ω(fakeContainerA.ReleaseCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
+			Context("when the build ID is malformed", func() {
+				BeforeEach(func() {
+					query = url.Values{"build-id": []string{"nope"}}
+				})
+
+				It("returns 400", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+				})
+
+				It("does not look for containers", func() {
+					Ω(fakeWorkerClient.FindContainersForIdentifierCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when finding the containers fails", func() {
+				BeforeEach(func() {
+					fakeWorkerClient.FindContainersForIdentifierReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look for containers", func() {
+				Ω(fakeWorkerClient.FindContainersForIdentifierCallCount()).Should(BeZero())
+			})
+		})
+	})
+})
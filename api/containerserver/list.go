@@ -0,0 +1,75 @@
+package containerserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/present"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) ListContainers(w http.ResponseWriter, r *http.Request) {
+	id := worker.Identifier{
+		Type:         worker.ContainerType(r.URL.Query().Get("type")),
+		Name:         r.URL.Query().Get("name"),
+		PipelineName: r.URL.Query().Get("pipeline"),
+		JobName:      r.URL.Query().Get("job"),
+	}
+
+	// members of the main team may see any team's containers
+	teamName := s.validator.TeamName(r)
+	if teamName != atc.DefaultTeamName {
+		id.TeamName = teamName
+	}
+
+	buildIDParam := r.URL.Query().Get("build-id")
+	if len(buildIDParam) != 0 {
+		var err error
+		id.BuildID, err = strconv.Atoi(buildIDParam)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("malformed build ID: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	lLog := s.logger.Session("list-containers", lager.Data{
+		"identifier": id,
+	})
+
+	containers, err := s.workerClient.FindContainersForIdentifier(id)
+	if err != nil {
+		lLog.Error("failed-to-find-containers", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	presented := []atc.Container{}
+	for _, container := range containers {
+		containerID, err := container.IdentifierFromProperties()
+		if err != nil {
+			lLog.Error("failed-to-get-container-identifier", err, lager.Data{"handle": container.Handle()})
+			container.Release()
+			continue
+		}
+
+		ttl, err := container.TTL()
+		if err != nil {
+			lLog.Error("failed-to-get-container-ttl", err, lager.Data{"handle": container.Handle()})
+			container.Release()
+			continue
+		}
+
+		presented = append(presented, present.Container(container, containerID, ttl))
+
+		container.Release()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(presented)
+}
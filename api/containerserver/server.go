@@ -0,0 +1,26 @@
+package containerserver
+
+import (
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/lager"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	workerClient worker.Client
+	validator    auth.Validator
+}
+
+func NewServer(
+	logger lager.Logger,
+	workerClient worker.Client,
+	validator auth.Validator,
+) *Server {
+	return &Server{
+		logger:       logger,
+		workerClient: workerClient,
+		validator:    validator,
+	}
+}
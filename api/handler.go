@@ -8,15 +8,18 @@ import (
 	"github.com/tedsuo/rata"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/authserver"
 	"github.com/concourse/atc/api/buildserver"
 	"github.com/concourse/atc/api/cliserver"
 	"github.com/concourse/atc/api/configserver"
+	"github.com/concourse/atc/api/containerserver"
 	"github.com/concourse/atc/api/hijackserver"
 	"github.com/concourse/atc/api/jobserver"
 	"github.com/concourse/atc/api/loglevelserver"
 	"github.com/concourse/atc/api/pipelineserver"
 	"github.com/concourse/atc/api/pipes"
 	"github.com/concourse/atc/api/resourceserver"
+	"github.com/concourse/atc/api/teamserver"
 	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
@@ -25,25 +28,80 @@ import (
 	"github.com/concourse/atc/worker"
 )
 
+// routeRoles lists the routes requiring more than RoleViewer, along with the
+// role they require. Routes not listed here are left to their own
+// authentication.
+var routeRoles = map[string]auth.Role{
+	atc.SaveConfig:     auth.RoleAdmin,
+	atc.DeletePipeline: auth.RoleAdmin,
+	atc.OrderPipelines: auth.RoleAdmin,
+	atc.SetLogLevel:    auth.RoleAdmin,
+	atc.SaveTeam:       auth.RoleAdmin,
+
+	atc.CreateBuild:            auth.RoleOperator,
+	atc.AbortBuild:             auth.RoleOperator,
+	atc.Hijack:                 auth.RoleOperator,
+	atc.ListContainers:         auth.RoleOperator,
+	atc.CreatePipe:             auth.RoleOperator,
+	atc.WritePipe:              auth.RoleOperator,
+	atc.ReadPipe:               auth.RoleOperator,
+	atc.PauseJob:               auth.RoleOperator,
+	atc.UnpauseJob:             auth.RoleOperator,
+	atc.PausePipeline:          auth.RoleOperator,
+	atc.UnpausePipeline:        auth.RoleOperator,
+	atc.PauseResource:          auth.RoleOperator,
+	atc.UnpauseResource:        auth.RoleOperator,
+	atc.CheckResource:          auth.RoleOperator,
+	atc.EnableResourceVersion:  auth.RoleOperator,
+	atc.DisableResourceVersion: auth.RoleOperator,
+	atc.PinResourceVersion:     auth.RoleOperator,
+	atc.UnpinResource:          auth.RoleOperator,
+}
+
+// SelfAuthenticatedRoutes returns the routes that authenticate requests
+// themselves rather than with the caller's credentials, and so must be
+// exempt from any authentication wrapping the whole API.
+func SelfAuthenticatedRoutes() rata.Routes {
+	routes := rata.Routes{}
+
+	for _, route := range atc.Routes {
+		switch route.Name {
+		case atc.CheckResourceWebhook, atc.RegisterWorker:
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
 func NewHandler(
 	logger lager.Logger,
 	validator auth.Validator,
 	pipelineDBFactory db.PipelineDBFactory,
 
+	providers auth.Providers,
+	basicAuthEnabled bool,
+	tokenGenerator auth.TokenGenerator,
+	workerRegistrationKey string,
+
 	configDB db.ConfigDB,
 
 	buildsDB buildserver.BuildsDB,
 	workerDB workerserver.WorkerDB,
 	pipeDB pipes.PipeDB,
 	pipelinesDB db.PipelinesDB,
+	teamsDB db.TeamsDB,
 
 	configValidator configserver.ConfigValidator,
 	peerURL string,
+	externalURL string,
 	eventHandlerFactory buildserver.EventHandlerFactory,
 	drain <-chan struct{},
 
 	engine engine.Engine,
 	workerClient worker.Client,
+	workerHealth worker.WorkerHealth,
+	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 
 	sink *lager.ReconfigurableSink,
 
@@ -70,22 +128,33 @@ func NewHandler(
 	hijackServer := hijackserver.NewServer(
 		logger,
 		workerClient,
+		validator,
+	)
+
+	containerServer := containerserver.NewServer(
+		logger,
+		workerClient,
+		validator,
 	)
 
 	jobServer := jobserver.NewServer(logger)
-	resourceServer := resourceserver.NewServer(logger, validator)
+	resourceServer := resourceserver.NewServer(logger, validator, radarSchedulerFactory)
 	pipeServer := pipes.NewServer(logger, peerURL, pipeDB)
 
 	pipelineServer := pipelineserver.NewServer(logger, pipelinesDB)
 
 	configServer := configserver.NewServer(logger, configDB, configValidator)
 
-	workerServer := workerserver.NewServer(logger, workerDB)
+	workerServer := workerserver.NewServer(logger, workerDB, workerHealth, validator, workerRegistrationKey)
 
 	logLevelServer := loglevelserver.NewServer(logger, sink)
 
 	cliServer := cliserver.NewServer(logger, absCLIDownloadsDir)
 
+	authServer := authserver.NewServer(logger, externalURL, providers, basicAuthEnabled, tokenGenerator, validator)
+
+	teamServer := teamserver.NewServer(logger, validator, teamsDB)
+
 	validate := func(handler http.Handler) http.Handler {
 		return auth.Handler{
 			Handler:   handler,
@@ -93,14 +162,22 @@ func NewHandler(
 		}
 	}
 
+	validateTeam := func(handler http.Handler) http.Handler {
+		return validate(auth.TeamHandler{
+			Handler:   handler,
+			Validator: validator,
+		})
+	}
+
 	handlers := map[string]http.Handler{
-		atc.GetConfig:  validate(http.HandlerFunc(configServer.GetConfig)),
-		atc.SaveConfig: validate(http.HandlerFunc(configServer.SaveConfig)),
+		atc.GetConfig:  validateTeam(http.HandlerFunc(configServer.GetConfig)),
+		atc.SaveConfig: validateTeam(http.HandlerFunc(configServer.SaveConfig)),
 
-		atc.Hijack: validate(http.HandlerFunc(hijackServer.Hijack)),
+		atc.Hijack:         validate(http.HandlerFunc(hijackServer.Hijack)),
+		atc.ListContainers: validate(http.HandlerFunc(containerServer.ListContainers)),
 
 		atc.ListBuilds:  http.HandlerFunc(buildServer.ListBuilds),
-		atc.CreateBuild: validate(http.HandlerFunc(buildServer.CreateBuild)),
+		atc.CreateBuild: validateTeam(http.HandlerFunc(buildServer.CreateBuild)),
 		atc.BuildEvents: http.HandlerFunc(buildServer.BuildEvents),
 		atc.AbortBuild:  validate(http.HandlerFunc(buildServer.AbortBuild)),
 
@@ -108,32 +185,50 @@ func NewHandler(
 		atc.GetJob:        pipelineHandlerFactory.HandlerFor(jobServer.GetJob),
 		atc.ListJobBuilds: pipelineHandlerFactory.HandlerFor(jobServer.ListJobBuilds),
 		atc.GetJobBuild:   pipelineHandlerFactory.HandlerFor(jobServer.GetJobBuild),
-		atc.PauseJob:      validate(pipelineHandlerFactory.HandlerFor(jobServer.PauseJob)),
-		atc.UnpauseJob:    validate(pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob)),
+		atc.PauseJob:      validateTeam(pipelineHandlerFactory.HandlerFor(jobServer.PauseJob)),
+		atc.UnpauseJob:    validateTeam(pipelineHandlerFactory.HandlerFor(jobServer.UnpauseJob)),
 
 		atc.ListPipelines:   http.HandlerFunc(pipelineServer.ListPipelines),
-		atc.DeletePipeline:  validate(pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline)),
-		atc.OrderPipelines:  validate(http.HandlerFunc(pipelineServer.OrderPipelines)),
-		atc.PausePipeline:   validate(pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline)),
-		atc.UnpausePipeline: validate(pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline)),
+		atc.DeletePipeline:  validateTeam(pipelineHandlerFactory.HandlerFor(pipelineServer.DeletePipeline)),
+		atc.OrderPipelines:  validateTeam(http.HandlerFunc(pipelineServer.OrderPipelines)),
+		atc.PausePipeline:   validateTeam(pipelineHandlerFactory.HandlerFor(pipelineServer.PausePipeline)),
+		atc.UnpausePipeline: validateTeam(pipelineHandlerFactory.HandlerFor(pipelineServer.UnpausePipeline)),
 
 		atc.ListResources:          pipelineHandlerFactory.HandlerFor(resourceServer.ListResources),
-		atc.EnableResourceVersion:  validate(pipelineHandlerFactory.HandlerFor(resourceServer.EnableResourceVersion)),
-		atc.DisableResourceVersion: validate(pipelineHandlerFactory.HandlerFor(resourceServer.DisableResourceVersion)),
-		atc.PauseResource:          validate(pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource)),
-		atc.UnpauseResource:        validate(pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource)),
+		atc.EnableResourceVersion:  validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.EnableResourceVersion)),
+		atc.DisableResourceVersion: validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.DisableResourceVersion)),
+		atc.PauseResource:          validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.PauseResource)),
+		atc.UnpauseResource:        validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.UnpauseResource)),
+		atc.PinResourceVersion:     validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.PinResourceVersion)),
+		atc.UnpinResource:          validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.UnpinResource)),
+		atc.CheckResource:          validateTeam(pipelineHandlerFactory.HandlerFor(resourceServer.CheckResource)),
+		atc.CheckResourceWebhook:   pipelineHandlerFactory.HandlerFor(resourceServer.CheckResourceWebhook),
 
 		atc.CreatePipe: validate(http.HandlerFunc(pipeServer.CreatePipe)),
 		atc.WritePipe:  validate(http.HandlerFunc(pipeServer.WritePipe)),
 		atc.ReadPipe:   validate(http.HandlerFunc(pipeServer.ReadPipe)),
 
 		atc.ListWorkers:    validate(http.HandlerFunc(workerServer.ListWorkers)),
-		atc.RegisterWorker: validate(http.HandlerFunc(workerServer.RegisterWorker)),
+		atc.RegisterWorker: http.HandlerFunc(workerServer.RegisterWorker),
 
 		atc.SetLogLevel: validate(http.HandlerFunc(logLevelServer.SetMinLevel)),
 		atc.GetLogLevel: http.HandlerFunc(logLevelServer.GetMinLevel),
 
 		atc.DownloadCLI: http.HandlerFunc(cliServer.Download),
+
+		atc.ListAuthMethods: http.HandlerFunc(authServer.ListAuthMethods),
+		atc.GetAuthToken:    validate(http.HandlerFunc(authServer.GetAuthToken)),
+
+		atc.ListTeams: validate(http.HandlerFunc(teamServer.ListTeams)),
+		atc.SaveTeam:  validate(http.HandlerFunc(teamServer.SaveTeam)),
+	}
+
+	for route, role := range routeRoles {
+		handlers[route] = auth.RoleHandler{
+			Validator: validator,
+			Role:      role,
+			Handler:   handlers[route],
+		}
 	}
 
 	return rata.NewRouter(atc.Routes, handlers)
@@ -135,6 +135,24 @@ var _ = Describe("Hijacking API", func() {
 						Ω(io.Stderr).ShouldNot(BeNil())
 					})
 
+					Context("when the user belongs to a team other than main", func() {
+						BeforeEach(func() {
+							authValidator.TeamNameReturns("some-team")
+						})
+
+						It("only looks up the team's containers", func() {
+							Eventually(fakeContainer.RunCallCount).Should(Equal(1))
+
+							Ω(fakeWorkerClient.LookupContainerArgsForCall(0)).Should(Equal(worker.Identifier{
+								BuildID:  128,
+								TeamName: "some-team",
+
+								Type: worker.ContainerType(stepType),
+								Name: stepName,
+							}))
+						})
+					})
+
 					Context("when the build ID is unspecified", func() {
 						BeforeEach(func() {
 							buildID = ""
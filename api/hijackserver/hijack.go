@@ -35,6 +35,12 @@ func (s *Server) parseRequest(r *http.Request) (hijackRequest, error) {
 		PipelineName: r.URL.Query().Get("pipeline"),
 	}
 
+	// members of the main team may hijack any team's containers
+	teamName := s.validator.TeamName(r)
+	if teamName != atc.DefaultTeamName {
+		workerIdentifier.TeamName = teamName
+	}
+
 	var err error
 
 	buildIDParam := r.URL.Query().Get("build-id")
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/lager"
 )
@@ -12,6 +13,7 @@ type Server struct {
 	logger lager.Logger
 
 	workerClient worker.Client
+	validator    auth.Validator
 
 	httpClient *http.Client
 }
@@ -19,10 +21,12 @@ type Server struct {
 func NewServer(
 	logger lager.Logger,
 	workerClient worker.Client,
+	validator auth.Validator,
 ) *Server {
 	return &Server{
 		logger:       logger,
 		workerClient: workerClient,
+		validator:    validator,
 
 		httpClient: &http.Client{
 			Transport: &http.Transport{
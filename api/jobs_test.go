@@ -19,7 +19,7 @@ var _ = Describe("Jobs API", func() {
 
 	BeforeEach(func() {
 		pipelineDB = new(dbfakes.FakePipelineDB)
-		pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+		pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
 	})
 
 	Describe("GET /api/v1/pipelines/:pipeline_name/jobs/:job_name", func() {
@@ -31,8 +31,9 @@ var _ = Describe("Jobs API", func() {
 			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job")
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-			pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+			Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+			teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+			Ω(teamName).Should(Equal(atc.DefaultTeamName))
 			Ω(pipelineName).Should(Equal("some-pipeline"))
 		})
 
@@ -442,21 +443,27 @@ var _ = Describe("Jobs API", func() {
 
 	Describe("GET /api/v1/pipelines/:pipeline_name/jobs/:job_name/builds", func() {
 		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = ""
+		})
 
 		JustBeforeEach(func() {
 			var err error
 
-			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job/builds")
+			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job/builds" + queryParams)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-			pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+			Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+			teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+			Ω(teamName).Should(Equal(atc.DefaultTeamName))
 			Ω(pipelineName).Should(Equal("some-pipeline"))
 		})
 
 		Context("when getting the build succeeds", func() {
 			BeforeEach(func() {
-				pipelineDB.GetAllJobBuildsReturns([]db.Build{
+				pipelineDB.GetJobBuildsReturns([]db.Build{
 					{
 						ID:           3,
 						Name:         "2",
@@ -471,14 +478,50 @@ var _ = Describe("Jobs API", func() {
 						PipelineName: "some-pipeline",
 						Status:       db.StatusSucceeded,
 					},
-				}, nil)
+				}, db.Pagination{}, nil)
 			})
 
-			It("fetches by job and build name", func() {
-				Ω(pipelineDB.GetAllJobBuildsCallCount()).Should(Equal(1))
+			It("fetches the most recent page of the job's builds", func() {
+				Ω(pipelineDB.GetJobBuildsCallCount()).Should(Equal(1))
 
-				jobName := pipelineDB.GetAllJobBuildsArgsForCall(0)
+				jobName, page := pipelineDB.GetJobBuildsArgsForCall(0)
 				Ω(jobName).Should(Equal("some-job"))
+				Ω(page).Should(Equal(db.Page{Limit: 100}))
+			})
+
+			Context("when a page is requested", func() {
+				BeforeEach(func() {
+					queryParams = "?since=3&limit=2"
+				})
+
+				It("fetches the requested page", func() {
+					Ω(pipelineDB.GetJobBuildsCallCount()).Should(Equal(1))
+
+					_, page := pipelineDB.GetJobBuildsArgsForCall(0)
+					Ω(page).Should(Equal(db.Page{Since: 3, Limit: 2}))
+				})
+			})
+
+			Context("when there are adjacent pages", func() {
+				BeforeEach(func() {
+					pipelineDB.GetJobBuildsReturns([]db.Build{}, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2},
+						Next:     &db.Page{Since: 2, Limit: 2},
+					}, nil)
+				})
+
+				It("returns Link headers for them", func() {
+					Ω(response.Header["Link"]).Should(ConsistOf([]string{
+						`</api/v1/pipelines/some-pipeline/jobs/some-job/builds?limit=2&until=4>; rel="previous"`,
+						`</api/v1/pipelines/some-pipeline/jobs/some-job/builds?limit=2&since=2>; rel="next"`,
+					}))
+				})
+			})
+
+			Context("when there are no adjacent pages", func() {
+				It("does not return a Link header", func() {
+					Ω(response.Header.Get("Link")).Should(BeEmpty())
+				})
 			})
 
 			It("returns 200 OK", func() {
@@ -510,13 +553,27 @@ var _ = Describe("Jobs API", func() {
 
 		Context("when getting the build fails", func() {
 			BeforeEach(func() {
-				pipelineDB.GetAllJobBuildsReturns(nil, errors.New("oh no!"))
+				pipelineDB.GetJobBuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
 			})
 
 			It("returns 404 Not Found", func() {
 				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
 			})
 		})
+
+		Context("when the page is invalid", func() {
+			BeforeEach(func() {
+				queryParams = "?limit=nope"
+			})
+
+			It("returns 400 Bad Request", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+
+			It("does not fetch any builds", func() {
+				Ω(pipelineDB.GetJobBuildsCallCount()).Should(BeZero())
+			})
+		})
 	})
 
 	Describe("GET /api/v1/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
@@ -528,8 +585,9 @@ var _ = Describe("Jobs API", func() {
 			response, err = client.Get(server.URL + "/api/v1/pipelines/some-pipeline/jobs/some-job/builds/some-build")
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-			pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+			Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+			teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+			Ω(teamName).Should(Equal(atc.DefaultTeamName))
 			Ω(pipelineName).Should(Equal("some-pipeline"))
 		})
 
@@ -600,8 +658,9 @@ var _ = Describe("Jobs API", func() {
 			})
 
 			It("injects the PipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("some-pipeline"))
 			})
 
@@ -660,8 +719,9 @@ var _ = Describe("Jobs API", func() {
 			})
 
 			It("injects the PipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("some-pipeline"))
 			})
 
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/pagination"
 	"github.com/concourse/atc/api/present"
 	"github.com/concourse/atc/db"
 )
@@ -13,12 +14,20 @@ func (s *Server) ListJobBuilds(pipelineDB db.PipelineDB) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		jobName := r.FormValue(":job_name")
 
-		builds, err := pipelineDB.GetAllJobBuilds(jobName)
+		page, err := pagination.ParsePage(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		builds, pages, err := pipelineDB.GetJobBuilds(jobName, page)
 		if err != nil {
 			w.WriteHeader(http.StatusNotFound)
 			return
 		}
 
+		pagination.AddLinkHeaders(w, r, pages)
+
 		w.WriteHeader(http.StatusOK)
 
 		resources := make([]atc.Build, len(builds))
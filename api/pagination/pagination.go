@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+)
+
+// DefaultLimit is the number of builds returned by requests that do not
+// specify a limit.
+const DefaultLimit = 100
+
+var ErrInvalidPage = errors.New("since, until and limit must be positive integers")
+
+// ParsePage reads the since, until and limit query parameters of a request.
+func ParsePage(r *http.Request) (db.Page, error) {
+	page := db.Page{Limit: DefaultLimit}
+
+	for param, dest := range map[string]*int{
+		"since": &page.Since,
+		"until": &page.Until,
+		"limit": &page.Limit,
+	} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return db.Page{}, ErrInvalidPage
+		}
+
+		*dest = parsed
+	}
+
+	return page, nil
+}
+
+// AddLinkHeaders adds a Link header to the response for each page adjacent to
+// the one being returned, pointing back at the requested path.
+func AddLinkHeaders(w http.ResponseWriter, r *http.Request, pagination db.Pagination) {
+	if pagination.Previous != nil {
+		w.Header().Add("Link", link(r, *pagination.Previous, "previous"))
+	}
+
+	if pagination.Next != nil {
+		w.Header().Add("Link", link(r, *pagination.Next, "next"))
+	}
+}
+
+func link(r *http.Request, page db.Page, rel string) string {
+	query := url.Values{}
+
+	if page.Since != 0 {
+		query.Set("since", strconv.Itoa(page.Since))
+	}
+
+	if page.Until != 0 {
+		query.Set("until", strconv.Itoa(page.Until))
+	}
+
+	if page.Limit != 0 {
+		query.Set("limit", strconv.Itoa(page.Limit))
+	}
+
+	return fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, query.Encode(), rel)
+}
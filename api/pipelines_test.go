@@ -12,6 +12,7 @@ import (
 
 	dbfakes "github.com/concourse/atc/db/fakes"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -23,15 +24,17 @@ var _ = Describe("Pipelines API", func() {
 		BeforeEach(func() {
 			pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
 				{
-					ID:     1,
-					Paused: false,
+					ID:       1,
+					Paused:   false,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name: "a-pipeline",
 					},
 				},
 				{
-					ID:     2,
-					Paused: true,
+					ID:       2,
+					Paused:   true,
+					TeamName: "some-team",
 					Pipeline: db.Pipeline{
 						Name: "another-pipeline",
 					},
@@ -75,11 +78,56 @@ var _ = Describe("Pipelines API", func() {
       {
         "name": "a-pipeline",
         "url": "/pipelines/a-pipeline",
-				"paused": false
+				"paused": false,
+				"team_name": "main"
       },{
         "name": "another-pipeline",
-        "url": "/pipelines/another-pipeline",
-				"paused": true
+        "url": "/teams/some-team/pipelines/another-pipeline",
+				"paused": true,
+				"team_name": "some-team"
+      }]`))
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
+				{
+					ID:       1,
+					TeamName: atc.DefaultTeamName,
+					Pipeline: db.Pipeline{
+						Name: "a-pipeline",
+					},
+				},
+				{
+					ID:       2,
+					TeamName: "some-team",
+					Pipeline: db.Pipeline{
+						Name: "another-pipeline",
+					},
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns only the team's pipelines", func() {
+			body, err := ioutil.ReadAll(response.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(body).Should(MatchJSON(`[
+      {
+        "name": "another-pipeline",
+        "url": "/teams/some-team/pipelines/another-pipeline",
+				"paused": false,
+				"team_name": "some-team"
       }]`))
 		})
 	})
@@ -91,7 +139,7 @@ var _ = Describe("Pipelines API", func() {
 		BeforeEach(func() {
 			pipelineDB = new(dbfakes.FakePipelineDB)
 
-			pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -115,8 +163,9 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline-name"))
 			})
 
@@ -136,6 +185,21 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when the user belongs to another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				authValidator.TeamNameReturns("some-other-team")
+			})
+
+			It("returns 403 Forbidden", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+			})
+
+			It("does not delete the pipeline", func() {
+				Ω(pipelineDB.DestroyCallCount()).Should(BeZero())
+			})
+		})
+
 		Context("when the user is not logged in", func() {
 			BeforeEach(func() {
 				authValidator.IsAuthenticatedReturns(false)
@@ -147,6 +211,54 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("DELETE /api/v1/teams/:team_name/pipelines/:pipeline_name", func() {
+		var response *http.Response
+		var pipelineDB *dbfakes.FakePipelineDB
+
+		BeforeEach(func() {
+			pipelineDB = new(dbfakes.FakePipelineDB)
+
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
+
+			authValidator.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/pipelines/a-pipeline-name", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when the user belongs to the team", func() {
+			BeforeEach(func() {
+				authValidator.TeamNameReturns("some-team")
+			})
+
+			It("deletes the team's pipeline", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNoContent))
+
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal("some-team"))
+				Ω(pipelineName).Should(Equal("a-pipeline-name"))
+
+				Ω(pipelineDB.DestroyCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("when the user belongs to another team", func() {
+			BeforeEach(func() {
+				authValidator.TeamNameReturns("some-other-team")
+			})
+
+			It("returns 403 Forbidden without touching the pipeline", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(BeZero())
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/pipelines/:pipeline_name/pause", func() {
 		var response *http.Response
 		var pipelineDB *dbfakes.FakePipelineDB
@@ -154,7 +266,7 @@ var _ = Describe("Pipelines API", func() {
 		BeforeEach(func() {
 			pipelineDB = new(dbfakes.FakePipelineDB)
 
-			pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -173,8 +285,9 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -217,7 +330,7 @@ var _ = Describe("Pipelines API", func() {
 		BeforeEach(func() {
 			pipelineDB = new(dbfakes.FakePipelineDB)
 
-			pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+			pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -236,8 +349,9 @@ var _ = Describe("Pipelines API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -321,7 +435,8 @@ var _ = Describe("Pipelines API", func() {
 
 				It("orders the pipelines", func() {
 					Ω(pipelinesDB.OrderPipelinesCallCount()).Should(Equal(1))
-					pipelineNames := pipelinesDB.OrderPipelinesArgsForCall(0)
+					teamName, pipelineNames := pipelinesDB.OrderPipelinesArgsForCall(0)
+					Ω(teamName).Should(Equal(atc.DefaultTeamName))
 					Ω(pipelineNames).Should(Equal(
 						[]string{
 							"a-pipeline",
@@ -15,12 +15,18 @@ func (s *Server) ListPipelines(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	teamName := r.FormValue(":team_name")
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
-	presentedPipelines := make([]atc.Pipeline, len(pipelines))
-	for i := 0; i < len(pipelines); i++ {
-		presentedPipelines[i] = present.Pipeline(pipelines[i])
+	presentedPipelines := []atc.Pipeline{}
+	for _, pipeline := range pipelines {
+		if teamName != "" && pipeline.TeamName != teamName {
+			continue
+		}
+
+		presentedPipelines = append(presentedPipelines, present.Pipeline(pipeline))
 	}
 
 	json.NewEncoder(w).Encode(presentedPipelines)
@@ -8,22 +8,22 @@ import (
 )
 
 func Build(build db.Build) atc.Build {
-	generator := rata.NewRequestGenerator("", routes.Routes)
-
-	req, err := generator.CreateRequest(
+	path, err := routes.PathForTeam(
+		routes.Routes,
+		build.TeamName,
 		routes.GetBuild,
 		rata.Params{"job": build.JobName, "build": build.Name, "pipeline_name": build.PipelineName},
-		nil,
 	)
 	if err != nil {
 		panic("failed to generate url: " + err.Error())
 	}
 
 	return atc.Build{
-		ID:      build.ID,
-		Name:    build.Name,
-		Status:  string(build.Status),
-		JobName: build.JobName,
-		URL:     req.URL.String(),
+		ID:       build.ID,
+		Name:     build.Name,
+		Status:   string(build.Status),
+		JobName:  build.JobName,
+		URL:      path,
+		TeamName: build.TeamName,
 	}
 }
@@ -0,0 +1,28 @@
+package present
+
+import (
+	"time"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+)
+
+func Container(container worker.Container, id worker.Identifier, ttl time.Duration) atc.Container {
+	return atc.Container{
+		ID:           container.Handle(),
+		WorkerName:   container.WorkerName(),
+		WorkerAddr:   container.WorkerAddr(),
+		TTLInSeconds: int64(ttl / time.Second),
+
+		TeamName:     id.TeamName,
+		PipelineName: id.PipelineName,
+		JobName:      id.JobName,
+		BuildID:      id.BuildID,
+
+		Type:         string(id.Type),
+		Name:         id.Name,
+		StepLocation: id.StepLocation,
+
+		CheckType: id.CheckType,
+	}
+}
@@ -8,12 +8,11 @@ import (
 )
 
 func Job(dbJob db.SavedJob, job atc.JobConfig, groups atc.GroupConfigs, finishedBuild, nextBuild *db.Build) atc.Job {
-	generator := rata.NewRequestGenerator("", routes.Routes)
-
-	req, err := generator.CreateRequest(
+	path, err := routes.PathForTeam(
+		routes.Routes,
+		dbJob.TeamName,
 		routes.GetJob,
 		rata.Params{"job": job.Name, "pipeline_name": dbJob.PipelineName},
-		nil,
 	)
 	if err != nil {
 		panic("failed to generate url: " + err.Error())
@@ -42,7 +41,7 @@ func Job(dbJob db.SavedJob, job atc.JobConfig, groups atc.GroupConfigs, finished
 
 	return atc.Job{
 		Name:          job.Name,
-		URL:           req.URL.String(),
+		URL:           path,
 		Paused:        dbJob.Paused,
 		FinishedBuild: presentedFinishedBuild,
 		NextBuild:     presentedNextBuild,
@@ -8,7 +8,7 @@ import (
 )
 
 func Pipeline(savedPipeline db.SavedPipeline) atc.Pipeline {
-	pathForRoute, err := routes.Routes.CreatePathForRoute(routes.Pipeline, rata.Params{
+	pathForRoute, err := routes.PathForTeam(routes.Routes, savedPipeline.TeamName, routes.Pipeline, rata.Params{
 		"pipeline_name": savedPipeline.Name,
 	})
 
@@ -17,8 +17,9 @@ func Pipeline(savedPipeline db.SavedPipeline) atc.Pipeline {
 	}
 
 	return atc.Pipeline{
-		Name:   savedPipeline.Name,
-		URL:    pathForRoute,
-		Paused: savedPipeline.Paused,
+		Name:     savedPipeline.Name,
+		URL:      pathForRoute,
+		Paused:   savedPipeline.Paused,
+		TeamName: savedPipeline.TeamName,
 	}
 }
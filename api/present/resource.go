@@ -8,12 +8,11 @@ import (
 )
 
 func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource db.SavedResource, showCheckError bool) atc.Resource {
-	generator := rata.NewRequestGenerator("", routes.Routes)
-
-	req, err := generator.CreateRequest(
+	path, err := routes.PathForTeam(
+		routes.Routes,
+		dbResource.TeamName,
 		routes.GetResource,
 		rata.Params{"resource": resource.Name, "pipeline_name": dbResource.PipelineName},
-		nil,
 	)
 	if err != nil {
 		panic("failed to generate url: " + err.Error())
@@ -37,10 +36,12 @@ func Resource(resource atc.ResourceConfig, groups atc.GroupConfigs, dbResource d
 		Name:   resource.Name,
 		Type:   resource.Type,
 		Groups: groupNames,
-		URL:    req.URL.String(),
+		URL:    path,
 
 		Paused: dbResource.Paused,
 
+		PinnedVersionID: dbResource.PinnedVersionID,
+
 		FailingToCheck: dbResource.FailingToCheck(),
 		CheckError:     checkErrString,
 	}
@@ -5,12 +5,27 @@ import (
 	"github.com/concourse/atc/db"
 )
 
-func Worker(workerInfo db.WorkerInfo) atc.Worker {
-	return atc.Worker{
+func Worker(workerInfo db.WorkerInfo, healthy bool) atc.Worker {
+	worker := atc.Worker{
+		Name:             workerInfo.Name,
 		Addr:             workerInfo.Addr,
 		ActiveContainers: workerInfo.ActiveContainers,
+		MaxContainers:    workerInfo.MaxContainers,
 		ResourceTypes:    workerInfo.ResourceTypes,
 		Platform:         workerInfo.Platform,
 		Tags:             workerInfo.Tags,
+		Team:             workerInfo.Team,
+		State:            workerInfo.State,
+		Healthy:          healthy,
 	}
+
+	if !workerInfo.RegisteredAt.IsZero() {
+		worker.RegisteredAt = workerInfo.RegisteredAt.Unix()
+	}
+
+	if !workerInfo.LastHeartbeatAt.IsZero() {
+		worker.LastHeartbeatAt = workerInfo.LastHeartbeatAt.Unix()
+	}
+
+	return worker
 }
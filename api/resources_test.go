@@ -1,16 +1,21 @@
 package api_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
+	radarfakes "github.com/concourse/atc/radar/fakes"
+	"github.com/concourse/atc/resource"
 )
 
 var _ = Describe("Resources API", func() {
@@ -18,7 +23,7 @@ var _ = Describe("Resources API", func() {
 
 	BeforeEach(func() {
 		pipelineDB = new(dbfakes.FakePipelineDB)
-		pipelineDBFactory.BuildWithNameReturns(pipelineDB, nil)
+		pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
 	})
 
 	Describe("GET /api/v1/pipelines/:pipeline_name/resources", func() {
@@ -30,8 +35,9 @@ var _ = Describe("Resources API", func() {
 			response, err = client.Get(server.URL + "/api/v1/pipelines/a-pipeline/resources")
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-			pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+			Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+			teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+			Ω(teamName).Should(Equal(atc.DefaultTeamName))
 			Ω(pipelineName).Should(Equal("a-pipeline"))
 		})
 
@@ -71,9 +77,10 @@ var _ = Describe("Resources API", func() {
 							}, nil
 						} else {
 							return db.SavedResource{
-								ID:           2,
-								Paused:       true,
-								PipelineName: "a-pipeline",
+								ID:              2,
+								Paused:          true,
+								PinnedVersionID: 42,
+								PipelineName:    "a-pipeline",
 								Resource: db.Resource{
 									Name: name,
 								},
@@ -101,6 +108,7 @@ var _ = Describe("Resources API", func() {
 								"type": "type-1",
 								"groups": ["group-1", "group-2"],
 								"paused": true,
+								"pinned_version_id": 42,
 								"url": "/pipelines/a-pipeline/resources/resource-1"
 							},
 							{
@@ -116,6 +124,7 @@ var _ = Describe("Resources API", func() {
 								"type": "type-3",
 								"groups": [],
 								"paused": true,
+								"pinned_version_id": 42,
 								"url": "/pipelines/a-pipeline/resources/resource-3"
 							}
 						]`))
@@ -137,6 +146,7 @@ var _ = Describe("Resources API", func() {
 								"type": "type-1",
 								"groups": ["group-1", "group-2"],
 								"paused": true,
+								"pinned_version_id": 42,
 								"url": "/pipelines/a-pipeline/resources/resource-1"
 							},
 							{
@@ -151,6 +161,7 @@ var _ = Describe("Resources API", func() {
 								"type": "type-3",
 								"groups": [],
 								"paused": true,
+								"pinned_version_id": 42,
 								"url": "/pipelines/a-pipeline/resources/resource-3"
 							}
 						]`))
@@ -202,8 +213,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -262,8 +274,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -322,8 +335,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -382,8 +396,9 @@ var _ = Describe("Resources API", func() {
 			})
 
 			It("injects the proper pipelineDB", func() {
-				Ω(pipelineDBFactory.BuildWithNameCallCount()).Should(Equal(1))
-				pipelineName := pipelineDBFactory.BuildWithNameArgsForCall(0)
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(pipelineName).Should(Equal("a-pipeline"))
 			})
 
@@ -422,4 +437,570 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", func() {
+		var versionID string
+		var response *http.Response
+
+		BeforeEach(func() {
+			versionID = "42"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/versions/"+versionID+"/pin", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("injects the proper pipelineDB", func() {
+				Ω(pipelineDBFactory.BuildWithTeamNameAndNameCallCount()).Should(Equal(1))
+				teamName, pipelineName := pipelineDBFactory.BuildWithTeamNameAndNameArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
+				Ω(pipelineName).Should(Equal("a-pipeline"))
+			})
+
+			Context("when pinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceReturns(nil)
+				})
+
+				It("pinned the right resource to the right version", func() {
+					resourceName, versionID := pipelineDB.PinResourceArgsForCall(0)
+					Ω(resourceName).Should(Equal("resource-name"))
+					Ω(versionID).Should(Equal(42))
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the version does not exist or belongs to another resource", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceReturns(db.ErrNoVersion)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("resource 'resource-name' has no version with id 42"))
+				})
+			})
+
+			Context("when the version id is malformed", func() {
+				BeforeEach(func() {
+					versionID = "nope"
+				})
+
+				It("returns 400 without pinning", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+					Ω(pipelineDB.PinResourceCallCount()).Should(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("malformed version id: nope"))
+				})
+			})
+
+			Context("when pinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.PinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/unpin", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			Context("when unpinning the resource succeeds", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceReturns(nil)
+				})
+
+				It("unpinned the right resource", func() {
+					Ω(pipelineDB.UnpinResourceArgsForCall(0)).Should(Equal("resource-name"))
+				})
+
+				It("returns 200", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when unpinning the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.UnpinResourceReturns(errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("POST /api/v1/pipelines/:pipeline_name/resources/:resource_name/check", func() {
+		var (
+			fakeScanner *radarfakes.FakeScanner
+
+			requestBody io.Reader
+			response    *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeSchedulerFactory.BuildScannerReturns(fakeScanner)
+
+			pipelineDB.GetConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "resource-name", Type: "git"},
+				},
+			}, 1, nil)
+
+			requestBody = nil
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/pipelines/a-pipeline/resources/resource-name/check", requestBody)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+			})
+
+			It("builds a scanner for the pipeline", func() {
+				Ω(fakeSchedulerFactory.BuildScannerCallCount()).Should(Equal(1))
+				Ω(fakeSchedulerFactory.BuildScannerArgsForCall(0)).Should(Equal(pipelineDB))
+			})
+
+			It("checks the resource from its current version", func() {
+				Ω(fakeScanner.ScanFromVersionCallCount()).Should(Equal(1))
+
+				_, resourceName, fromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+				Ω(resourceName).Should(Equal("resource-name"))
+				Ω(fromVersion).Should(BeNil())
+			})
+
+			Context("when a version to check from is given", func() {
+				BeforeEach(func() {
+					requestBody = bytes.NewBufferString(`{"from":{"ref":"abcdef"}}`)
+				})
+
+				It("checks from it", func() {
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(Equal(1))
+
+					_, _, fromVersion := fakeScanner.ScanFromVersionArgsForCall(0)
+					Ω(fromVersion).Should(Equal(atc.Version{"ref": "abcdef"}))
+				})
+			})
+
+			Context("when the request body is invalid", func() {
+				BeforeEach(func() {
+					requestBody = bytes.NewBufferString(`{`)
+				})
+
+				It("returns 400 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the check finds versions", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns([]atc.Version{
+						{"ref": "abcdef"},
+						{"ref": "fedcba"},
+					}, nil)
+				})
+
+				It("returns 200 with the new versions", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(body).Should(MatchJSON(`[{"ref":"abcdef"},{"ref":"fedcba"}]`))
+				})
+			})
+
+			Context("when the check finds no versions", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(nil, nil)
+				})
+
+				It("returns 200 with an empty list", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(body).Should(MatchJSON(`[]`))
+				})
+			})
+
+			Context("when the check script fails", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(nil, resource.ErrResourceScriptFailed{
+						ExitStatus: 2,
+						Stderr:     "bad source",
+					})
+				})
+
+				It("returns 400 with the exit status and stderr", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(body).Should(MatchJSON(`{"exit_status":2,"stderr":"bad source"}`))
+				})
+			})
+
+			Context("when checking fails for another reason", func() {
+				BeforeEach(func() {
+					fakeScanner.ScanFromVersionReturns(nil, errors.New("no workers"))
+				})
+
+				It("returns 500 with the error", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(ContainSubstring("no workers"))
+				})
+			})
+
+			Context("when the resource is not in the config", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 1, nil)
+				})
+
+				It("returns 404 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when getting the config fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetConfigReturns(atc.Config{}, 0, errors.New("welp"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when the pipeline is paused", func() {
+				BeforeEach(func() {
+					pipelineDB.GetPipelineNameReturns("a-pipeline")
+					pipelineDB.IsPausedReturns(true, nil)
+				})
+
+				It("returns 409 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusConflict))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("pipeline 'a-pipeline' is paused"))
+				})
+			})
+
+			Context("when the resource is paused", func() {
+				BeforeEach(func() {
+					pipelineDB.GetResourceReturns(db.SavedResource{
+						Resource: db.Resource{Name: "resource-name"},
+						Paused:   true,
+					}, nil)
+				})
+
+				It("returns 409 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusConflict))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("resource 'resource-name' is paused"))
+				})
+			})
+
+			Context("when checking if the pipeline is paused fails", func() {
+				BeforeEach(func() {
+					pipelineDB.IsPausedReturns(false, errors.New("welp"))
+				})
+
+				It("returns 500 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when getting the resource fails", func() {
+				BeforeEach(func() {
+					pipelineDB.GetResourceReturns(db.SavedResource{}, errors.New("welp"))
+				})
+
+				It("returns 500 without checking", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+				Ω(fakeScanner.ScanFromVersionCallCount()).Should(BeZero())
+			})
+		})
+	})
+
+	Describe("POST /api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", func() {
+		var (
+			fakeScanner *radarfakes.FakeScanner
+
+			webhookURL   string
+			webhookToken string
+			tokenHeader  string
+			response     *http.Response
+		)
+
+		BeforeEach(func() {
+			fakeScanner = new(radarfakes.FakeScanner)
+			fakeSchedulerFactory.BuildScannerReturns(fakeScanner)
+
+			pipelineDB.GetConfigReturns(atc.Config{
+				Resources: atc.ResourceConfigs{
+					{Name: "resource-name", Type: "git", WebhookToken: "some-token"},
+					{Name: "tokenless-resource", Type: "git"},
+				},
+			}, 1, nil)
+
+			pipelineDB.ScopedNameStub = func(name string) string {
+				return "a-pipeline:" + name
+			}
+
+			webhookURL = server.URL
+			webhookToken = "some-token"
+			tokenHeader = ""
+		})
+
+		webhook := func(resourceName string) {
+			var err error
+
+			request, err := http.NewRequest("POST", webhookURL+"/api/v1/pipelines/a-pipeline/resources/"+resourceName+"/check/webhook?webhook_token="+webhookToken, nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			if tokenHeader != "" {
+				request.Header.Set("X-Concourse-Webhook-Token", tokenHeader)
+			}
+
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		Context("when the token matches", func() {
+			JustBeforeEach(func() {
+				webhook("resource-name")
+			})
+
+			It("does not require authentication", func() {
+				Ω(authValidator.IsAuthenticatedCallCount()).Should(BeZero())
+			})
+
+			It("returns 202", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusAccepted))
+			})
+
+			It("scans the resource", func() {
+				Ω(fakeSchedulerFactory.BuildScannerCallCount()).Should(Equal(1))
+				Ω(fakeSchedulerFactory.BuildScannerArgsForCall(0)).Should(Equal(pipelineDB))
+
+				Eventually(fakeScanner.ScanCallCount).Should(Equal(1))
+
+				_, resourceName := fakeScanner.ScanArgsForCall(0)
+				Ω(resourceName).Should(Equal("resource-name"))
+			})
+
+			Context("when the API is behind authentication", func() {
+				BeforeEach(func() {
+					webhookURL = protectedServer.URL
+					authValidator.IsAuthenticatedReturns(false)
+				})
+
+				It("still scans the resource", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusAccepted))
+					Eventually(fakeScanner.ScanCallCount).Should(Equal(1))
+				})
+			})
+
+			Context("when a scan of the resource is already running", func() {
+				var finishScan chan struct{}
+
+				BeforeEach(func() {
+					finishScan = make(chan struct{})
+
+					fakeScanner.ScanStub = func(lager.Logger, string) error {
+						<-finishScan
+						return nil
+					}
+				})
+
+				AfterEach(func() {
+					close(finishScan)
+				})
+
+				It("collapses further requests into one pending scan", func() {
+					Eventually(fakeScanner.ScanCallCount).Should(Equal(1))
+
+					webhook("resource-name")
+					Ω(response.StatusCode).Should(Equal(http.StatusAccepted))
+
+					webhook("resource-name")
+					Ω(response.StatusCode).Should(Equal(http.StatusAccepted))
+
+					Consistently(fakeScanner.ScanCallCount).Should(Equal(1))
+
+					finishScan <- struct{}{}
+
+					Eventually(fakeScanner.ScanCallCount).Should(Equal(2))
+
+					finishScan <- struct{}{}
+
+					Consistently(fakeScanner.ScanCallCount).Should(Equal(2))
+				})
+			})
+		})
+
+		Context("when the token is given in a header", func() {
+			BeforeEach(func() {
+				webhookToken = ""
+				tokenHeader = "some-token"
+			})
+
+			JustBeforeEach(func() {
+				webhook("resource-name")
+			})
+
+			It("scans the resource", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusAccepted))
+				Eventually(fakeScanner.ScanCallCount).Should(Equal(1))
+			})
+		})
+
+		Context("when the token in the header does not match", func() {
+			BeforeEach(func() {
+				webhookToken = ""
+				tokenHeader = "wrong-token"
+			})
+
+			JustBeforeEach(func() {
+				webhook("resource-name")
+			})
+
+			It("returns 401 without scanning", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+				Consistently(fakeScanner.ScanCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the token does not match", func() {
+			BeforeEach(func() {
+				webhookToken = "wrong-token"
+			})
+
+			JustBeforeEach(func() {
+				webhook("resource-name")
+			})
+
+			It("returns 401 without scanning", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+				Consistently(fakeScanner.ScanCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the resource has no webhook token", func() {
+			BeforeEach(func() {
+				webhookToken = ""
+			})
+
+			JustBeforeEach(func() {
+				webhook("tokenless-resource")
+			})
+
+			It("returns 401 without scanning", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+				Consistently(fakeScanner.ScanCallCount).Should(BeZero())
+			})
+		})
+
+		Context("when the resource is not in the config", func() {
+			JustBeforeEach(func() {
+				webhook("bogus-resource")
+			})
+
+			It("returns 404", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
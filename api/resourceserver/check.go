@@ -0,0 +1,102 @@
+package resourceserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/resource"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) CheckResource(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		logger := s.logger.Session("check-resource", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"resource": resourceName,
+		})
+
+		var reqBody atc.CheckRequestBody
+		err := json.NewDecoder(r.Body).Decode(&reqBody)
+		if err != nil && err != io.EOF {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		_, found := config.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		pipelinePaused, err := pipelineDB.IsPaused()
+		if err != nil {
+			logger.Error("failed-to-check-if-pipeline-paused", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if pipelinePaused {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "pipeline '%s' is paused", pipelineDB.GetPipelineName())
+			return
+		}
+
+		savedResource, err := pipelineDB.GetResource(resourceName)
+		if err != nil {
+			logger.Error("failed-to-get-resource", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if savedResource.Paused {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, "resource '%s' is paused", resourceName)
+			return
+		}
+
+		scanner := s.scannerFactory.BuildScanner(pipelineDB)
+
+		versions, err := scanner.ScanFromVersion(logger, resourceName, reqBody.From)
+		if err != nil {
+			logger.Error("failed-to-check", err)
+
+			if scriptErr, ok := err.(resource.ErrResourceScriptFailed); ok {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+
+				json.NewEncoder(w).Encode(atc.CheckResponseBody{
+					ExitStatus: scriptErr.ExitStatus,
+					Stderr:     scriptErr.Stderr,
+				})
+
+				return
+			}
+
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if versions == nil {
+			versions = []atc.Version{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		json.NewEncoder(w).Encode(versions)
+	})
+}
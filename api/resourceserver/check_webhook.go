@@ -0,0 +1,94 @@
+package resourceserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+// WebhookTokenHeader may carry the webhook token instead of the
+// webhook_token query parameter, keeping it out of access logs.
+const WebhookTokenHeader = "X-Concourse-Webhook-Token"
+
+// CheckResourceWebhook kicks off a check of the resource without requiring
+// authentication, as long as the request carries the resource's configured
+// webhook_token. The check runs in the background; its outcome is recorded
+// like any other check. Requests arriving while a check is running collapse
+// into a single check that runs once it finishes.
+func (s *Server) CheckResourceWebhook(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+		webhookToken := r.Header.Get(WebhookTokenHeader)
+		if webhookToken == "" {
+			webhookToken = r.URL.Query().Get("webhook_token")
+		}
+
+		logger := s.logger.Session("check-resource-webhook", lager.Data{
+			"pipeline": pipelineDB.GetPipelineName(),
+			"resource": resourceName,
+		})
+
+		config, _, err := pipelineDB.GetConfig()
+		if err != nil {
+			logger.Error("failed-to-get-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resourceConfig, found := config.Resources.Lookup(resourceName)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if resourceConfig.WebhookToken == "" || subtle.ConstantTimeCompare([]byte(webhookToken), []byte(resourceConfig.WebhookToken)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		s.scanInBackground(logger, pipelineDB, resourceName)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func (s *Server) scanInBackground(logger lager.Logger, pipelineDB db.PipelineDB, resourceName string) {
+	key := pipelineDB.ScopedName(resourceName)
+
+	s.scansL.Lock()
+	defer s.scansL.Unlock()
+
+	if _, running := s.scans[key]; running {
+		logger.Debug("scan-already-running")
+		s.scans[key] = true
+		return
+	}
+
+	s.scans[key] = false
+
+	scanner := s.scannerFactory.BuildScanner(pipelineDB)
+
+	go func() {
+		for {
+			err := scanner.Scan(logger, resourceName)
+			if err != nil {
+				logger.Error("failed-to-scan", err)
+			}
+
+			s.scansL.Lock()
+
+			if !s.scans[key] {
+				delete(s.scans, key)
+				s.scansL.Unlock()
+				return
+			}
+
+			s.scans[key] = false
+
+			s.scansL.Unlock()
+		}
+	}()
+}
@@ -0,0 +1,37 @@
+package resourceserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/atc/db"
+	"github.com/tedsuo/rata"
+)
+
+func (s *Server) PinResourceVersion(pipelineDB db.PipelineDB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resourceName := rata.Param(r, "resource_name")
+
+		versionID, err := strconv.Atoi(rata.Param(r, "resource_version_id"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "malformed version id: %s", rata.Param(r, "resource_version_id"))
+			return
+		}
+
+		err = pipelineDB.PinResource(resourceName, versionID)
+		if err == db.ErrNoVersion {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "resource '%s' has no version with id %d", resourceName, versionID)
+			return
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -1,23 +1,40 @@
 package resourceserver
 
 import (
+	"sync"
+
 	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/radar"
 )
 
 type Server struct {
 	logger lager.Logger
 
-	validator auth.Validator
+	validator      auth.Validator
+	scannerFactory ScannerFactory
+
+	// background scans by scoped resource name, and whether another scan is
+	// pending once they finish
+	scans  map[string]bool
+	scansL sync.Mutex
+}
+
+type ScannerFactory interface {
+	BuildScanner(pipelineDB db.PipelineDB) radar.Scanner
 }
 
 func NewServer(
 	logger lager.Logger,
 	validator auth.Validator,
+	scannerFactory ScannerFactory,
 ) *Server {
 	return &Server{
-		logger:    logger,
-		validator: validator,
+		logger:         logger,
+		validator:      validator,
+		scannerFactory: scannerFactory,
+		scans:          map[string]bool{},
 	}
 }
@@ -0,0 +1,116 @@
+package api_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc/auth"
+	dbfakes "github.com/concourse/atc/db/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Roles", func() {
+	var pipelineDB *dbfakes.FakePipelineDB
+
+	BeforeEach(func() {
+		pipelineDB = new(dbfakes.FakePipelineDB)
+		pipelineDBFactory.BuildWithTeamNameAndNameReturns(pipelineDB, nil)
+
+		authValidator.IsAuthenticatedReturns(true)
+	})
+
+	request := func(method string, path string) *http.Response {
+		req, err := http.NewRequest(method, server.URL+path, bytes.NewBufferString("{}"))
+		Ω(err).ShouldNot(HaveOccurred())
+
+		response, err := client.Do(req)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return response
+	}
+
+	Context("when the caller is a viewer", func() {
+		BeforeEach(func() {
+			authValidator.RoleReturns(auth.RoleViewer)
+		})
+
+		It("may read the config", func() {
+			Ω(request("GET", "/api/v1/pipelines/a-pipeline/config").StatusCode).Should(Equal(http.StatusOK))
+		})
+
+		It("may not pause a pipeline", func() {
+			response := request("PUT", "/api/v1/pipelines/a-pipeline/pause")
+			Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(body)).Should(ContainSubstring("operator"))
+
+			Ω(pipelineDB.PauseCallCount()).Should(BeZero())
+		})
+
+		It("may not abort a build", func() {
+			Ω(request("POST", "/api/v1/builds/42/abort").StatusCode).Should(Equal(http.StatusForbidden))
+			Ω(buildsDB.GetBuildCallCount()).Should(BeZero())
+		})
+	})
+
+	Context("when the caller is an operator", func() {
+		BeforeEach(func() {
+			authValidator.RoleReturns(auth.RoleOperator)
+		})
+
+		It("may pause a pipeline", func() {
+			Ω(request("PUT", "/api/v1/pipelines/a-pipeline/pause").StatusCode).Should(Equal(http.StatusOK))
+			Ω(pipelineDB.PauseCallCount()).Should(Equal(1))
+		})
+
+		It("may not delete a pipeline", func() {
+			response := request("DELETE", "/api/v1/pipelines/a-pipeline")
+			Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(body)).Should(ContainSubstring("admin"))
+
+			Ω(pipelineDB.DestroyCallCount()).Should(BeZero())
+		})
+
+		It("may not save the config", func() {
+			Ω(request("PUT", "/api/v1/pipelines/a-pipeline/config").StatusCode).Should(Equal(http.StatusForbidden))
+			Ω(configDB.SaveConfigCallCount()).Should(BeZero())
+		})
+
+		It("may not set the log level", func() {
+			Ω(request("PUT", "/api/v1/log-level").StatusCode).Should(Equal(http.StatusForbidden))
+		})
+
+		It("may not register a worker", func() {
+			Ω(request("POST", "/api/v1/workers").StatusCode).Should(Equal(http.StatusForbidden))
+			Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+		})
+	})
+
+	Context("when the caller is an admin", func() {
+		BeforeEach(func() {
+			authValidator.RoleReturns(auth.RoleAdmin)
+		})
+
+		It("may delete a pipeline", func() {
+			Ω(request("DELETE", "/api/v1/pipelines/a-pipeline").StatusCode).Should(Equal(http.StatusNoContent))
+			Ω(pipelineDB.DestroyCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the caller is not authenticated", func() {
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(false)
+		})
+
+		It("returns 401 rather than 403", func() {
+			Ω(request("DELETE", "/api/v1/pipelines/a-pipeline").StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+	})
+})
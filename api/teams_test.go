@@ -0,0 +1,167 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Teams API", func() {
+	Describe("GET /api/v1/teams", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look up the teams", func() {
+				Ω(teamsDB.GetTeamsCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when getting the teams succeeds", func() {
+			BeforeEach(func() {
+				teamsDB.GetTeamsReturns([]db.SavedTeam{
+					{ID: 1, Team: db.Team{Name: "main"}},
+					{ID: 2, Team: db.Team{Name: "some-team"}},
+				}, nil)
+			})
+
+			It("returns 200 OK", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+			})
+
+			It("returns the teams", func() {
+				body, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(body).Should(MatchJSON(`[
+					{"name": "main"},
+					{"name": "some-team"}
+				]`))
+			})
+		})
+
+		Context("when getting the teams fails", func() {
+			BeforeEach(func() {
+				teamsDB.GetTeamsReturns(nil, errors.New("oh no!"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when authenticated as the main team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				authValidator.TeamNameReturns(atc.DefaultTeamName)
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					teamsDB.GetTeamByNameReturns(db.SavedTeam{}, db.ErrNoTeam)
+					teamsDB.SaveTeamReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, nil)
+				})
+
+				It("returns 201 Created", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusCreated))
+				})
+
+				It("saves the team", func() {
+					Ω(teamsDB.SaveTeamCallCount()).Should(Equal(1))
+					Ω(teamsDB.SaveTeamArgsForCall(0)).Should(Equal(db.Team{Name: "some-team"}))
+				})
+
+				It("returns the team", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(body).Should(MatchJSON(`{"name": "some-team"}`))
+				})
+
+				Context("when saving the team fails", func() {
+					BeforeEach(func() {
+						teamsDB.SaveTeamReturns(db.SavedTeam{}, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the team already exists", func() {
+				BeforeEach(func() {
+					teamsDB.GetTeamByNameReturns(db.SavedTeam{ID: 2, Team: db.Team{Name: "some-team"}}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+				})
+
+				It("does not save it again", func() {
+					Ω(teamsDB.SaveTeamCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when authenticated as another team", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				authValidator.TeamNameReturns("some-other-team")
+			})
+
+			It("returns 403 Forbidden", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+			})
+
+			It("does not save the team", func() {
+				Ω(teamsDB.SaveTeamCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+})
@@ -0,0 +1,27 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+)
+
+func (s *Server) ListTeams(w http.ResponseWriter, r *http.Request) {
+	savedTeams, err := s.db.GetTeams()
+	if err != nil {
+		s.logger.Error("failed-to-get-teams", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	teams := make([]atc.Team, len(savedTeams))
+	for i, team := range savedTeams {
+		teams[i] = atc.Team{Name: team.Name}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	json.NewEncoder(w).Encode(teams)
+}
@@ -0,0 +1,44 @@
+package teamserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+func (s *Server) SaveTeam(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+
+	hLog := s.logger.Session("save-team", lager.Data{
+		"team": teamName,
+	})
+
+	// only the main team may create teams
+	if s.validator.TeamName(r) != atc.DefaultTeamName {
+		auth.Forbidden(w, atc.DefaultTeamName)
+		return
+	}
+
+	status := http.StatusOK
+
+	savedTeam, err := s.db.GetTeamByName(teamName)
+	if err == db.ErrNoTeam {
+		savedTeam, err = s.db.SaveTeam(db.Team{Name: teamName})
+		status = http.StatusCreated
+	}
+
+	if err != nil {
+		hLog.Error("failed-to-save-team", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(atc.Team{Name: savedTeam.Name})
+}
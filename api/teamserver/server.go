@@ -0,0 +1,26 @@
+package teamserver
+
+import (
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+type Server struct {
+	logger lager.Logger
+
+	validator auth.Validator
+	db        db.TeamsDB
+}
+
+func NewServer(
+	logger lager.Logger,
+	validator auth.Validator,
+	db db.TeamsDB,
+) *Server {
+	return &Server{
+		logger:    logger,
+		validator: validator,
+		db:        db,
+	}
+}
@@ -6,11 +6,16 @@ import (
 	"errors"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/workerserver"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
 )
 
 var _ = Describe("Workers API", func() {
@@ -36,13 +41,19 @@ var _ = Describe("Workers API", func() {
 				BeforeEach(func() {
 					workerDB.WorkersReturns([]db.WorkerInfo{
 						{
+							Name:             "worker-a",
 							Addr:             "1.2.3.4:7777",
 							ActiveContainers: 1,
+							MaxContainers:    10,
 							ResourceTypes: []atc.WorkerResourceType{
 								{Type: "some-resource", Image: "some-resource-image"},
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
+							State:    atc.WorkerStateRunning,
+
+							RegisteredAt:    time.Unix(100, 0),
+							LastHeartbeatAt: time.Unix(200, 0),
 						},
 						{
 							Addr:             "1.2.3.4:8888",
@@ -52,8 +63,13 @@ var _ = Describe("Workers API", func() {
 							},
 							Platform: "beos",
 							Tags:     []string{"best", "os", "ever", "rip"},
+							State:    atc.WorkerStateStalled,
 						},
 					}, nil)
+
+					fakeWorkerHealth.HealthyStub = func(name string) bool {
+						return name == "worker-a"
+					}
 				})
 
 				It("returns 200", func() {
@@ -67,13 +83,20 @@ var _ = Describe("Workers API", func() {
 
 					Ω(returnedWorkers).Should(Equal([]atc.Worker{
 						{
+							Name:             "worker-a",
 							Addr:             "1.2.3.4:7777",
 							ActiveContainers: 1,
+							MaxContainers:    10,
 							ResourceTypes: []atc.WorkerResourceType{
 								{Type: "some-resource", Image: "some-resource-image"},
 							},
 							Platform: "freebsd",
 							Tags:     []string{"demon"},
+							State:    atc.WorkerStateRunning,
+							Healthy:  true,
+
+							RegisteredAt:    100,
+							LastHeartbeatAt: 200,
 						},
 						{
 							Addr:             "1.2.3.4:8888",
@@ -83,9 +106,16 @@ var _ = Describe("Workers API", func() {
 							},
 							Platform: "beos",
 							Tags:     []string{"best", "os", "ever", "rip"},
+							State:    atc.WorkerStateStalled,
+							Healthy:  false,
 						},
 					}))
 				})
+
+				It("looks up the health of each worker by name", func() {
+					Ω(fakeWorkerHealth.HealthyCallCount()).Should(Equal(2))
+					Ω(fakeWorkerHealth.HealthyArgsForCall(0)).Should(Equal("worker-a"))
+				})
 			})
 
 			Context("when getting the workers fails", func() {
@@ -110,10 +140,139 @@ var _ = Describe("Workers API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/workers", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+
+			workerDB.WorkersReturns([]db.WorkerInfo{
+				{Addr: "1.2.3.4:7777", Platform: "linux"},
+				{Addr: "1.2.3.4:8888", Platform: "linux", Team: "some-team"},
+				{Addr: "1.2.3.4:9999", Platform: "linux", Team: "some-other-team"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/workers", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns the shared workers and the team's own workers", func() {
+			Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+			var returnedWorkers []atc.Worker
+			err := json.NewDecoder(response.Body).Decode(&returnedWorkers)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(returnedWorkers).Should(Equal([]atc.Worker{
+				{Addr: "1.2.3.4:7777", Platform: "linux", Healthy: true},
+				{Addr: "1.2.3.4:8888", Platform: "linux", Team: "some-team", Healthy: true},
+			}))
+		})
+	})
+
+	Describe("POST /api/v1/teams/:team_name/workers", func() {
+		var (
+			authorization string
+			serverURL     string
+
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			authValidator.IsAuthenticatedReturns(true)
+			workerDB.GetWorkerReturns(db.WorkerInfo{}, db.ErrNoWorker)
+
+			authorization = ""
+			serverURL = server.URL
+		})
+
+		JustBeforeEach(func() {
+			payload, err := json.Marshal(atc.Worker{
+				Addr:     "1.2.3.4:7777",
+				Platform: "linux",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			req, err := http.NewRequest("POST", serverURL+"/api/v1/teams/some-team/workers?ttl=30s", ioutil.NopCloser(bytes.NewBuffer(payload)))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+
+			response, err = client.Do(req)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("when the user belongs to the team", func() {
+			BeforeEach(func() {
+				authValidator.TeamNameReturns("some-team")
+			})
+
+			It("saves the worker as belonging to the team", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+				Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+				savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+				Ω(savedInfo.Team).Should(Equal("some-team"))
+			})
+
+			Context("when the team does not exist", func() {
+				BeforeEach(func() {
+					workerDB.SaveWorkerReturns(db.ErrNoTeam)
+				})
+
+				It("returns 404", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("team 'some-team' does not exist"))
+				})
+			})
+		})
+
+		Context("when the user belongs to another team", func() {
+			BeforeEach(func() {
+				authValidator.TeamNameReturns("some-other-team")
+			})
+
+			It("returns 403 without saving the worker", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+				Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when presenting a registration token to an API that requires authentication", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				authorization = "Worker " + workerserver.RegistrationToken("some-worker-key", "1.2.3.4:7777")
+				serverURL = protectedServer.URL
+			})
+
+			It("saves the worker as belonging to the team", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+				Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+				savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+				Ω(savedInfo.Team).Should(Equal("some-team"))
+			})
+		})
+	})
+
 	Describe("POST /api/v1/workers", func() {
 		var (
-			worker atc.Worker
-			ttl    string
+			worker        atc.Worker
+			ttl           string
+			authorization string
+			serverURL     string
 
 			response *http.Response
 		)
@@ -122,6 +281,7 @@ var _ = Describe("Workers API", func() {
 			worker = atc.Worker{
 				Addr:             "1.2.3.4:7777",
 				ActiveContainers: 2,
+				MaxContainers:    5,
 				ResourceTypes: []atc.WorkerResourceType{
 					{Type: "some-resource", Image: "some-resource-image"},
 				},
@@ -130,15 +290,23 @@ var _ = Describe("Workers API", func() {
 			}
 
 			ttl = "30s"
+			authorization = ""
+			serverURL = server.URL
+
+			workerDB.GetWorkerReturns(db.WorkerInfo{}, db.ErrNoWorker)
 		})
 
 		JustBeforeEach(func() {
 			payload, err := json.Marshal(worker)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			req, err := http.NewRequest("POST", server.URL+"/api/v1/workers?ttl="+ttl, ioutil.NopCloser(bytes.NewBuffer(payload)))
+			req, err := http.NewRequest("POST", serverURL+"/api/v1/workers?ttl="+ttl, ioutil.NopCloser(bytes.NewBuffer(payload)))
 			Ω(err).ShouldNot(HaveOccurred())
 
+			if authorization != "" {
+				req.Header.Set("Authorization", authorization)
+			}
+
 			response, err = client.Do(req)
 			Ω(err).ShouldNot(HaveOccurred())
 		})
@@ -158,8 +326,10 @@ var _ = Describe("Workers API", func() {
 
 					savedInfo, savedTTL := workerDB.SaveWorkerArgsForCall(0)
 					Ω(savedInfo).Should(Equal(db.WorkerInfo{
+						Name:             "1.2.3.4:7777",
 						Addr:             "1.2.3.4:7777",
 						ActiveContainers: 2,
+						MaxContainers:    5,
 						ResourceTypes: []atc.WorkerResourceType{
 							{Type: "some-resource", Image: "some-resource-image"},
 						},
@@ -178,6 +348,189 @@ var _ = Describe("Workers API", func() {
 						Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("and the worker has been retired", func() {
+					BeforeEach(func() {
+						workerDB.SaveWorkerReturns(db.ErrWorkerRetired)
+					})
+
+					It("returns 410", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusGone))
+
+						body, err := ioutil.ReadAll(response.Body)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(string(body)).Should(Equal("worker '1.2.3.4:7777' has been retired; register it as running to bring it back"))
+					})
+				})
+			})
+
+			Context("when the worker has a name", func() {
+				BeforeEach(func() {
+					worker.Name = "some-worker"
+				})
+
+				It("saves it under that name", func() {
+					Ω(workerDB.GetWorkerCallCount()).Should(Equal(1))
+					Ω(workerDB.GetWorkerArgsForCall(0)).Should(Equal("some-worker"))
+
+					Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+					savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+					Ω(savedInfo.Name).Should(Equal("some-worker"))
+					Ω(savedInfo.Addr).Should(Equal("1.2.3.4:7777"))
+				})
+
+				Context("when the worker is known at the same address", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.WorkerInfo{
+							Name: "some-worker",
+							Addr: "1.2.3.4:7777",
+						}, nil)
+					})
+
+					It("saves it", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusOK))
+						Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+					})
+				})
+
+				Context("when the worker is known at a different address", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.WorkerInfo{
+							Name: "some-worker",
+							Addr: "5.6.7.8:7777",
+						}, nil)
+					})
+
+					It("returns 409", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusConflict))
+					})
+
+					It("does not save it", func() {
+						Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+					})
+
+					It("tells the worker to re-authenticate", func() {
+						body, err := ioutil.ReadAll(response.Body)
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(string(body)).Should(Equal("worker 'some-worker' is registered at a different address; re-register with its registration token to move it"))
+					})
+
+					Context("when the worker presents its registration token", func() {
+						BeforeEach(func() {
+							authorization = "Worker " + workerserver.RegistrationToken("some-worker-key", "some-worker")
+						})
+
+						It("saves it at the new address", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+							Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+							savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+							Ω(savedInfo.Addr).Should(Equal("1.2.3.4:7777"))
+						})
+					})
+
+					Context("when no registration key is configured", func() {
+						var noKeyServer *httptest.Server
+
+						BeforeEach(func() {
+							workerServer := workerserver.NewServer(
+								lagertest.NewTestLogger("test"),
+								workerDB,
+								fakeWorkerHealth,
+								authValidator,
+								"",
+							)
+
+							noKeyServer = httptest.NewServer(http.HandlerFunc(workerServer.RegisterWorker))
+							serverURL = noKeyServer.URL
+						})
+
+						AfterEach(func() {
+							noKeyServer.Close()
+						})
+
+						It("saves it at the new address", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusOK))
+
+							Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+							savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+							Ω(savedInfo.Name).Should(Equal("some-worker"))
+							Ω(savedInfo.Addr).Should(Equal("1.2.3.4:7777"))
+						})
+					})
+
+					Context("when the worker presents another worker's registration token", func() {
+						BeforeEach(func() {
+							authorization = "Worker " + workerserver.RegistrationToken("some-worker-key", "some-other-worker")
+						})
+
+						It("returns 401 without saving it", func() {
+							Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+							Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+						})
+					})
+				})
+
+				Context("when looking up the worker fails", func() {
+					BeforeEach(func() {
+						workerDB.GetWorkerReturns(db.WorkerInfo{}, errors.New("oh no!"))
+					})
+
+					It("returns 500", func() {
+						Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+					})
+
+					It("does not save it", func() {
+						Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+					})
+				})
+			})
+
+			Context("when the worker is landing", func() {
+				BeforeEach(func() {
+					worker.State = atc.WorkerStateLanding
+				})
+
+				It("saves its state", func() {
+					Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+					savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+					Ω(savedInfo.State).Should(Equal(atc.WorkerStateLanding))
+				})
+			})
+
+			Context("when the worker is retiring", func() {
+				BeforeEach(func() {
+					worker.State = atc.WorkerStateRetiring
+				})
+
+				It("saves its state", func() {
+					Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+
+					savedInfo, _ := workerDB.SaveWorkerArgsForCall(0)
+					Ω(savedInfo.State).Should(Equal(atc.WorkerStateRetiring))
+				})
+			})
+
+			Context("when the worker's state is invalid", func() {
+				BeforeEach(func() {
+					worker.State = atc.WorkerStateStalled
+				})
+
+				It("returns 400", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+				})
+
+				It("returns the validation error in the response body", func() {
+					Ω(ioutil.ReadAll(response.Body)).Should(Equal([]byte("invalid state: stalled")))
+				})
+
+				It("does not save it", func() {
+					Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+				})
 			})
 
 			Context("when the TTL is invalid", func() {
@@ -230,5 +583,85 @@ var _ = Describe("Workers API", func() {
 				Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
 			})
 		})
+
+		Context("when authenticated as a non-admin", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(true)
+				authValidator.RoleReturns(auth.RoleOperator)
+			})
+
+			It("returns 403", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+			})
+
+			It("does not save it", func() {
+				Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when presenting the worker's registration token", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				authorization = "Worker " + workerserver.RegistrationToken("some-worker-key", "1.2.3.4:7777")
+			})
+
+			It("returns 200", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+			})
+
+			It("saves it without checking for a known worker", func() {
+				Ω(workerDB.GetWorkerCallCount()).Should(BeZero())
+				Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+			})
+
+			Context("when the API requires authentication", func() {
+				BeforeEach(func() {
+					serverURL = protectedServer.URL
+				})
+
+				It("lets the worker register itself", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusOK))
+					Ω(workerDB.SaveWorkerCallCount()).Should(Equal(1))
+				})
+			})
+		})
+
+		Context("when presenting the shared registration key itself", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				authorization = "Worker some-worker-key"
+			})
+
+			It("returns 401 without saving it", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+				Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when presenting a token signed with another key", func() {
+			BeforeEach(func() {
+				authValidator.IsAuthenticatedReturns(false)
+				authorization = "Worker " + workerserver.RegistrationToken("bogus-key", "1.2.3.4:7777")
+			})
+
+			It("returns 401", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not save it", func() {
+				Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+			})
+
+			Context("when the API requires authentication", func() {
+				BeforeEach(func() {
+					serverURL = protectedServer.URL
+				})
+
+				It("still returns 401", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+					Ω(workerDB.SaveWorkerCallCount()).Should(BeZero())
+				})
+			})
+		})
 	})
 })
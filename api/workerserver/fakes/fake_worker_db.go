@@ -19,6 +19,15 @@ type FakeWorkerDB struct {
 	saveWorkerReturns struct {
 		result1 error
 	}
+	GetWorkerStub        func(name string) (db.WorkerInfo, error)
+	getWorkerMutex       sync.RWMutex
+	getWorkerArgsForCall []struct {
+		name string
+	}
+	getWorkerReturns struct {
+		result1 db.WorkerInfo
+		result2 error
+	}
 	WorkersStub        func() ([]db.WorkerInfo, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct{}
@@ -61,6 +70,39 @@ func (fake *FakeWorkerDB) SaveWorkerReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorkerDB) GetWorker(name string) (db.WorkerInfo, error) {
+	fake.getWorkerMutex.Lock()
+	fake.getWorkerArgsForCall = append(fake.getWorkerArgsForCall, struct {
+		name string
+	}{name})
+	fake.getWorkerMutex.Unlock()
+	if fake.GetWorkerStub != nil {
+		return fake.GetWorkerStub(name)
+	} else {
+		return fake.getWorkerReturns.result1, fake.getWorkerReturns.result2
+	}
+}
+
+func (fake *FakeWorkerDB) GetWorkerCallCount() int {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return len(fake.getWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerDB) GetWorkerArgsForCall(i int) string {
+	fake.getWorkerMutex.RLock()
+	defer fake.getWorkerMutex.RUnlock()
+	return fake.getWorkerArgsForCall[i].name
+}
+
+func (fake *FakeWorkerDB) GetWorkerReturns(result1 db.WorkerInfo, result2 error) {
+	fake.GetWorkerStub = nil
+	fake.getWorkerReturns = struct {
+		result1 db.WorkerInfo
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerDB) Workers() ([]db.WorkerInfo, error) {
 	fake.workersMutex.Lock()
 	fake.workersArgsForCall = append(fake.workersArgsForCall, struct{}{})
@@ -15,9 +15,15 @@ func (s *Server) ListWorkers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	workers := make([]atc.Worker, len(workerInfos))
-	for i := 0; i < len(workerInfos); i++ {
-		workers[i] = present.Worker(workerInfos[i])
+	teamName := r.FormValue(":team_name")
+
+	workers := []atc.Worker{}
+	for _, info := range workerInfos {
+		if teamName != "" && info.Team != "" && info.Team != teamName {
+			continue
+		}
+
+		workers = append(workers, present.Worker(info, s.health.Healthy(info.Name)))
 	}
 
 	json.NewEncoder(w).Encode(workers)
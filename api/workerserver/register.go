@@ -1,17 +1,36 @@
 package workerserver
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
 	"expvar"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
+// RegistrationTokenType is the Authorization scheme workers use to present
+// their registration token, i.e. "Authorization: Worker <token>".
+const RegistrationTokenType = "Worker"
+
+// RegistrationToken returns the token the named worker presents to register
+// itself. It is signed with the registration key, and is only valid for the
+// worker it was issued to.
+func RegistrationToken(registrationKey string, workerName string) string {
+	mac := hmac.New(sha256.New, []byte(registrationKey))
+	mac.Write([]byte(workerName))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 type IntMetric int
 
 func (i IntMetric) String() string {
@@ -21,6 +40,26 @@ func (i IntMetric) String() string {
 var workerContainers = expvar.NewMap("WorkerContainers")
 
 func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
+	token, presentedToken := s.registrationToken(r)
+
+	if !presentedToken {
+		if !s.validator.IsAuthenticated(r) {
+			auth.Unauthorized(w)
+			return
+		}
+
+		if !s.validator.Role(r).Permits(auth.RoleAdmin) {
+			auth.MissingPermission(w, auth.RoleAdmin)
+			return
+		}
+
+		teamName := auth.RequestedTeamName(r)
+		if !auth.IsAuthorizedForTeam(s.validator, r, teamName) {
+			auth.Forbidden(w, teamName)
+			return
+		}
+	}
+
 	var registration atc.Worker
 	err := json.NewDecoder(r.Body).Decode(&registration)
 	if err != nil {
@@ -34,6 +73,14 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch registration.State {
+	case "", atc.WorkerStateRunning, atc.WorkerStateLanding, atc.WorkerStateRetiring:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "invalid state: %s", registration.State)
+		return
+	}
+
 	var ttl time.Duration
 
 	ttlStr := r.URL.Query().Get("ttl")
@@ -46,15 +93,63 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	workerContainers.Set(registration.Addr, IntMetric(registration.ActiveContainers))
+	name := registration.Name
+	if name == "" {
+		name = registration.Addr
+	}
+
+	if presentedToken && !s.validRegistrationToken(token, name) {
+		auth.Unauthorized(w)
+		return
+	}
+
+	// only the worker itself may move a known worker, so that other
+	// credentials cannot redirect its containers elsewhere; without a
+	// registration key workers have no other way to authenticate, so they
+	// may move freely
+	if !presentedToken && s.registrationKey != "" {
+		existing, err := s.db.GetWorker(name)
+		switch err {
+		case nil:
+			if existing.Addr != registration.Addr {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, "worker '%s' is registered at a different address; re-register with its registration token to move it", name)
+				return
+			}
+		case db.ErrNoWorker:
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	workerContainers.Set(name, IntMetric(registration.ActiveContainers))
+
+	teamName := r.FormValue(":team_name")
 
 	err = s.db.SaveWorker(db.WorkerInfo{
+		Name:             name,
 		Addr:             registration.Addr,
 		ActiveContainers: registration.ActiveContainers,
+		MaxContainers:    registration.MaxContainers,
 		ResourceTypes:    registration.ResourceTypes,
 		Platform:         registration.Platform,
 		Tags:             registration.Tags,
+		Team:             teamName,
+		State:            registration.State,
 	}, ttl)
+	if err == db.ErrNoTeam {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "team '%s' does not exist", teamName)
+		return
+	}
+
+	if err == db.ErrWorkerRetired {
+		w.WriteHeader(http.StatusGone)
+		fmt.Fprintf(w, "worker '%s' has been retired; register it as %s to bring it back", name, atc.WorkerStateRunning)
+		return
+	}
+
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -62,3 +157,24 @@ func (s *Server) RegisterWorker(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// registrationToken returns the token presented by the request, if workers
+// may register with one.
+func (s *Server) registrationToken(r *http.Request) (string, bool) {
+	if s.registrationKey == "" {
+		return "", false
+	}
+
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, RegistrationTokenType+" ") {
+		return "", false
+	}
+
+	return header[len(RegistrationTokenType)+1:], true
+}
+
+func (s *Server) validRegistrationToken(token string, workerName string) bool {
+	expected := RegistrationToken(s.registrationKey, workerName)
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
@@ -3,29 +3,43 @@ package workerserver
 import (
 	"time"
 
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
 	"github.com/pivotal-golang/lager"
 )
 
 type Server struct {
 	logger lager.Logger
 
-	db WorkerDB
+	db        WorkerDB
+	health    worker.WorkerHealth
+	validator auth.Validator
+
+	// empty if workers must register as an admin
+	registrationKey string
 }
 
 //go:generate counterfeiter . WorkerDB
 
 type WorkerDB interface {
 	SaveWorker(db.WorkerInfo, time.Duration) error
+	GetWorker(name string) (db.WorkerInfo, error)
 	Workers() ([]db.WorkerInfo, error)
 }
 
 func NewServer(
 	logger lager.Logger,
 	db WorkerDB,
+	health worker.WorkerHealth,
+	validator auth.Validator,
+	registrationKey string,
 ) *Server {
 	return &Server{
-		logger: logger,
-		db:     db,
+		logger:          logger,
+		db:              db,
+		health:          health,
+		validator:       validator,
+		registrationKey: registrationKey,
 	}
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -13,20 +14,27 @@ type CookieSetHandler struct {
 
 func (handler CookieSetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	auth := r.Header.Get("Authorization")
+	fromCookie := false
+
 	if auth == "" {
 		cookie, err := r.Cookie(CookieName)
 		if err == nil {
 			auth = cookie.Value
+			fromCookie = true
 		}
 	}
 
 	if auth != "" {
-		http.SetCookie(w, &http.Cookie{
-			Name:    CookieName,
-			Value:   auth,
-			Path:    "/",
-			Expires: time.Now().Add(1 * time.Minute),
-		})
+		// session tokens set by the OAuth callback carry their own expiry;
+		// refreshing them here would cut the session down to a minute
+		if !(fromCookie && strings.HasPrefix(auth, TokenTypeBearer+" ")) {
+			http.SetCookie(w, &http.Cookie{
+				Name:    CookieName,
+				Value:   auth,
+				Path:    "/",
+				Expires: time.Now().Add(1 * time.Minute),
+			})
+		}
 
 		r.Header.Set("Authorization", auth)
 	}
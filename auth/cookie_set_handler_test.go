@@ -109,6 +109,29 @@ var _ = Describe("CookieSetHandler", func() {
 			itSetsAuthCookie()
 		})
 
+		Context("with a bearer token in the ATC-Authorization cookie", func() {
+			BeforeEach(func() {
+				request.AddCookie(&http.Cookie{
+					Name:  auth.CookieName,
+					Value: "Bearer some-token",
+				})
+			})
+
+			It("returns 200", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusOK))
+			})
+
+			It("proxies to the handler with the Authorization header set", func() {
+				responseBody, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(responseBody)).Should(Equal("auth: Bearer some-token"))
+			})
+
+			It("does not shorten the session by re-setting the cookie", func() {
+				Ω(response.Cookies()).Should(HaveLen(0))
+			})
+		})
+
 		Context("with no credentials", func() {
 			It("does not set ATC-Authorization", func() {
 				Ω(response.Cookies()).Should(HaveLen(0))
@@ -0,0 +1,173 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeProvider struct {
+	DisplayNameStub        func() string
+	displayNameMutex       sync.RWMutex
+	displayNameArgsForCall []struct{}
+	displayNameReturns struct {
+		result1 string
+	}
+	AuthCodeURLStub        func(state string) string
+	authCodeURLMutex       sync.RWMutex
+	authCodeURLArgsForCall []struct {
+		state string
+	}
+	authCodeURLReturns struct {
+		result1 string
+	}
+	ExchangeStub        func(code string) (*http.Client, error)
+	exchangeMutex       sync.RWMutex
+	exchangeArgsForCall []struct {
+		code string
+	}
+	exchangeReturns struct {
+		result1 *http.Client
+		result2 error
+	}
+	VerifyStub        func(lager.Logger, *http.Client) (auth.Grant, bool, error)
+	verifyMutex       sync.RWMutex
+	verifyArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	verifyReturns struct {
+		result1 auth.Grant
+		result2 bool
+		result3 error
+	}
+}
+
+func (fake *FakeProvider) DisplayName() string {
+	fake.displayNameMutex.Lock()
+	fake.displayNameArgsForCall = append(fake.displayNameArgsForCall, struct{}{})
+	fake.displayNameMutex.Unlock()
+	if fake.DisplayNameStub != nil {
+		return fake.DisplayNameStub()
+	} else {
+		return fake.displayNameReturns.result1
+	}
+}
+
+func (fake *FakeProvider) DisplayNameCallCount() int {
+	fake.displayNameMutex.RLock()
+	defer fake.displayNameMutex.RUnlock()
+	return len(fake.displayNameArgsForCall)
+}
+
+func (fake *FakeProvider) DisplayNameReturns(result1 string) {
+	fake.DisplayNameStub = nil
+	fake.displayNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProvider) AuthCodeURL(state string) string {
+	fake.authCodeURLMutex.Lock()
+	fake.authCodeURLArgsForCall = append(fake.authCodeURLArgsForCall, struct {
+		state string
+	}{state})
+	fake.authCodeURLMutex.Unlock()
+	if fake.AuthCodeURLStub != nil {
+		return fake.AuthCodeURLStub(state)
+	} else {
+		return fake.authCodeURLReturns.result1
+	}
+}
+
+func (fake *FakeProvider) AuthCodeURLCallCount() int {
+	fake.authCodeURLMutex.RLock()
+	defer fake.authCodeURLMutex.RUnlock()
+	return len(fake.authCodeURLArgsForCall)
+}
+
+func (fake *FakeProvider) AuthCodeURLArgsForCall(i int) string {
+	fake.authCodeURLMutex.RLock()
+	defer fake.authCodeURLMutex.RUnlock()
+	return fake.authCodeURLArgsForCall[i].state
+}
+
+func (fake *FakeProvider) AuthCodeURLReturns(result1 string) {
+	fake.AuthCodeURLStub = nil
+	fake.authCodeURLReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeProvider) Exchange(code string) (*http.Client, error) {
+	fake.exchangeMutex.Lock()
+	fake.exchangeArgsForCall = append(fake.exchangeArgsForCall, struct {
+		code string
+	}{code})
+	fake.exchangeMutex.Unlock()
+	if fake.ExchangeStub != nil {
+		return fake.ExchangeStub(code)
+	} else {
+		return fake.exchangeReturns.result1, fake.exchangeReturns.result2
+	}
+}
+
+func (fake *FakeProvider) ExchangeCallCount() int {
+	fake.exchangeMutex.RLock()
+	defer fake.exchangeMutex.RUnlock()
+	return len(fake.exchangeArgsForCall)
+}
+
+func (fake *FakeProvider) ExchangeArgsForCall(i int) string {
+	fake.exchangeMutex.RLock()
+	defer fake.exchangeMutex.RUnlock()
+	return fake.exchangeArgsForCall[i].code
+}
+
+func (fake *FakeProvider) ExchangeReturns(result1 *http.Client, result2 error) {
+	fake.ExchangeStub = nil
+	fake.exchangeReturns = struct {
+		result1 *http.Client
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProvider) Verify(arg1 lager.Logger, arg2 *http.Client) (auth.Grant, bool, error) {
+	fake.verifyMutex.Lock()
+	fake.verifyArgsForCall = append(fake.verifyArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.verifyMutex.Unlock()
+	if fake.VerifyStub != nil {
+		return fake.VerifyStub(arg1, arg2)
+	} else {
+		return fake.verifyReturns.result1, fake.verifyReturns.result2, fake.verifyReturns.result3
+	}
+}
+
+func (fake *FakeProvider) VerifyCallCount() int {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return len(fake.verifyArgsForCall)
+}
+
+func (fake *FakeProvider) VerifyArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return fake.verifyArgsForCall[i].arg1, fake.verifyArgsForCall[i].arg2
+}
+
+func (fake *FakeProvider) VerifyReturns(result1 auth.Grant, result2 bool, result3 error) {
+	fake.VerifyStub = nil
+	fake.verifyReturns = struct {
+		result1 auth.Grant
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ auth.Provider = new(FakeProvider)
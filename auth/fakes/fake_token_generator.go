@@ -0,0 +1,64 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/atc/auth"
+)
+
+type FakeTokenGenerator struct {
+	GenerateTokenStub        func(subject string, teamName string, role auth.Role, expiration time.Time) (auth.TokenType, auth.TokenValue, error)
+	generateTokenMutex       sync.RWMutex
+	generateTokenArgsForCall []struct {
+		subject    string
+		teamName   string
+		role       auth.Role
+		expiration time.Time
+	}
+	generateTokenReturns struct {
+		result1 auth.TokenType
+		result2 auth.TokenValue
+		result3 error
+	}
+}
+
+func (fake *FakeTokenGenerator) GenerateToken(subject string, teamName string, role auth.Role, expiration time.Time) (auth.TokenType, auth.TokenValue, error) {
+	fake.generateTokenMutex.Lock()
+	fake.generateTokenArgsForCall = append(fake.generateTokenArgsForCall, struct {
+		subject    string
+		teamName   string
+		role       auth.Role
+		expiration time.Time
+	}{subject, teamName, role, expiration})
+	fake.generateTokenMutex.Unlock()
+	if fake.GenerateTokenStub != nil {
+		return fake.GenerateTokenStub(subject, teamName, role, expiration)
+	} else {
+		return fake.generateTokenReturns.result1, fake.generateTokenReturns.result2, fake.generateTokenReturns.result3
+	}
+}
+
+func (fake *FakeTokenGenerator) GenerateTokenCallCount() int {
+	fake.generateTokenMutex.RLock()
+	defer fake.generateTokenMutex.RUnlock()
+	return len(fake.generateTokenArgsForCall)
+}
+
+func (fake *FakeTokenGenerator) GenerateTokenArgsForCall(i int) (string, string, auth.Role, time.Time) {
+	fake.generateTokenMutex.RLock()
+	defer fake.generateTokenMutex.RUnlock()
+	return fake.generateTokenArgsForCall[i].subject, fake.generateTokenArgsForCall[i].teamName, fake.generateTokenArgsForCall[i].role, fake.generateTokenArgsForCall[i].expiration
+}
+
+func (fake *FakeTokenGenerator) GenerateTokenReturns(result1 auth.TokenType, result2 auth.TokenValue, result3 error) {
+	fake.GenerateTokenStub = nil
+	fake.generateTokenReturns = struct {
+		result1 auth.TokenType
+		result2 auth.TokenValue
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ auth.TokenGenerator = new(FakeTokenGenerator)
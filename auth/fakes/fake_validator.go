@@ -17,6 +17,22 @@ type FakeValidator struct {
 	isAuthenticatedReturns struct {
 		result1 bool
 	}
+	TeamNameStub        func(*http.Request) string
+	teamNameMutex       sync.RWMutex
+	teamNameArgsForCall []struct {
+		arg1 *http.Request
+	}
+	teamNameReturns struct {
+		result1 string
+	}
+	RoleStub        func(*http.Request) auth.Role
+	roleMutex       sync.RWMutex
+	roleArgsForCall []struct {
+		arg1 *http.Request
+	}
+	roleReturns struct {
+		result1 auth.Role
+	}
 }
 
 func (fake *FakeValidator) IsAuthenticated(arg1 *http.Request) bool {
@@ -51,4 +67,68 @@ func (fake *FakeValidator) IsAuthenticatedReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeValidator) TeamName(arg1 *http.Request) string {
+	fake.teamNameMutex.Lock()
+	fake.teamNameArgsForCall = append(fake.teamNameArgsForCall, struct {
+		arg1 *http.Request
+	}{arg1})
+	fake.teamNameMutex.Unlock()
+	if fake.TeamNameStub != nil {
+		return fake.TeamNameStub(arg1)
+	} else {
+		return fake.teamNameReturns.result1
+	}
+}
+
+func (fake *FakeValidator) TeamNameCallCount() int {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return len(fake.teamNameArgsForCall)
+}
+
+func (fake *FakeValidator) TeamNameArgsForCall(i int) *http.Request {
+	fake.teamNameMutex.RLock()
+	defer fake.teamNameMutex.RUnlock()
+	return fake.teamNameArgsForCall[i].arg1
+}
+
+func (fake *FakeValidator) TeamNameReturns(result1 string) {
+	fake.TeamNameStub = nil
+	fake.teamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeValidator) Role(arg1 *http.Request) auth.Role {
+	fake.roleMutex.Lock()
+	fake.roleArgsForCall = append(fake.roleArgsForCall, struct {
+		arg1 *http.Request
+	}{arg1})
+	fake.roleMutex.Unlock()
+	if fake.RoleStub != nil {
+		return fake.RoleStub(arg1)
+	} else {
+		return fake.roleReturns.result1
+	}
+}
+
+func (fake *FakeValidator) RoleCallCount() int {
+	fake.roleMutex.RLock()
+	defer fake.roleMutex.RUnlock()
+	return len(fake.roleArgsForCall)
+}
+
+func (fake *FakeValidator) RoleArgsForCall(i int) *http.Request {
+	fake.roleMutex.RLock()
+	defer fake.roleMutex.RUnlock()
+	return fake.roleArgsForCall[i].arg1
+}
+
+func (fake *FakeValidator) RoleReturns(result1 auth.Role) {
+	fake.RoleStub = nil
+	fake.roleReturns = struct {
+		result1 auth.Role
+	}{result1}
+}
+
 var _ auth.Validator = new(FakeValidator)
@@ -0,0 +1,61 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+type FakeVerifier struct {
+	VerifyStub        func(lager.Logger, *http.Client) (auth.Grant, bool, error)
+	verifyMutex       sync.RWMutex
+	verifyArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}
+	verifyReturns struct {
+		result1 auth.Grant
+		result2 bool
+		result3 error
+	}
+}
+
+func (fake *FakeVerifier) Verify(arg1 lager.Logger, arg2 *http.Client) (auth.Grant, bool, error) {
+	fake.verifyMutex.Lock()
+	fake.verifyArgsForCall = append(fake.verifyArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 *http.Client
+	}{arg1, arg2})
+	fake.verifyMutex.Unlock()
+	if fake.VerifyStub != nil {
+		return fake.VerifyStub(arg1, arg2)
+	} else {
+		return fake.verifyReturns.result1, fake.verifyReturns.result2, fake.verifyReturns.result3
+	}
+}
+
+func (fake *FakeVerifier) VerifyCallCount() int {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return len(fake.verifyArgsForCall)
+}
+
+func (fake *FakeVerifier) VerifyArgsForCall(i int) (lager.Logger, *http.Client) {
+	fake.verifyMutex.RLock()
+	defer fake.verifyMutex.RUnlock()
+	return fake.verifyArgsForCall[i].arg1, fake.verifyArgsForCall[i].arg2
+}
+
+func (fake *FakeVerifier) VerifyReturns(result1 auth.Grant, result2 bool, result3 error) {
+	fake.VerifyStub = nil
+	fake.verifyReturns = struct {
+		result1 auth.Grant
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ auth.Verifier = new(FakeVerifier)
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+//go:generate counterfeiter . Client
+
+type Client interface {
+	Organizations(*http.Client) ([]string, error)
+
+	// Teams returns the user's teams as 'organization/team-slug'.
+	Teams(*http.Client) ([]string, error)
+}
+
+type client struct {
+	apiURL string
+}
+
+func NewClient(apiURL string) Client {
+	return &client{
+		apiURL: strings.TrimRight(apiURL, "/"),
+	}
+}
+
+type organization struct {
+	Login string `json:"login"`
+}
+
+type team struct {
+	Slug         string       `json:"slug"`
+	Organization organization `json:"organization"`
+}
+
+func (c *client) Organizations(httpClient *http.Client) ([]string, error) {
+	var orgs []organization
+	err := c.get(httpClient, "/user/orgs", &orgs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, org := range orgs {
+		names = append(names, org.Login)
+	}
+
+	return names, nil
+}
+
+func (c *client) Teams(httpClient *http.Client) ([]string, error) {
+	var teams []team
+	err := c.get(httpClient, "/user/teams", &teams)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, membership := range teams {
+		names = append(names, membership.Organization.Login+"/"+membership.Slug)
+	}
+
+	return names, nil
+}
+
+func (c *client) get(httpClient *http.Client, path string, dest interface{}) error {
+	response, err := httpClient.Get(c.apiURL + path)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected response from GitHub: %s", response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(dest)
+}
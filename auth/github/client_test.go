@@ -0,0 +1,105 @@
+package github_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/auth/github"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		githubServer *ghttp.Server
+
+		client github.Client
+	)
+
+	BeforeEach(func() {
+		githubServer = ghttp.NewServer()
+
+		client = github.NewClient(githubServer.URL() + "/")
+	})
+
+	AfterEach(func() {
+		githubServer.Close()
+	})
+
+	Describe("Organizations", func() {
+		Context("when listing the user's organizations succeeds", func() {
+			BeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user/orgs"),
+						ghttp.RespondWith(http.StatusOK, `[
+							{"login":"org-1"},
+							{"login":"org-2"}
+						]`),
+					),
+				)
+			})
+
+			It("returns the organization logins", func() {
+				orgs, err := client.Organizations(http.DefaultClient)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(orgs).Should(Equal([]string{"org-1", "org-2"}))
+			})
+		})
+
+		Context("when GitHub responds with an error", func() {
+			BeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user/orgs"),
+						ghttp.RespondWith(http.StatusUnauthorized, `{"message":"Bad credentials"}`),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Organizations(http.DefaultClient)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Teams", func() {
+		Context("when listing the user's teams succeeds", func() {
+			BeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user/teams"),
+						ghttp.RespondWith(http.StatusOK, `[
+							{"slug":"team-1","organization":{"login":"org-1"}},
+							{"slug":"team-2","organization":{"login":"org-2"}}
+						]`),
+					),
+				)
+			})
+
+			It("returns the teams qualified by their organization", func() {
+				teams, err := client.Teams(http.DefaultClient)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(teams).Should(Equal([]string{"org-1/team-1", "org-2/team-2"}))
+			})
+		})
+
+		Context("when GitHub responds with an error", func() {
+			BeforeEach(func() {
+				githubServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/user/teams"),
+						ghttp.RespondWith(http.StatusUnauthorized, `{"message":"Bad credentials"}`),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, err := client.Teams(http.DefaultClient)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+})
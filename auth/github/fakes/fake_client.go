@@ -0,0 +1,98 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/concourse/atc/auth/github"
+)
+
+type FakeClient struct {
+	OrganizationsStub        func(*http.Client) ([]string, error)
+	organizationsMutex       sync.RWMutex
+	organizationsArgsForCall []struct {
+		arg1 *http.Client
+	}
+	organizationsReturns struct {
+		result1 []string
+		result2 error
+	}
+	TeamsStub        func(*http.Client) ([]string, error)
+	teamsMutex       sync.RWMutex
+	teamsArgsForCall []struct {
+		arg1 *http.Client
+	}
+	teamsReturns struct {
+		result1 []string
+		result2 error
+	}
+}
+
+func (fake *FakeClient) Organizations(arg1 *http.Client) ([]string, error) {
+	fake.organizationsMutex.Lock()
+	fake.organizationsArgsForCall = append(fake.organizationsArgsForCall, struct {
+		arg1 *http.Client
+	}{arg1})
+	fake.organizationsMutex.Unlock()
+	if fake.OrganizationsStub != nil {
+		return fake.OrganizationsStub(arg1)
+	} else {
+		return fake.organizationsReturns.result1, fake.organizationsReturns.result2
+	}
+}
+
+func (fake *FakeClient) OrganizationsCallCount() int {
+	fake.organizationsMutex.RLock()
+	defer fake.organizationsMutex.RUnlock()
+	return len(fake.organizationsArgsForCall)
+}
+
+func (fake *FakeClient) OrganizationsArgsForCall(i int) *http.Client {
+	fake.organizationsMutex.RLock()
+	defer fake.organizationsMutex.RUnlock()
+	return fake.organizationsArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) OrganizationsReturns(result1 []string, result2 error) {
+	fake.OrganizationsStub = nil
+	fake.organizationsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Teams(arg1 *http.Client) ([]string, error) {
+	fake.teamsMutex.Lock()
+	fake.teamsArgsForCall = append(fake.teamsArgsForCall, struct {
+		arg1 *http.Client
+	}{arg1})
+	fake.teamsMutex.Unlock()
+	if fake.TeamsStub != nil {
+		return fake.TeamsStub(arg1)
+	} else {
+		return fake.teamsReturns.result1, fake.teamsReturns.result2
+	}
+}
+
+func (fake *FakeClient) TeamsCallCount() int {
+	fake.teamsMutex.RLock()
+	defer fake.teamsMutex.RUnlock()
+	return len(fake.teamsArgsForCall)
+}
+
+func (fake *FakeClient) TeamsArgsForCall(i int) *http.Client {
+	fake.teamsMutex.RLock()
+	defer fake.teamsMutex.RUnlock()
+	return fake.teamsArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) TeamsReturns(result1 []string, result2 error) {
+	fake.TeamsStub = nil
+	fake.teamsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+var _ github.Client = new(FakeClient)
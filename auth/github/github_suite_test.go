@@ -0,0 +1,13 @@
+package github_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGitHub(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "GitHub Suite")
+}
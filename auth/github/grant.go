@@ -0,0 +1,74 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+)
+
+// DefaultGrant is given to members of an authorized organization who are not
+// matched by any TeamGrant.
+var DefaultGrant = auth.Grant{
+	TeamName: atc.DefaultTeamName,
+	Role:     auth.RoleViewer,
+}
+
+// TeamGrant gives the members of an organization, or of a team within it,
+// a team and role in the ATC.
+type TeamGrant struct {
+	Organization string
+	Team         string
+
+	auth.Grant
+}
+
+// ParseTeamGrants parses a comma-separated list of
+// 'organization[/team]=atc-team:role' grants.
+func ParseTeamGrants(list string) ([]TeamGrant, error) {
+	grants := []TeamGrant{}
+
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("malformed grant '%s': expected 'organization[/team]=atc-team:role'", entry)
+		}
+
+		members := strings.SplitN(parts[0], "/", 2)
+		target := strings.SplitN(parts[1], ":", 2)
+		if members[0] == "" || len(target) != 2 || target[0] == "" {
+			return nil, fmt.Errorf("malformed grant '%s': expected 'organization[/team]=atc-team:role'", entry)
+		}
+
+		role, err := auth.ParseRole(target[1])
+		if err != nil {
+			return nil, fmt.Errorf("malformed grant '%s': %s", entry, err)
+		}
+
+		grant := TeamGrant{
+			Organization: members[0],
+			Grant: auth.Grant{
+				TeamName: target[0],
+				Role:     role,
+			},
+		}
+
+		if len(members) == 2 {
+			if members[1] == "" {
+				return nil, fmt.Errorf("malformed grant '%s': empty team", entry)
+			}
+
+			grant.Team = members[1]
+		}
+
+		grants = append(grants, grant)
+	}
+
+	return grants, nil
+}
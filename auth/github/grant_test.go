@@ -0,0 +1,49 @@
+package github_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/github"
+)
+
+var _ = Describe("ParseTeamGrants", func() {
+	It("parses organization and team grants", func() {
+		grants, err := github.ParseTeamGrants("some-org=some-team:viewer, some-org/admins=main:admin")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		Ω(grants).Should(Equal([]github.TeamGrant{
+			{
+				Organization: "some-org",
+				Grant:        auth.Grant{TeamName: "some-team", Role: auth.RoleViewer},
+			},
+			{
+				Organization: "some-org",
+				Team:         "admins",
+				Grant:        auth.Grant{TeamName: "main", Role: auth.RoleAdmin},
+			},
+		}))
+	})
+
+	It("returns no grants for an empty list", func() {
+		grants, err := github.ParseTeamGrants("")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(grants).Should(BeEmpty())
+	})
+
+	It("rejects grants without a role", func() {
+		_, err := github.ParseTeamGrants("some-org=some-team")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("rejects grants with an unknown role", func() {
+		_, err := github.ParseTeamGrants("some-org=some-team:overlord")
+		Ω(err).Should(MatchError("malformed grant 'some-org=some-team:overlord': unknown role 'overlord'"))
+	})
+
+	It("rejects grants with an empty team", func() {
+		_, err := github.ParseTeamGrants("some-org/=some-team:viewer")
+		Ω(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+	"golang.org/x/oauth2"
+)
+
+const ProviderName = "github"
+const DisplayName = "GitHub"
+
+const (
+	DefaultAuthURL  = "https://github.com/login/oauth/authorize"
+	DefaultTokenURL = "https://github.com/login/oauth/access_token"
+	DefaultAPIURL   = "https://api.github.com/"
+)
+
+var Scopes = []string{"read:org"}
+
+type AuthorizationConfig struct {
+	ClientID      string
+	ClientSecret  string
+	Organizations []string
+	Grants        []TeamGrant
+
+	// override these for GitHub Enterprise
+	AuthURL  string
+	TokenURL string
+	APIURL   string
+}
+
+type Provider struct {
+	config   *oauth2.Config
+	verifier auth.Verifier
+}
+
+func NewProvider(
+	config AuthorizationConfig,
+	redirectURL string,
+) auth.Provider {
+	authURL := config.AuthURL
+	if authURL == "" {
+		authURL = DefaultAuthURL
+	}
+
+	tokenURL := config.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+
+	apiURL := config.APIURL
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	return Provider{
+		config: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: tokenURL,
+			},
+			Scopes:      Scopes,
+			RedirectURL: redirectURL,
+		},
+
+		verifier: NewOrganizationVerifier(
+			config.Organizations,
+			config.Grants,
+			NewClient(apiURL),
+		),
+	}
+}
+
+func (provider Provider) DisplayName() string {
+	return DisplayName
+}
+
+func (provider Provider) AuthCodeURL(state string) string {
+	return provider.config.AuthCodeURL(state)
+}
+
+func (provider Provider) Exchange(code string) (*http.Client, error) {
+	token, err := provider.config.Exchange(oauth2.NoContext, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.config.Client(oauth2.NoContext, token), nil
+}
+
+func (provider Provider) Verify(logger lager.Logger, httpClient *http.Client) (auth.Grant, bool, error) {
+	return provider.verifier.Verify(logger, httpClient)
+}
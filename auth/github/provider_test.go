@@ -0,0 +1,102 @@
+package github_test
+
+import (
+	"net/http"
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/github"
+)
+
+var _ = Describe("Provider", func() {
+	var (
+		oauthServer *ghttp.Server
+
+		provider auth.Provider
+	)
+
+	BeforeEach(func() {
+		oauthServer = ghttp.NewServer()
+
+		provider = github.NewProvider(
+			github.AuthorizationConfig{
+				ClientID:      "some-client-id",
+				ClientSecret:  "some-client-secret",
+				Organizations: []string{"some-org"},
+
+				AuthURL:  oauthServer.URL() + "/login/oauth/authorize",
+				TokenURL: oauthServer.URL() + "/login/oauth/access_token",
+				APIURL:   oauthServer.URL() + "/api/",
+			},
+			"https://atc.example.com/auth/github/callback",
+		)
+	})
+
+	AfterEach(func() {
+		oauthServer.Close()
+	})
+
+	It("is displayed as GitHub", func() {
+		Ω(provider.DisplayName()).Should(Equal("GitHub"))
+	})
+
+	Describe("AuthCodeURL", func() {
+		It("points at the authorize endpoint with the client, scopes, redirect and state", func() {
+			authURL, err := url.Parse(provider.AuthCodeURL("some-state"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(authURL.Host).Should(Equal(oauthServer.Addr()))
+			Ω(authURL.Path).Should(Equal("/login/oauth/authorize"))
+
+			query := authURL.Query()
+			Ω(query.Get("client_id")).Should(Equal("some-client-id"))
+			Ω(query.Get("scope")).Should(Equal("read:org"))
+			Ω(query.Get("redirect_uri")).Should(Equal("https://atc.example.com/auth/github/callback"))
+			Ω(query.Get("state")).Should(Equal("some-state"))
+		})
+	})
+
+	Describe("exchanging a code and verifying the user", func() {
+		BeforeEach(func() {
+			oauthServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/login/oauth/access_token"),
+					func(w http.ResponseWriter, r *http.Request) {
+						err := r.ParseForm()
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(r.Form.Get("code")).Should(Equal("some-code"))
+					},
+					ghttp.RespondWith(
+						http.StatusOK,
+						`{"access_token":"some-access-token","token_type":"bearer"}`,
+						http.Header{"Content-Type": []string{"application/json"}},
+					),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/user/orgs"),
+					ghttp.VerifyHeader(http.Header{
+						"Authorization": []string{"Bearer some-access-token"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `[{"login":"some-org"}]`),
+				),
+			)
+		})
+
+		It("uses the exchanged token to check the user's organizations", func() {
+			httpClient, err := provider.Exchange("some-code")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			grant, verified, err := provider.Verify(lagertest.NewTestLogger("test"), httpClient)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(verified).Should(BeTrue())
+			Ω(grant).Should(Equal(github.DefaultGrant))
+
+			Ω(oauthServer.ReceivedRequests()).Should(HaveLen(2))
+		})
+	})
+})
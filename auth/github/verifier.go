@@ -0,0 +1,105 @@
+package github
+
+import (
+	"net/http"
+
+	"github.com/concourse/atc/auth"
+	"github.com/pivotal-golang/lager"
+)
+
+type OrganizationVerifier struct {
+	organizations []string
+	grants        []TeamGrant
+	gitHubClient  Client
+}
+
+// NewOrganizationVerifier verifies members of the given organizations, who
+// are given the DefaultGrant, and members matched by any of the grants. A
+// user matched by several grants gets the one with the most privileged role,
+// preferring earlier grants among equals.
+func NewOrganizationVerifier(
+	organizations []string,
+	grants []TeamGrant,
+	gitHubClient Client,
+) auth.Verifier {
+	return OrganizationVerifier{
+		organizations: organizations,
+		grants:        grants,
+		gitHubClient:  gitHubClient,
+	}
+}
+
+func (verifier OrganizationVerifier) Verify(logger lager.Logger, httpClient *http.Client) (auth.Grant, bool, error) {
+	orgs, err := verifier.gitHubClient.Organizations(httpClient)
+	if err != nil {
+		logger.Error("failed-to-get-organizations", err)
+		return auth.Grant{}, false, err
+	}
+
+	var teams []string
+	if verifier.grantsTeams() {
+		teams, err = verifier.gitHubClient.Teams(httpClient)
+		if err != nil {
+			logger.Error("failed-to-get-teams", err)
+			return auth.Grant{}, false, err
+		}
+	}
+
+	var grant auth.Grant
+	granted := false
+
+	for _, candidate := range verifier.grants {
+		var member bool
+		if candidate.Team == "" {
+			member = contains(orgs, candidate.Organization)
+		} else {
+			member = contains(teams, candidate.Organization+"/"+candidate.Team)
+		}
+
+		if !member {
+			continue
+		}
+
+		if !granted || !grant.Role.Permits(candidate.Role) {
+			grant = candidate.Grant
+			granted = true
+		}
+	}
+
+	if granted {
+		return grant, true, nil
+	}
+
+	for _, authorizedOrg := range verifier.organizations {
+		if contains(orgs, authorizedOrg) {
+			return DefaultGrant, true, nil
+		}
+	}
+
+	logger.Info("not-in-organizations", lager.Data{
+		"have": orgs,
+		"want": verifier.organizations,
+	})
+
+	return auth.Grant{}, false, nil
+}
+
+func (verifier OrganizationVerifier) grantsTeams() bool {
+	for _, grant := range verifier.grants {
+		if grant.Team != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,176 @@
+package github_test
+
+import (
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/github"
+	"github.com/concourse/atc/auth/github/fakes"
+)
+
+var _ = Describe("OrganizationVerifier", func() {
+	var (
+		organizations []string
+		grants        []github.TeamGrant
+		fakeClient    *fakes.FakeClient
+
+		verifier auth.Verifier
+	)
+
+	BeforeEach(func() {
+		organizations = []string{"some-org", "some-other-org"}
+		grants = nil
+		fakeClient = new(fakes.FakeClient)
+	})
+
+	JustBeforeEach(func() {
+		verifier = github.NewOrganizationVerifier(organizations, grants, fakeClient)
+	})
+
+	Describe("Verify", func() {
+		var (
+			httpClient *http.Client
+
+			grant     auth.Grant
+			verified  bool
+			verifyErr error
+		)
+
+		BeforeEach(func() {
+			httpClient = &http.Client{}
+		})
+
+		JustBeforeEach(func() {
+			grant, verified, verifyErr = verifier.Verify(lagertest.NewTestLogger("test"), httpClient)
+		})
+
+		It("asks GitHub using the given client", func() {
+			Ω(fakeClient.OrganizationsCallCount()).Should(Equal(1))
+			Ω(fakeClient.OrganizationsArgsForCall(0)).Should(Equal(httpClient))
+		})
+
+		It("does not list the user's teams when no grant needs them", func() {
+			Ω(fakeClient.TeamsCallCount()).Should(BeZero())
+		})
+
+		Context("when the user is in one of the organizations", func() {
+			BeforeEach(func() {
+				fakeClient.OrganizationsReturns([]string{"nope", "some-other-org"}, nil)
+			})
+
+			It("returns true with the least privileged grant", func() {
+				Ω(verifyErr).ShouldNot(HaveOccurred())
+				Ω(verified).Should(BeTrue())
+				Ω(grant).Should(Equal(auth.Grant{
+					TeamName: "main",
+					Role:     auth.RoleViewer,
+				}))
+			})
+		})
+
+		Context("when the user is not in any of the organizations", func() {
+			BeforeEach(func() {
+				fakeClient.OrganizationsReturns([]string{"nope"}, nil)
+			})
+
+			It("returns false", func() {
+				Ω(verifyErr).ShouldNot(HaveOccurred())
+				Ω(verified).Should(BeFalse())
+			})
+		})
+
+		Context("when listing the organizations fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeClient.OrganizationsReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(verifyErr).Should(Equal(disaster))
+				Ω(verified).Should(BeFalse())
+			})
+		})
+
+		Context("with grants", func() {
+			BeforeEach(func() {
+				grants = []github.TeamGrant{
+					{
+						Organization: "some-org",
+						Grant:        auth.Grant{TeamName: "some-team", Role: auth.RoleOperator},
+					},
+					{
+						Organization: "some-org",
+						Team:         "admins",
+						Grant:        auth.Grant{TeamName: "some-team", Role: auth.RoleAdmin},
+					},
+					{
+						Organization: "granted-org",
+						Grant:        auth.Grant{TeamName: "granted-team", Role: auth.RoleViewer},
+					},
+				}
+
+				fakeClient.OrganizationsReturns([]string{"some-org"}, nil)
+			})
+
+			It("lists the user's teams using the given client", func() {
+				Ω(fakeClient.TeamsCallCount()).Should(Equal(1))
+				Ω(fakeClient.TeamsArgsForCall(0)).Should(Equal(httpClient))
+			})
+
+			Context("when the user is in an organization with a grant", func() {
+				BeforeEach(func() {
+					fakeClient.TeamsReturns([]string{"some-org/developers"}, nil)
+				})
+
+				It("returns the organization's grant", func() {
+					Ω(verifyErr).ShouldNot(HaveOccurred())
+					Ω(verified).Should(BeTrue())
+					Ω(grant).Should(Equal(auth.Grant{TeamName: "some-team", Role: auth.RoleOperator}))
+				})
+			})
+
+			Context("when the user is also in a team with a more privileged grant", func() {
+				BeforeEach(func() {
+					fakeClient.TeamsReturns([]string{"some-org/admins"}, nil)
+				})
+
+				It("returns the more privileged grant", func() {
+					Ω(verifyErr).ShouldNot(HaveOccurred())
+					Ω(verified).Should(BeTrue())
+					Ω(grant).Should(Equal(auth.Grant{TeamName: "some-team", Role: auth.RoleAdmin}))
+				})
+			})
+
+			Context("when the user is only in an organization that is not otherwise authorized", func() {
+				BeforeEach(func() {
+					fakeClient.OrganizationsReturns([]string{"granted-org"}, nil)
+				})
+
+				It("returns its grant", func() {
+					Ω(verifyErr).ShouldNot(HaveOccurred())
+					Ω(verified).Should(BeTrue())
+					Ω(grant).Should(Equal(auth.Grant{TeamName: "granted-team", Role: auth.RoleViewer}))
+				})
+			})
+
+			Context("when listing the teams fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeClient.TeamsReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(verifyErr).Should(Equal(disaster))
+					Ω(verified).Should(BeFalse())
+				})
+			})
+		})
+	})
+})
@@ -3,21 +3,106 @@ package auth
 import (
 	"fmt"
 	"net/http"
+	"strings"
+
+	"github.com/tedsuo/rata"
+
+	"github.com/concourse/atc"
 )
 
 type Handler struct {
 	Validator Validator
 	Handler   http.Handler
+
+	// Exempt routes are served without authentication, for routes that
+	// authenticate requests themselves.
+	Exempt rata.Routes
 }
 
 func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if h.Validator.IsAuthenticated(r) {
+	if h.isExempt(r) || h.Validator.IsAuthenticated(r) {
 		h.Handler.ServeHTTP(w, r)
 	} else {
 		Unauthorized(w)
 	}
 }
 
+func (h Handler) isExempt(r *http.Request) bool {
+	for _, route := range h.Exempt {
+		if route.Method == r.Method && pathMatches(route.Path, r.URL.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathMatches reports whether the path matches the route's path, where
+// ':name' segments match any non-empty segment.
+func pathMatches(routePath string, path string) bool {
+	routeSegments := strings.Split(routePath, "/")
+	segments := strings.Split(path, "/")
+
+	if len(routeSegments) != len(segments) {
+		return false
+	}
+
+	for i, routeSegment := range routeSegments {
+		if strings.HasPrefix(routeSegment, ":") {
+			if segments[i] == "" {
+				return false
+			}
+
+			continue
+		}
+
+		if routeSegment != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TeamHandler only serves requests from callers belonging to the team named
+// by the route's :team_name (atc.DefaultTeamName if absent). Members of
+// atc.DefaultTeamName may act on behalf of any team.
+type TeamHandler struct {
+	Validator Validator
+	Handler   http.Handler
+}
+
+func (h TeamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	teamName := RequestedTeamName(r)
+
+	if IsAuthorizedForTeam(h.Validator, r, teamName) {
+		h.Handler.ServeHTTP(w, r)
+	} else {
+		Forbidden(w, teamName)
+	}
+}
+
+// RequestedTeamName returns the route's :team_name, or atc.DefaultTeamName
+// for routes that are not team-scoped.
+func RequestedTeamName(r *http.Request) string {
+	teamName := r.FormValue(":team_name")
+	if teamName == "" {
+		return atc.DefaultTeamName
+	}
+
+	return teamName
+}
+
+func IsAuthorizedForTeam(validator Validator, r *http.Request, teamName string) bool {
+	callerTeam := validator.TeamName(r)
+	return callerTeam == atc.DefaultTeamName || callerTeam == teamName
+}
+
+func Forbidden(w http.ResponseWriter, teamName string) {
+	w.WriteHeader(http.StatusForbidden)
+	fmt.Fprintf(w, "not a member of team '%s'", teamName)
+}
+
 func Unauthorized(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
 	w.WriteHeader(http.StatusUnauthorized)
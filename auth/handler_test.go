@@ -11,8 +11,11 @@ import (
 	"code.google.com/p/go.crypto/bcrypt"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/tedsuo/rata"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/fakes"
 )
 
 var _ = Describe("BasicAuthHandler", func() {
@@ -181,3 +184,141 @@ var _ = Describe("ExtractUsernameAndPassword", func() {
 		})
 	})
 })
+
+var _ = Describe("Handler with exempt routes", func() {
+	var (
+		fakeValidator *fakes.FakeValidator
+
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		fakeValidator = new(fakes.FakeValidator)
+
+		server = httptest.NewServer(auth.Handler{
+			Validator: fakeValidator,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}),
+			Exempt: rata.Routes{
+				{Path: "/things/:thing_name/poke", Method: "POST", Name: "poke"},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	do := func(method string, path string) *http.Response {
+		request, err := http.NewRequest(method, server.URL+path, nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		response, err := http.DefaultClient.Do(request)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return response
+	}
+
+	It("serves exempt routes without authentication", func() {
+		Ω(do("POST", "/things/some-thing/poke").StatusCode).Should(Equal(http.StatusTeapot))
+		Ω(fakeValidator.IsAuthenticatedCallCount()).Should(BeZero())
+	})
+
+	It("requires authentication for other methods on the same path", func() {
+		Ω(do("GET", "/things/some-thing/poke").StatusCode).Should(Equal(http.StatusUnauthorized))
+	})
+
+	It("requires authentication for paths that only partially match", func() {
+		Ω(do("POST", "/things//poke").StatusCode).Should(Equal(http.StatusUnauthorized))
+		Ω(do("POST", "/things/some-thing/poke/more").StatusCode).Should(Equal(http.StatusUnauthorized))
+		Ω(do("POST", "/other-things/some-thing/poke").StatusCode).Should(Equal(http.StatusUnauthorized))
+	})
+
+	Context("when authenticated", func() {
+		BeforeEach(func() {
+			fakeValidator.IsAuthenticatedReturns(true)
+		})
+
+		It("serves the other routes", func() {
+			Ω(do("GET", "/things/some-thing/poke").StatusCode).Should(Equal(http.StatusTeapot))
+		})
+	})
+})
+
+var _ = Describe("TeamHandler", func() {
+	var (
+		fakeValidator *fakes.FakeValidator
+
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		fakeValidator = new(fakes.FakeValidator)
+
+		handler, err := rata.NewRouter(rata.Routes{
+			{Path: "/teams/:team_name/thing", Method: "GET", Name: "thing"},
+			{Path: "/thing", Method: "GET", Name: "thing"},
+		}, rata.Handlers{
+			"thing": auth.TeamHandler{
+				Validator: fakeValidator,
+				Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusTeapot)
+				}),
+			},
+		})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		server = httptest.NewServer(handler)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(path string) *http.Response {
+		response, err := http.Get(server.URL + path)
+		Ω(err).ShouldNot(HaveOccurred())
+		return response
+	}
+
+	Context("when the caller belongs to the requested team", func() {
+		BeforeEach(func() {
+			fakeValidator.TeamNameReturns("some-team")
+		})
+
+		It("proxies to the handler", func() {
+			Ω(get("/teams/some-team/thing").StatusCode).Should(Equal(http.StatusTeapot))
+		})
+	})
+
+	Context("when the caller belongs to another team", func() {
+		BeforeEach(func() {
+			fakeValidator.TeamNameReturns("some-other-team")
+		})
+
+		It("returns 403", func() {
+			response := get("/teams/some-team/thing")
+			Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+
+			body, err := ioutil.ReadAll(response.Body)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(body)).Should(ContainSubstring("some-team"))
+		})
+
+		It("treats routes without a team as belonging to the main team", func() {
+			Ω(get("/thing").StatusCode).Should(Equal(http.StatusForbidden))
+		})
+	})
+
+	Context("when the caller belongs to the main team", func() {
+		BeforeEach(func() {
+			fakeValidator.TeamNameReturns(atc.DefaultTeamName)
+		})
+
+		It("may act on behalf of any team", func() {
+			Ω(get("/teams/some-team/thing").StatusCode).Should(Equal(http.StatusTeapot))
+			Ω(get("/thing").StatusCode).Should(Equal(http.StatusTeapot))
+		})
+	})
+})
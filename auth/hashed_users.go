@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LoadHashedUsers reads a file of 'username:bcrypted-password[:team[:role]]'
+// lines and returns a validator accepting any of them. Users without a team
+// belong to atc.DefaultTeamName, and users without a role are viewers. Blank
+// lines and lines starting with '#' are ignored.
+func LoadHashedUsers(path string) (ValidatorBasket, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	basket := ValidatorBasket{}
+
+	scanner := bufio.NewScanner(file)
+
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 4)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("malformed user on line %d of %s", lineNumber, path)
+		}
+
+		validator := BasicAuthHashedValidator{
+			Username:       parts[0],
+			HashedPassword: parts[1],
+		}
+
+		if len(parts) >= 3 {
+			validator.Team = parts[2]
+		}
+
+		if len(parts) == 4 {
+			role, err := ParseRole(parts[3])
+			if err != nil {
+				return nil, fmt.Errorf("malformed user on line %d of %s: %s", lineNumber, path, err)
+			}
+
+			validator.AssignedRole = role
+		}
+
+		basket = append(basket, validator)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return basket, nil
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type OAuthBeginHandler struct {
+	logger    lager.Logger
+	providers Providers
+}
+
+func NewOAuthBeginHandler(
+	logger lager.Logger,
+	providers Providers,
+) http.Handler {
+	return &OAuthBeginHandler{
+		logger:    logger,
+		providers: providers,
+	}
+}
+
+func (handler *OAuthBeginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	providerName := r.FormValue(":provider")
+
+	provider, found := handler.providers[providerName]
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "unknown provider %s", providerName)
+		return
+	}
+
+	entropy := make([]byte, 32)
+	_, err := rand.Read(entropy)
+	if err != nil {
+		handler.logger.Error("failed-to-generate-entropy", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	stateJSON, err := json.Marshal(OAuthState{
+		Redirect: r.FormValue("redirect"),
+		Entropy:  hex.EncodeToString(entropy),
+	})
+	if err != nil {
+		handler.logger.Error("failed-to-marshal-state", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	encodedState := base64.URLEncoding.EncodeToString(stateJSON)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:    OAuthStateCookie,
+		Value:   encodedState,
+		Path:    "/",
+		Expires: time.Now().Add(CookieAge),
+	})
+
+	http.Redirect(w, r, provider.AuthCodeURL(encodedState), http.StatusTemporaryRedirect)
+}
@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+)
+
+type OAuthCallbackHandler struct {
+	logger         lager.Logger
+	providers      Providers
+	tokenGenerator TokenGenerator
+}
+
+func NewOAuthCallbackHandler(
+	logger lager.Logger,
+	providers Providers,
+	tokenGenerator TokenGenerator,
+) http.Handler {
+	return &OAuthCallbackHandler{
+		logger:         logger,
+		providers:      providers,
+		tokenGenerator: tokenGenerator,
+	}
+}
+
+func (handler *OAuthCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	hLog := handler.logger.Session("callback")
+
+	providerName := r.FormValue(":provider")
+	paramState := r.FormValue("state")
+
+	provider, found := handler.providers[providerName]
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "unknown provider %s", providerName)
+		return
+	}
+
+	cookieState, err := r.Cookie(OAuthStateCookie)
+	if err != nil {
+		hLog.Info("no-state-cookie", lager.Data{
+			"error": err.Error(),
+		})
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "state cookie not set")
+		return
+	}
+
+	if cookieState.Value != paramState {
+		hLog.Info("state-cookie-mismatch", lager.Data{
+			"param-state":  paramState,
+			"cookie-state": cookieState.Value,
+		})
+
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "state cookie does not match param")
+		return
+	}
+
+	stateJSON, err := base64.URLEncoding.DecodeString(paramState)
+	if err != nil {
+		hLog.Info("failed-to-decode-state", lager.Data{
+			"error": err.Error(),
+		})
+
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var oauthState OAuthState
+	err = json.Unmarshal(stateJSON, &oauthState)
+	if err != nil {
+		hLog.Info("failed-to-unmarshal-state", lager.Data{
+			"error": err.Error(),
+		})
+
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	httpClient, err := provider.Exchange(r.FormValue("code"))
+	if err != nil {
+		hLog.Error("failed-to-exchange-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	grant, verified, err := provider.Verify(hLog.Session("verify"), httpClient)
+	if err != nil {
+		hLog.Error("failed-to-verify-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !verified {
+		hLog.Info("verification-failed")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, "not authorized")
+		return
+	}
+
+	exp := time.Now().Add(CookieAge)
+
+	tokenType, signedToken, err := handler.tokenGenerator.GenerateToken(providerName, grant.TeamName, grant.Role, exp)
+	if err != nil {
+		hLog.Error("failed-to-sign-token", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:    CookieName,
+		Value:   string(tokenType) + " " + string(signedToken),
+		Path:    "/",
+		Expires: exp,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   OAuthStateCookie,
+		Path:   "/",
+		MaxAge: -1,
+	})
+
+	http.Redirect(w, r, SafeRedirect(oauthState.Redirect), http.StatusTemporaryRedirect)
+}
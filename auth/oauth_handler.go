@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pivotal-golang/lager"
+	"github.com/tedsuo/rata"
+)
+
+const (
+	OAuthBegin    = "OAuthBegin"
+	OAuthCallback = "OAuthCallback"
+)
+
+var OAuthRoutes = rata.Routes{
+	{Path: "/auth/:provider", Method: "GET", Name: OAuthBegin},
+	{Path: "/auth/:provider/callback", Method: "GET", Name: OAuthCallback},
+}
+
+const OAuthStateCookie = "_concourse_oauth_state"
+
+// how long a session established through an OAuth provider lasts
+const CookieAge = 24 * time.Hour
+
+type OAuthState struct {
+	Redirect string `json:"redirect"`
+	Entropy  string `json:"entropy"`
+}
+
+func NewOAuthHandler(
+	logger lager.Logger,
+	providers Providers,
+	tokenGenerator TokenGenerator,
+) (http.Handler, error) {
+	return rata.NewRouter(OAuthRoutes, map[string]http.Handler{
+		OAuthBegin: NewOAuthBeginHandler(
+			logger.Session("oauth-begin"),
+			providers,
+		),
+
+		OAuthCallback: NewOAuthCallbackHandler(
+			logger.Session("oauth-callback"),
+			providers,
+			tokenGenerator,
+		),
+	})
+}
+
+// SafeRedirect only redirects within the ATC; anything else could be used to
+// send a freshly logged-in user to an arbitrary site. Browsers treat a
+// leading "/\" like "//", so it is refused too.
+func SafeRedirect(path string) string {
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.HasPrefix(path, "/\\") {
+		return "/"
+	}
+
+	return path
+}
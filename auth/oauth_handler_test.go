@@ -0,0 +1,326 @@
+package auth_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/fakes"
+)
+
+var _ = Describe("OAuthHandler", func() {
+	var (
+		fakeProviderA      *fakes.FakeProvider
+		fakeProviderB      *fakes.FakeProvider
+		fakeTokenGenerator *fakes.FakeTokenGenerator
+
+		server *httptest.Server
+		client *http.Client
+	)
+
+	BeforeEach(func() {
+		fakeProviderA = new(fakes.FakeProvider)
+		fakeProviderB = new(fakes.FakeProvider)
+		fakeTokenGenerator = new(fakes.FakeTokenGenerator)
+
+		handler, err := auth.NewOAuthHandler(
+			lagertest.NewTestLogger("test"),
+			auth.Providers{
+				"a": fakeProviderA,
+				"b": fakeProviderB,
+			},
+			fakeTokenGenerator,
+		)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		server = httptest.NewServer(handler)
+
+		client = &http.Client{
+			Transport: &http.Transport{},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	cookieNamed := func(response *http.Response, name string) *http.Cookie {
+		for _, cookie := range response.Cookies() {
+			if cookie.Name == name {
+				return cookie
+			}
+		}
+
+		return nil
+	}
+
+	Describe("GET /auth/:provider", func() {
+		var redirectTarget *httptest.Server
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			redirectTarget = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			fakeProviderA.AuthCodeURLStub = func(state string) string {
+				return redirectTarget.URL + "/oauth?state=" + url.QueryEscape(state)
+			}
+
+			var err error
+			request, err = http.NewRequest("GET", server.URL+"/auth/a?redirect=/some-path", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			redirectTarget.Close()
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("redirects to the provider's auth code URL", func() {
+			Ω(response.StatusCode).Should(Equal(http.StatusTemporaryRedirect))
+
+			location, err := response.Location()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(location.Path).Should(Equal("/oauth"))
+		})
+
+		It("sets the state as a cookie matching the state given to the provider", func() {
+			Ω(fakeProviderA.AuthCodeURLCallCount()).Should(Equal(1))
+			state := fakeProviderA.AuthCodeURLArgsForCall(0)
+
+			stateCookie := cookieNamed(response, auth.OAuthStateCookie)
+			Ω(stateCookie).ShouldNot(BeNil())
+			Ω(stateCookie.Value).Should(Equal(state))
+		})
+
+		It("encodes the redirect path in the state", func() {
+			state := fakeProviderA.AuthCodeURLArgsForCall(0)
+
+			decoded, err := base64.URLEncoding.DecodeString(state)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var oauthState auth.OAuthState
+			err = json.Unmarshal(decoded, &oauthState)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(oauthState.Redirect).Should(Equal("/some-path"))
+			Ω(oauthState.Entropy).ShouldNot(BeEmpty())
+		})
+
+		It("does not touch the other providers", func() {
+			Ω(fakeProviderB.AuthCodeURLCallCount()).Should(BeZero())
+		})
+
+		Context("with an unknown provider", func() {
+			BeforeEach(func() {
+				var err error
+				request, err = http.NewRequest("GET", server.URL+"/auth/bogus", nil)
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("returns 404", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /auth/:provider/callback", func() {
+		var state string
+		var request *http.Request
+		var response *http.Response
+
+		BeforeEach(func() {
+			stateJSON, err := json.Marshal(auth.OAuthState{
+				Redirect: "/some-path",
+				Entropy:  "some-entropy",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			state = base64.URLEncoding.EncodeToString(stateJSON)
+
+			request, err = http.NewRequest("GET", server.URL+"/auth/b/callback?code=some-code&state="+url.QueryEscape(state), nil)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Do(request)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		Context("with a matching state cookie", func() {
+			var exchangedClient *http.Client
+
+			BeforeEach(func() {
+				request.AddCookie(&http.Cookie{
+					Name:  auth.OAuthStateCookie,
+					Value: state,
+				})
+
+				exchangedClient = &http.Client{}
+				fakeProviderB.ExchangeReturns(exchangedClient, nil)
+			})
+
+			It("exchanges the code with the provider", func() {
+				Ω(fakeProviderB.ExchangeCallCount()).Should(Equal(1))
+				Ω(fakeProviderB.ExchangeArgsForCall(0)).Should(Equal("some-code"))
+			})
+
+			Context("when the user is verified", func() {
+				BeforeEach(func() {
+					fakeProviderB.VerifyReturns(auth.Grant{
+						TeamName: "some-team",
+						Role:     auth.RoleOperator,
+					}, true, nil)
+					fakeTokenGenerator.GenerateTokenReturns("Bearer", "some-token", nil)
+				})
+
+				It("verifies using the exchanged client", func() {
+					Ω(fakeProviderB.VerifyCallCount()).Should(Equal(1))
+					_, verifiedClient := fakeProviderB.VerifyArgsForCall(0)
+					Ω(verifiedClient).Should(Equal(exchangedClient))
+				})
+
+				It("generates a token for the granted team and role that expires with the cookie", func() {
+					Ω(fakeTokenGenerator.GenerateTokenCallCount()).Should(Equal(1))
+
+					subject, teamName, role, expiration := fakeTokenGenerator.GenerateTokenArgsForCall(0)
+					Ω(subject).Should(Equal("b"))
+					Ω(teamName).Should(Equal("some-team"))
+					Ω(role).Should(Equal(auth.RoleOperator))
+					Ω(expiration).Should(BeTemporally("~", time.Now().Add(auth.CookieAge), time.Minute))
+				})
+
+				It("sets the token as the auth cookie", func() {
+					authCookie := cookieNamed(response, auth.CookieName)
+					Ω(authCookie).ShouldNot(BeNil())
+					Ω(authCookie.Value).Should(Equal("Bearer some-token"))
+					Ω(authCookie.Path).Should(Equal("/"))
+				})
+
+				It("redirects to the path from the state", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusTemporaryRedirect))
+
+					location, err := response.Location()
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(location.Path).Should(Equal("/some-path"))
+				})
+
+				Context("when the redirect in the state leaves the ATC", func() {
+					BeforeEach(func() {
+						stateJSON, err := json.Marshal(auth.OAuthState{
+							Redirect: "//evil.example.com/",
+						})
+						Ω(err).ShouldNot(HaveOccurred())
+
+						state = base64.URLEncoding.EncodeToString(stateJSON)
+
+						request, err = http.NewRequest("GET", server.URL+"/auth/b/callback?code=some-code&state="+url.QueryEscape(state), nil)
+						Ω(err).ShouldNot(HaveOccurred())
+
+						request.AddCookie(&http.Cookie{
+							Name:  auth.OAuthStateCookie,
+							Value: state,
+						})
+					})
+
+					It("redirects to the root instead", func() {
+						location, err := response.Location()
+						Ω(err).ShouldNot(HaveOccurred())
+						Ω(location.Host).Should(Equal(request.URL.Host))
+						Ω(location.Path).Should(Equal("/"))
+					})
+				})
+			})
+
+			Context("when the user is not verified", func() {
+				BeforeEach(func() {
+					fakeProviderB.VerifyReturns(auth.Grant{}, false, nil)
+				})
+
+				It("returns 401", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusUnauthorized))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(string(body)).Should(Equal("not authorized"))
+				})
+
+				It("does not set the auth cookie", func() {
+					Ω(cookieNamed(response, auth.CookieName)).Should(BeNil())
+				})
+			})
+
+			Context("when verifying fails", func() {
+				BeforeEach(func() {
+					fakeProviderB.VerifyReturns(auth.Grant{}, false, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when exchanging the code fails", func() {
+				BeforeEach(func() {
+					fakeProviderB.ExchangeReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Ω(response.StatusCode).Should(Equal(http.StatusInternalServerError))
+				})
+
+				It("does not verify", func() {
+					Ω(fakeProviderB.VerifyCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("with a mismatched state cookie", func() {
+			BeforeEach(func() {
+				request.AddCookie(&http.Cookie{
+					Name:  auth.OAuthStateCookie,
+					Value: "some-other-state",
+				})
+			})
+
+			It("returns 400", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+
+			It("does not exchange the code", func() {
+				Ω(fakeProviderB.ExchangeCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("without a state cookie", func() {
+			It("returns 400", func() {
+				Ω(response.StatusCode).Should(Equal(http.StatusBadRequest))
+			})
+
+			It("does not exchange the code", func() {
+				Ω(fakeProviderB.ExchangeCallCount()).Should(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . Provider
+
+type Provider interface {
+	DisplayName() string
+
+	AuthCodeURL(state string) string
+	Exchange(code string) (*http.Client, error)
+
+	Verifier
+}
+
+//go:generate counterfeiter . Verifier
+
+type Verifier interface {
+	// Verify determines whether the user behind the client may log in, and
+	// if so, which team and role they are granted.
+	Verify(lager.Logger, *http.Client) (Grant, bool, error)
+}
+
+// Grant is the team and role given to a user who logs in via OAuth.
+type Grant struct {
+	TeamName string
+	Role     Role
+}
+
+// Providers is the registry of OAuth providers, keyed by the name used in
+// their routes, e.g. /auth/github.
+type Providers map[string]Provider
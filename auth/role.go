@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Role determines which authenticated routes a caller may use. Each role
+// permits everything the roles below it permit.
+type Role string
+
+const (
+	// RoleViewer may read anything that requires authentication.
+	RoleViewer Role = "viewer"
+
+	// RoleOperator may additionally trigger, abort and hijack builds and
+	// pause and unpause pipelines, jobs and resources.
+	RoleOperator Role = "operator"
+
+	// RoleAdmin may additionally configure and delete pipelines, register
+	// workers, create teams and change the log level.
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if _, found := roleRanks[role]; !found {
+		return "", fmt.Errorf("unknown role '%s'", name)
+	}
+
+	return role, nil
+}
+
+func (role Role) Permits(required Role) bool {
+	rank, found := roleRanks[role]
+	return found && rank >= roleRanks[required]
+}
+
+// RoleHandler only serves requests from callers whose role permits the given
+// Role. Unauthenticated requests are rejected with 401 and requests with an
+// insufficient role with 403.
+type RoleHandler struct {
+	Validator Validator
+	Role      Role
+	Handler   http.Handler
+}
+
+func (h RoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.Validator.IsAuthenticated(r) {
+		Unauthorized(w)
+		return
+	}
+
+	if !h.Validator.Role(r).Permits(h.Role) {
+		MissingPermission(w, h.Role)
+		return
+	}
+
+	h.Handler.ServeHTTP(w, r)
+}
+
+func MissingPermission(w http.ResponseWriter, required Role) {
+	w.WriteHeader(http.StatusForbidden)
+	fmt.Fprintf(w, "missing permission: requires the '%s' role", required)
+}
+
+// roleOrDefault grants the least privileged role to callers whose role was
+// not configured, so that admins must be named explicitly.
+func roleOrDefault(role Role) Role {
+	if role == "" {
+		return RoleViewer
+	}
+
+	return role
+}
@@ -0,0 +1,123 @@
+package auth_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/fakes"
+)
+
+var _ = Describe("Role", func() {
+	Describe("Permits", func() {
+		It("permits roles at or below its own", func() {
+			Ω(auth.RoleAdmin.Permits(auth.RoleAdmin)).Should(BeTrue())
+			Ω(auth.RoleAdmin.Permits(auth.RoleOperator)).Should(BeTrue())
+			Ω(auth.RoleAdmin.Permits(auth.RoleViewer)).Should(BeTrue())
+
+			Ω(auth.RoleOperator.Permits(auth.RoleOperator)).Should(BeTrue())
+			Ω(auth.RoleOperator.Permits(auth.RoleViewer)).Should(BeTrue())
+
+			Ω(auth.RoleViewer.Permits(auth.RoleViewer)).Should(BeTrue())
+		})
+
+		It("does not permit roles above its own", func() {
+			Ω(auth.RoleOperator.Permits(auth.RoleAdmin)).Should(BeFalse())
+			Ω(auth.RoleViewer.Permits(auth.RoleOperator)).Should(BeFalse())
+			Ω(auth.RoleViewer.Permits(auth.RoleAdmin)).Should(BeFalse())
+		})
+
+		It("permits nothing for an unknown or empty role", func() {
+			Ω(auth.Role("bogus").Permits(auth.RoleViewer)).Should(BeFalse())
+			Ω(auth.Role("").Permits(auth.RoleViewer)).Should(BeFalse())
+		})
+	})
+
+	Describe("ParseRole", func() {
+		It("parses known roles", func() {
+			Ω(auth.ParseRole("viewer")).Should(Equal(auth.RoleViewer))
+			Ω(auth.ParseRole("operator")).Should(Equal(auth.RoleOperator))
+			Ω(auth.ParseRole("admin")).Should(Equal(auth.RoleAdmin))
+		})
+
+		It("errors for unknown roles", func() {
+			_, err := auth.ParseRole("overlord")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("RoleHandler", func() {
+	var (
+		fakeValidator *fakes.FakeValidator
+
+		server *httptest.Server
+	)
+
+	BeforeEach(func() {
+		fakeValidator = new(fakes.FakeValidator)
+
+		server = httptest.NewServer(auth.RoleHandler{
+			Validator: fakeValidator,
+			Role:      auth.RoleOperator,
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			}),
+		})
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func() *http.Response {
+		response, err := http.Get(server.URL)
+		Ω(err).ShouldNot(HaveOccurred())
+		return response
+	}
+
+	Context("when the request is authenticated", func() {
+		BeforeEach(func() {
+			fakeValidator.IsAuthenticatedReturns(true)
+		})
+
+		Context("with a role permitting the required role", func() {
+			BeforeEach(func() {
+				fakeValidator.RoleReturns(auth.RoleAdmin)
+			})
+
+			It("proxies to the handler", func() {
+				Ω(get().StatusCode).Should(Equal(http.StatusTeapot))
+			})
+		})
+
+		Context("with an insufficient role", func() {
+			BeforeEach(func() {
+				fakeValidator.RoleReturns(auth.RoleViewer)
+			})
+
+			It("returns 403 naming the missing permission", func() {
+				response := get()
+				Ω(response.StatusCode).Should(Equal(http.StatusForbidden))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(string(body)).Should(ContainSubstring("operator"))
+			})
+		})
+	})
+
+	Context("when the request is not authenticated", func() {
+		BeforeEach(func() {
+			fakeValidator.IsAuthenticatedReturns(false)
+		})
+
+		It("returns 401", func() {
+			Ω(get().StatusCode).Should(Equal(http.StatusUnauthorized))
+		})
+	})
+})
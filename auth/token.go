@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/atc"
+)
+
+const TokenTypeBearer = "Bearer"
+
+var ErrInvalidToken = errors.New("invalid token")
+var ErrTokenExpired = errors.New("token has expired")
+
+type TokenType string
+type TokenValue string
+
+//go:generate counterfeiter . TokenGenerator
+
+type TokenGenerator interface {
+	GenerateToken(subject string, teamName string, role Role, expiration time.Time) (TokenType, TokenValue, error)
+}
+
+type tokenGenerator struct {
+	signingKey []byte
+}
+
+func NewTokenGenerator(signingKey []byte) TokenGenerator {
+	return tokenGenerator{
+		signingKey: signingKey,
+	}
+}
+
+type tokenClaims struct {
+	Subject    string `json:"sub"`
+	Team       string `json:"team,omitempty"`
+	Role       Role   `json:"role,omitempty"`
+	Expiration int64  `json:"exp"`
+}
+
+func (generator tokenGenerator) GenerateToken(subject string, teamName string, role Role, expiration time.Time) (TokenType, TokenValue, error) {
+	payload, err := json.Marshal(tokenClaims{
+		Subject:    subject,
+		Team:       teamName,
+		Role:       role,
+		Expiration: expiration.Unix(),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	encodedPayload := base64.URLEncoding.EncodeToString(payload)
+
+	return TokenTypeBearer, TokenValue(encodedPayload + "." + sign(generator.signingKey, encodedPayload)), nil
+}
+
+type TokenValidator struct {
+	SigningKey []byte
+}
+
+func (validator TokenValidator) IsAuthenticated(r *http.Request) bool {
+	token, err := ExtractBearerToken(r.Header.Get("Authorization"))
+	if err != nil {
+		return false
+	}
+
+	_, err = validator.claims(token)
+	return err == nil
+}
+
+func (validator TokenValidator) TeamName(r *http.Request) string {
+	token, err := ExtractBearerToken(r.Header.Get("Authorization"))
+	if err != nil {
+		return ""
+	}
+
+	claims, err := validator.claims(token)
+	if err != nil {
+		return ""
+	}
+
+	return teamOrDefault(claims.Team)
+}
+
+func (validator TokenValidator) Role(r *http.Request) Role {
+	token, err := ExtractBearerToken(r.Header.Get("Authorization"))
+	if err != nil {
+		return ""
+	}
+
+	claims, err := validator.claims(token)
+	if err != nil {
+		return ""
+	}
+
+	return roleOrDefault(claims.Role)
+}
+
+func (validator TokenValidator) claims(token string) (tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return tokenClaims{}, ErrInvalidToken
+	}
+
+	expectedSignature := sign(validator.SigningKey, parts[0])
+	if !hmac.Equal([]byte(parts[1]), []byte(expectedSignature)) {
+		return tokenClaims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.URLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return tokenClaims{}, ErrInvalidToken
+	}
+
+	var claims tokenClaims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return tokenClaims{}, ErrInvalidToken
+	}
+
+	if time.Now().Unix() >= claims.Expiration {
+		return tokenClaims{}, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+func ExtractBearerToken(authorizationHeader string) (string, error) {
+	if !strings.HasPrefix(authorizationHeader, TokenTypeBearer+" ") {
+		return "", ErrUnparsableHeader
+	}
+
+	token := authorizationHeader[len(TokenTypeBearer)+1:]
+	if token == "" {
+		return "", ErrUnparsableHeader
+	}
+
+	return token, nil
+}
+
+func sign(key []byte, payload string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(payload))
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
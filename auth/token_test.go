@@ -0,0 +1,129 @@
+package auth_test
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc/auth"
+)
+
+var _ = Describe("Tokens", func() {
+	var signingKey []byte
+
+	var generator auth.TokenGenerator
+	var validator auth.TokenValidator
+
+	BeforeEach(func() {
+		signingKey = []byte("some-signing-key")
+
+		generator = auth.NewTokenGenerator(signingKey)
+		validator = auth.TokenValidator{SigningKey: signingKey}
+	})
+
+	requestWith := func(authorization string) *http.Request {
+		request, err := http.NewRequest("GET", "http://example.com", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		request.Header.Set("Authorization", authorization)
+
+		return request
+	}
+
+	Describe("generating a token", func() {
+		It("returns a bearer token", func() {
+			tokenType, tokenValue, err := generator.GenerateToken("some-user", "some-team", auth.RoleOperator, time.Now().Add(time.Hour))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(tokenType).Should(Equal(auth.TokenType(auth.TokenTypeBearer)))
+			Ω(tokenValue).ShouldNot(BeEmpty())
+		})
+	})
+
+	Describe("validating a token", func() {
+		var tokenType auth.TokenType
+		var tokenValue auth.TokenValue
+		var expiration time.Time
+
+		BeforeEach(func() {
+			expiration = time.Now().Add(time.Hour)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			tokenType, tokenValue, err = generator.GenerateToken("some-user", "some-team", auth.RoleOperator, expiration)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("accepts a token it generated", func() {
+			Ω(validator.IsAuthenticated(requestWith(string(tokenType) + " " + string(tokenValue)))).Should(BeTrue())
+		})
+
+		It("resolves the team the token was generated for", func() {
+			Ω(validator.TeamName(requestWith(string(tokenType) + " " + string(tokenValue)))).Should(Equal("some-team"))
+		})
+
+		It("resolves the role the token was generated for", func() {
+			Ω(validator.Role(requestWith(string(tokenType) + " " + string(tokenValue)))).Should(Equal(auth.RoleOperator))
+		})
+
+		It("resolves no role for an invalid token", func() {
+			Ω(validator.Role(requestWith(string(tokenType) + " bogus"))).Should(BeEmpty())
+		})
+
+		It("resolves no team for an invalid token", func() {
+			Ω(validator.TeamName(requestWith(string(tokenType) + " bogus"))).Should(BeEmpty())
+		})
+
+		It("rejects a token signed with another key", func() {
+			otherValidator := auth.TokenValidator{SigningKey: []byte("some-other-key")}
+			Ω(otherValidator.IsAuthenticated(requestWith(string(tokenType) + " " + string(tokenValue)))).Should(BeFalse())
+		})
+
+		It("rejects a token whose claims have been tampered with", func() {
+			parts := strings.Split(string(tokenValue), ".")
+			tampered := parts[0] + "x." + parts[1]
+
+			Ω(validator.IsAuthenticated(requestWith(string(tokenType) + " " + tampered))).Should(BeFalse())
+		})
+
+		It("rejects a token using the wrong scheme", func() {
+			Ω(validator.IsAuthenticated(requestWith("Basic " + string(tokenValue)))).Should(BeFalse())
+		})
+
+		It("rejects a missing token", func() {
+			Ω(validator.IsAuthenticated(requestWith(""))).Should(BeFalse())
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				expiration = time.Now().Add(-time.Minute)
+			})
+
+			It("rejects it", func() {
+				Ω(validator.IsAuthenticated(requestWith(string(tokenType) + " " + string(tokenValue)))).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("ExtractBearerToken", func() {
+		It("returns the token", func() {
+			token, err := auth.ExtractBearerToken("Bearer some-token")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(token).Should(Equal("some-token"))
+		})
+
+		It("errors when the header is not a bearer token", func() {
+			_, err := auth.ExtractBearerToken("Basic some-token")
+			Ω(err).Should(Equal(auth.ErrUnparsableHeader))
+		})
+
+		It("errors when the token is empty", func() {
+			_, err := auth.ExtractBearerToken("Bearer ")
+			Ω(err).Should(Equal(auth.ErrUnparsableHeader))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"code.google.com/p/go.crypto/bcrypt"
+	"github.com/concourse/atc"
 )
 
 var ErrUnparsableHeader = errors.New("cannot parse 'Authorization' header")
@@ -14,15 +15,63 @@ var ErrUnparsableHeader = errors.New("cannot parse 'Authorization' header")
 //go:generate counterfeiter . Validator
 type Validator interface {
 	IsAuthenticated(*http.Request) bool
+
+	// TeamName returns the team the caller belongs to, or "" if the request
+	// is not authenticated.
+	TeamName(*http.Request) string
+
+	// Role returns the caller's role, or "" if the request is not
+	// authenticated.
+	Role(*http.Request) Role
 }
 
 type NoopValidator struct{}
 
 func (NoopValidator) IsAuthenticated(*http.Request) bool { return true }
+func (NoopValidator) TeamName(*http.Request) string      { return atc.DefaultTeamName }
+func (NoopValidator) Role(*http.Request) Role            { return RoleAdmin }
+
+type ValidatorBasket []Validator
+
+func (basket ValidatorBasket) IsAuthenticated(r *http.Request) bool {
+	for _, validator := range basket {
+		if validator.IsAuthenticated(r) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (basket ValidatorBasket) TeamName(r *http.Request) string {
+	for _, validator := range basket {
+		if validator.IsAuthenticated(r) {
+			return validator.TeamName(r)
+		}
+	}
+
+	return ""
+}
+
+func (basket ValidatorBasket) Role(r *http.Request) Role {
+	for _, validator := range basket {
+		if validator.IsAuthenticated(r) {
+			return validator.Role(r)
+		}
+	}
+
+	return ""
+}
 
 type BasicAuthHashedValidator struct {
 	Username       string
 	HashedPassword string
+
+	// defaults to atc.DefaultTeamName
+	Team string
+
+	// defaults to RoleViewer
+	AssignedRole Role
 }
 
 func (validator BasicAuthHashedValidator) IsAuthenticated(r *http.Request) bool {
@@ -36,6 +85,22 @@ func (validator BasicAuthHashedValidator) IsAuthenticated(r *http.Request) bool
 	return validator.correctCredentials(username, password)
 }
 
+func (validator BasicAuthHashedValidator) TeamName(r *http.Request) string {
+	if !validator.IsAuthenticated(r) {
+		return ""
+	}
+
+	return teamOrDefault(validator.Team)
+}
+
+func (validator BasicAuthHashedValidator) Role(r *http.Request) Role {
+	if !validator.IsAuthenticated(r) {
+		return ""
+	}
+
+	return roleOrDefault(validator.AssignedRole)
+}
+
 func (validator BasicAuthHashedValidator) correctCredentials(username string, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(validator.HashedPassword), []byte(password))
 	return validator.Username == username && err == nil
@@ -44,6 +109,12 @@ func (validator BasicAuthHashedValidator) correctCredentials(username string, pa
 type BasicAuthValidator struct {
 	Username string
 	Password string
+
+	// defaults to atc.DefaultTeamName
+	Team string
+
+	// defaults to RoleViewer
+	AssignedRole Role
 }
 
 func (validator BasicAuthValidator) IsAuthenticated(r *http.Request) bool {
@@ -57,10 +128,34 @@ func (validator BasicAuthValidator) IsAuthenticated(r *http.Request) bool {
 	return validator.correctCredentials(username, password)
 }
 
+func (validator BasicAuthValidator) TeamName(r *http.Request) string {
+	if !validator.IsAuthenticated(r) {
+		return ""
+	}
+
+	return teamOrDefault(validator.Team)
+}
+
+func (validator BasicAuthValidator) Role(r *http.Request) Role {
+	if !validator.IsAuthenticated(r) {
+		return ""
+	}
+
+	return roleOrDefault(validator.AssignedRole)
+}
+
 func (validator BasicAuthValidator) correctCredentials(username string, password string) bool {
 	return validator.Username == username && validator.Password == password
 }
 
+func teamOrDefault(teamName string) string {
+	if teamName == "" {
+		return atc.DefaultTeamName
+	}
+
+	return teamName
+}
+
 func ExtractUsernameAndPassword(authorizationHeader string) (string, string, error) {
 	if !strings.HasPrefix(authorizationHeader, "Basic ") {
 		return "", "", ErrUnparsableHeader
@@ -0,0 +1,197 @@
+package auth_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"code.google.com/p/go.crypto/bcrypt"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/fakes"
+)
+
+var _ = Describe("ValidatorBasket", func() {
+	var (
+		fakeValidatorA *fakes.FakeValidator
+		fakeValidatorB *fakes.FakeValidator
+
+		basket  auth.ValidatorBasket
+		request *http.Request
+	)
+
+	BeforeEach(func() {
+		fakeValidatorA = new(fakes.FakeValidator)
+		fakeValidatorB = new(fakes.FakeValidator)
+
+		basket = auth.ValidatorBasket{fakeValidatorA, fakeValidatorB}
+
+		var err error
+		request, err = http.NewRequest("GET", "http://example.com", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("when any validator authenticates the request", func() {
+		BeforeEach(func() {
+			fakeValidatorB.IsAuthenticatedReturns(true)
+		})
+
+		It("is authenticated", func() {
+			Ω(basket.IsAuthenticated(request)).Should(BeTrue())
+		})
+
+		It("passes the request to the validators", func() {
+			basket.IsAuthenticated(request)
+
+			Ω(fakeValidatorA.IsAuthenticatedArgsForCall(0)).Should(Equal(request))
+			Ω(fakeValidatorB.IsAuthenticatedArgsForCall(0)).Should(Equal(request))
+		})
+
+		It("resolves the team from the validator that authenticated it", func() {
+			fakeValidatorA.TeamNameReturns("team-a")
+			fakeValidatorB.TeamNameReturns("team-b")
+
+			Ω(basket.TeamName(request)).Should(Equal("team-b"))
+		})
+
+		It("resolves the role from the validator that authenticated it", func() {
+			fakeValidatorA.RoleReturns(auth.RoleAdmin)
+			fakeValidatorB.RoleReturns(auth.RoleViewer)
+
+			Ω(basket.Role(request)).Should(Equal(auth.RoleViewer))
+		})
+	})
+
+	Context("when no validator authenticates the request", func() {
+		It("is not authenticated", func() {
+			Ω(basket.IsAuthenticated(request)).Should(BeFalse())
+		})
+
+		It("resolves no team", func() {
+			Ω(basket.TeamName(request)).Should(BeEmpty())
+		})
+
+		It("resolves no role", func() {
+			Ω(basket.Role(request)).Should(BeEmpty())
+		})
+	})
+
+	Context("when empty", func() {
+		It("is not authenticated", func() {
+			Ω(auth.ValidatorBasket{}.IsAuthenticated(request)).Should(BeFalse())
+		})
+	})
+})
+
+var _ = Describe("LoadHashedUsers", func() {
+	var tmpdir string
+	var usersFile string
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "hashed-users")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		usersFile = filepath.Join(tmpdir, "users")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	requestAs := func(username, password string) *http.Request {
+		request, err := http.NewRequest("GET", "http://example.com", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		request.SetBasicAuth(username, password)
+
+		return request
+	}
+
+	Context("with a well-formed file", func() {
+		BeforeEach(func() {
+			hashA, err := bcrypt.GenerateFromPassword([]byte("password-a"), bcrypt.MinCost)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			hashB, err := bcrypt.GenerateFromPassword([]byte("password-b"), bcrypt.MinCost)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			hashC, err := bcrypt.GenerateFromPassword([]byte("password-c"), bcrypt.MinCost)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			contents := "# team members\nuser-a:" + string(hashA) + "\n\nuser-b:" + string(hashB) + ":team-b\nuser-c:" + string(hashC) + "::admin\n"
+
+			err = ioutil.WriteFile(usersFile, []byte(contents), 0600)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("authenticates each user with their own password", func() {
+			basket, err := auth.LoadHashedUsers(usersFile)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(basket).Should(HaveLen(3))
+
+			Ω(basket.IsAuthenticated(requestAs("user-a", "password-a"))).Should(BeTrue())
+			Ω(basket.IsAuthenticated(requestAs("user-b", "password-b"))).Should(BeTrue())
+
+			Ω(basket.IsAuthenticated(requestAs("user-a", "password-b"))).Should(BeFalse())
+			Ω(basket.IsAuthenticated(requestAs("user-c", "password-a"))).Should(BeFalse())
+		})
+
+		It("places users in their team, defaulting to the main team", func() {
+			basket, err := auth.LoadHashedUsers(usersFile)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(basket.TeamName(requestAs("user-a", "password-a"))).Should(Equal(atc.DefaultTeamName))
+			Ω(basket.TeamName(requestAs("user-b", "password-b"))).Should(Equal("team-b"))
+			Ω(basket.TeamName(requestAs("user-c", "password-c"))).Should(Equal(atc.DefaultTeamName))
+		})
+
+		It("gives users their role, defaulting to viewer", func() {
+			basket, err := auth.LoadHashedUsers(usersFile)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(basket.Role(requestAs("user-a", "password-a"))).Should(Equal(auth.RoleViewer))
+			Ω(basket.Role(requestAs("user-b", "password-b"))).Should(Equal(auth.RoleViewer))
+			Ω(basket.Role(requestAs("user-c", "password-c"))).Should(Equal(auth.RoleAdmin))
+		})
+	})
+
+	Context("with an unknown role", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(usersFile, []byte("user-a:some-hash:team-a:overlord\n"), 0600)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns an error naming the line and the role", func() {
+			_, err := auth.LoadHashedUsers(usersFile)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("line 1"))
+			Ω(err.Error()).Should(ContainSubstring("overlord"))
+		})
+	})
+
+	Context("with a malformed line", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(usersFile, []byte("user-a\n"), 0600)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns an error naming the line", func() {
+			_, err := auth.LoadHashedUsers(usersFile)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("line 1"))
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		It("returns an error", func() {
+			_, err := auth.LoadHashedUsers(filepath.Join(tmpdir, "bogus"))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
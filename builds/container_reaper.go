@@ -0,0 +1,145 @@
+package builds
+
+import (
+	"expvar"
+	"time"
+
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+)
+
+var reapedContainers = expvar.NewMap("ReapedContainers")
+
+//go:generate counterfeiter . ContainerReaperDB
+
+type ContainerReaperDB interface {
+	GetBuild(buildID int) (db.Build, error)
+}
+
+func NewContainerReaper(
+	logger lager.Logger,
+
+	workerClient worker.Client,
+	reaperDB ContainerReaperDB,
+
+	gracePeriod time.Duration,
+	clock clock.Clock,
+) *ContainerReaper {
+	return &ContainerReaper{
+		logger:       logger,
+		workerClient: workerClient,
+		reaperDB:     reaperDB,
+		gracePeriod:  gracePeriod,
+		clock:        clock,
+	}
+}
+
+// ContainerReaper destroys the containers of builds that finished more than
+// a grace period ago and which are no longer being kept alive, e.g. because
+// the ATC running the build went away before releasing them.
+type ContainerReaper struct {
+	logger lager.Logger
+
+	workerClient worker.Client
+	reaperDB     ContainerReaperDB
+
+	gracePeriod time.Duration
+	clock       clock.Clock
+}
+
+func (reaper *ContainerReaper) Reap() {
+	reaper.logger.Info("start")
+	defer reaper.logger.Info("done")
+
+	containers, err := reaper.workerClient.FindContainersForIdentifier(worker.Identifier{})
+	if err != nil {
+		reaper.logger.Error("failed-to-find-containers", err)
+		return
+	}
+
+	for _, container := range containers {
+		reaper.reap(container)
+	}
+}
+
+func (reaper *ContainerReaper) reap(container worker.Container) {
+	rLog := reaper.logger.Session("reap", lager.Data{
+		"handle": container.Handle(),
+		"worker": container.WorkerName(),
+	})
+
+	id, err := container.IdentifierFromProperties()
+	if err != nil {
+		rLog.Error("failed-to-get-identifier", err)
+		container.Release()
+		return
+	}
+
+	if id.BuildID == 0 {
+		// not a build's container; left to expire on its own
+		container.Release()
+		return
+	}
+
+	if !reaper.buildFinishedLongAgo(rLog, id.BuildID) {
+		container.Release()
+		return
+	}
+
+	keptAlive, err := container.KeptAlive()
+	if err != nil {
+		rLog.Error("failed-to-get-keepalive", err)
+		container.Release()
+		return
+	}
+
+	// a container never kept alive was abandoned before its first heartbeat,
+	// e.g. by an ATC that went away, so its full TTL does not count
+	if keptAlive {
+		ttl, err := container.TTL()
+		if err != nil {
+			rLog.Error("failed-to-get-ttl", err)
+			container.Release()
+			return
+		}
+
+		if ttl > 0 {
+			// still being kept alive, e.g. by someone hijacking it
+			container.Release()
+			return
+		}
+	}
+
+	err = container.Destroy()
+	if err != nil {
+		rLog.Error("failed-to-destroy", err)
+		return
+	}
+
+	reapedContainers.Add(container.WorkerName(), 1)
+
+	rLog.Info("destroyed", lager.Data{
+		"build-id": id.BuildID,
+	})
+}
+
+func (reaper *ContainerReaper) buildFinishedLongAgo(logger lager.Logger, buildID int) bool {
+	build, err := reaper.reaperDB.GetBuild(buildID)
+	if err == db.ErrNoBuild {
+		// the build went away along with its pipeline
+		return true
+	}
+
+	if err != nil {
+		logger.Error("failed-to-get-build", err)
+		return false
+	}
+
+	if build.IsRunning() {
+		return false
+	}
+
+	return build.EndTime.IsZero() || reaper.clock.Now().Sub(build.EndTime) >= reaper.gracePeriod
+}
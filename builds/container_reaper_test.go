@@ -0,0 +1,206 @@
+package builds_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/builds/fakes"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/worker"
+	wfakes "github.com/concourse/atc/worker/fakes"
+)
+
+var _ = Describe("ContainerReaper", func() {
+	var (
+		fakeWorkerClient *wfakes.FakeClient
+		fakeReaperDB     *fakes.FakeContainerReaperDB
+		fakeClock        *fakeclock.FakeClock
+
+		reaper *builds.ContainerReaper
+
+		fakeContainer *wfakes.FakeContainer
+	)
+
+	BeforeEach(func() {
+		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeReaperDB = new(fakes.FakeContainerReaperDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+
+		reaper = builds.NewContainerReaper(
+			lagertest.NewTestLogger("test"),
+			fakeWorkerClient,
+			fakeReaperDB,
+			5*time.Minute,
+			fakeClock,
+		)
+
+		fakeContainer = new(wfakes.FakeContainer)
+		fakeContainer.HandleReturns("some-handle")
+		fakeContainer.WorkerNameReturns("some-worker")
+		fakeContainer.IdentifierFromPropertiesReturns(worker.Identifier{
+			BuildID: 42,
+			Type:    worker.ContainerTypeTask,
+			Name:    "some-task",
+		}, nil)
+		fakeContainer.KeptAliveReturns(true, nil)
+		fakeContainer.TTLReturns(0, nil)
+
+		fakeWorkerClient.FindContainersForIdentifierReturns([]worker.Container{fakeContainer}, nil)
+	})
+
+	JustBeforeEach(func() {
+		reaper.Reap()
+	})
+
+	It("looks at all containers", func() {
+		Ω(fakeWorkerClient.FindContainersForIdentifierCallCount()).Should(Equal(1))
+		Ω(fakeWorkerClient.FindContainersForIdentifierArgsForCall(0)).Should(BeZero())
+	})
+
+	It("looks up the container's build", func() {
+		Ω(fakeReaperDB.GetBuildCallCount()).Should(Equal(1))
+		Ω(fakeReaperDB.GetBuildArgsForCall(0)).Should(Equal(42))
+	})
+
+	Context("when the build finished longer ago than the grace period", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetBuildReturns(db.Build{
+				ID:      42,
+				Status:  db.StatusSucceeded,
+				EndTime: fakeClock.Now().Add(-10 * time.Minute),
+			}, nil)
+		})
+
+		It("destroys the container", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(Equal(1))
+		})
+
+		Context("when the container is still being kept alive", func() {
+			BeforeEach(func() {
+				fakeContainer.TTLReturns(4*time.Minute, nil)
+			})
+
+			It("releases the container without destroying it", func() {
+				Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+				Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("when the container has never been kept alive", func() {
+			BeforeEach(func() {
+				fakeContainer.KeptAliveReturns(false, nil)
+				fakeContainer.TTLReturns(5*time.Minute, nil)
+			})
+
+			It("destroys the container", func() {
+				Ω(fakeContainer.DestroyCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("when getting whether the container has been kept alive fails", func() {
+			BeforeEach(func() {
+				fakeContainer.KeptAliveReturns(false, errors.New("nope"))
+			})
+
+			It("releases the container without destroying it", func() {
+				Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+				Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+			})
+		})
+
+		Context("when getting the container's TTL fails", func() {
+			BeforeEach(func() {
+				fakeContainer.TTLReturns(0, errors.New("nope"))
+			})
+
+			It("releases the container without destroying it", func() {
+				Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+				Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
+	Context("when the build finished within the grace period", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetBuildReturns(db.Build{
+				ID:      42,
+				Status:  db.StatusFailed,
+				EndTime: fakeClock.Now().Add(-time.Minute),
+			}, nil)
+		})
+
+		It("releases the container without destroying it", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+			Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the build is still running", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetBuildReturns(db.Build{
+				ID:     42,
+				Status: db.StatusStarted,
+			}, nil)
+		})
+
+		It("releases the container without destroying it", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+			Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the build no longer exists", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetBuildReturns(db.Build{}, db.ErrNoBuild)
+		})
+
+		It("destroys the container", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when looking up the build fails", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetBuildReturns(db.Build{}, errors.New("nope"))
+		})
+
+		It("releases the container without destroying it", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+			Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when the container does not belong to a build", func() {
+		BeforeEach(func() {
+			fakeContainer.IdentifierFromPropertiesReturns(worker.Identifier{
+				Type: worker.ContainerTypeCheck,
+				Name: "some-resource",
+			}, nil)
+		})
+
+		It("does not look up a build", func() {
+			Ω(fakeReaperDB.GetBuildCallCount()).Should(BeZero())
+		})
+
+		It("releases the container without destroying it", func() {
+			Ω(fakeContainer.DestroyCallCount()).Should(BeZero())
+			Ω(fakeContainer.ReleaseCallCount()).Should(Equal(1))
+		})
+	})
+
+	Context("when finding the containers fails", func() {
+		BeforeEach(func() {
+			fakeWorkerClient.FindContainersForIdentifierReturns(nil, errors.New("nope"))
+		})
+
+		It("does not look up any builds", func() {
+			Ω(fakeReaperDB.GetBuildCallCount()).Should(BeZero())
+		})
+	})
+})
@@ -0,0 +1,31 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/builds"
+)
+
+type FakeBuildReaper struct {
+	ReapStub        func()
+	reapMutex       sync.RWMutex
+	reapArgsForCall []struct{}
+}
+
+func (fake *FakeBuildReaper) Reap() {
+	fake.reapMutex.Lock()
+	fake.reapArgsForCall = append(fake.reapArgsForCall, struct{}{})
+	fake.reapMutex.Unlock()
+	if fake.ReapStub != nil {
+		fake.ReapStub()
+	}
+}
+
+func (fake *FakeBuildReaper) ReapCallCount() int {
+	fake.reapMutex.RLock()
+	defer fake.reapMutex.RUnlock()
+	return len(fake.reapArgsForCall)
+}
+
+var _ builds.BuildReaper = new(FakeBuildReaper)
@@ -0,0 +1,56 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/db"
+)
+
+type FakeContainerReaperDB struct {
+	GetBuildStub        func(buildID int) (db.Build, error)
+	getBuildMutex       sync.RWMutex
+	getBuildArgsForCall []struct {
+		buildID int
+	}
+	getBuildReturns struct {
+		result1 db.Build
+		result2 error
+	}
+}
+
+func (fake *FakeContainerReaperDB) GetBuild(buildID int) (db.Build, error) {
+	fake.getBuildMutex.Lock()
+	fake.getBuildArgsForCall = append(fake.getBuildArgsForCall, struct {
+		buildID int
+	}{buildID})
+	fake.getBuildMutex.Unlock()
+	if fake.GetBuildStub != nil {
+		return fake.GetBuildStub(buildID)
+	} else {
+		return fake.getBuildReturns.result1, fake.getBuildReturns.result2
+	}
+}
+
+func (fake *FakeContainerReaperDB) GetBuildCallCount() int {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return len(fake.getBuildArgsForCall)
+}
+
+func (fake *FakeContainerReaperDB) GetBuildArgsForCall(i int) int {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return fake.getBuildArgsForCall[i].buildID
+}
+
+func (fake *FakeContainerReaperDB) GetBuildReturns(result1 db.Build, result2 error) {
+	fake.GetBuildStub = nil
+	fake.getBuildReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+var _ builds.ContainerReaperDB = new(FakeContainerReaperDB)
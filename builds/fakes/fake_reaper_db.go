@@ -0,0 +1,93 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/db"
+)
+
+type FakeReaperDB struct {
+	GetAllActivePipelinesStub        func() ([]db.SavedPipeline, error)
+	getAllActivePipelinesMutex       sync.RWMutex
+	getAllActivePipelinesArgsForCall []struct{}
+	getAllActivePipelinesReturns struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}
+	ReapBuildEventsStub        func(pipelineID int, jobName string, retention atc.BuildLogRetention) (int64, error)
+	reapBuildEventsMutex       sync.RWMutex
+	reapBuildEventsArgsForCall []struct {
+		pipelineID int
+		jobName    string
+		retention  atc.BuildLogRetention
+	}
+	reapBuildEventsReturns struct {
+		result1 int64
+		result2 error
+	}
+}
+
+func (fake *FakeReaperDB) GetAllActivePipelines() ([]db.SavedPipeline, error) {
+	fake.getAllActivePipelinesMutex.Lock()
+	fake.getAllActivePipelinesArgsForCall = append(fake.getAllActivePipelinesArgsForCall, struct{}{})
+	fake.getAllActivePipelinesMutex.Unlock()
+	if fake.GetAllActivePipelinesStub != nil {
+		return fake.GetAllActivePipelinesStub()
+	} else {
+		return fake.getAllActivePipelinesReturns.result1, fake.getAllActivePipelinesReturns.result2
+	}
+}
+
+func (fake *FakeReaperDB) GetAllActivePipelinesCallCount() int {
+	fake.getAllActivePipelinesMutex.RLock()
+	defer fake.getAllActivePipelinesMutex.RUnlock()
+	return len(fake.getAllActivePipelinesArgsForCall)
+}
+
+func (fake *FakeReaperDB) GetAllActivePipelinesReturns(result1 []db.SavedPipeline, result2 error) {
+	fake.GetAllActivePipelinesStub = nil
+	fake.getAllActivePipelinesReturns = struct {
+		result1 []db.SavedPipeline
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReaperDB) ReapBuildEvents(pipelineID int, jobName string, retention atc.BuildLogRetention) (int64, error) {
+	fake.reapBuildEventsMutex.Lock()
+	fake.reapBuildEventsArgsForCall = append(fake.reapBuildEventsArgsForCall, struct {
+		pipelineID int
+		jobName    string
+		retention  atc.BuildLogRetention
+	}{pipelineID, jobName, retention})
+	fake.reapBuildEventsMutex.Unlock()
+	if fake.ReapBuildEventsStub != nil {
+		return fake.ReapBuildEventsStub(pipelineID, jobName, retention)
+	} else {
+		return fake.reapBuildEventsReturns.result1, fake.reapBuildEventsReturns.result2
+	}
+}
+
+func (fake *FakeReaperDB) ReapBuildEventsCallCount() int {
+	fake.reapBuildEventsMutex.RLock()
+	defer fake.reapBuildEventsMutex.RUnlock()
+	return len(fake.reapBuildEventsArgsForCall)
+}
+
+func (fake *FakeReaperDB) ReapBuildEventsArgsForCall(i int) (int, string, atc.BuildLogRetention) {
+	fake.reapBuildEventsMutex.RLock()
+	defer fake.reapBuildEventsMutex.RUnlock()
+	return fake.reapBuildEventsArgsForCall[i].pipelineID, fake.reapBuildEventsArgsForCall[i].jobName, fake.reapBuildEventsArgsForCall[i].retention
+}
+
+func (fake *FakeReaperDB) ReapBuildEventsReturns(result1 int64, result2 error) {
+	fake.ReapBuildEventsStub = nil
+	fake.reapBuildEventsReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+var _ builds.ReaperDB = new(FakeReaperDB)
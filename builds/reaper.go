@@ -0,0 +1,70 @@
+package builds
+
+import (
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/pivotal-golang/lager"
+)
+
+//go:generate counterfeiter . ReaperDB
+
+type ReaperDB interface {
+	GetAllActivePipelines() ([]db.SavedPipeline, error)
+	ReapBuildEvents(pipelineID int, jobName string, retention atc.BuildLogRetention) (int64, error)
+}
+
+func NewReaper(
+	logger lager.Logger,
+
+	reaperDB ReaperDB,
+) *Reaper {
+	return &Reaper{
+		logger:   logger,
+		reaperDB: reaperDB,
+	}
+}
+
+// Reaper deletes the events of finished builds according to the
+// build_log_retention policy of their job. Jobs without a policy keep their
+// events forever.
+type Reaper struct {
+	logger lager.Logger
+
+	reaperDB ReaperDB
+}
+
+func (reaper *Reaper) Reap() {
+	reaper.logger.Info("start")
+	defer reaper.logger.Info("done")
+
+	pipelines, err := reaper.reaperDB.GetAllActivePipelines()
+	if err != nil {
+		reaper.logger.Error("failed-to-lookup-pipelines", err)
+		return
+	}
+
+	for _, pipeline := range pipelines {
+		for _, job := range pipeline.Config.Jobs {
+			if job.BuildLogRetention == nil {
+				continue
+			}
+
+			rLog := reaper.logger.Session("reap", lager.Data{
+				"pipeline": pipeline.Name,
+				"job":      job.Name,
+			})
+
+			reaped, err := reaper.reaperDB.ReapBuildEvents(pipeline.ID, job.Name, *job.BuildLogRetention)
+			if err != nil {
+				rLog.Error("failed-to-reap-build-events", err)
+				continue
+			}
+
+			if reaped > 0 {
+				rLog.Info("reaped-build-events", lager.Data{
+					"events": reaped,
+				})
+			}
+		}
+	}
+}
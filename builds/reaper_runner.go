@@ -0,0 +1,39 @@
+package builds
+
+import (
+	"os"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+)
+
+//go:generate counterfeiter . BuildReaper
+
+type BuildReaper interface {
+	Reap()
+}
+
+type ReaperRunner struct {
+	Reaper   BuildReaper
+	Interval time.Duration
+	Clock    clock.Clock
+}
+
+func (runner ReaperRunner) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	runner.Reaper.Reap()
+
+	ticker := runner.Clock.NewTicker(runner.Interval)
+
+	for {
+		select {
+		case <-ticker.C():
+			runner.Reaper.Reap()
+		case <-signals:
+			return nil
+		}
+	}
+
+	panic("unreachable")
+}
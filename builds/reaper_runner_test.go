@@ -0,0 +1,70 @@
+package builds_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/concourse/atc/builds"
+	"github.com/concourse/atc/builds/fakes"
+)
+
+var _ = Describe("ReaperRunner", func() {
+	var fakeReaper *fakes.FakeBuildReaper
+	var fakeClock *fakeclock.FakeClock
+	var reaperRunner ReaperRunner
+	var process ifrit.Process
+	var interval = 10 * time.Second
+
+	BeforeEach(func() {
+		fakeReaper = new(fakes.FakeBuildReaper)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		reaperRunner = ReaperRunner{
+			Reaper:   fakeReaper,
+			Interval: interval,
+			Clock:    fakeClock,
+		}
+	})
+
+	JustBeforeEach(func() {
+		process = ifrit.Invoke(reaperRunner)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	It("reaps immediately", func() {
+		Eventually(fakeReaper.ReapCallCount).Should(Equal(1))
+	})
+
+	Context("when the interval elapses", func() {
+		JustBeforeEach(func() {
+			Eventually(fakeReaper.ReapCallCount).Should(Equal(1))
+			fakeClock.Increment(interval)
+		})
+
+		It("reaps", func() {
+			Eventually(fakeReaper.ReapCallCount).Should(Equal(2))
+			Consistently(fakeReaper.ReapCallCount).Should(Equal(2))
+		})
+
+		Context("when the interval elapses", func() {
+			JustBeforeEach(func() {
+				Eventually(fakeReaper.ReapCallCount).Should(Equal(2))
+				fakeClock.Increment(interval)
+			})
+
+			It("reaps again", func() {
+				Eventually(fakeReaper.ReapCallCount).Should(Equal(3))
+				Consistently(fakeReaper.ReapCallCount).Should(Equal(3))
+			})
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package builds_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/builds"
+	"github.com/concourse/atc/builds/fakes"
+	"github.com/concourse/atc/db"
+)
+
+var _ = Describe("Reaper", func() {
+	var (
+		fakeReaperDB *fakes.FakeReaperDB
+
+		reaper *builds.Reaper
+		logger *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		fakeReaperDB = new(fakes.FakeReaperDB)
+		logger = lagertest.NewTestLogger("test")
+
+		reaper = builds.NewReaper(logger, fakeReaperDB)
+	})
+
+	Describe("Reap", func() {
+		BeforeEach(func() {
+			fakeReaperDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
+				{
+					ID: 1,
+					Pipeline: db.Pipeline{
+						Name: "some-pipeline",
+						Config: atc.Config{
+							Jobs: atc.JobConfigs{
+								{
+									Name:              "kept-forever",
+									BuildLogRetention: nil,
+								},
+								{
+									Name:              "keep-some-builds",
+									BuildLogRetention: &atc.BuildLogRetention{Builds: 10},
+								},
+							},
+						},
+					},
+				},
+				{
+					ID: 2,
+					Pipeline: db.Pipeline{
+						Name: "some-other-pipeline",
+						Config: atc.Config{
+							Jobs: atc.JobConfigs{
+								{
+									Name:              "keep-some-days",
+									BuildLogRetention: &atc.BuildLogRetention{Days: 7},
+								},
+							},
+						},
+					},
+				},
+			}, nil)
+		})
+
+		It("reaps the build events of each job with a retention policy", func() {
+			reaper.Reap()
+
+			Ω(fakeReaperDB.ReapBuildEventsCallCount()).Should(Equal(2))
+
+			pipelineID, jobName, retention := fakeReaperDB.ReapBuildEventsArgsForCall(0)
+			Ω(pipelineID).Should(Equal(1))
+			Ω(jobName).Should(Equal("keep-some-builds"))
+			Ω(retention).Should(Equal(atc.BuildLogRetention{Builds: 10}))
+
+			pipelineID, jobName, retention = fakeReaperDB.ReapBuildEventsArgsForCall(1)
+			Ω(pipelineID).Should(Equal(2))
+			Ω(jobName).Should(Equal("keep-some-days"))
+			Ω(retention).Should(Equal(atc.BuildLogRetention{Days: 7}))
+		})
+
+		Context("when reaping a job fails", func() {
+			BeforeEach(func() {
+				fakeReaperDB.ReapBuildEventsReturns(0, errors.New("nope"))
+			})
+
+			It("continues with the remaining jobs", func() {
+				reaper.Reap()
+
+				Ω(fakeReaperDB.ReapBuildEventsCallCount()).Should(Equal(2))
+			})
+		})
+
+		Context("when looking up the pipelines fails", func() {
+			BeforeEach(func() {
+				fakeReaperDB.GetAllActivePipelinesReturns(nil, errors.New("nope"))
+			})
+
+			It("does not reap anything", func() {
+				reaper.Reap()
+
+				Ω(fakeReaperDB.ReapBuildEventsCallCount()).Should(BeZero())
+			})
+		})
+	})
+})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"encoding/json"
 	"errors"
@@ -30,10 +31,16 @@ import (
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/api"
 	"github.com/concourse/atc/api/buildserver"
+	"github.com/concourse/atc/api/workerserver"
 	"github.com/concourse/atc/auth"
+	"github.com/concourse/atc/auth/github"
 	"github.com/concourse/atc/builds"
 	"github.com/concourse/atc/config"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/creds/localfile"
+	"github.com/concourse/atc/creds/vault"
 	Db "github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/migrations"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/exec"
@@ -75,6 +82,12 @@ var gardenAddr = flag.String(
 	"garden API network address (host:port or socket path). leave empty for dynamic registration.",
 )
 
+var containerPlacementStrategy = flag.String(
+	"containerPlacementStrategy",
+	worker.RandomPlacement,
+	"how to choose a worker for each container (random/fewest-containers/input-locality). ignored when gardenAddr is set.",
+)
+
 var resourceTypes = flag.String(
 	"resourceTypes",
 	`[
@@ -136,7 +149,7 @@ var debugListenPort = flag.Int(
 var httpUsername = flag.String(
 	"httpUsername",
 	"",
-	"basic auth username for the server",
+	"basic auth username for the server; this user is an admin of the main team",
 )
 
 var httpPassword = flag.String(
@@ -151,12 +164,168 @@ var httpHashedPassword = flag.String(
 	"bcrypted basic auth password for the server",
 )
 
+var httpUsersFile = flag.String(
+	"httpUsersFile",
+	"",
+	"file of username:bcrypted-password[:team[:role]] lines permitted to log in via basic auth; role is one of viewer (the default), operator or admin",
+)
+
+var externalURLString = flag.String(
+	"externalURL",
+	"http://127.0.0.1:8080",
+	"URL used to reach the ATC from a browser, for OAuth redirects",
+)
+
+var encryptionKey = flag.String(
+	"encryptionKey",
+	"",
+	"key used to encrypt pipeline configs, build metadata, and resource sources in the database (stored in plaintext if empty)",
+)
+
+var oldEncryptionKey = flag.String(
+	"oldEncryptionKey",
+	"",
+	"key the database was previously encrypted with; values that -encryptionKey cannot decrypt are decrypted with it",
+)
+
+var encryptColumns = flag.Bool(
+	"encryptColumns",
+	false,
+	"re-encrypt everything in the database with -encryptionKey, decrypting with -oldEncryptionKey where needed, and exit",
+)
+
+var sessionSigningKey = flag.String(
+	"sessionSigningKey",
+	"",
+	"key used to sign session tokens (random if empty; sessions will not survive restarts)",
+)
+
+var workerRegistrationKey = flag.String(
+	"workerRegistrationKey",
+	"",
+	"key used to sign the tokens workers may present as 'Authorization: Worker <token>' to register instead of an admin's credentials",
+)
+
+var printWorkerRegistrationToken = flag.String(
+	"printWorkerRegistrationToken",
+	"",
+	"print the registration token for the named worker, signed with -workerRegistrationKey, and exit",
+)
+
+var gitHubAuthClientID = flag.String(
+	"gitHubAuthClientID",
+	"",
+	"client ID of the GitHub application to authenticate with",
+)
+
+var gitHubAuthClientSecret = flag.String(
+	"gitHubAuthClientSecret",
+	"",
+	"client secret of the GitHub application to authenticate with",
+)
+
+var gitHubAuthOrganizations = flag.String(
+	"gitHubAuthOrganizations",
+	"",
+	"comma-separated list of GitHub organizations whose members may log in as viewers of the main team",
+)
+
+var gitHubAuthGrants = flag.String(
+	"gitHubAuthGrants",
+	"",
+	"comma-separated list of 'organization[/team]=atc-team:role' grants for GitHub users",
+)
+
+var gitHubAuthAuthURL = flag.String(
+	"gitHubAuthAuthURL",
+	github.DefaultAuthURL,
+	"GitHub OAuth authorize URL (override for GitHub Enterprise)",
+)
+
+var gitHubAuthTokenURL = flag.String(
+	"gitHubAuthTokenURL",
+	github.DefaultTokenURL,
+	"GitHub OAuth token URL (override for GitHub Enterprise)",
+)
+
+var gitHubAuthAPIURL = flag.String(
+	"gitHubAuthAPIURL",
+	github.DefaultAPIURL,
+	"GitHub API URL (override for GitHub Enterprise)",
+)
+
+var credentialsFile = flag.String(
+	"credentialsFile",
+	"",
+	"path to a JSON file of encrypted credentials, named <team>/<pipeline>/<name> or <team>/<name>, to resolve ((placeholders)) in pipeline configs with",
+)
+
+var credentialsFileKey = flag.String(
+	"credentialsFileKey",
+	"",
+	"key the values in -credentialsFile were encrypted with",
+)
+
+var vaultURL = flag.String(
+	"vaultURL",
+	"",
+	"URL of a Vault server to resolve ((placeholders)) in pipeline configs with",
+)
+
+var vaultToken = flag.String(
+	"vaultToken",
+	"",
+	"token to authenticate to Vault with",
+)
+
+var vaultPathPrefix = flag.String(
+	"vaultPathPrefix",
+	"secret/concourse",
+	"path under which credentials are stored in Vault, as <team>/<pipeline>/<name> or <team>/<name>",
+)
+
 var checkInterval = flag.Duration(
 	"checkInterval",
 	1*time.Minute,
 	"interval on which to poll for new versions of resources",
 )
 
+var buildLogReapInterval = flag.Duration(
+	"buildLogReapInterval",
+	1*time.Hour,
+	"interval on which to reap build logs according to each job's build_log_retention",
+)
+
+var containerReapInterval = flag.Duration(
+	"containerReapInterval",
+	5*time.Minute,
+	"interval on which to destroy containers left behind by finished builds",
+)
+
+var workerHealthCheckInterval = flag.Duration(
+	"workerHealthCheckInterval",
+	10*time.Second,
+	"interval on which to ping each worker's garden server",
+)
+
+var workerHealthCheckTimeout = flag.Duration(
+	"workerHealthCheckTimeout",
+	5*time.Second,
+	"how long to wait for a worker's garden server to respond to a health check",
+)
+
+var workerHealthCheckFailures = flag.Int(
+	"workerHealthCheckFailures",
+	3,
+	"number of consecutive failed health checks after which a worker is given no new containers until it recovers",
+)
+
+var containerReapGracePeriod = flag.Duration(
+	"containerReapGracePeriod",
+	5*time.Minute,
+	"how long after a build finishes its containers may be destroyed, if nothing is keeping them alive",
+)
+
 var publiclyViewable = flag.Bool(
 	"publiclyViewable",
 	false,
@@ -184,8 +353,32 @@ var cliDownloadsDir = flag.String(
 func main() {
 	flag.Parse()
 
-	if !*dev && (*httpUsername == "" || (*httpHashedPassword == "" && *httpPassword == "")) {
-		fatal(errors.New("must specify -httpUsername and -httpPassword or -httpHashedPassword or turn on dev mode"))
+	if *printWorkerRegistrationToken != "" {
+		if *workerRegistrationKey == "" {
+			fatal(errors.New("must specify -workerRegistrationKey to print a worker registration token"))
+		}
+
+		fmt.Println(workerserver.RegistrationToken(*workerRegistrationKey, *printWorkerRegistrationToken))
+		return
+	}
+
+	basicAuthConfigured := *httpUsername != "" && (*httpHashedPassword != "" || *httpPassword != "")
+	gitHubAuthConfigured := *gitHubAuthClientID != "" && *gitHubAuthClientSecret != ""
+
+	if !*dev && !basicAuthConfigured && *httpUsersFile == "" && !gitHubAuthConfigured {
+		fatal(errors.New("must specify -httpUsername and -httpPassword or -httpHashedPassword, -httpUsersFile, or -gitHubAuthClientID and -gitHubAuthClientSecret, or turn on dev mode"))
+	}
+
+	if gitHubAuthConfigured && *gitHubAuthOrganizations == "" && *gitHubAuthGrants == "" {
+		fatal(errors.New("must specify -gitHubAuthOrganizations or -gitHubAuthGrants when configuring GitHub auth"))
+	}
+
+	if *credentialsFile != "" && *vaultURL != "" {
+		fatal(errors.New("must specify at most one of -credentialsFile and -vaultURL"))
+	}
+
+	if *credentialsFile != "" && *credentialsFileKey == "" {
+		fatal(errors.New("must specify -credentialsFileKey when configuring -credentialsFile"))
 	}
 
 	if _, err := os.Stat(*templatesDir); err != nil {
@@ -225,11 +418,43 @@ func main() {
 		break
 	}
 
+	var encryptionStrategy encryption.Strategy = encryption.NoEncryption{}
+	if *encryptionKey != "" {
+		encryptionStrategy, err = encryption.NewKey(*encryptionKey)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	var oldEncryptionStrategy encryption.Strategy = encryption.NoEncryption{}
+	if *oldEncryptionKey != "" {
+		oldEncryptionStrategy, err = encryption.NewKey(*oldEncryptionKey)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	if *encryptColumns {
+		err = Db.EncryptColumns(logger.Session("db"), dbConn, encryptionStrategy, oldEncryptionStrategy)
+		if err != nil {
+			fatal(err)
+		}
+
+		return
+	}
+
+	if *oldEncryptionKey != "" {
+		encryptionStrategy = encryption.Fallback{
+			Current: encryptionStrategy,
+			Old:     oldEncryptionStrategy,
+		}
+	}
+
 	listener := pq.NewListener(*sqlDataSource, time.Second, time.Minute, nil)
 	bus := Db.NewNotificationsBus(listener)
 
-	db := Db.NewSQL(logger.Session("db"), dbConn, bus)
-	pipelineDBFactory := Db.NewPipelineDBFactory(logger.Session("db"), dbConn, bus, db)
+	db := Db.NewSQL(logger.Session("db"), dbConn, bus, encryptionStrategy)
+	pipelineDBFactory := Db.NewPipelineDBFactory(logger.Session("db"), dbConn, bus, db, encryptionStrategy)
 
 	var configDB Db.ConfigDB
 	configDB = Db.PlanConvertingConfigDB{db}
@@ -240,6 +465,15 @@ func main() {
 		logger.Fatal("invalid-resource-types", err)
 	}
 
+	workerHealthChecker := worker.NewHealthChecker(
+		logger.Session("worker-health-checker"),
+		db,
+		*workerHealthCheckInterval,
+		*workerHealthCheckTimeout,
+		*workerHealthCheckFailures,
+		clock.NewClock(),
+	)
+
 	var workerClient worker.Client
 	if *gardenAddr != "" {
 		workerClient = worker.NewGardenWorker(
@@ -249,13 +483,35 @@ func main() {
 				logger.Session("garden-connection"),
 			)),
 			clock.NewClock(),
+			*gardenAddr,
+			*gardenAddr,
 			-1,
+			0,
 			resourceTypesNG,
 			"linux",
 			[]string{},
+			"",
+			atc.WorkerStateRunning,
 		)
 	} else {
-		workerClient = worker.NewPool(worker.NewDBWorkerProvider(db, logger))
+		placementStrategy, err := worker.NewPlacementStrategy(*containerPlacementStrategy)
+		if err != nil {
+			logger.Fatal("invalid-container-placement-strategy", err)
+		}
+
+		workerClient = worker.NewPool(logger.Session("worker-pool"), worker.NewDBWorkerProvider(db, workerHealthChecker, logger), placementStrategy)
+	}
+
+	var credsManager creds.Manager
+	if *credentialsFile != "" {
+		credsManager, err = localfile.NewManager(*credentialsFile, *credentialsFileKey)
+		if err != nil {
+			fatal(err)
+		}
+	} else if *vaultURL != "" {
+		credsManager = vault.NewManager(*vaultURL, *vaultToken, *vaultPathPrefix, http.DefaultClient)
+	} else {
+		credsManager = creds.NoopManager{}
 	}
 
 	resourceTracker := resource.NewTracker(workerClient)
@@ -266,53 +522,146 @@ func main() {
 		}
 
 		return guid.String()
-	})
+	}, clock.NewClock(), credsManager)
 	execEngine := engine.NewExecEngine(gardenFactory, engine.NewBuildDelegateFactory(db), db)
 
 	engine := engine.NewDBEngine(engine.Engines{execEngine}, db, db)
 
-	var webValidator auth.Validator
+	signingKey := []byte(*sessionSigningKey)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+
+		_, err := rand.Read(signingKey)
+		if err != nil {
+			fatal(err)
+		}
+	}
+
+	tokenGenerator := auth.NewTokenGenerator(signingKey)
+
+	callbacksURL, err := url.Parse(*callbacksURLString)
+	if err != nil {
+		fatal(err)
+	}
+
+	externalURL, err := url.Parse(*externalURLString)
+	if err != nil {
+		fatal(err)
+	}
+
+	providers := auth.Providers{}
+
+	if gitHubAuthConfigured {
+		gitHubGrants, err := github.ParseTeamGrants(*gitHubAuthGrants)
+		if err != nil {
+			fatal(err)
+		}
+
+		var gitHubOrganizations []string
+		if *gitHubAuthOrganizations != "" {
+			gitHubOrganizations = strings.Split(*gitHubAuthOrganizations, ",")
+		}
+
+		providers[github.ProviderName] = github.NewProvider(
+			github.AuthorizationConfig{
+				ClientID:      *gitHubAuthClientID,
+				ClientSecret:  *gitHubAuthClientSecret,
+				Organizations: gitHubOrganizations,
+				Grants:        gitHubGrants,
+				AuthURL:       *gitHubAuthAuthURL,
+				TokenURL:      *gitHubAuthTokenURL,
+				APIURL:        *gitHubAuthAPIURL,
+			},
+			externalURL.String()+"/auth/"+github.ProviderName+"/callback",
+		)
+	}
+
+	basicAuthValidators := auth.ValidatorBasket{}
 
 	if *httpUsername != "" && *httpHashedPassword != "" {
-		webValidator = auth.BasicAuthHashedValidator{
+		basicAuthValidators = append(basicAuthValidators, auth.BasicAuthHashedValidator{
 			Username:       *httpUsername,
 			HashedPassword: *httpHashedPassword,
-		}
+			AssignedRole:   auth.RoleAdmin,
+		})
 	} else if *httpUsername != "" && *httpPassword != "" {
-		webValidator = auth.BasicAuthValidator{
-			Username: *httpUsername,
-			Password: *httpPassword,
+		basicAuthValidators = append(basicAuthValidators, auth.BasicAuthValidator{
+			Username:     *httpUsername,
+			Password:     *httpPassword,
+			AssignedRole: auth.RoleAdmin,
+		})
+	}
+
+	if *httpUsersFile != "" {
+		users, err := auth.LoadHashedUsers(*httpUsersFile)
+		if err != nil {
+			fatal(err)
 		}
+
+		basicAuthValidators = append(basicAuthValidators, users...)
+	}
+
+	basicAuthEnabled := len(basicAuthValidators) > 0
+
+	var webValidator auth.Validator
+
+	if basicAuthEnabled || len(providers) > 0 {
+		webValidator = append(
+			basicAuthValidators,
+			auth.TokenValidator{SigningKey: signingKey},
+		)
 	} else {
 		webValidator = auth.NoopValidator{}
 	}
 
-	callbacksURL, err := url.Parse(*callbacksURLString)
+	oauthHandler, err := auth.NewOAuthHandler(
+		logger.Session("oauth"),
+		providers,
+		tokenGenerator,
+	)
 	if err != nil {
 		fatal(err)
 	}
 
 	drain := make(chan struct{})
 
+	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
+		resourceTracker,
+		*checkInterval,
+		db,
+		engine,
+		db,
+		credsManager,
+	)
+
 	apiHandler, err := api.NewHandler(
 		logger,            // logger lager.Logger,
 		webValidator,      // validator auth.Validator,
 		pipelineDBFactory, // pipelineDBFactory db.PipelineDBFactory,
 
+		providers,              // providers auth.Providers,
+		basicAuthEnabled,       // basicAuthEnabled bool,
+		tokenGenerator,         // tokenGenerator auth.TokenGenerator,
+		*workerRegistrationKey, // workerRegistrationKey string,
+
 		configDB, // configDB db.ConfigDB,
 
 		db, // buildsDB buildserver.BuildsDB,
 		db, // workerDB workerserver.WorkerDB,
 		db, // pipeDB pipes.PipeDB,
 		db, // pipelinesDB db.PipelinesDB,
+		db, // teamsDB db.TeamsDB,
 
 		config.ValidateConfig,       // configValidator configserver.ConfigValidator,
 		callbacksURL.String(),       // peerURL string,
+		externalURL.String(),        // externalURL string,
 		buildserver.NewEventHandler, // eventHandlerFactory buildserver.EventHandlerFactory,
 		drain, // drain <-chan struct{},
 
-		engine,       // engine engine.Engine,
-		workerClient, // workerClient worker.Client,
+		engine,                // engine engine.Engine,
+		workerClient,          // workerClient worker.Client,
+		workerHealthChecker,   // workerHealth worker.WorkerHealth,
+		radarSchedulerFactory, // radarSchedulerFactory pipelines.RadarSchedulerFactory,
 
 		sink, // sink *lager.ReconfigurableSink,
 
@@ -322,17 +671,11 @@ func main() {
 		fatal(err)
 	}
 
-	radarSchedulerFactory := pipelines.NewRadarSchedulerFactory(
-		resourceTracker,
-		*checkInterval,
-		db,
-		engine,
-		db,
-	)
-
 	webHandler, err := web.NewHandler(
 		logger,
 		webValidator,
+		providers,
+		basicAuthEnabled,
 		radarSchedulerFactory,
 		db,
 		pipelineDBFactory,
@@ -354,10 +697,21 @@ func main() {
 	httpHandler = webMux
 
 	if !*publiclyViewable {
-		httpHandler = auth.Handler{
-			Handler:   httpHandler,
+		// logging in must be reachable before being authenticated
+		publicMux := http.NewServeMux()
+		publicMux.Handle("/auth/", oauthHandler)
+		publicMux.Handle("/login", webHandler)
+		publicMux.Handle("/public/", webHandler)
+		publicMux.Handle("/api/v1/auth/methods", apiHandler)
+		publicMux.Handle("/", auth.Handler{
+			Handler:   webMux,
 			Validator: webValidator,
-		}
+			Exempt:    api.SelfAuthenticatedRoutes(),
+		})
+
+		httpHandler = publicMux
+	} else {
+		webMux.Handle("/auth/", oauthHandler)
 	}
 
 	// copy Authorization header as ATC-Authorization cookie for websocket auth
@@ -412,6 +766,19 @@ func main() {
 		engine,
 	)
 
+	buildReaper := builds.NewReaper(
+		logger.Session("build-reaper"),
+		db,
+	)
+
+	containerReaper := builds.NewContainerReaper(
+		logger.Session("container-reaper"),
+		workerClient,
+		db,
+		*containerReapGracePeriod,
+		clock.NewClock(),
+	)
+
 	memberGrouper := []grouper.Member{
 		{"web", http_server.New(webListenAddr, httpHandler)},
 
@@ -438,6 +805,20 @@ func main() {
 			Interval: 10 * time.Second,
 			Clock:    clock.NewClock(),
 		}},
+
+		{"build-reaper", builds.ReaperRunner{
+			Reaper:   buildReaper,
+			Interval: *buildLogReapInterval,
+			Clock:    clock.NewClock(),
+		}},
+
+		{"container-reaper", builds.ReaperRunner{
+			Reaper:   containerReaper,
+			Interval: *containerReapInterval,
+			Clock:    clock.NewClock(),
+		}},
+
+		{"worker-health-checker", workerHealthChecker},
 	}
 
 	group := grouper.NewParallel(os.Interrupt, memberGrouper)
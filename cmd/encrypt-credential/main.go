@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/concourse/atc/creds/localfile"
+)
+
+var key = flag.String(
+	"key",
+	"",
+	"key to encrypt with; must match the atc's -credentialsFileKey",
+)
+
+func main() {
+	flag.Parse()
+
+	if *key == "" {
+		fatal(errors.New("must specify -key"))
+	}
+
+	value, err := ioutil.ReadAll(os.Stdin)
+	if err != nil {
+		fatal(err)
+	}
+
+	ciphertext, err := localfile.Encrypt(*key, strings.TrimSuffix(string(value), "\n"))
+	if err != nil {
+		fatal(err)
+	}
+
+	fmt.Println(ciphertext)
+}
+
+func fatal(err error) {
+	println(err.Error())
+	os.Exit(1)
+}
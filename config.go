@@ -1,6 +1,7 @@
 package atc
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -43,6 +44,14 @@ type ResourceConfig struct {
 
 	Type   string `yaml:"type" json:"type" mapstructure:"type"`
 	Source Source `yaml:"source" json:"source" mapstructure:"source"`
+
+	// Sensitive names the fields of the source whose values are redacted from
+	// build output.
+	Sensitive []string `yaml:"sensitive,omitempty" json:"sensitive,omitempty" mapstructure:"sensitive"`
+
+	CheckEvery   string `yaml:"check_every,omitempty" json:"check_every,omitempty" mapstructure:"check_every"`
+	CheckTimeout string `yaml:"check_timeout,omitempty" json:"check_timeout,omitempty" mapstructure:"check_timeout"`
+	WebhookToken string `yaml:"webhook_token,omitempty" json:"webhook_token,omitempty" mapstructure:"webhook_token"`
 }
 
 type JobConfig struct {
@@ -59,6 +68,17 @@ type JobConfig struct {
 	OutputConfigs []JobOutputConfig `yaml:"outputs,omitempty" json:"outputs,omitempty" mapstructure:"outputs"`
 
 	Plan PlanSequence `yaml:"plan,omitempty" json:"plan,omitempty" mapstructure:"plan"`
+
+	BuildLogRetention *BuildLogRetention `yaml:"build_log_retention,omitempty" json:"build_log_retention,omitempty" mapstructure:"build_log_retention"`
+}
+
+// BuildLogRetention determines how long the events of a job's finished
+// builds are kept. A build's events are kept if it is among the most recent
+// Builds builds or finished within the last Days days; zero disables either
+// criterion.
+type BuildLogRetention struct {
+	Builds int `yaml:"builds,omitempty" json:"builds,omitempty" mapstructure:"builds"`
+	Days   int `yaml:"days,omitempty" json:"days,omitempty" mapstructure:"days"`
 }
 
 func (config JobConfig) IsSerial() bool {
@@ -141,6 +161,8 @@ type PlanConfig struct {
 	Passed []string `yaml:"passed,omitempty" json:"passed,omitempty" mapstructure:"passed"`
 	// whether to trigger based on this resource changing
 	Trigger bool `yaml:"trigger,omitempty" json:"trigger,omitempty" mapstructure:"trigger"`
+	// which versions of the resource to build with; defaults to the latest
+	Version *VersionConfig `yaml:"version,omitempty" json:"version,omitempty" mapstructure:"version"`
 
 	// name of 'output', e.g. rootfs-tarball
 	Put string `yaml:"put,omitempty" json:"put,omitempty" mapstructure:"put"`
@@ -157,6 +179,11 @@ type PlanConfig struct {
 	TaskConfigPath string `yaml:"file,omitempty" json:"file,omitempty" mapstructure:"file"`
 	// inlined task config
 	TaskConfig *TaskConfig `yaml:"config,omitempty" json:"config,omitempty" mapstructure:"config"`
+	// maps the task's input names to the names of artifacts in the build,
+	// e.g. repo: my-repo
+	InputMapping map[string]string `yaml:"input_mapping,omitempty" json:"input_mapping,omitempty" mapstructure:"input_mapping"`
+	// maps the task's output names to the names later steps refer to them by
+	OutputMapping map[string]string `yaml:"output_mapping,omitempty" json:"output_mapping,omitempty" mapstructure:"output_mapping"`
 
 	// used by Get and Put for specifying params to the resource
 	Params Params `yaml:"params,omitempty" json:"params,omitempty" mapstructure:"params"`
@@ -290,6 +317,90 @@ func (c *Condition) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+const (
+	VersionLatest = "latest"
+	VersionEvery  = "every"
+)
+
+// VersionConfig is the version a get step asks for: either the literal
+// "latest" or "every", or a specific version of the resource.
+type VersionConfig struct {
+	Every  bool    `mapstructure:"every"`
+	Latest bool    `mapstructure:"latest"`
+	Pinned Version `mapstructure:"pinned"`
+}
+
+func (c *VersionConfig) UnmarshalJSON(version []byte) error {
+	var data interface{}
+	if err := json.Unmarshal(version, &data); err != nil {
+		return fmt.Errorf("invalid version: %s", err)
+	}
+
+	return c.parse(data)
+}
+
+func (c *VersionConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var data interface{}
+	if err := unmarshal(&data); err != nil {
+		return fmt.Errorf("invalid version: %s", err)
+	}
+
+	return c.parse(data)
+}
+
+func (c *VersionConfig) parse(data interface{}) error {
+	switch actual := data.(type) {
+	case string:
+		switch actual {
+		case VersionLatest:
+			c.Latest = true
+		case VersionEvery:
+			c.Every = true
+		default:
+			return fmt.Errorf("unknown version: %s (must be latest/every or a version)", actual)
+		}
+
+	case map[string]interface{}:
+		c.Pinned = Version(actual)
+
+	case map[interface{}]interface{}:
+		c.Pinned = Version{}
+
+		for key, val := range actual {
+			name, ok := key.(string)
+			if !ok {
+				return fmt.Errorf("invalid version: non-string key %v", key)
+			}
+
+			c.Pinned[name] = val
+		}
+
+	default:
+		return errors.New("invalid version; must be latest, every, or a version")
+	}
+
+	return nil
+}
+
+func (c VersionConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.value())
+}
+
+func (c VersionConfig) MarshalYAML() (interface{}, error) {
+	return c.value(), nil
+}
+
+func (c VersionConfig) value() interface{} {
+	switch {
+	case c.Every:
+		return VersionEvery
+	case c.Pinned != nil:
+		return c.Pinned
+	default:
+		return VersionLatest
+	}
+}
+
 type Duration time.Duration
 
 func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
@@ -391,6 +502,7 @@ func collectInputs(plan PlanConfig) []JobInput {
 			Resource: resource,
 			Passed:   plan.Passed,
 			Trigger:  plan.Trigger,
+			Version:  plan.Version,
 		})
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/concourse/atc"
 )
@@ -113,11 +114,37 @@ func validateResources(c atc.Config) error {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		errorMessages = append(errorMessages, validateDuration(identifier+".check_every", resource.CheckEvery)...)
+		errorMessages = append(errorMessages, validateDuration(identifier+".check_timeout", resource.CheckTimeout)...)
+
+		for _, field := range resource.Sensitive {
+			if _, found := resource.Source[field]; !found {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.sensitive refers to '%s', which is not in its source", identifier, field))
+			}
+		}
 	}
 
 	return compositeErr(errorMessages)
 }
 
+func validateDuration(identifier string, duration string) []string {
+	if duration == "" {
+		return nil
+	}
+
+	parsed, err := time.ParseDuration(duration)
+	if err != nil {
+		return []string{fmt.Sprintf("%s '%s' is not a valid duration", identifier, duration)}
+	}
+
+	if parsed <= 0 {
+		return []string{fmt.Sprintf("%s '%s' must be positive", identifier, duration)}
+	}
+
+	return nil
+}
+
 func validateJobs(c atc.Config) error {
 	errorMessages := []string{}
 
@@ -148,14 +175,37 @@ func validateJobs(c atc.Config) error {
 			errorMessages = append(errorMessages, identifier+" has both a plan and inputs/outputs/build config specified")
 		}
 
+		if job.BuildLogRetention != nil {
+			errorMessages = append(errorMessages, validateBuildLogRetention(identifier+".build_log_retention", *job.BuildLogRetention)...)
+		}
+
 		errorMessages = append(errorMessages, validateConditionals(identifier+".plan", job.Plan)...)
 		errorMessages = append(errorMessages, validatePlan(c, identifier+".plan", atc.PlanConfig{Do: &job.Plan})...)
+		errorMessages = append(errorMessages, validateArtifactNames(identifier+".plan", atc.PlanConfig{Do: &job.Plan})...)
 		errorMessages = append(errorMessages, validateInputOutputConfig(c, job, identifier)...)
 	}
 
 	return compositeErr(errorMessages)
 }
 
+func validateBuildLogRetention(identifier string, retention atc.BuildLogRetention) []string {
+	errorMessages := []string{}
+
+	if retention.Builds < 0 {
+		errorMessages = append(errorMessages, identifier+".builds must not be negative")
+	}
+
+	if retention.Days < 0 {
+		errorMessages = append(errorMessages, identifier+".days must not be negative")
+	}
+
+	if retention.Builds == 0 && retention.Days == 0 {
+		errorMessages = append(errorMessages, identifier+" must specify builds or days")
+	}
+
+	return errorMessages
+}
+
 func validateConditionals(identifier string, planSequence atc.PlanSequence) []string {
 	hasConditionals := hasConditionals(planSequence)
 	hasHooks := hasHooks(planSequence)
@@ -285,7 +335,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		subIdentifier := fmt.Sprintf("%s.get.%s", identifier, plan.Get)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"privileged", "config", "file"},
+			[]string{"privileged", "config", "file", "input_mapping", "output_mapping"},
 			plan, subIdentifier)...,
 		)
 
@@ -357,7 +407,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		subIdentifier := fmt.Sprintf("%s.put.%s", identifier, plan.Put)
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"passed", "trigger", "privileged", "config", "file"},
+			[]string{"passed", "trigger", "version", "privileged", "config", "file", "input_mapping", "output_mapping"},
 			plan, subIdentifier)...,
 		)
 
@@ -394,7 +444,7 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 		}
 
 		errorMessages = append(errorMessages, validateInapplicableFields(
-			[]string{"resource", "passed", "trigger"},
+			[]string{"resource", "passed", "trigger", "version"},
 			plan, subIdentifier)...,
 		)
 
@@ -402,6 +452,10 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 			errorMessages = append(errorMessages, subIdentifier+" specifies params, which should be config.params")
 		}
 
+		if plan.TaskConfig != nil {
+			errorMessages = append(errorMessages, validateTaskOutputs(plan.Task, *plan.TaskConfig, plan.OutputMapping, subIdentifier)...)
+		}
+
 	case plan.Try != nil:
 		subIdentifier := fmt.Sprintf("%s.try", identifier)
 		errorMessages = append(errorMessages, validatePlan(c, subIdentifier, *plan.Try)...)
@@ -425,6 +479,128 @@ func validatePlan(c atc.Config, identifier string, plan atc.PlanConfig) []string
 	return errorMessages
 }
 
+func validateTaskOutputs(taskName string, config atc.TaskConfig, outputMapping map[string]string, identifier string) []string {
+	errorMessages := []string{}
+
+	inputNames := map[string]bool{}
+	for _, input := range config.Inputs {
+		inputNames[input.Name] = true
+	}
+
+	outputNames := map[string]bool{}
+	mappedNames := map[string]bool{}
+	for i, output := range config.Outputs {
+		outputIdentifier := fmt.Sprintf("%s.config.outputs[%d]", identifier, i)
+
+		mappedName := output.Name
+		if mapped, found := outputMapping[output.Name]; found {
+			mappedName = mapped
+		}
+
+		switch {
+		case output.Name == "":
+			errorMessages = append(errorMessages, outputIdentifier+" has no name")
+		case outputNames[output.Name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has the same name as another output ('%s')", outputIdentifier, output.Name))
+		case inputNames[output.Name]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has the same name as an input ('%s')", outputIdentifier, output.Name))
+		case mappedNames[mappedName]:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s is mapped to the same name as another output ('%s')", outputIdentifier, mappedName))
+		case mappedName == taskName:
+			errorMessages = append(errorMessages, fmt.Sprintf("%s has the same name as its task ('%s')", outputIdentifier, mappedName))
+		}
+
+		outputNames[output.Name] = true
+		mappedNames[mappedName] = true
+	}
+
+	return errorMessages
+}
+
+// validateArtifactNames checks that the outputs of the tasks in a job's plan
+// are not named the same as any get in the plan, or as another task's output,
+// as the artifacts would replace each other.
+func validateArtifactNames(identifier string, plan atc.PlanConfig) []string {
+	errorMessages := []string{}
+
+	gets := map[string]bool{}
+	walkPlan(identifier, plan, func(identifier string, plan atc.PlanConfig) {
+		if plan.Get != "" {
+			gets[plan.Get] = true
+		}
+	})
+
+	outputTasks := map[string]string{}
+	walkPlan(identifier, plan, func(identifier string, plan atc.PlanConfig) {
+		if plan.Task == "" || plan.TaskConfig == nil {
+			return
+		}
+
+		taskIdentifier := fmt.Sprintf("%s.task.%s", identifier, plan.Task)
+
+		for i, output := range plan.TaskConfig.Outputs {
+			if output.Name == "" {
+				continue
+			}
+
+			outputIdentifier := fmt.Sprintf("%s.config.outputs[%d]", taskIdentifier, i)
+
+			mappedName := output.Name
+			if mapped, found := plan.OutputMapping[output.Name]; found {
+				mappedName = mapped
+			}
+
+			otherTask, found := outputTasks[mappedName]
+
+			switch {
+			case gets[mappedName]:
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has the same name as a get ('%s')", outputIdentifier, mappedName))
+			case found && otherTask != taskIdentifier:
+				errorMessages = append(errorMessages, fmt.Sprintf("%s has the same name as an output of %s ('%s')", outputIdentifier, otherTask, mappedName))
+			}
+
+			if !found {
+				outputTasks[mappedName] = taskIdentifier
+			}
+		}
+	})
+
+	return errorMessages
+}
+
+// walkPlan calls visit with each step of the plan, identified the same way
+// as by validatePlan.
+func walkPlan(identifier string, plan atc.PlanConfig, visit func(string, atc.PlanConfig)) {
+	visit(identifier, plan)
+
+	switch {
+	case plan.Do != nil:
+		for i, plan := range *plan.Do {
+			walkPlan(fmt.Sprintf("%s[%d]", identifier, i), plan, visit)
+		}
+
+	case plan.Aggregate != nil:
+		for i, plan := range *plan.Aggregate {
+			walkPlan(fmt.Sprintf("%s.aggregate[%d]", identifier, i), plan, visit)
+		}
+
+	case plan.Try != nil:
+		walkPlan(fmt.Sprintf("%s.try", identifier), *plan.Try, visit)
+	}
+
+	if plan.Ensure != nil {
+		walkPlan(fmt.Sprintf("%s.ensure", identifier), *plan.Ensure, visit)
+	}
+
+	if plan.Success != nil {
+		walkPlan(fmt.Sprintf("%s.success", identifier), *plan.Success, visit)
+	}
+
+	if plan.Failure != nil {
+		walkPlan(fmt.Sprintf("%s.failure", identifier), *plan.Failure, visit)
+	}
+}
+
 func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig, identifier string) []string {
 	errorMessages := []string{}
 	foundInapplicableFields := []string{}
@@ -443,6 +619,10 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.Trigger {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "version":
+			if plan.Version != nil {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		case "privileged":
 			if plan.Privileged {
 				foundInapplicableFields = append(foundInapplicableFields, field)
@@ -455,6 +635,14 @@ func validateInapplicableFields(inapplicableFields []string, plan atc.PlanConfig
 			if plan.TaskConfigPath != "" {
 				foundInapplicableFields = append(foundInapplicableFields, field)
 			}
+		case "input_mapping":
+			if len(plan.InputMapping) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
+		case "output_mapping":
+			if len(plan.OutputMapping) != 0 {
+				foundInapplicableFields = append(foundInapplicableFields, field)
+			}
 		}
 	}
 
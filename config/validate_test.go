@@ -173,6 +173,77 @@ var _ = Describe("ValidateConfig", func() {
 				))
 			})
 		})
+
+		Context("when a resource has valid check durations", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:         "some-other-resource",
+					Type:         "some-type",
+					CheckEvery:   "10m",
+					CheckTimeout: "1m30s",
+				})
+			})
+
+			It("does not return an error", func() {
+				Ω(validateErr).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when a resource has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:       "some-other-resource",
+					Type:       "some-type",
+					CheckEvery: "often",
+				})
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring("resources.some-other-resource.check_every 'often' is not a valid duration"))
+			})
+		})
+
+		Context("when a resource has a non-positive check_timeout", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:         "some-other-resource",
+					Type:         "some-type",
+					CheckTimeout: "0s",
+				})
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring("resources.some-other-resource.check_timeout '0s' must be positive"))
+			})
+		})
+
+		Context("when a resource marks a field of its source as sensitive", func() {
+			BeforeEach(func() {
+				config.Resources = append(config.Resources, atc.ResourceConfig{
+					Name:      "some-other-resource",
+					Type:      "some-type",
+					Source:    atc.Source{"password": "some-password"},
+					Sensitive: []string{"password"},
+				})
+			})
+
+			It("returns no error", func() {
+				Ω(validateErr).ShouldNot(HaveOccurred())
+			})
+
+			Context("when the field is not in the source", func() {
+				BeforeEach(func() {
+					config.Resources[len(config.Resources)-1].Sensitive = []string{"passwrod"}
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring("resources.some-other-resource.sensitive refers to 'passwrod', which is not in its source"))
+				})
+			})
+		})
 	})
 
 	Describe("validating a job", func() {
@@ -209,6 +280,48 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a valid build log retention policy", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{Builds: 10, Days: 7}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns no error", func() {
+				Ω(validateErr).ShouldNot(HaveOccurred())
+			})
+		})
+
+		Context("when a job's build log retention policy is empty", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"jobs.some-other-job.build_log_retention must specify builds or days",
+				))
+			})
+		})
+
+		Context("when a job's build log retention policy is negative", func() {
+			BeforeEach(func() {
+				job.BuildLogRetention = &atc.BuildLogRetention{Builds: -1, Days: -2}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Ω(validateErr).Should(HaveOccurred())
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"jobs.some-other-job.build_log_retention.builds must not be negative",
+				))
+				Ω(validateErr.Error()).Should(ContainSubstring(
+					"jobs.some-other-job.build_log_retention.days must not be negative",
+				))
+			})
+		})
+
 		Context("when a job has no config and no config path", func() {
 			BeforeEach(func() {
 				job.TaskConfig = nil
@@ -419,6 +532,7 @@ var _ = Describe("ValidateConfig", func() {
 						Resource: "some-resource",
 						Passed:   []string{"hi"},
 						Trigger:  true,
+						Version:  &atc.VersionConfig{Every: true},
 					})
 
 					config.Jobs = append(config.Jobs, job)
@@ -427,7 +541,7 @@ var _ = Describe("ValidateConfig", func() {
 				It("returns an error", func() {
 					Ω(validateErr).Should(HaveOccurred())
 					Ω(validateErr.Error()).Should(ContainSubstring(
-						"jobs.some-other-job.plan[0].task.lol has invalid fields specified (resource, passed, trigger)",
+						"jobs.some-other-job.plan[0].task.lol has invalid fields specified (resource, passed, trigger, version)",
 					))
 				})
 			})
@@ -467,12 +581,205 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan's config declares outputs", func() {
+				var taskConfig *atc.TaskConfig
+
+				BeforeEach(func() {
+					taskConfig = &atc.TaskConfig{
+						Inputs: []atc.TaskInputConfig{{Name: "some-input"}},
+						Outputs: []atc.TaskOutputConfig{
+							{Name: "some-output"},
+							{Name: "some-other-output", Path: "some/path"},
+						},
+					}
+
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Task:       "lol",
+						TaskConfig: taskConfig,
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Ω(validateErr).ShouldNot(HaveOccurred())
+				})
+
+				Context("when an output is declared twice", func() {
+					BeforeEach(func() {
+						taskConfig.Outputs = append(taskConfig.Outputs, atc.TaskOutputConfig{Name: "some-output"})
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[2] has the same name as another output ('some-output')",
+						))
+					})
+				})
+
+				Context("when an output has the same name as an input", func() {
+					BeforeEach(func() {
+						taskConfig.Outputs = append(taskConfig.Outputs, atc.TaskOutputConfig{Name: "some-input"})
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[2] has the same name as an input ('some-input')",
+						))
+					})
+				})
+
+				Context("when an output has the same name as its task", func() {
+					BeforeEach(func() {
+						taskConfig.Outputs = append(taskConfig.Outputs, atc.TaskOutputConfig{Name: "lol"})
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[2] has the same name as its task ('lol')",
+						))
+					})
+				})
+
+				Context("when an output is mapped to the same name as another", func() {
+					BeforeEach(func() {
+						job.Plan[0].OutputMapping = map[string]string{"some-other-output": "some-output"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[1] is mapped to the same name as another output ('some-output')",
+						))
+					})
+				})
+
+				Context("when an output is mapped to the name of its task", func() {
+					BeforeEach(func() {
+						job.Plan[0].OutputMapping = map[string]string{"some-output": "lol"}
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[0] has the same name as its task ('lol')",
+						))
+					})
+				})
+
+				Context("when an output has no name", func() {
+					BeforeEach(func() {
+						taskConfig.Outputs = append(taskConfig.Outputs, atc.TaskOutputConfig{Path: "some/path"})
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[2] has no name",
+						))
+					})
+				})
+
+				Context("when another task in the plan declares an output with the same name", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Aggregate: &atc.PlanSequence{
+								{
+									Task: "other-task",
+									TaskConfig: &atc.TaskConfig{
+										Outputs: []atc.TaskOutputConfig{{Name: "some-output"}},
+									},
+								},
+							},
+						})
+
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[1].aggregate[0].task.other-task.config.outputs[0] has the same name as an output of jobs.some-other-job.plan[0].task.lol ('some-output')",
+						))
+					})
+
+					Context("when it is mapped to another name", func() {
+						BeforeEach(func() {
+							(*job.Plan[1].Aggregate)[0].OutputMapping = map[string]string{"some-output": "other-output"}
+						})
+
+						It("does not return an error", func() {
+							Ω(validateErr).ShouldNot(HaveOccurred())
+						})
+					})
+				})
+
+				Context("when an output has the same name as a get in the plan", func() {
+					BeforeEach(func() {
+						job.Plan = append(job.Plan, atc.PlanConfig{
+							Get:      "some-output",
+							Resource: "some-resource",
+						})
+
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[0] has the same name as a get ('some-output')",
+						))
+					})
+				})
+
+				Context("when an output is mapped to the name of a get in a hook", func() {
+					BeforeEach(func() {
+						job.Plan[0].OutputMapping = map[string]string{"some-other-output": "some-resource"}
+						job.Plan[0].Ensure = &atc.PlanConfig{Get: "some-resource"}
+
+						config.Jobs[len(config.Jobs)-1] = job
+					})
+
+					It("returns an error", func() {
+						Ω(validateErr).Should(HaveOccurred())
+						Ω(validateErr.Error()).Should(ContainSubstring(
+							"jobs.some-other-job.plan[0].task.lol.config.outputs[1] has the same name as a get ('some-resource')",
+						))
+					})
+				})
+			})
+
+			Context("when a get plan maps inputs or outputs", func() {
+				BeforeEach(func() {
+					job.Plan = append(job.Plan, atc.PlanConfig{
+						Get:           "some-resource",
+						InputMapping:  map[string]string{"a": "b"},
+						OutputMapping: map[string]string{"c": "d"},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Ω(validateErr).Should(HaveOccurred())
+					Ω(validateErr.Error()).Should(ContainSubstring(
+						"jobs.some-other-job.plan[0].get.some-resource has invalid fields specified (input_mapping, output_mapping)",
+					))
+				})
+			})
+
 			Context("when a put plan has invalid fields specified", func() {
 				BeforeEach(func() {
 					job.Plan = append(job.Plan, atc.PlanConfig{
 						Put:            "lol",
 						Passed:         []string{"get", "only"},
 						Trigger:        true,
+						Version:        &atc.VersionConfig{Latest: true},
 						Privileged:     true,
 						TaskConfigPath: "btaskyml",
 					})
@@ -483,7 +790,7 @@ var _ = Describe("ValidateConfig", func() {
 				It("returns an error", func() {
 					Ω(validateErr).Should(HaveOccurred())
 					Ω(validateErr.Error()).Should(ContainSubstring(
-						"jobs.some-other-job.plan[0].put.lol has invalid fields specified (passed, trigger, privileged, file)",
+						"jobs.some-other-job.plan[0].put.lol has invalid fields specified (passed, trigger, version, privileged, file)",
 					))
 				})
 			})
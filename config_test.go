@@ -1,6 +1,7 @@
 package atc_test
 
 import (
+	"encoding/json"
 	"time"
 
 	. "github.com/concourse/atc"
@@ -137,4 +138,58 @@ var _ = Describe("Config", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("VersionConfig", func() {
+		It("can be unmarshalled from YAML as the string 'latest'", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("latest"), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(version).To(Equal(VersionConfig{Latest: true}))
+		})
+
+		It("can be unmarshalled from YAML as the string 'every'", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("every"), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(version).To(Equal(VersionConfig{Every: true}))
+		})
+
+		It("can be unmarshalled from YAML as a specific version", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("ref: abc"), &version)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(version).To(Equal(VersionConfig{Pinned: Version{"ref": "abc"}}))
+		})
+
+		It("fails to unmarshal other strings", func() {
+			var version VersionConfig
+			err := yaml.Unmarshal([]byte("bogus"), &version)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("round-trips through JSON", func() {
+			for _, version := range []VersionConfig{
+				{Latest: true},
+				{Every: true},
+				{Pinned: Version{"ref": "abc"}},
+			} {
+				payload, err := json.Marshal(version)
+				Expect(err).ToNot(HaveOccurred())
+
+				var unmarshalled VersionConfig
+				err = json.Unmarshal(payload, &unmarshalled)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(unmarshalled).To(Equal(version))
+			}
+		})
+
+		It("marshals to JSON as written in the pipeline", func() {
+			Expect(json.Marshal(VersionConfig{Every: true})).To(MatchJSON(`"every"`))
+			Expect(json.Marshal(VersionConfig{Pinned: Version{"ref": "abc"}})).To(MatchJSON(`{"ref":"abc"}`))
+		})
+	})
 })
@@ -0,0 +1,21 @@
+package atc
+
+type Container struct {
+	ID         string `json:"id"`
+	WorkerName string `json:"worker_name"`
+	WorkerAddr string `json:"worker_addr"`
+
+	// seconds until the container is reaped if it stops being kept alive
+	TTLInSeconds int64 `json:"ttl_in_seconds"`
+
+	TeamName     string `json:"team_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	JobName      string `json:"job_name,omitempty"`
+	BuildID      int    `json:"build_id,omitempty"`
+
+	Type         string `json:"type"`
+	Name         string `json:"name"`
+	StepLocation uint   `json:"step_location,omitempty"`
+
+	CheckType string `json:"check_type,omitempty"`
+}
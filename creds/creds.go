@@ -0,0 +1,307 @@
+package creds
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/concourse/atc"
+)
+
+//go:generate counterfeiter . Manager
+
+// Manager looks up the credentials that ((name)) placeholders in pipeline
+// configuration refer to.
+type Manager interface {
+	// Get returns the value of the named credential; found is false if there
+	// is no such credential.
+	Get(name string) (value string, found bool, err error)
+}
+
+// Scoped returns a Manager that only sees the credentials of the given team
+// and pipeline: ((name)) is looked up as <team>/<pipeline>/<name>, falling
+// back to <team>/<name> for credentials shared by the team's pipelines. One-off
+// builds have no pipeline, and only see the team's credentials.
+func Scoped(manager Manager, teamName string, pipelineName string) Manager {
+	if teamName == "" {
+		teamName = atc.DefaultTeamName
+	}
+
+	return scopedManager{
+		manager:      manager,
+		teamName:     teamName,
+		pipelineName: pipelineName,
+	}
+}
+
+type scopedManager struct {
+	manager      Manager
+	teamName     string
+	pipelineName string
+}
+
+func (manager scopedManager) Get(name string) (string, bool, error) {
+	if manager.pipelineName != "" {
+		value, found, err := manager.manager.Get(manager.teamName + "/" + manager.pipelineName + "/" + name)
+		if err != nil || found {
+			return value, found, err
+		}
+	}
+
+	return manager.manager.Get(manager.teamName + "/" + name)
+}
+
+// NoopManager has no credentials, for when no credential manager is
+// configured.
+type NoopManager struct{}
+
+func (NoopManager) Get(string) (string, bool, error) {
+	return "", false, nil
+}
+
+// RecordingManager looks up credentials through another Manager and keeps
+// track of the values it found, so that they can be redacted from output.
+type RecordingManager struct {
+	manager Manager
+
+	values  []string
+	valuesL sync.Mutex
+}
+
+func NewRecordingManager(manager Manager) *RecordingManager {
+	return &RecordingManager{manager: manager}
+}
+
+func (manager *RecordingManager) Get(name string) (string, bool, error) {
+	value, found, err := manager.manager.Get(name)
+	if err != nil || !found {
+		return value, found, err
+	}
+
+	manager.valuesL.Lock()
+	manager.values = append(manager.values, value)
+	manager.valuesL.Unlock()
+
+	return value, found, nil
+}
+
+// Values returns every credential value found so far.
+func (manager *RecordingManager) Values() []string {
+	manager.valuesL.Lock()
+	defer manager.valuesL.Unlock()
+
+	values := make([]string, len(manager.values))
+	copy(values, manager.values)
+
+	return values
+}
+
+// InvalidCredentialNameError is returned for placeholders whose names would
+// reach outside of the credentials they may see, e.g. ((../other-team/name)).
+type InvalidCredentialNameError struct {
+	Name string
+}
+
+func (err InvalidCredentialNameError) Error() string {
+	return fmt.Sprintf("invalid credential name: %s", err.Name)
+}
+
+// validName reports whether each of the name's /-separated segments names
+// something, rather than being empty or referring to a parent or itself.
+func validName(name string) bool {
+	for _, segment := range strings.Split(name, "/") {
+		switch segment {
+		case "", ".", "..":
+			return false
+		}
+	}
+
+	return true
+}
+
+type UndefinedCredentialsError struct {
+	Names []string
+}
+
+func (err UndefinedCredentialsError) Error() string {
+	return fmt.Sprintf("undefined credentials: %s", strings.Join(err.Names, ", "))
+}
+
+var placeholderRegexp = regexp.MustCompile(`\(\(([-\w./]+)\)\)`)
+
+// Resolve returns a copy of the value with each ((name)) placeholder in its
+// strings replaced by the named credential. It walks into maps and slices as
+// decoded from JSON or YAML; any other values are returned as they are.
+func Resolve(manager Manager, value interface{}) (interface{}, error) {
+	r := &resolver{
+		manager: manager,
+		values:  map[string]string{},
+
+		undefined: map[string]struct{}{},
+	}
+
+	resolved := r.resolve(value)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if len(r.undefined) > 0 {
+		names := []string{}
+		for name := range r.undefined {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return nil, UndefinedCredentialsError{Names: names}
+	}
+
+	return resolved, nil
+}
+
+func ResolveSource(manager Manager, source atc.Source) (atc.Source, error) {
+	if source == nil {
+		return nil, nil
+	}
+
+	resolved, err := Resolve(manager, map[string]interface{}(source))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Source(resolved.(map[string]interface{})), nil
+}
+
+func ResolveParams(manager Manager, params atc.Params) (atc.Params, error) {
+	if params == nil {
+		return nil, nil
+	}
+
+	resolved, err := Resolve(manager, map[string]interface{}(params))
+	if err != nil {
+		return nil, err
+	}
+
+	return atc.Params(resolved.(map[string]interface{})), nil
+}
+
+// ResolveTaskConfig resolves the task's params and the source of its image
+// resource, if it has one.
+func ResolveTaskConfig(manager Manager, config atc.TaskConfig) (atc.TaskConfig, error) {
+	if config.Params != nil {
+		params := map[string]interface{}{}
+		for k, v := range config.Params {
+			params[k] = v
+		}
+
+		resolved, err := Resolve(manager, params)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		config.Params = map[string]string{}
+		for k, v := range resolved.(map[string]interface{}) {
+			config.Params[k] = v.(string)
+		}
+	}
+
+	if config.ImageResource != nil {
+		source, err := ResolveSource(manager, config.ImageResource.Source)
+		if err != nil {
+			return atc.TaskConfig{}, err
+		}
+
+		imageResource := *config.ImageResource
+		imageResource.Source = source
+		config.ImageResource = &imageResource
+	}
+
+	return config, nil
+}
+
+type resolver struct {
+	manager Manager
+
+	values    map[string]string
+	undefined map[string]struct{}
+	err       error
+}
+
+func (r *resolver) resolve(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(v)
+
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			resolved[key] = r.resolve(val)
+		}
+
+		return resolved
+
+	case map[interface{}]interface{}:
+		resolved := make(map[interface{}]interface{}, len(v))
+		for key, val := range v {
+			resolved[key] = r.resolve(val)
+		}
+
+		return resolved
+
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, val := range v {
+			resolved[i] = r.resolve(val)
+		}
+
+		return resolved
+
+	default:
+		return value
+	}
+}
+
+func (r *resolver) resolveString(str string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(str, func(placeholder string) string {
+		name := placeholderRegexp.FindStringSubmatch(placeholder)[1]
+
+		value, found := r.lookup(name)
+		if !found {
+			return placeholder
+		}
+
+		return value
+	})
+}
+
+func (r *resolver) lookup(name string) (string, bool) {
+	if value, found := r.values[name]; found {
+		return value, true
+	}
+
+	if r.err != nil {
+		return "", false
+	}
+
+	if !validName(name) {
+		r.err = InvalidCredentialNameError{Name: name}
+		return "", false
+	}
+
+	value, found, err := r.manager.Get(name)
+	if err != nil {
+		r.err = err
+		return "", false
+	}
+
+	if !found {
+		r.undefined[name] = struct{}{}
+		return "", false
+	}
+
+	r.values[name] = value
+
+	return value, true
+}
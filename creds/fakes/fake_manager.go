@@ -0,0 +1,57 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/creds"
+)
+
+type FakeManager struct {
+	GetStub        func(name string) (string, bool, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		name string
+	}
+	getReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+}
+
+func (fake *FakeManager) Get(name string) (string, bool, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		name string
+	}{name})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(name)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2, fake.getReturns.result3
+	}
+}
+
+func (fake *FakeManager) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeManager) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].name
+}
+
+func (fake *FakeManager) GetReturns(result1 string, result2 bool, result3 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+var _ creds.Manager = new(FakeManager)
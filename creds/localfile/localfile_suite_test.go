@@ -0,0 +1,13 @@
+package localfile_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestLocalFile(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Local File Suite")
+}
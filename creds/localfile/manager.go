@@ -0,0 +1,115 @@
+package localfile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+var ErrCiphertextTooShort = errors.New("ciphertext too short")
+
+// Manager reads credentials from a JSON file mapping each credential's name
+// to its value, encrypted with Encrypt under the manager's key. Lookups are
+// scoped with creds.Scoped, so names look like main/some-pipeline/some-name.
+// The file is read on every lookup, so it may be changed without restarting.
+type Manager struct {
+	path string
+	aead cipher.AEAD
+}
+
+func NewManager(path string, key string) (*Manager, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		path: path,
+		aead: aead,
+	}, nil
+}
+
+func (manager *Manager) Get(name string) (string, bool, error) {
+	file, err := os.Open(manager.path)
+	if err != nil {
+		return "", false, err
+	}
+
+	defer file.Close()
+
+	var encrypted map[string]string
+	err = json.NewDecoder(file).Decode(&encrypted)
+	if err != nil {
+		return "", false, fmt.Errorf("malformed credentials file: %s", err)
+	}
+
+	ciphertext, found := encrypted[name]
+	if !found {
+		return "", false, nil
+	}
+
+	value, err := decrypt(manager.aead, ciphertext)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to decrypt credential '%s': %s", name, err)
+	}
+
+	return value, true, nil
+}
+
+// Encrypt encrypts a credential's value for the credentials file.
+func Encrypt(key string, value string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func decrypt(aead cipher.AEAD, ciphertext string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", ErrCiphertextTooShort
+	}
+
+	nonce := sealed[:aead.NonceSize()]
+
+	value, err := aead.Open(nil, nonce, sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(value), nil
+}
+
+// keys of any length are accepted; AES-256 is keyed with their digest
+func newAEAD(key string) (cipher.AEAD, error) {
+	digest := sha256.Sum256([]byte(key))
+
+	block, err := aes.NewCipher(digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,127 @@
+package localfile_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/atc/creds/localfile"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		tmpdir    string
+		credsPath string
+
+		manager *localfile.Manager
+	)
+
+	writeCreds := func(creds map[string]string) {
+		payload, err := json.Marshal(creds)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = ioutil.WriteFile(credsPath, payload, 0600)
+		Ω(err).ShouldNot(HaveOccurred())
+	}
+
+	encrypt := func(key string, value string) string {
+		ciphertext, err := localfile.Encrypt(key, value)
+		Ω(err).ShouldNot(HaveOccurred())
+		return ciphertext
+	}
+
+	BeforeEach(func() {
+		var err error
+		tmpdir, err = ioutil.TempDir("", "local-file-creds")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		credsPath = filepath.Join(tmpdir, "creds.json")
+
+		manager, err = localfile.NewManager(credsPath, "some-key")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	Context("when the credential is in the file", func() {
+		BeforeEach(func() {
+			writeCreds(map[string]string{
+				"some-credential": encrypt("some-key", "some-value"),
+			})
+		})
+
+		It("returns its decrypted value", func() {
+			value, found, err := manager.Get("some-credential")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeTrue())
+			Ω(value).Should(Equal("some-value"))
+		})
+
+		It("does not store it in plaintext", func() {
+			Ω(ioutil.ReadFile(credsPath)).ShouldNot(ContainSubstring("some-value"))
+		})
+	})
+
+	Context("when the credential is not in the file", func() {
+		BeforeEach(func() {
+			writeCreds(map[string]string{})
+		})
+
+		It("is not found", func() {
+			_, found, err := manager.Get("some-credential")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+	})
+
+	Context("when the credential was encrypted with another key", func() {
+		BeforeEach(func() {
+			writeCreds(map[string]string{
+				"some-credential": encrypt("some-other-key", "some-value"),
+			})
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when the credential is not valid ciphertext", func() {
+		BeforeEach(func() {
+			writeCreds(map[string]string{
+				"some-credential": "some-value",
+			})
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when the file is malformed", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(credsPath, []byte("{"), 0600)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
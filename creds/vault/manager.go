@@ -0,0 +1,100 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Manager looks up credentials in a Vault generic secret backend. Each
+// credential is a secret under the path prefix, named after the credential,
+// with its value in the secret's 'value' field. Lookups are scoped with
+// creds.Scoped, so a pipeline's credentials live under
+// <prefix>/<team>/<pipeline>/ or <prefix>/<team>/.
+type Manager struct {
+	url        string
+	token      string
+	pathPrefix string
+
+	client *http.Client
+}
+
+func NewManager(url string, token string, pathPrefix string, client *http.Client) *Manager {
+	return &Manager{
+		url:        strings.TrimRight(url, "/"),
+		token:      token,
+		pathPrefix: strings.Trim(pathPrefix, "/"),
+
+		client: client,
+	}
+}
+
+type secret struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (manager *Manager) Get(name string) (string, bool, error) {
+	secretPath, err := escapePath(name)
+	if err != nil {
+		return "", false, err
+	}
+
+	request, err := http.NewRequest("GET", manager.url+"/v1/"+manager.pathPrefix+"/"+secretPath, nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	request.Header.Set("X-Vault-Token", manager.token)
+
+	response, err := manager.client.Do(request)
+	if err != nil {
+		return "", false, err
+	}
+
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("unexpected response from Vault: %s", response.Status)
+	}
+
+	var s secret
+	err = json.NewDecoder(response.Body).Decode(&s)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, found := s.Data["value"]
+	if !found {
+		return "", false, fmt.Errorf("secret '%s' has no 'value' field", name)
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", false, fmt.Errorf("secret '%s' has a non-string 'value'", name)
+	}
+
+	return str, true, nil
+}
+
+// escapePath escapes each of the name's segments, so that it can only refer to
+// a secret under the path prefix.
+func escapePath(name string) (string, error) {
+	segments := strings.Split(name, "/")
+
+	for i, segment := range segments {
+		switch segment {
+		case "", ".", "..":
+			return "", fmt.Errorf("invalid secret name: %s", name)
+		}
+
+		segments[i] = strings.Replace(url.QueryEscape(segment), "+", "%20", -1)
+	}
+
+	return strings.Join(segments, "/"), nil
+}
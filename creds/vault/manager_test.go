@@ -0,0 +1,127 @@
+package vault_test
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/atc/creds/vault"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		vaultServer *ghttp.Server
+
+		manager *vault.Manager
+	)
+
+	BeforeEach(func() {
+		vaultServer = ghttp.NewServer()
+
+		manager = vault.NewManager(vaultServer.URL()+"/", "some-token", "/secret/concourse/", http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		vaultServer.Close()
+	})
+
+	Context("when the secret exists", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/secret/concourse/some-credential"),
+					ghttp.VerifyHeader(http.Header{"X-Vault-Token": []string{"some-token"}}),
+					ghttp.RespondWith(http.StatusOK, `{
+						"lease_duration": 2592000,
+						"data": {"value": "some-value"}
+					}`),
+				),
+			)
+		})
+
+		It("returns its value", func() {
+			value, found, err := manager.Get("some-credential")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeTrue())
+			Ω(value).Should(Equal("some-value"))
+		})
+	})
+
+	Context("when the secret's name has segments that need escaping", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/secret/concourse/some-team/some pipeline/a?b"),
+					ghttp.RespondWith(http.StatusOK, `{"data": {"value": "some-value"}}`),
+				),
+			)
+		})
+
+		It("escapes each of them", func() {
+			value, found, err := manager.Get("some-team/some pipeline/a?b")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeTrue())
+			Ω(value).Should(Equal("some-value"))
+		})
+	})
+
+	Context("when the secret's name refers outside of the path prefix", func() {
+		It("returns an error without asking Vault", func() {
+			_, _, err := manager.Get("some-team/../../sys/keys")
+			Ω(err).Should(HaveOccurred())
+
+			Ω(vaultServer.ReceivedRequests()).Should(BeEmpty())
+		})
+	})
+
+	Context("when the secret has no value", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/secret/concourse/some-credential"),
+					ghttp.RespondWith(http.StatusOK, `{"data": {"password": "some-value"}}`),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("when the secret does not exist", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/secret/concourse/some-credential"),
+					ghttp.RespondWith(http.StatusNotFound, `{"errors": []}`),
+				),
+			)
+		})
+
+		It("is not found", func() {
+			_, found, err := manager.Get("some-credential")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(found).Should(BeFalse())
+		})
+	})
+
+	Context("when Vault responds with an error", func() {
+		BeforeEach(func() {
+			vaultServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v1/secret/concourse/some-credential"),
+					ghttp.RespondWith(http.StatusForbidden, `{"errors": ["permission denied"]}`),
+				),
+			)
+		})
+
+		It("returns an error", func() {
+			_, _, err := manager.Get("some-credential")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
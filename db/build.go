@@ -23,6 +23,7 @@ type Build struct {
 	JobID        int
 	JobName      string
 	PipelineName string
+	TeamName     string
 
 	Engine         string
 	EngineMetadata string
@@ -53,10 +54,12 @@ type Resource struct {
 }
 
 type SavedResource struct {
-	ID           int
-	CheckError   error
-	Paused       bool
-	PipelineName string
+	ID              int
+	CheckError      error
+	Paused          bool
+	PinnedVersionID int
+	PipelineName    string
+	TeamName        string
 	Resource
 }
 
@@ -64,6 +67,10 @@ func (r SavedResource) FailingToCheck() bool {
 	return r.CheckError != nil
 }
 
+func (r SavedResource) Pinned() bool {
+	return r.PinnedVersionID != 0
+}
+
 type VersionedResource struct {
 	Resource     string
 	Type         string
@@ -9,13 +9,15 @@ import (
 
 type DB interface {
 	GetBuild(buildID int) (Build, error)
-	GetAllBuilds() ([]Build, error)
+	// GetBuilds returns a page of builds, newest first. An empty teamName
+	// returns the builds of every team.
+	GetBuilds(teamName string, page Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 
 	CreatePipe(pipeGUID string, url string) error
 	GetPipe(pipeGUID string) (Pipe, error)
 
-	CreateOneOffBuild() (Build, error)
+	CreateOneOffBuild(teamName string) (Build, error)
 
 	StartBuild(buildID int, engineName, engineMetadata string) (bool, error)
 	FinishBuild(buildID int, status Status) error
@@ -27,6 +29,11 @@ type DB interface {
 	GetBuildEvents(buildID int, from uint) (EventSource, error)
 	SaveBuildEvent(buildID int, event atc.Event) error
 
+	// ReapBuildEvents deletes the events of the given job's completed builds
+	// that fall outside of the retention policy, returning how many were
+	// deleted. The builds themselves are kept.
+	ReapBuildEvents(pipelineID int, jobName string, retention atc.BuildLogRetention) (int64, error)
+
 	AcquireWriteLockImmediately(locks []NamedLock) (Lock, error)
 	AcquireWriteLock(locks []NamedLock) (Lock, error)
 	AcquireReadLock(locks []NamedLock) (Lock, error)
@@ -38,6 +45,7 @@ type DB interface {
 	AbortNotifier(buildID int) (Notifier, error)
 
 	Workers() ([]WorkerInfo, error) // auto-expires workers based on ttl
+	GetWorker(name string) (WorkerInfo, error)
 	SaveWorker(WorkerInfo, time.Duration) error
 
 	GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, error)
@@ -54,16 +62,24 @@ type Notifier interface {
 
 type PipelinesDB interface {
 	GetAllActivePipelines() ([]SavedPipeline, error)
-	GetPipelineByName(pipelineName string) (SavedPipeline, error)
+	GetPipelineByTeamNameAndName(teamName string, pipelineName string) (SavedPipeline, error)
+
+	OrderPipelines(teamName string, pipelineNames []string) error
+}
+
+//go:generate counterfeiter . TeamsDB
 
-	OrderPipelines([]string) error
+type TeamsDB interface {
+	GetTeams() ([]SavedTeam, error)
+	GetTeamByName(teamName string) (SavedTeam, error)
+	SaveTeam(Team) (SavedTeam, error)
 }
 
 //go:generate counterfeiter . ConfigDB
 
 type ConfigDB interface {
-	GetConfig(pipelineName string) (atc.Config, ConfigVersion, error)
-	SaveConfig(string, atc.Config, ConfigVersion, PipelinePausedState) (bool, error)
+	GetConfig(teamName string, pipelineName string) (atc.Config, ConfigVersion, error)
+	SaveConfig(string, string, atc.Config, ConfigVersion, PipelinePausedState) (bool, error)
 }
 
 // sequence identifier used for compare-and-swap
@@ -111,10 +127,23 @@ type JobHistory struct {
 }
 
 type WorkerInfo struct {
+	Name string
 	Addr string
 
 	ActiveContainers int
+	MaxContainers    int
 	ResourceTypes    []atc.WorkerResourceType
 	Platform         string
 	Tags             []string
+
+	// empty if the worker is shared by all teams
+	Team string
+
+	// when saving, empty keeps the worker's current state, or makes a stalled
+	// or new worker running
+	State atc.WorkerState
+
+	// ignored when saving
+	RegisteredAt    time.Time
+	LastHeartbeatAt time.Time
 }
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pivotal-golang/lager"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+type encryptedColumn struct {
+	table  string
+	column string
+}
+
+var encryptedColumns = []encryptedColumn{
+	{table: "pipelines", column: "config"},
+	{table: "builds", column: "engine_metadata"},
+	{table: "versioned_resources", column: "source"},
+}
+
+// encryptBatchSize bounds how many rows are locked at a time while they are
+// being re-encrypted.
+const encryptBatchSize = 100
+
+// EncryptColumns brings every encrypted column in line with the current
+// strategy. Rows whose key ID already matches the current strategy's are
+// left alone; the rest are decrypted, falling back to the old strategy, and
+// re-encrypted. Running it with a new key as current and the previous key as
+// old rotates the key; running it with NoEncryption as current and the
+// previous key as old stores everything in plaintext again.
+//
+// Rows are updated in batches, each in its own transaction, so that builds
+// writing their metadata are only held up briefly.
+func EncryptColumns(logger lager.Logger, conn *sql.DB, current encryption.Strategy, old encryption.Strategy) error {
+	strategy := encryption.Fallback{
+		Current: current,
+		Old:     old,
+	}
+
+	for _, col := range encryptedColumns {
+		err := encryptColumn(logger.Session("encrypt", lager.Data{
+			"table":  col.table,
+			"column": col.column,
+		}), conn, col, strategy)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type encryptedRow struct {
+	id    int
+	value string
+	nonce *string
+}
+
+func encryptColumn(logger lager.Logger, conn *sql.DB, col encryptedColumn, strategy encryption.Strategy) error {
+	lastID := 0
+	updated := 0
+
+	for {
+		batch, err := encryptBatch(conn, col, strategy, lastID)
+		if err != nil {
+			logger.Error("failed-to-encrypt", err)
+			return err
+		}
+
+		if len(batch) == 0 {
+			break
+		}
+
+		lastID = batch[len(batch)-1].id
+		updated += len(batch)
+	}
+
+	logger.Info("done", lager.Data{"updated": updated})
+
+	return nil
+}
+
+func encryptBatch(conn *sql.DB, col encryptedColumn, strategy encryption.Strategy, afterID int) ([]encryptedRow, error) {
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	// rows encrypted before key IDs were recorded have a nonce but no key ID
+	rows, err := tx.Query(fmt.Sprintf(`
+		SELECT id, %[2]s, nonce
+		FROM %[1]s
+		WHERE id > $1
+		AND %[2]s IS NOT NULL
+		AND (
+			encryption_key_id IS DISTINCT FROM $2::text
+			OR (encryption_key_id IS NULL AND nonce IS NOT NULL)
+		)
+		ORDER BY id ASC
+		LIMIT $3
+		FOR UPDATE
+	`, col.table, col.column), afterID, strategy.KeyID(), encryptBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []encryptedRow
+
+	for rows.Next() {
+		var row encryptedRow
+
+		err := rows.Scan(&row.id, &row.value, &row.nonce)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		batch = append(batch, row)
+	}
+
+	rows.Close()
+
+	for _, row := range batch {
+		plaintext, err := strategy.Decrypt(row.value, row.nonce)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt %s.%s of row %d: %s", col.table, col.column, row.id, err)
+		}
+
+		value, nonce, err := strategy.Encrypt(plaintext)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = tx.Exec(fmt.Sprintf(`
+			UPDATE %[1]s
+			SET %[2]s = $1, nonce = $2, encryption_key_id = $3
+			WHERE id = $4
+		`, col.table, col.column), value, nonce, strategy.KeyID(), row.id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,376 @@
+package db_test
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
+)
+
+var _ = Describe("Encrypting columns", func() {
+	var dbConn *sql.DB
+	var listener *pq.Listener
+	var newSQL func(encryption.Strategy) *db.SQLDB
+	var newPipelineDB func(encryption.Strategy) db.PipelineDB
+
+	var key *encryption.Key
+	var config atc.Config
+	var build db.Build
+
+	rawConfig := func() (string, *string) {
+		var config string
+		var nonce *string
+		err := dbConn.QueryRow(`
+			SELECT config, nonce
+			FROM pipelines
+			WHERE name = 'some-pipeline'
+		`).Scan(&config, &nonce)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return config, nonce
+	}
+
+	rawEngineMetadata := func() (string, *string) {
+		var metadata string
+		var nonce *string
+		err := dbConn.QueryRow(`
+			SELECT engine_metadata, nonce
+			FROM builds
+			WHERE id = $1
+		`, build.ID).Scan(&metadata, &nonce)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return metadata, nonce
+	}
+
+	rawSource := func() (string, *string) {
+		var source string
+		var nonce *string
+		err := dbConn.QueryRow(`
+			SELECT source, nonce
+			FROM versioned_resources
+		`).Scan(&source, &nonce)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return source, nonce
+	}
+
+	latestSource := func(pipelineDB db.PipelineDB) (db.Source, error) {
+		savedResource, err := pipelineDB.GetResource("some-resource")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		savedVersion, err := pipelineDB.GetLatestVersionedResource(savedResource)
+		if err != nil {
+			return nil, err
+		}
+
+		return savedVersion.Source, nil
+	}
+
+	rawKeyIDs := func() (*string, *string) {
+		var configKeyID, metadataKeyID *string
+		err := dbConn.QueryRow(`
+			SELECT encryption_key_id
+			FROM pipelines
+			WHERE name = 'some-pipeline'
+		`).Scan(&configKeyID)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = dbConn.QueryRow(`
+			SELECT encryption_key_id
+			FROM builds
+			WHERE id = $1
+		`, build.ID).Scan(&metadataKeyID)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		return configKeyID, metadataKeyID
+	}
+
+	BeforeEach(func() {
+		postgresRunner.CreateTestDB()
+		dbConn = postgresRunner.Open()
+		listener = pq.NewListener(postgresRunner.DataSourceName(), time.Second, time.Minute, nil)
+
+		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
+		bus := db.NewNotificationsBus(listener)
+
+		newSQL = func(strategy encryption.Strategy) *db.SQLDB {
+			return db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus, strategy)
+		}
+
+		newPipelineDB = func(strategy encryption.Strategy) db.PipelineDB {
+			pipelineDB, err := db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, newSQL(strategy), strategy).BuildWithTeamNameAndName(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			return pipelineDB
+		}
+
+		var err error
+		key, err = encryption.NewKey("some-key")
+		Ω(err).ShouldNot(HaveOccurred())
+
+		config = atc.Config{
+			Resources: atc.ResourceConfigs{
+				{
+					Name:   "some-resource",
+					Type:   "some-type",
+					Source: atc.Source{"password": "some-password"},
+				},
+			},
+		}
+
+		plaintextDB := newSQL(encryption.NoEncryption{})
+
+		_, err = plaintextDB.SaveConfig(atc.DefaultTeamName, "some-pipeline", config, 0, db.PipelineUnpaused)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = newPipelineDB(encryption.NoEncryption{}).SaveResourceVersions(config.Resources[0], []atc.Version{{"version": "1"}})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		build, err = plaintextDB.CreateOneOffBuild(atc.DefaultTeamName)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		started, err := plaintextDB.StartBuild(build.ID, "some-engine", `{"password":"some-password"}`)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(started).Should(BeTrue())
+	})
+
+	AfterEach(func() {
+		err := dbConn.Close()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		err = listener.Close()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		postgresRunner.DropTestDB()
+	})
+
+	Context("when there are more rows than fit in a batch", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			plaintextDB := newSQL(encryption.NoEncryption{})
+
+			builds = []db.Build{build}
+			for i := 0; i < 150; i++ {
+				build, err := plaintextDB.CreateOneOffBuild(atc.DefaultTeamName)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				started, err := plaintextDB.StartBuild(build.ID, "some-engine", `{"password":"some-password"}`)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(started).Should(BeTrue())
+
+				builds = append(builds, build)
+			}
+		})
+
+		It("encrypts all of them", func() {
+			err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, key, encryption.NoEncryption{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var plaintextRows int
+			err = dbConn.QueryRow(`
+				SELECT COUNT(*)
+				FROM builds
+				WHERE nonce IS NULL
+			`).Scan(&plaintextRows)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(plaintextRows).Should(BeZero())
+
+			for _, b := range builds {
+				savedBuild, err := newSQL(key).GetBuild(b.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedBuild.EngineMetadata).Should(Equal(`{"password":"some-password"}`))
+			}
+		})
+	})
+
+	Context("when a key is configured", func() {
+		BeforeEach(func() {
+			err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, key, encryption.NoEncryption{})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("encrypts the existing rows", func() {
+			storedConfig, nonce := rawConfig()
+			Ω(storedConfig).ShouldNot(ContainSubstring("some-password"))
+			Ω(nonce).ShouldNot(BeNil())
+
+			storedMetadata, nonce := rawEngineMetadata()
+			Ω(storedMetadata).ShouldNot(ContainSubstring("some-password"))
+			Ω(nonce).ShouldNot(BeNil())
+
+			storedSource, nonce := rawSource()
+			Ω(storedSource).ShouldNot(ContainSubstring("some-password"))
+			Ω(nonce).ShouldNot(BeNil())
+		})
+
+		It("records the key they were encrypted with", func() {
+			configKeyID, metadataKeyID := rawKeyIDs()
+			Ω(configKeyID).Should(Equal(key.KeyID()))
+			Ω(metadataKeyID).Should(Equal(key.KeyID()))
+		})
+
+		It("can read them back with the key", func() {
+			sqlDB := newSQL(key)
+
+			savedConfig, _, err := sqlDB.GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedConfig).Should(Equal(config))
+
+			savedBuild, err := sqlDB.GetBuild(build.ID)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedBuild.EngineMetadata).Should(Equal(`{"password":"some-password"}`))
+
+			savedSource, err := latestSource(newPipelineDB(key))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedSource).Should(Equal(db.Source{"password": "some-password"}))
+		})
+
+		It("cannot read them back without it", func() {
+			_, _, err := newSQL(encryption.NoEncryption{}).GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).Should(Equal(encryption.ErrDataIsEncrypted))
+		})
+
+		It("encrypts newly saved versions", func() {
+			pipelineDB := newPipelineDB(key)
+
+			resourceConfig := config.Resources[0]
+			resourceConfig.Source = atc.Source{"password": "some-other-password"}
+
+			err := pipelineDB.SaveResourceVersions(resourceConfig, []atc.Version{{"version": "2"}})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			var storedSource string
+			var nonce *string
+			err = dbConn.QueryRow(`
+				SELECT source, nonce
+				FROM versioned_resources
+				WHERE version = '{"version":"2"}'
+			`).Scan(&storedSource, &nonce)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(storedSource).ShouldNot(ContainSubstring("some-other-password"))
+			Ω(nonce).ShouldNot(BeNil())
+
+			savedSource, err := latestSource(pipelineDB)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedSource).Should(Equal(db.Source{"password": "some-other-password"}))
+		})
+
+		It("encrypts newly saved configs", func() {
+			sqlDB := newSQL(key)
+
+			_, version, err := sqlDB.GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			config.Resources[0].Source = atc.Source{"password": "some-other-password"}
+
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "some-pipeline", config, version, db.PipelineNoChange)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			storedConfig, nonce := rawConfig()
+			Ω(storedConfig).ShouldNot(ContainSubstring("some-other-password"))
+			Ω(nonce).ShouldNot(BeNil())
+
+			savedConfig, _, err := sqlDB.GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedConfig).Should(Equal(config))
+		})
+
+		It("encrypts newly saved engine metadata", func() {
+			sqlDB := newSQL(key)
+
+			err := sqlDB.SaveBuildEngineMetadata(build.ID, `{"password":"some-other-password"}`)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			storedMetadata, nonce := rawEngineMetadata()
+			Ω(storedMetadata).ShouldNot(ContainSubstring("some-other-password"))
+			Ω(nonce).ShouldNot(BeNil())
+
+			savedBuild, err := sqlDB.GetBuild(build.ID)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedBuild.EngineMetadata).Should(Equal(`{"password":"some-other-password"}`))
+		})
+
+		It("leaves the rows alone when run again", func() {
+			storedConfig, nonce := rawConfig()
+
+			err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, key, encryption.NoEncryption{})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			reencryptedConfig, reencryptedNonce := rawConfig()
+			Ω(reencryptedConfig).Should(Equal(storedConfig))
+			Ω(reencryptedNonce).Should(Equal(nonce))
+		})
+
+		Context("when the key is rotated", func() {
+			var newKey *encryption.Key
+
+			BeforeEach(func() {
+				var err error
+				newKey, err = encryption.NewKey("some-new-key")
+				Ω(err).ShouldNot(HaveOccurred())
+			})
+
+			It("re-encrypts the rows with the new key", func() {
+				err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, newKey, key)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				savedConfig, _, err := newSQL(newKey).GetConfig(atc.DefaultTeamName, "some-pipeline")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedConfig).Should(Equal(config))
+
+				_, _, err = newSQL(key).GetConfig(atc.DefaultTeamName, "some-pipeline")
+				Ω(err).Should(HaveOccurred())
+			})
+
+			It("can read rows not yet re-encrypted while falling back to the old key", func() {
+				sqlDB := newSQL(encryption.Fallback{
+					Current: newKey,
+					Old:     key,
+				})
+
+				savedConfig, _, err := sqlDB.GetConfig(atc.DefaultTeamName, "some-pipeline")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedConfig).Should(Equal(config))
+
+				savedBuild, err := sqlDB.GetBuild(build.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(savedBuild.EngineMetadata).Should(Equal(`{"password":"some-password"}`))
+			})
+
+			Context("without the old key", func() {
+				It("fails", func() {
+					err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, newKey, encryption.NoEncryption{})
+					Ω(err).Should(HaveOccurred())
+				})
+			})
+		})
+
+		Context("when the key is removed", func() {
+			It("decrypts the rows with the old key", func() {
+				err := db.EncryptColumns(lagertest.NewTestLogger("test"), dbConn, encryption.NoEncryption{}, key)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				storedConfig, nonce := rawConfig()
+				Ω(storedConfig).Should(ContainSubstring("some-password"))
+				Ω(nonce).Should(BeNil())
+
+				storedMetadata, nonce := rawEngineMetadata()
+				Ω(storedMetadata).Should(Equal(`{"password":"some-password"}`))
+				Ω(nonce).Should(BeNil())
+
+				configKeyID, metadataKeyID := rawKeyIDs()
+				Ω(configKeyID).Should(BeNil())
+				Ω(metadataKeyID).Should(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,144 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+var ErrDataIsEncrypted = errors.New("data is encrypted but no encryption key is configured")
+
+//go:generate counterfeiter . Strategy
+
+// Strategy encrypts sensitive columns before they are written to the
+// database. Encrypt returns the value to store along with the nonce it was
+// sealed with; a nil nonce means the value is stored in plaintext. KeyID
+// identifies the key values are encrypted with, and is stored next to them
+// so that rows can be told apart without decrypting them.
+type Strategy interface {
+	Encrypt(plaintext []byte) (string, *string, error)
+	Decrypt(ciphertext string, nonce *string) ([]byte, error)
+	KeyID() *string
+}
+
+// Key encrypts with AES-GCM. Keys of any length are accepted; AES-256 is
+// keyed with their digest.
+type Key struct {
+	aead cipher.AEAD
+	id   string
+}
+
+func NewKey(key string) (*Key, error) {
+	digest := sha256.Sum256([]byte(key))
+
+	block, err := aes.NewCipher(digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	idDigest := sha256.Sum256(digest[:])
+
+	return &Key{
+		aead: aead,
+		id:   hex.EncodeToString(idDigest[:8]),
+	}, nil
+}
+
+// KeyID is derived from a digest of the key, and does not reveal it.
+func (key *Key) KeyID() *string {
+	return &key.id
+}
+
+func (key *Key) Encrypt(plaintext []byte) (string, *string, error) {
+	nonce := make([]byte, key.aead.NonceSize())
+
+	_, err := io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sealed := key.aead.Seal(nil, nonce, plaintext, nil)
+
+	encodedNonce := base64.StdEncoding.EncodeToString(nonce)
+
+	return base64.StdEncoding.EncodeToString(sealed), &encodedNonce, nil
+}
+
+// Decrypt returns values stored before encryption was configured as-is, so
+// that rows written in plaintext remain readable until they are encrypted.
+func (key *Key) Decrypt(ciphertext string, encodedNonce *string) ([]byte, error) {
+	if encodedNonce == nil {
+		return []byte(ciphertext), nil
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(*encodedNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.aead.Open(nil, nonce, sealed, nil)
+}
+
+// NoEncryption stores values in plaintext.
+type NoEncryption struct{}
+
+func (NoEncryption) Encrypt(plaintext []byte) (string, *string, error) {
+	return string(plaintext), nil, nil
+}
+
+func (NoEncryption) Decrypt(ciphertext string, nonce *string) ([]byte, error) {
+	if nonce != nil {
+		return nil, ErrDataIsEncrypted
+	}
+
+	return []byte(ciphertext), nil
+}
+
+func (NoEncryption) KeyID() *string {
+	return nil
+}
+
+// Fallback encrypts with the current strategy, and decrypts with the old one
+// any values the current one cannot, so that values encrypted with a
+// previous key remain readable while the key is being rotated.
+type Fallback struct {
+	Current Strategy
+	Old     Strategy
+}
+
+func (fallback Fallback) Encrypt(plaintext []byte) (string, *string, error) {
+	return fallback.Current.Encrypt(plaintext)
+}
+
+func (fallback Fallback) Decrypt(ciphertext string, nonce *string) ([]byte, error) {
+	plaintext, err := fallback.Current.Decrypt(ciphertext, nonce)
+	if err == nil {
+		return plaintext, nil
+	}
+
+	plaintext, oldErr := fallback.Old.Decrypt(ciphertext, nonce)
+	if oldErr != nil {
+		return nil, err
+	}
+
+	return plaintext, nil
+}
+
+func (fallback Fallback) KeyID() *string {
+	return fallback.Current.KeyID()
+}
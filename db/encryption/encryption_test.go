@@ -0,0 +1,142 @@
+package encryption_test
+
+import (
+	"github.com/concourse/atc/db/encryption"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Encryption", func() {
+	Describe("Key", func() {
+		var key *encryption.Key
+
+		BeforeEach(func() {
+			var err error
+			key, err = encryption.NewKey("some-key")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("round-trips values", func() {
+			ciphertext, nonce, err := key.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(nonce).ShouldNot(BeNil())
+			Ω(ciphertext).ShouldNot(ContainSubstring("some-plaintext"))
+
+			plaintext, err := key.Decrypt(ciphertext, nonce)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(plaintext)).Should(Equal("some-plaintext"))
+		})
+
+		It("seals each value with a new nonce", func() {
+			_, nonceA, err := key.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, nonceB, err := key.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(*nonceA).ShouldNot(Equal(*nonceB))
+		})
+
+		It("returns values stored in plaintext as-is", func() {
+			plaintext, err := key.Decrypt("some-plaintext", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(plaintext)).Should(Equal("some-plaintext"))
+		})
+
+		It("identifies itself without revealing the key", func() {
+			Ω(key.KeyID()).ShouldNot(BeNil())
+			Ω(*key.KeyID()).ShouldNot(ContainSubstring("some-key"))
+
+			sameKey, err := encryption.NewKey("some-key")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(sameKey.KeyID()).Should(Equal(key.KeyID()))
+
+			otherKey, err := encryption.NewKey("some-other-key")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(otherKey.KeyID()).ShouldNot(Equal(key.KeyID()))
+		})
+
+		Context("when the value was encrypted with another key", func() {
+			It("fails to decrypt it", func() {
+				otherKey, err := encryption.NewKey("some-other-key")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				ciphertext, nonce, err := otherKey.Encrypt([]byte("some-plaintext"))
+				Ω(err).ShouldNot(HaveOccurred())
+
+				_, err = key.Decrypt(ciphertext, nonce)
+				Ω(err).Should(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("NoEncryption", func() {
+		It("stores values in plaintext", func() {
+			ciphertext, nonce, err := encryption.NoEncryption{}.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ciphertext).Should(Equal("some-plaintext"))
+			Ω(nonce).Should(BeNil())
+
+			plaintext, err := encryption.NoEncryption{}.Decrypt(ciphertext, nonce)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(plaintext)).Should(Equal("some-plaintext"))
+		})
+
+		It("refuses to read encrypted values", func() {
+			nonce := "some-nonce"
+			_, err := encryption.NoEncryption{}.Decrypt("some-ciphertext", &nonce)
+			Ω(err).Should(Equal(encryption.ErrDataIsEncrypted))
+		})
+	})
+
+	Describe("Fallback", func() {
+		var oldKey *encryption.Key
+		var newKey *encryption.Key
+		var fallback encryption.Fallback
+
+		BeforeEach(func() {
+			var err error
+			oldKey, err = encryption.NewKey("some-old-key")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			newKey, err = encryption.NewKey("some-new-key")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			fallback = encryption.Fallback{
+				Current: newKey,
+				Old:     oldKey,
+			}
+		})
+
+		It("encrypts with the current strategy", func() {
+			ciphertext, nonce, err := fallback.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(fallback.KeyID()).Should(Equal(newKey.KeyID()))
+
+			plaintext, err := newKey.Decrypt(ciphertext, nonce)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(plaintext)).Should(Equal("some-plaintext"))
+		})
+
+		It("decrypts values encrypted with the old strategy", func() {
+			ciphertext, nonce, err := oldKey.Encrypt([]byte("some-plaintext"))
+			Ω(err).ShouldNot(HaveOccurred())
+
+			plaintext, err := fallback.Decrypt(ciphertext, nonce)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(string(plaintext)).Should(Equal("some-plaintext"))
+		})
+
+		Context("when neither strategy can decrypt the value", func() {
+			It("returns the current strategy's error", func() {
+				nonce := "some-nonce"
+				_, err := encryption.Fallback{
+					Current: encryption.NoEncryption{},
+					Old:     encryption.NoEncryption{},
+				}.Decrypt("some-ciphertext", &nonce)
+				Ω(err).Should(Equal(encryption.ErrDataIsEncrypted))
+			})
+		})
+	})
+})
@@ -0,0 +1,131 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+type FakeStrategy struct {
+	EncryptStub        func(plaintext []byte) (string, *string, error)
+	encryptMutex       sync.RWMutex
+	encryptArgsForCall []struct {
+		plaintext []byte
+	}
+	encryptReturns struct {
+		result1 string
+		result2 *string
+		result3 error
+	}
+	DecryptStub        func(ciphertext string, nonce *string) ([]byte, error)
+	decryptMutex       sync.RWMutex
+	decryptArgsForCall []struct {
+		ciphertext string
+		nonce      *string
+	}
+	decryptReturns struct {
+		result1 []byte
+		result2 error
+	}
+	KeyIDStub        func() *string
+	keyIDMutex       sync.RWMutex
+	keyIDArgsForCall []struct{}
+	keyIDReturns struct {
+		result1 *string
+	}
+}
+
+func (fake *FakeStrategy) Encrypt(plaintext []byte) (string, *string, error) {
+	fake.encryptMutex.Lock()
+	fake.encryptArgsForCall = append(fake.encryptArgsForCall, struct {
+		plaintext []byte
+	}{plaintext})
+	fake.encryptMutex.Unlock()
+	if fake.EncryptStub != nil {
+		return fake.EncryptStub(plaintext)
+	} else {
+		return fake.encryptReturns.result1, fake.encryptReturns.result2, fake.encryptReturns.result3
+	}
+}
+
+func (fake *FakeStrategy) EncryptCallCount() int {
+	fake.encryptMutex.RLock()
+	defer fake.encryptMutex.RUnlock()
+	return len(fake.encryptArgsForCall)
+}
+
+func (fake *FakeStrategy) EncryptArgsForCall(i int) []byte {
+	fake.encryptMutex.RLock()
+	defer fake.encryptMutex.RUnlock()
+	return fake.encryptArgsForCall[i].plaintext
+}
+
+func (fake *FakeStrategy) EncryptReturns(result1 string, result2 *string, result3 error) {
+	fake.EncryptStub = nil
+	fake.encryptReturns = struct {
+		result1 string
+		result2 *string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeStrategy) Decrypt(ciphertext string, nonce *string) ([]byte, error) {
+	fake.decryptMutex.Lock()
+	fake.decryptArgsForCall = append(fake.decryptArgsForCall, struct {
+		ciphertext string
+		nonce      *string
+	}{ciphertext, nonce})
+	fake.decryptMutex.Unlock()
+	if fake.DecryptStub != nil {
+		return fake.DecryptStub(ciphertext, nonce)
+	} else {
+		return fake.decryptReturns.result1, fake.decryptReturns.result2
+	}
+}
+
+func (fake *FakeStrategy) DecryptCallCount() int {
+	fake.decryptMutex.RLock()
+	defer fake.decryptMutex.RUnlock()
+	return len(fake.decryptArgsForCall)
+}
+
+func (fake *FakeStrategy) DecryptArgsForCall(i int) (string, *string) {
+	fake.decryptMutex.RLock()
+	defer fake.decryptMutex.RUnlock()
+	return fake.decryptArgsForCall[i].ciphertext, fake.decryptArgsForCall[i].nonce
+}
+
+func (fake *FakeStrategy) DecryptReturns(result1 []byte, result2 error) {
+	fake.DecryptStub = nil
+	fake.decryptReturns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStrategy) KeyID() *string {
+	fake.keyIDMutex.Lock()
+	fake.keyIDArgsForCall = append(fake.keyIDArgsForCall, struct{}{})
+	fake.keyIDMutex.Unlock()
+	if fake.KeyIDStub != nil {
+		return fake.KeyIDStub()
+	} else {
+		return fake.keyIDReturns.result1
+	}
+}
+
+func (fake *FakeStrategy) KeyIDCallCount() int {
+	fake.keyIDMutex.RLock()
+	defer fake.keyIDMutex.RUnlock()
+	return len(fake.keyIDArgsForCall)
+}
+
+func (fake *FakeStrategy) KeyIDReturns(result1 *string) {
+	fake.KeyIDStub = nil
+	fake.keyIDReturns = struct {
+		result1 *string
+	}{result1}
+}
+
+var _ encryption.Strategy = new(FakeStrategy)
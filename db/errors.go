@@ -3,6 +3,10 @@ package db
 import "errors"
 
 var ErrNoVersions = errors.New("no versions found")
+var ErrNoVersion = errors.New("no version found")
 var ErrNoBuild = errors.New("no build found")
+var ErrNoTeam = errors.New("no team found")
+var ErrNoWorker = errors.New("no worker found")
+var ErrWorkerRetired = errors.New("worker has been retired")
 
 var ErrLockRowNotPresentOrAlreadyDeleted = errors.New("lock could not be acquired because it didn't exist or was already cleaned up")
@@ -15,6 +15,12 @@ type FakePipelineDB struct {
 	getPipelineNameReturns struct {
 		result1 string
 	}
+	GetPipelineTeamNameStub        func() string
+	getPipelineTeamNameMutex       sync.RWMutex
+	getPipelineTeamNameArgsForCall []struct{}
+	getPipelineTeamNameReturns struct {
+		result1 string
+	}
 	ScopedNameStub        func(string) string
 	scopedNameMutex       sync.RWMutex
 	scopedNameArgsForCall []struct {
@@ -124,6 +130,23 @@ type FakePipelineDB struct {
 	disableVersionedResourceReturns struct {
 		result1 error
 	}
+	PinResourceStub        func(resourceName string, versionedResourceID int) error
+	pinResourceMutex       sync.RWMutex
+	pinResourceArgsForCall []struct {
+		resourceName        string
+		versionedResourceID int
+	}
+	pinResourceReturns struct {
+		result1 error
+	}
+	UnpinResourceStub        func(resourceName string) error
+	unpinResourceMutex       sync.RWMutex
+	unpinResourceArgsForCall []struct {
+		resourceName string
+	}
+	unpinResourceReturns struct {
+		result1 error
+	}
 	SetResourceCheckErrorStub        func(resource db.SavedResource, err error) error
 	setResourceCheckErrorMutex       sync.RWMutex
 	setResourceCheckErrorArgsForCall []struct {
@@ -177,6 +200,17 @@ type FakePipelineDB struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetJobBuildsStub        func(job string, page db.Page) ([]db.Build, db.Pagination, error)
+	getJobBuildsMutex       sync.RWMutex
+	getJobBuildsArgsForCall []struct {
+		job  string
+		page db.Page
+	}
+	getJobBuildsReturns struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}
 	GetJobBuildStub        func(job string, build string) (db.Build, error)
 	getJobBuildMutex       sync.RWMutex
 	getJobBuildArgsForCall []struct {
@@ -197,11 +231,11 @@ type FakePipelineDB struct {
 		result2 error
 	}
 	CreateJobBuildForCandidateInputsStub        func(job string) (db.Build, bool, error)
-	createJobBuildIfNoBuildsPendingMutex       sync.RWMutex
-	createJobBuildIfNoBuildsPendingArgsForCall []struct {
+	createJobBuildForCandidateInputsMutex       sync.RWMutex
+	createJobBuildForCandidateInputsArgsForCall []struct {
 		job string
 	}
-	createJobBuildIfNoBuildsPendingReturns struct {
+	createJobBuildForCandidateInputsReturns struct {
 		result1 db.Build
 		result2 bool
 		result3 error
@@ -215,10 +249,11 @@ type FakePipelineDB struct {
 	useInputsForBuildReturns struct {
 		result1 error
 	}
-	GetLatestInputVersionsStub        func([]atc.JobInput) ([]db.BuildInput, error)
+	GetLatestInputVersionsStub        func(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error)
 	getLatestInputVersionsMutex       sync.RWMutex
 	getLatestInputVersionsArgsForCall []struct {
-		arg1 []atc.JobInput
+		jobName string
+		inputs  []atc.JobInput
 	}
 	getLatestInputVersionsReturns struct {
 		result1 []db.BuildInput
@@ -338,6 +373,30 @@ func (fake *FakePipelineDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) GetPipelineTeamName() string {
+	fake.getPipelineTeamNameMutex.Lock()
+	fake.getPipelineTeamNameArgsForCall = append(fake.getPipelineTeamNameArgsForCall, struct{}{})
+	fake.getPipelineTeamNameMutex.Unlock()
+	if fake.GetPipelineTeamNameStub != nil {
+		return fake.GetPipelineTeamNameStub()
+	} else {
+		return fake.getPipelineTeamNameReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) GetPipelineTeamNameCallCount() int {
+	fake.getPipelineTeamNameMutex.RLock()
+	defer fake.getPipelineTeamNameMutex.RUnlock()
+	return len(fake.getPipelineTeamNameArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetPipelineTeamNameReturns(result1 string) {
+	fake.GetPipelineTeamNameStub = nil
+	fake.getPipelineTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakePipelineDB) ScopedName(arg1 string) string {
 	fake.scopedNameMutex.Lock()
 	fake.scopedNameArgsForCall = append(fake.scopedNameArgsForCall, struct {
@@ -753,6 +812,71 @@ func (fake *FakePipelineDB) DisableVersionedResourceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePipelineDB) PinResource(resourceName string, versionedResourceID int) error {
+	fake.pinResourceMutex.Lock()
+	fake.pinResourceArgsForCall = append(fake.pinResourceArgsForCall, struct {
+		resourceName        string
+		versionedResourceID int
+	}{resourceName, versionedResourceID})
+	fake.pinResourceMutex.Unlock()
+	if fake.PinResourceStub != nil {
+		return fake.PinResourceStub(resourceName, versionedResourceID)
+	} else {
+		return fake.pinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) PinResourceCallCount() int {
+	fake.pinResourceMutex.RLock()
+	defer fake.pinResourceMutex.RUnlock()
+	return len(fake.pinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) PinResourceArgsForCall(i int) (string, int) {
+	fake.pinResourceMutex.RLock()
+	defer fake.pinResourceMutex.RUnlock()
+	return fake.pinResourceArgsForCall[i].resourceName, fake.pinResourceArgsForCall[i].versionedResourceID
+}
+
+func (fake *FakePipelineDB) PinResourceReturns(result1 error) {
+	fake.PinResourceStub = nil
+	fake.pinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakePipelineDB) UnpinResource(resourceName string) error {
+	fake.unpinResourceMutex.Lock()
+	fake.unpinResourceArgsForCall = append(fake.unpinResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.unpinResourceMutex.Unlock()
+	if fake.UnpinResourceStub != nil {
+		return fake.UnpinResourceStub(resourceName)
+	} else {
+		return fake.unpinResourceReturns.result1
+	}
+}
+
+func (fake *FakePipelineDB) UnpinResourceCallCount() int {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return len(fake.unpinResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) UnpinResourceArgsForCall(i int) string {
+	fake.unpinResourceMutex.RLock()
+	defer fake.unpinResourceMutex.RUnlock()
+	return fake.unpinResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) UnpinResourceReturns(result1 error) {
+	fake.UnpinResourceStub = nil
+	fake.unpinResourceReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePipelineDB) SetResourceCheckError(resource db.SavedResource, err error) error {
 	fake.setResourceCheckErrorMutex.Lock()
 	fake.setResourceCheckErrorArgsForCall = append(fake.setResourceCheckErrorArgsForCall, struct {
@@ -950,6 +1074,41 @@ func (fake *FakePipelineDB) GetAllJobBuildsReturns(result1 []db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakePipelineDB) GetJobBuilds(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getJobBuildsMutex.Lock()
+	fake.getJobBuildsArgsForCall = append(fake.getJobBuildsArgsForCall, struct {
+		job  string
+		page db.Page
+	}{job, page})
+	fake.getJobBuildsMutex.Unlock()
+	if fake.GetJobBuildsStub != nil {
+		return fake.GetJobBuildsStub(job, page)
+	} else {
+		return fake.getJobBuildsReturns.result1, fake.getJobBuildsReturns.result2, fake.getJobBuildsReturns.result3
+	}
+}
+
+func (fake *FakePipelineDB) GetJobBuildsCallCount() int {
+	fake.getJobBuildsMutex.RLock()
+	defer fake.getJobBuildsMutex.RUnlock()
+	return len(fake.getJobBuildsArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetJobBuildsArgsForCall(i int) (string, db.Page) {
+	fake.getJobBuildsMutex.RLock()
+	defer fake.getJobBuildsMutex.RUnlock()
+	return fake.getJobBuildsArgsForCall[i].job, fake.getJobBuildsArgsForCall[i].page
+}
+
+func (fake *FakePipelineDB) GetJobBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetJobBuildsStub = nil
+	fake.getJobBuildsReturns = struct {
+		result1 []db.Build
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipelineDB) GetJobBuild(job string, build string) (db.Build, error) {
 	fake.getJobBuildMutex.Lock()
 	fake.getJobBuildArgsForCall = append(fake.getJobBuildArgsForCall, struct {
@@ -1018,33 +1177,33 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputs(job string) (db.Build, bool, error) {
-	fake.createJobBuildIfNoBuildsPendingMutex.Lock()
-	fake.createJobBuildIfNoBuildsPendingArgsForCall = append(fake.createJobBuildIfNoBuildsPendingArgsForCall, struct {
+	fake.createJobBuildForCandidateInputsMutex.Lock()
+	fake.createJobBuildForCandidateInputsArgsForCall = append(fake.createJobBuildForCandidateInputsArgsForCall, struct {
 		job string
 	}{job})
-	fake.createJobBuildIfNoBuildsPendingMutex.Unlock()
+	fake.createJobBuildForCandidateInputsMutex.Unlock()
 	if fake.CreateJobBuildForCandidateInputsStub != nil {
 		return fake.CreateJobBuildForCandidateInputsStub(job)
 	} else {
-		return fake.createJobBuildIfNoBuildsPendingReturns.result1, fake.createJobBuildIfNoBuildsPendingReturns.result2, fake.createJobBuildIfNoBuildsPendingReturns.result3
+		return fake.createJobBuildForCandidateInputsReturns.result1, fake.createJobBuildForCandidateInputsReturns.result2, fake.createJobBuildForCandidateInputsReturns.result3
 	}
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsCallCount() int {
-	fake.createJobBuildIfNoBuildsPendingMutex.RLock()
-	defer fake.createJobBuildIfNoBuildsPendingMutex.RUnlock()
-	return len(fake.createJobBuildIfNoBuildsPendingArgsForCall)
+	fake.createJobBuildForCandidateInputsMutex.RLock()
+	defer fake.createJobBuildForCandidateInputsMutex.RUnlock()
+	return len(fake.createJobBuildForCandidateInputsArgsForCall)
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsArgsForCall(i int) string {
-	fake.createJobBuildIfNoBuildsPendingMutex.RLock()
-	defer fake.createJobBuildIfNoBuildsPendingMutex.RUnlock()
-	return fake.createJobBuildIfNoBuildsPendingArgsForCall[i].job
+	fake.createJobBuildForCandidateInputsMutex.RLock()
+	defer fake.createJobBuildForCandidateInputsMutex.RUnlock()
+	return fake.createJobBuildForCandidateInputsArgsForCall[i].job
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsReturns(result1 db.Build, result2 bool, result3 error) {
 	fake.CreateJobBuildForCandidateInputsStub = nil
-	fake.createJobBuildIfNoBuildsPendingReturns = struct {
+	fake.createJobBuildForCandidateInputsReturns = struct {
 		result1 db.Build
 		result2 bool
 		result3 error
@@ -1084,14 +1243,15 @@ func (fake *FakePipelineDB) UseInputsForBuildReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakePipelineDB) GetLatestInputVersions(arg1 []atc.JobInput) ([]db.BuildInput, error) {
+func (fake *FakePipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error) {
 	fake.getLatestInputVersionsMutex.Lock()
 	fake.getLatestInputVersionsArgsForCall = append(fake.getLatestInputVersionsArgsForCall, struct {
-		arg1 []atc.JobInput
-	}{arg1})
+		jobName string
+		inputs  []atc.JobInput
+	}{jobName, inputs})
 	fake.getLatestInputVersionsMutex.Unlock()
 	if fake.GetLatestInputVersionsStub != nil {
-		return fake.GetLatestInputVersionsStub(arg1)
+		return fake.GetLatestInputVersionsStub(jobName, inputs)
 	} else {
 		return fake.getLatestInputVersionsReturns.result1, fake.getLatestInputVersionsReturns.result2
 	}
@@ -1103,10 +1263,10 @@ func (fake *FakePipelineDB) GetLatestInputVersionsCallCount() int {
 	return len(fake.getLatestInputVersionsArgsForCall)
 }
 
-func (fake *FakePipelineDB) GetLatestInputVersionsArgsForCall(i int) []atc.JobInput {
+func (fake *FakePipelineDB) GetLatestInputVersionsArgsForCall(i int) (string, []atc.JobInput) {
 	fake.getLatestInputVersionsMutex.RLock()
 	defer fake.getLatestInputVersionsMutex.RUnlock()
-	return fake.getLatestInputVersionsArgsForCall[i].arg1
+	return fake.getLatestInputVersionsArgsForCall[i].jobName, fake.getLatestInputVersionsArgsForCall[i].inputs
 }
 
 func (fake *FakePipelineDB) GetLatestInputVersionsReturns(result1 []db.BuildInput, result2 error) {
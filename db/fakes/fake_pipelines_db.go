@@ -15,19 +15,21 @@ type FakePipelinesDB struct {
 		result1 []db.SavedPipeline
 		result2 error
 	}
-	GetPipelineByNameStub        func(pipelineName string) (db.SavedPipeline, error)
-	getPipelineByNameMutex       sync.RWMutex
-	getPipelineByNameArgsForCall []struct {
+	GetPipelineByTeamNameAndNameStub        func(teamName string, pipelineName string) (db.SavedPipeline, error)
+	getPipelineByTeamNameAndNameMutex       sync.RWMutex
+	getPipelineByTeamNameAndNameArgsForCall []struct {
+		teamName     string
 		pipelineName string
 	}
-	getPipelineByNameReturns struct {
+	getPipelineByTeamNameAndNameReturns struct {
 		result1 db.SavedPipeline
 		result2 error
 	}
-	OrderPipelinesStub        func([]string) error
+	OrderPipelinesStub        func(teamName string, pipelineNames []string) error
 	orderPipelinesMutex       sync.RWMutex
 	orderPipelinesArgsForCall []struct {
-		arg1 []string
+		teamName      string
+		pipelineNames []string
 	}
 	orderPipelinesReturns struct {
 		result1 error
@@ -59,47 +61,49 @@ func (fake *FakePipelinesDB) GetAllActivePipelinesReturns(result1 []db.SavedPipe
 	}{result1, result2}
 }
 
-func (fake *FakePipelinesDB) GetPipelineByName(pipelineName string) (db.SavedPipeline, error) {
-	fake.getPipelineByNameMutex.Lock()
-	fake.getPipelineByNameArgsForCall = append(fake.getPipelineByNameArgsForCall, struct {
+func (fake *FakePipelinesDB) GetPipelineByTeamNameAndName(teamName string, pipelineName string) (db.SavedPipeline, error) {
+	fake.getPipelineByTeamNameAndNameMutex.Lock()
+	fake.getPipelineByTeamNameAndNameArgsForCall = append(fake.getPipelineByTeamNameAndNameArgsForCall, struct {
+		teamName     string
 		pipelineName string
-	}{pipelineName})
-	fake.getPipelineByNameMutex.Unlock()
-	if fake.GetPipelineByNameStub != nil {
-		return fake.GetPipelineByNameStub(pipelineName)
+	}{teamName, pipelineName})
+	fake.getPipelineByTeamNameAndNameMutex.Unlock()
+	if fake.GetPipelineByTeamNameAndNameStub != nil {
+		return fake.GetPipelineByTeamNameAndNameStub(teamName, pipelineName)
 	} else {
-		return fake.getPipelineByNameReturns.result1, fake.getPipelineByNameReturns.result2
+		return fake.getPipelineByTeamNameAndNameReturns.result1, fake.getPipelineByTeamNameAndNameReturns.result2
 	}
 }
 
-func (fake *FakePipelinesDB) GetPipelineByNameCallCount() int {
-	fake.getPipelineByNameMutex.RLock()
-	defer fake.getPipelineByNameMutex.RUnlock()
-	return len(fake.getPipelineByNameArgsForCall)
+func (fake *FakePipelinesDB) GetPipelineByTeamNameAndNameCallCount() int {
+	fake.getPipelineByTeamNameAndNameMutex.RLock()
+	defer fake.getPipelineByTeamNameAndNameMutex.RUnlock()
+	return len(fake.getPipelineByTeamNameAndNameArgsForCall)
 }
 
-func (fake *FakePipelinesDB) GetPipelineByNameArgsForCall(i int) string {
-	fake.getPipelineByNameMutex.RLock()
-	defer fake.getPipelineByNameMutex.RUnlock()
-	return fake.getPipelineByNameArgsForCall[i].pipelineName
+func (fake *FakePipelinesDB) GetPipelineByTeamNameAndNameArgsForCall(i int) (string, string) {
+	fake.getPipelineByTeamNameAndNameMutex.RLock()
+	defer fake.getPipelineByTeamNameAndNameMutex.RUnlock()
+	return fake.getPipelineByTeamNameAndNameArgsForCall[i].teamName, fake.getPipelineByTeamNameAndNameArgsForCall[i].pipelineName
 }
 
-func (fake *FakePipelinesDB) GetPipelineByNameReturns(result1 db.SavedPipeline, result2 error) {
-	fake.GetPipelineByNameStub = nil
-	fake.getPipelineByNameReturns = struct {
+func (fake *FakePipelinesDB) GetPipelineByTeamNameAndNameReturns(result1 db.SavedPipeline, result2 error) {
+	fake.GetPipelineByTeamNameAndNameStub = nil
+	fake.getPipelineByTeamNameAndNameReturns = struct {
 		result1 db.SavedPipeline
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePipelinesDB) OrderPipelines(arg1 []string) error {
+func (fake *FakePipelinesDB) OrderPipelines(teamName string, pipelineNames []string) error {
 	fake.orderPipelinesMutex.Lock()
 	fake.orderPipelinesArgsForCall = append(fake.orderPipelinesArgsForCall, struct {
-		arg1 []string
-	}{arg1})
+		teamName      string
+		pipelineNames []string
+	}{teamName, pipelineNames})
 	fake.orderPipelinesMutex.Unlock()
 	if fake.OrderPipelinesStub != nil {
-		return fake.OrderPipelinesStub(arg1)
+		return fake.OrderPipelinesStub(teamName, pipelineNames)
 	} else {
 		return fake.orderPipelinesReturns.result1
 	}
@@ -111,10 +115,10 @@ func (fake *FakePipelinesDB) OrderPipelinesCallCount() int {
 	return len(fake.orderPipelinesArgsForCall)
 }
 
-func (fake *FakePipelinesDB) OrderPipelinesArgsForCall(i int) []string {
+func (fake *FakePipelinesDB) OrderPipelinesArgsForCall(i int) (string, []string) {
 	fake.orderPipelinesMutex.RLock()
 	defer fake.orderPipelinesMutex.RUnlock()
-	return fake.orderPipelinesArgsForCall[i].arg1
+	return fake.orderPipelinesArgsForCall[i].teamName, fake.orderPipelinesArgsForCall[i].pipelineNames
 }
 
 func (fake *FakePipelinesDB) OrderPipelinesReturns(result1 error) {
@@ -0,0 +1,129 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/db"
+)
+
+type FakeTeamsDB struct {
+	GetTeamsStub        func() ([]db.SavedTeam, error)
+	getTeamsMutex       sync.RWMutex
+	getTeamsArgsForCall []struct{}
+	getTeamsReturns struct {
+		result1 []db.SavedTeam
+		result2 error
+	}
+	GetTeamByNameStub        func(teamName string) (db.SavedTeam, error)
+	getTeamByNameMutex       sync.RWMutex
+	getTeamByNameArgsForCall []struct {
+		teamName string
+	}
+	getTeamByNameReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+	SaveTeamStub        func(db.Team) (db.SavedTeam, error)
+	saveTeamMutex       sync.RWMutex
+	saveTeamArgsForCall []struct {
+		arg1 db.Team
+	}
+	saveTeamReturns struct {
+		result1 db.SavedTeam
+		result2 error
+	}
+}
+
+func (fake *FakeTeamsDB) GetTeams() ([]db.SavedTeam, error) {
+	fake.getTeamsMutex.Lock()
+	fake.getTeamsArgsForCall = append(fake.getTeamsArgsForCall, struct{}{})
+	fake.getTeamsMutex.Unlock()
+	if fake.GetTeamsStub != nil {
+		return fake.GetTeamsStub()
+	} else {
+		return fake.getTeamsReturns.result1, fake.getTeamsReturns.result2
+	}
+}
+
+func (fake *FakeTeamsDB) GetTeamsCallCount() int {
+	fake.getTeamsMutex.RLock()
+	defer fake.getTeamsMutex.RUnlock()
+	return len(fake.getTeamsArgsForCall)
+}
+
+func (fake *FakeTeamsDB) GetTeamsReturns(result1 []db.SavedTeam, result2 error) {
+	fake.GetTeamsStub = nil
+	fake.getTeamsReturns = struct {
+		result1 []db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamsDB) GetTeamByName(teamName string) (db.SavedTeam, error) {
+	fake.getTeamByNameMutex.Lock()
+	fake.getTeamByNameArgsForCall = append(fake.getTeamByNameArgsForCall, struct {
+		teamName string
+	}{teamName})
+	fake.getTeamByNameMutex.Unlock()
+	if fake.GetTeamByNameStub != nil {
+		return fake.GetTeamByNameStub(teamName)
+	} else {
+		return fake.getTeamByNameReturns.result1, fake.getTeamByNameReturns.result2
+	}
+}
+
+func (fake *FakeTeamsDB) GetTeamByNameCallCount() int {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return len(fake.getTeamByNameArgsForCall)
+}
+
+func (fake *FakeTeamsDB) GetTeamByNameArgsForCall(i int) string {
+	fake.getTeamByNameMutex.RLock()
+	defer fake.getTeamByNameMutex.RUnlock()
+	return fake.getTeamByNameArgsForCall[i].teamName
+}
+
+func (fake *FakeTeamsDB) GetTeamByNameReturns(result1 db.SavedTeam, result2 error) {
+	fake.GetTeamByNameStub = nil
+	fake.getTeamByNameReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeamsDB) SaveTeam(arg1 db.Team) (db.SavedTeam, error) {
+	fake.saveTeamMutex.Lock()
+	fake.saveTeamArgsForCall = append(fake.saveTeamArgsForCall, struct {
+		arg1 db.Team
+	}{arg1})
+	fake.saveTeamMutex.Unlock()
+	if fake.SaveTeamStub != nil {
+		return fake.SaveTeamStub(arg1)
+	} else {
+		return fake.saveTeamReturns.result1, fake.saveTeamReturns.result2
+	}
+}
+
+func (fake *FakeTeamsDB) SaveTeamCallCount() int {
+	fake.saveTeamMutex.RLock()
+	defer fake.saveTeamMutex.RUnlock()
+	return len(fake.saveTeamArgsForCall)
+}
+
+func (fake *FakeTeamsDB) SaveTeamArgsForCall(i int) db.Team {
+	fake.saveTeamMutex.RLock()
+	defer fake.saveTeamMutex.RUnlock()
+	return fake.saveTeamArgsForCall[i].arg1
+}
+
+func (fake *FakeTeamsDB) SaveTeamReturns(result1 db.SavedTeam, result2 error) {
+	fake.SaveTeamStub = nil
+	fake.saveTeamReturns = struct {
+		result1 db.SavedTeam
+		result2 error
+	}{result1, result2}
+}
+
+var _ db.TeamsDB = new(FakeTeamsDB)
@@ -35,7 +35,7 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 		})
 
 		It("saves and propagates events correctly", func() {
-			build, err := database.CreateOneOffBuild()
+			build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(build.Name).Should(Equal("1"))
 
@@ -110,7 +110,7 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 		})
 
 		It("saves and emits status events", func() {
-			build, err := database.CreateOneOffBuild()
+			build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(build.Name).Should(Equal("1"))
 
@@ -151,11 +151,29 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 		})
 
 		It("can keep track of workers", func() {
-			Ω(database.Workers()).Should(BeEmpty())
+			// registration and heartbeat times are checked separately
+			withoutTimes := func(info db.WorkerInfo) db.WorkerInfo {
+				info.RegisteredAt = time.Time{}
+				info.LastHeartbeatAt = time.Time{}
+				return info
+			}
+
+			workers := func() ([]db.WorkerInfo, error) {
+				infos, err := database.Workers()
+				for i, info := range infos {
+					infos[i] = withoutTimes(info)
+				}
+
+				return infos, err
+			}
+
+			Ω(workers()).Should(BeEmpty())
 
 			infoA := db.WorkerInfo{
+				Name:             "worker-a",
 				Addr:             "1.2.3.4:7777",
 				ActiveContainers: 42,
+				MaxContainers:    100,
 				ResourceTypes: []atc.WorkerResourceType{
 					{Type: "some-resource-a", Image: "some-image-a"},
 				},
@@ -164,6 +182,7 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			}
 
 			infoB := db.WorkerInfo{
+				Name:             "worker-b",
 				Addr:             "1.2.3.4:8888",
 				ActiveContainers: 42,
 				ResourceTypes: []atc.WorkerResourceType{
@@ -173,42 +192,160 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 				Tags:     []string{"russ", "cox", "was", "here"},
 			}
 
+			running := func(info db.WorkerInfo) db.WorkerInfo {
+				info.State = atc.WorkerStateRunning
+				return info
+			}
+
+			stalled := func(info db.WorkerInfo) db.WorkerInfo {
+				info.State = atc.WorkerStateStalled
+				return info
+			}
+
 			By("persisting workers with no TTLs")
 			err := database.SaveWorker(infoA, 0)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(database.Workers()).Should(ConsistOf(infoA))
+			Ω(workers()).Should(ConsistOf(running(infoA)))
 
 			By("being idempotent")
 			err = database.SaveWorker(infoA, 0)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Ω(database.Workers()).Should(ConsistOf(infoA))
+			Ω(workers()).Should(ConsistOf(running(infoA)))
 
-			By("expiring TTLs")
+			By("stalling workers whose TTLs expire")
 			ttl := 1 * time.Second
 
 			err = database.SaveWorker(infoB, ttl)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Consistently(database.Workers, ttl/2).Should(ConsistOf(infoA, infoB))
-			Eventually(database.Workers, 2*ttl).Should(ConsistOf(infoA))
+			Consistently(workers, ttl/2).Should(ConsistOf(running(infoA), running(infoB)))
+			Eventually(workers, 2*ttl).Should(ConsistOf(running(infoA), stalled(infoB)))
+
+			By("running stalled workers again once they heartbeat")
+			err = database.SaveWorker(infoB, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA), running(infoB)))
+
+			By("keeping the state of landing workers across heartbeats")
+			landingB := infoB
+			landingB.State = atc.WorkerStateLanding
+
+			err = database.SaveWorker(landingB, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = database.SaveWorker(infoB, ttl)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA), landingB))
+
+			By("removing landing workers whose TTLs expire")
+			Eventually(workers, 2*ttl).Should(ConsistOf(running(infoA)))
+
+			By("removing retiring workers once their containers are gone")
+			retiringB := infoB
+			retiringB.State = atc.WorkerStateRetiring
+
+			err = database.SaveWorker(retiringB, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA), retiringB))
+
+			retiringB.ActiveContainers = 0
+
+			err = database.SaveWorker(retiringB, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
+
+			_, err = database.GetWorker("worker-b")
+			Ω(err).Should(Equal(db.ErrNoWorker))
+
+			By("rejecting heartbeats from retired workers")
+			err = database.SaveWorker(infoB, ttl)
+			Ω(err).Should(Equal(db.ErrWorkerRetired))
+
+			err = database.SaveWorker(retiringB, ttl)
+			Ω(err).Should(Equal(db.ErrWorkerRetired))
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
+
+			By("bringing retired workers back when they register as running")
+			err = database.SaveWorker(running(infoB), 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA), running(infoB)))
+
+			retiringB.ActiveContainers = 0
+
+			err = database.SaveWorker(retiringB, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
 
 			By("overwriting TTLs")
 			err = database.SaveWorker(infoA, ttl)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			Consistently(database.Workers, ttl/2).Should(ConsistOf(infoA))
-			Eventually(database.Workers, 2*ttl).Should(BeEmpty())
+			Consistently(workers, ttl/2).Should(ConsistOf(running(infoA)))
+			Eventually(workers, 2*ttl).Should(ConsistOf(stalled(infoA)))
+
+			By("saving the team a worker is dedicated to")
+			infoA.Team = atc.DefaultTeamName
+
+			err = database.SaveWorker(infoA, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
+
+			By("refusing to dedicate a worker to a team that does not exist")
+			bogusTeamA := infoA
+			bogusTeamA.Team = "bogus-team"
+
+			err = database.SaveWorker(bogusTeamA, 0)
+			Ω(err).Should(Equal(db.ErrNoTeam))
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
+
+			By("moving workers to a new address")
+			infoA.Addr = "5.6.7.8:7777"
+
+			err = database.SaveWorker(infoA, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(workers()).Should(ConsistOf(running(infoA)))
+
+			By("looking up workers by name")
+			savedA, err := database.GetWorker("worker-a")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(withoutTimes(savedA)).Should(Equal(running(infoA)))
+
+			_, err = database.GetWorker("bogus-worker")
+			Ω(err).Should(Equal(db.ErrNoWorker))
+
+			By("tracking when workers registered and last heartbeated")
+			Ω(savedA.RegisteredAt).Should(BeTemporally("~", time.Now(), 10*time.Second))
+			Ω(savedA.LastHeartbeatAt).Should(BeTemporally(">", savedA.RegisteredAt))
+
+			err = database.SaveWorker(infoA, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			heartbeatedA, err := database.GetWorker("worker-a")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(heartbeatedA.RegisteredAt).Should(Equal(savedA.RegisteredAt))
+			Ω(heartbeatedA.LastHeartbeatAt).Should(BeTemporally(">", savedA.LastHeartbeatAt))
 		})
 
 		It("can create one-off builds with increasing names", func() {
-			oneOff, err := database.CreateOneOffBuild()
+			oneOff, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(oneOff.ID).ShouldNot(BeZero())
 			Ω(oneOff.JobName).Should(BeZero())
 			Ω(oneOff.Name).Should(Equal("1"))
 			Ω(oneOff.Status).Should(Equal(db.StatusPending))
+			Ω(oneOff.TeamName).Should(Equal(atc.DefaultTeamName))
 
 			oneOffGot, err := database.GetBuild(oneOff.ID)
 			Ω(err).ShouldNot(HaveOccurred())
@@ -218,7 +355,7 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(jobBuild.Name).Should(Equal("1"))
 
-			nextOneOff, err := database.CreateOneOffBuild()
+			nextOneOff, err := database.CreateOneOffBuild(atc.DefaultTeamName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(nextOneOff.ID).ShouldNot(BeZero())
 			Ω(nextOneOff.ID).ShouldNot(Equal(oneOff.ID))
@@ -226,11 +363,69 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			Ω(nextOneOff.Name).Should(Equal("2"))
 			Ω(nextOneOff.Status).Should(Equal(db.StatusPending))
 
-			allBuilds, err := database.GetAllBuilds()
+			allBuilds, _, err := database.GetBuilds("", db.Page{})
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(allBuilds).Should(Equal([]db.Build{nextOneOff, jobBuild, oneOff}))
 		})
 
+		Describe("GetBuilds", func() {
+			var allBuilds []db.Build
+
+			BeforeEach(func() {
+				allBuilds = []db.Build{}
+
+				for i := 0; i < 5; i++ {
+					build, err := database.CreateOneOffBuild(atc.DefaultTeamName)
+					Ω(err).ShouldNot(HaveOccurred())
+
+					allBuilds = append([]db.Build{build}, allBuilds...)
+				}
+			})
+
+			It("returns the most recent builds up to the limit", func() {
+				builds, pagination, err := database.GetBuilds("", db.Page{Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(Equal(allBuilds[0:2]))
+				Ω(pagination.Previous).Should(BeNil())
+				Ω(pagination.Next).Should(Equal(&db.Page{Since: allBuilds[1].ID, Limit: 2}))
+			})
+
+			It("returns the builds older than the since cursor", func() {
+				builds, pagination, err := database.GetBuilds("", db.Page{Since: allBuilds[1].ID, Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(Equal(allBuilds[2:4]))
+				Ω(pagination.Previous).Should(Equal(&db.Page{Until: allBuilds[2].ID, Limit: 2}))
+				Ω(pagination.Next).Should(Equal(&db.Page{Since: allBuilds[3].ID, Limit: 2}))
+			})
+
+			It("returns the builds immediately newer than the until cursor", func() {
+				builds, pagination, err := database.GetBuilds("", db.Page{Until: allBuilds[4].ID, Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(Equal(allBuilds[2:4]))
+				Ω(pagination.Previous).Should(Equal(&db.Page{Until: allBuilds[2].ID, Limit: 2}))
+				Ω(pagination.Next).Should(Equal(&db.Page{Since: allBuilds[3].ID, Limit: 2}))
+			})
+
+			It("does not point past the oldest build", func() {
+				builds, pagination, err := database.GetBuilds("", db.Page{Since: allBuilds[2].ID, Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(Equal(allBuilds[3:5]))
+				Ω(pagination.Previous).ShouldNot(BeNil())
+				Ω(pagination.Next).Should(BeNil())
+			})
+
+			It("filters by team", func() {
+				builds, pagination, err := database.GetBuilds("some-other-team", db.Page{Limit: 2})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(BeEmpty())
+				Ω(pagination).Should(Equal(db.Pagination{}))
+
+				builds, _, err = database.GetBuilds(atc.DefaultTeamName, db.Page{})
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(builds).Should(Equal(allBuilds))
+			})
+		})
+
 		Describe("GetAllStartedBuilds", func() {
 			var build1 db.Build
 			var build2 db.Build
@@ -238,13 +433,13 @@ func dbSharedBehavior(database *dbSharedBehaviorInput) func() {
 			BeforeEach(func() {
 				var err error
 
-				build1, err = database.CreateOneOffBuild()
+				build1, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				build2, err = database.PipelineDB.CreateJobBuild("some-job")
 				Ω(err).ShouldNot(HaveOccurred())
 
-				build3, err = database.CreateOneOffBuild()
+				build3, err = database.CreateOneOffBuild(atc.DefaultTeamName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				started, err := database.StartBuild(build1.ID, "some-engine", "so-meta")
@@ -0,0 +1,88 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func CreateTeams(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE teams (
+			id serial PRIMARY KEY,
+			name text NOT NULL,
+			CONSTRAINT constraint_teams_name_unique UNIQUE (name)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO teams (name) VALUES ('main')
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines ADD COLUMN team_id integer REFERENCES teams (id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE pipelines
+		SET team_id = (SELECT id FROM teams WHERE name = 'main')
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines ALTER COLUMN team_id SET NOT NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines DROP CONSTRAINT constraint_pipelines_name_unique
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE pipelines ADD CONSTRAINT constraint_pipelines_team_id_name_unique UNIQUE (team_id, name)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds ADD COLUMN team_id integer REFERENCES teams (id)
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE builds
+		SET team_id = (SELECT id FROM teams WHERE name = 'main')
+	`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		ALTER TABLE builds ALTER COLUMN team_id SET NOT NULL
+	`)
+	if err != nil {
+		return err
+	}
+
+	// workers without a team are shared by all teams
+	_, err = tx.Exec(`
+		ALTER TABLE workers ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE
+	`)
+
+	return err
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddNameToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE workers ADD COLUMN name text`)
+	if err != nil {
+		return err
+	}
+
+	// existing workers are known by their address
+	_, err = tx.Exec(`UPDATE workers SET name = addr`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE workers ALTER COLUMN name SET NOT NULL`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE workers DROP CONSTRAINT workers_addr_key`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE workers ADD CONSTRAINT workers_name_key UNIQUE (name)`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
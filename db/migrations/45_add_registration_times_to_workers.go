@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddRegistrationTimesToWorkers(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE workers ADD COLUMN registered_at timestamp with time zone NOT NULL DEFAULT now()`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE workers ADD COLUMN last_heartbeat_at timestamp with time zone NOT NULL DEFAULT now()`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
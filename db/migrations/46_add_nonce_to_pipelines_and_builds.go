@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddNonceToPipelinesAndBuilds(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE pipelines ADD COLUMN nonce text`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE builds ADD COLUMN nonce text`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/BurntSushi/migration"
+
+func AddEncryptionToVersionedResources(tx migration.LimitedTx) error {
+	_, err := tx.Exec(`ALTER TABLE versioned_resources ADD COLUMN nonce text`)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`ALTER TABLE versioned_resources ADD COLUMN encryption_key_id text`)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
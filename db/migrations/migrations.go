@@ -42,4 +42,13 @@ var Migrations = []migration.Migrator{
 	AddPausedToPipelines,
 	AddOrderingToPipelines,
 	AddInputsDeterminedToBuilds,
+	CreateTeams,
+	AddPinnedVersionToResources,
+	AddStateToWorkers,
+	AddMaxContainersToWorkers,
+	AddNameToWorkers,
+	AddRegistrationTimesToWorkers,
+	AddNonceToPipelinesAndBuilds,
+	AddEncryptionKeyIDToPipelinesAndBuilds,
+	AddEncryptionToVersionedResources,
 }
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/concourse/atc/db/encryption"
+)
+
+// Page selects a window of builds, newest first. Since and Until are
+// exclusive build ID cursors: Since selects the builds older than it and
+// Until the builds newer than it. A Limit of 0 selects every matching build.
+type Page struct {
+	Since int
+	Until int
+	Limit int
+}
+
+// Pagination points to the pages adjacent to the one returned. Previous holds
+// newer builds and Next older ones; either is nil if there are none.
+type Pagination struct {
+	Previous *Page
+	Next     *Page
+}
+
+func getBuildsWithPagination(conn *sql.DB, strategy encryption.Strategy, condition string, conditionArgs []interface{}, page Page) ([]Build, Pagination, error) {
+	from := `
+		FROM builds b
+		LEFT OUTER JOIN jobs j ON b.job_id = j.id
+		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
+		WHERE ` + condition
+
+	args := append([]interface{}{}, conditionArgs...)
+	query := `SELECT ` + qualifiedBuildColumns + from
+
+	order := "DESC"
+
+	if page.Since != 0 {
+		args = append(args, page.Since)
+		query += fmt.Sprintf(" AND b.id < $%d", len(args))
+	} else if page.Until != 0 {
+		// walk up from the cursor so that the builds immediately newer than it
+		// are the ones that fit within the limit
+		args = append(args, page.Until)
+		query += fmt.Sprintf(" AND b.id > $%d", len(args))
+		order = "ASC"
+	}
+
+	query += " ORDER BY b.id " + order
+
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := conn.Query(query, args...)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer rows.Close()
+
+	bs := []Build{}
+
+	for rows.Next() {
+		build, err := scanBuild(rows, strategy)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if order == "ASC" {
+			bs = append([]Build{build}, bs...)
+		} else {
+			bs = append(bs, build)
+		}
+	}
+
+	if len(bs) == 0 {
+		return bs, Pagination{}, nil
+	}
+
+	var minID, maxID int
+	err = conn.QueryRow(`SELECT COALESCE(MIN(b.id), 0), COALESCE(MAX(b.id), 0)`+from, conditionArgs...).Scan(&minID, &maxID)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	var pagination Pagination
+
+	newest := bs[0].ID
+	if newest < maxID {
+		pagination.Previous = &Page{Until: newest, Limit: page.Limit}
+	}
+
+	oldest := bs[len(bs)-1].ID
+	if oldest > minID {
+		pagination.Next = &Page{Since: oldest, Limit: page.Limit}
+	}
+
+	return bs, pagination, nil
+}
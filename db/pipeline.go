@@ -9,8 +9,9 @@ type Pipeline struct {
 }
 
 type SavedPipeline struct {
-	ID     int
-	Paused bool
+	ID       int
+	Paused   bool
+	TeamName string
 
 	Pipeline
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager"
 )
@@ -16,6 +17,7 @@ import (
 
 type PipelineDB interface {
 	GetPipelineName() string
+	GetPipelineTeamName() string
 	ScopedName(string) string
 
 	Pause() error
@@ -35,6 +37,8 @@ type PipelineDB interface {
 	GetLatestVersionedResource(resource SavedResource) (SavedVersionedResource, error)
 	EnableVersionedResource(resourceID int) error
 	DisableVersionedResource(resourceID int) error
+	PinResource(resourceName string, versionedResourceID int) error
+	UnpinResource(resourceName string) error
 	SetResourceCheckError(resource SavedResource, err error) error
 
 	GetJob(job string) (SavedJob, error)
@@ -44,13 +48,14 @@ type PipelineDB interface {
 	GetJobFinishedAndNextBuild(job string) (*Build, *Build, error)
 
 	GetAllJobBuilds(job string) ([]Build, error)
+	GetJobBuilds(job string, page Page) ([]Build, Pagination, error)
 	GetJobBuild(job string, build string) (Build, error)
 	CreateJobBuild(job string) (Build, error)
 	CreateJobBuildForCandidateInputs(job string) (Build, bool, error)
 
 	UseInputsForBuild(buildID int, inputs []BuildInput) error
 
-	GetLatestInputVersions([]atc.JobInput) ([]BuildInput, error)
+	GetLatestInputVersions(jobName string, inputs []atc.JobInput) ([]BuildInput, error)
 	GetJobBuildForInputs(job string, inputs []BuildInput) (Build, error)
 	GetNextPendingBuild(job string) (Build, error)
 
@@ -70,6 +75,8 @@ type pipelineDB struct {
 	conn *sql.DB
 	bus  *notificationsBus
 
+	encryption encryption.Strategy
+
 	SavedPipeline
 }
 
@@ -77,8 +84,16 @@ func (pdb *pipelineDB) GetPipelineName() string {
 	return pdb.Name
 }
 
+func (pdb *pipelineDB) GetPipelineTeamName() string {
+	return pdb.TeamName
+}
+
 func (pdb *pipelineDB) ScopedName(name string) string {
-	return pdb.Name + ":" + name
+	if pdb.TeamName == "" || pdb.TeamName == atc.DefaultTeamName {
+		return pdb.Name + ":" + name
+	}
+
+	return pdb.TeamName + "/" + pdb.Name + ":" + name
 }
 
 func (pdb *pipelineDB) Unpause() error {
@@ -194,14 +209,15 @@ func (pdb *pipelineDB) Destroy() error {
 }
 
 func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, error) {
-	var configBlob []byte
+	var configBlob string
+	var nonce *string
 	var version int
 
 	err := pdb.conn.QueryRow(`
-			SELECT config, version
+			SELECT config, nonce, version
 			FROM pipelines
 			WHERE id = $1
-		`, pdb.ID).Scan(&configBlob, &version)
+		`, pdb.ID).Scan(&configBlob, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, nil
@@ -210,8 +226,7 @@ func (pdb *pipelineDB) GetConfig() (atc.Config, ConfigVersion, error) {
 		}
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	config, err := decryptConfig(pdb.encryption, configBlob, nonce)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -260,7 +275,7 @@ func (pdb *pipelineDB) GetResourceHistory(resource string) ([]*VersionHistory, e
 	}
 
 	vrRows, err := pdb.conn.Query(`
-		SELECT v.id, v.enabled, v.type, v.version, v.source, v.metadata, r.name
+		SELECT v.id, v.enabled, v.type, v.version, v.source, v.nonce, v.metadata, r.name
 		FROM versioned_resources v
 		INNER JOIN resources r ON v.resource_id = r.id
 		WHERE v.resource_id = $1
@@ -276,13 +291,14 @@ func (pdb *pipelineDB) GetResourceHistory(resource string) ([]*VersionHistory, e
 		var svr SavedVersionedResource
 
 		var versionString, sourceString, metadataString string
+		var nonce *string
 
-		err := vrRows.Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionString, &sourceString, &metadataString, &svr.Resource)
+		err := vrRows.Scan(&svr.ID, &svr.Enabled, &svr.Type, &versionString, &sourceString, &nonce, &metadataString, &svr.Resource)
 		if err != nil {
 			return nil, err
 		}
 
-		err = json.Unmarshal([]byte(sourceString), &svr.Source)
+		svr.Source, err = decryptSource(pdb.encryption, sourceString, nonce)
 		if err != nil {
 			return nil, err
 		}
@@ -392,14 +408,15 @@ func (pdb *pipelineDB) GetResourceHistory(resource string) ([]*VersionHistory, e
 
 func (pdb *pipelineDB) getResource(tx *sql.Tx, name string) (SavedResource, error) {
 	var checkErr sql.NullString
+	var pinnedVersionID sql.NullInt64
 	var resource SavedResource
 
 	err := tx.QueryRow(`
-			SELECT id, name, check_error, paused
+			SELECT id, name, check_error, paused, pinned_version_id
 			FROM resources
 			WHERE name = $1
 				AND pipeline_id = $2
-		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused)
+		`, name, pdb.ID).Scan(&resource.ID, &resource.Name, &checkErr, &resource.Paused, &pinnedVersionID)
 	if err != nil {
 		return SavedResource{}, err
 	}
@@ -408,7 +425,12 @@ func (pdb *pipelineDB) getResource(tx *sql.Tx, name string) (SavedResource, erro
 		resource.CheckError = errors.New(checkErr.String)
 	}
 
+	if pinnedVersionID.Valid {
+		resource.PinnedVersionID = int(pinnedVersionID.Int64)
+	}
+
 	resource.PipelineName = pdb.Name
+	resource.TeamName = pdb.TeamName
 
 	return resource, nil
 }
@@ -456,6 +478,84 @@ func (pdb *pipelineDB) updatePaused(resource string, pause bool) error {
 	return tx.Commit()
 }
 
+func (pdb *pipelineDB) PinResource(resource string, versionedResourceID int) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.registerResource(tx, resource)
+	if err != nil {
+		return err
+	}
+
+	// only versions of the resource itself may be pinned
+	result, err := tx.Exec(`
+		UPDATE resources r
+		SET pinned_version_id = v.id
+		FROM versioned_resources v
+		WHERE v.id = $1
+			AND v.resource_id = r.id
+			AND r.name = $2
+			AND r.pipeline_id = $3
+	`, versionedResourceID, resource, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrNoVersion
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return tx.Commit()
+}
+
+func (pdb *pipelineDB) UnpinResource(resource string) error {
+	tx, err := pdb.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = pdb.registerResource(tx, resource)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+		UPDATE resources
+		SET pinned_version_id = NULL
+		WHERE name = $1
+			AND pipeline_id = $2
+	`, resource, pdb.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return nonOneRowAffectedError{rowsAffected}
+	}
+
+	return tx.Commit()
+}
+
 func (pdb *pipelineDB) SaveResourceVersions(config atc.ResourceConfig, versions []atc.Version) error {
 	tx, err := pdb.conn.Begin()
 	if err != nil {
@@ -530,6 +630,7 @@ func (pdb *pipelineDB) EnableVersionedResource(resourceID int) error {
 
 func (pdb *pipelineDB) GetLatestVersionedResource(resource SavedResource) (SavedVersionedResource, error) {
 	var sourceBytes, versionBytes, metadataBytes string
+	var nonce *string
 
 	svr := SavedVersionedResource{
 		VersionedResource: VersionedResource{
@@ -538,17 +639,17 @@ func (pdb *pipelineDB) GetLatestVersionedResource(resource SavedResource) (Saved
 	}
 
 	err := pdb.conn.QueryRow(`
-		SELECT id, enabled, type, source, version, metadata
+		SELECT id, enabled, type, source, nonce, version, metadata
 		FROM versioned_resources
 		WHERE resource_id = $1
 		ORDER BY id DESC
 		LIMIT 1
-	`, resource.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &sourceBytes, &versionBytes, &metadataBytes)
+	`, resource.ID).Scan(&svr.ID, &svr.Enabled, &svr.Type, &sourceBytes, &nonce, &versionBytes, &metadataBytes)
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
 
-	err = json.Unmarshal([]byte(sourceBytes), &svr.Source)
+	svr.Source, err = decryptSource(pdb.encryption, sourceBytes, nonce)
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -618,6 +719,11 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 		return SavedVersionedResource{}, err
 	}
 
+	encryptedSource, nonce, err := pdb.encryption.Encrypt(sourceJSON)
+	if err != nil {
+		return SavedVersionedResource{}, err
+	}
+
 	metadataJSON, err := json.Marshal(vr.Metadata)
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -627,8 +733,8 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 	var enabled bool
 
 	_, err = tx.Exec(`
-		INSERT INTO versioned_resources (resource_id, type, version, source, metadata)
-		SELECT $1, $2, $3, $4, $5
+		INSERT INTO versioned_resources (resource_id, type, version, source, metadata, nonce, encryption_key_id)
+		SELECT $1, $2, $3, $4, $5, $6, $7
 		WHERE NOT EXISTS (
 			SELECT 1
 			FROM versioned_resources
@@ -636,7 +742,7 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 			AND type = $2
 			AND version = $3
 		)
-	`, savedResource.ID, vr.Type, string(versionJSON), string(sourceJSON), string(metadataJSON))
+	`, savedResource.ID, vr.Type, string(versionJSON), encryptedSource, string(metadataJSON), nonce, pdb.encryption.KeyID())
 	if err != nil {
 		return SavedVersionedResource{}, err
 	}
@@ -644,12 +750,12 @@ func (pdb *pipelineDB) saveVersionedResource(tx *sql.Tx, vr VersionedResource) (
 	// separate from above, as it conditionally inserts (can't use RETURNING)
 	err = tx.QueryRow(`
 		UPDATE versioned_resources
-		SET source = $4, metadata = $5
+		SET source = $4, metadata = $5, nonce = $6, encryption_key_id = $7
 		WHERE resource_id = $1
 		AND type = $2
 		AND version = $3
 		RETURNING id, enabled
-	`, savedResource.ID, vr.Type, string(versionJSON), string(sourceJSON), string(metadataJSON)).Scan(&id, &enabled)
+	`, savedResource.ID, vr.Type, string(versionJSON), encryptedSource, string(metadataJSON), nonce, pdb.encryption.KeyID()).Scan(&id, &enabled)
 
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -841,8 +947,8 @@ func (pdb *pipelineDB) createJobBuild(jobName string, tx *sql.Tx) (Build, error)
 	// RETURNING statement in lib/pq... sorry
 
 	build, err := pdb.scanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, job_id, status)
-		VALUES ($1, $2, 'pending')
+		INSERT INTO builds (name, job_id, status, team_id)
+		VALUES ($1, $2, 'pending', (SELECT team_id FROM pipelines WHERE id = $3))
 		RETURNING `+buildColumns+`,
 			(
 				SELECT j.name
@@ -854,8 +960,13 @@ func (pdb *pipelineDB) createJobBuild(jobName string, tx *sql.Tx) (Build, error)
 				FROM jobs j
 				INNER JOIN pipelines p ON j.pipeline_id = p.id
 				WHERE j.id = job_id
+			),
+			(
+				SELECT t.name
+				FROM teams t
+				WHERE t.id = team_id
 			)
-	`, name, dbJob.ID))
+	`, name, dbJob.ID, pdb.ID))
 	if err != nil {
 		return Build{}, err
 	}
@@ -1053,7 +1164,7 @@ func (pdb *pipelineDB) GetBuildResources(buildID int) ([]BuildInput, []BuildOutp
 	outputs := []BuildOutput{}
 
 	rows, err := pdb.conn.Query(`
-		SELECT i.name, r.name, v.type, v.source, v.version, v.metadata,
+		SELECT i.name, r.name, v.type, v.source, v.nonce, v.version, v.metadata,
 		NOT EXISTS (
 			SELECT 1
 			FROM build_inputs ci, builds cb
@@ -1080,12 +1191,13 @@ func (pdb *pipelineDB) GetBuildResources(buildID int) ([]BuildInput, []BuildOutp
 		var firstOccurrence bool
 
 		var source, version, metadata string
-		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &source, &version, &metadata, &firstOccurrence)
+		var nonce *string
+		err := rows.Scan(&inputName, &vr.Resource, &vr.Type, &source, &nonce, &version, &metadata, &firstOccurrence)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(source), &vr.Source)
+		vr.Source, err = decryptSource(pdb.encryption, source, nonce)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1110,7 +1222,7 @@ func (pdb *pipelineDB) GetBuildResources(buildID int) ([]BuildInput, []BuildOutp
 	}
 
 	rows, err = pdb.conn.Query(`
-		SELECT r.name, v.type, v.source, v.version, v.metadata
+		SELECT r.name, v.type, v.source, v.nonce, v.version, v.metadata
 		FROM versioned_resources v, build_outputs o, builds b, resources r
 		WHERE b.id = $1
 		AND o.build_id = b.id
@@ -1133,12 +1245,13 @@ func (pdb *pipelineDB) GetBuildResources(buildID int) ([]BuildInput, []BuildOutp
 		var vr VersionedResource
 
 		var source, version, metadata string
-		err := rows.Scan(&vr.Resource, &vr.Type, &source, &version, &metadata)
+		var nonce *string
+		err := rows.Scan(&vr.Resource, &vr.Type, &source, &nonce, &version, &metadata)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		err = json.Unmarshal([]byte(source), &vr.Source)
+		vr.Source, err = decryptSource(pdb.encryption, source, nonce)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1417,7 +1530,7 @@ func (pdb *pipelineDB) GetCurrentBuild(job string) (Build, error) {
 }
 
 // buckle up
-func (pdb *pipelineDB) GetLatestInputVersions(inputs []atc.JobInput) ([]BuildInput, error) {
+func (pdb *pipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobInput) ([]BuildInput, error) {
 	fromAliases := []string{}
 	conditions := []string{}
 	params := []interface{}{}
@@ -1440,6 +1553,9 @@ func (pdb *pipelineDB) GetLatestInputVersions(inputs []atc.JobInput) ([]BuildInp
 		conditions = append(conditions, fmt.Sprintf("v%d.resource_id = $%d", i+1, i+1))
 		conditions = append(conditions, fmt.Sprintf("v%d.resource_id = r%d.id", i+1, i+1))
 
+		// a pinned resource only ever resolves to its pinned version
+		conditions = append(conditions, fmt.Sprintf("(r%[1]d.pinned_version_id IS NULL OR v%[1]d.id = r%[1]d.pinned_version_id)", i+1))
+
 		for _, name := range input.Passed {
 			idx, found := passedJobs[name]
 			if !found {
@@ -1470,51 +1586,63 @@ func (pdb *pipelineDB) GetLatestInputVersions(inputs []atc.JobInput) ([]BuildInp
 	buildInputs := []BuildInput{}
 
 	for i, input := range inputs {
-		svr := SavedVersionedResource{
-			Enabled: true, // this is inherent with the following query
-		}
+		inputConditions := append([]string{}, conditions...)
+		inputParams := append([]interface{}{}, params...)
 
-		var source, version, metadata string
-
-		err := pdb.conn.QueryRow(fmt.Sprintf(
-			`
-				SELECT v%[1]d.id, r%[1]d.name, v%[1]d.type, v%[1]d.source, v%[1]d.version, v%[1]d.metadata
-				FROM %s
-				WHERE %s
-				AND v%[1]d.enabled
-				ORDER BY v%[1]d.id DESC
-				LIMIT 1
-			`,
-			i+1,
-			strings.Join(fromAliases, ", "),
-			strings.Join(conditions, "\nAND "),
-		), params...).Scan(&svr.ID, &svr.Resource, &svr.Type, &source, &version, &metadata)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, ErrNoVersions
+		if input.Version != nil && input.Version.Pinned != nil {
+			versionJSON, err := json.Marshal(input.Version.Pinned)
+			if err != nil {
+				return nil, err
 			}
 
-			return nil, err
+			// compare as jsonb so that key order and fields the config leaves
+			// out do not matter
+			inputParams = append(inputParams, string(versionJSON))
+			inputConditions = append(inputConditions, fmt.Sprintf("v%d.version::jsonb @> $%d::jsonb", i+1, len(inputParams)))
 		}
 
-		params = append(params, svr.ID)
-		conditions = append(conditions, fmt.Sprintf("v%d.id = $%d", i+1, len(params)))
+		var svr SavedVersionedResource
+		var found bool
+		var err error
 
-		err = json.Unmarshal([]byte(source), &svr.Source)
-		if err != nil {
-			return nil, err
+		if input.Version != nil && input.Version.Every {
+			lastBuiltID, built, err := pdb.getLastBuiltVersionID(jobName, input.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			// build with the oldest version the job has not built with yet, and
+			// only fall back to the latest version once it has caught up. a job
+			// that has never built with the input starts from the latest version
+			// rather than replaying the resource's entire history.
+			if built {
+				svr, found, err = pdb.getInputVersion(
+					i+1,
+					fromAliases,
+					append(inputConditions, fmt.Sprintf("v%d.id > $%d", i+1, len(inputParams)+1)),
+					append(inputParams, lastBuiltID),
+					"ASC",
+				)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 
-		err = json.Unmarshal([]byte(version), &svr.Version)
-		if err != nil {
-			return nil, err
+		if !found {
+			svr, found, err = pdb.getInputVersion(i+1, fromAliases, inputConditions, inputParams, "DESC")
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		err = json.Unmarshal([]byte(metadata), &svr.Metadata)
-		if err != nil {
-			return nil, err
+		if !found {
+			return nil, ErrNoVersions
 		}
 
+		params = append(params, svr.ID)
+		conditions = append(conditions, fmt.Sprintf("v%d.id = $%d", i+1, len(params)))
+
 		buildInputs = append(buildInputs, BuildInput{
 			Name:              input.Name,
 			VersionedResource: svr.VersionedResource,
@@ -1524,6 +1652,73 @@ func (pdb *pipelineDB) GetLatestInputVersions(inputs []atc.JobInput) ([]BuildInp
 	return buildInputs, nil
 }
 
+func (pdb *pipelineDB) getInputVersion(idx int, fromAliases []string, conditions []string, params []interface{}, order string) (SavedVersionedResource, bool, error) {
+	svr := SavedVersionedResource{
+		Enabled: true, // this is inherent with the following query
+	}
+
+	var source, version, metadata string
+	var nonce *string
+
+	err := pdb.conn.QueryRow(fmt.Sprintf(
+		`
+			SELECT v%[1]d.id, r%[1]d.name, v%[1]d.type, v%[1]d.source, v%[1]d.nonce, v%[1]d.version, v%[1]d.metadata
+			FROM %[2]s
+			WHERE %[3]s
+			AND v%[1]d.enabled
+			ORDER BY v%[1]d.id %[4]s
+			LIMIT 1
+		`,
+		idx,
+		strings.Join(fromAliases, ", "),
+		strings.Join(conditions, "\nAND "),
+		order,
+	), params...).Scan(&svr.ID, &svr.Resource, &svr.Type, &source, &nonce, &version, &metadata)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedVersionedResource{}, false, nil
+		}
+
+		return SavedVersionedResource{}, false, err
+	}
+
+	svr.Source, err = decryptSource(pdb.encryption, source, nonce)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(version), &svr.Version)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	err = json.Unmarshal([]byte(metadata), &svr.Metadata)
+	if err != nil {
+		return SavedVersionedResource{}, false, err
+	}
+
+	return svr, true, nil
+}
+
+func (pdb *pipelineDB) getLastBuiltVersionID(jobName string, inputName string) (int, bool, error) {
+	var id sql.NullInt64
+
+	err := pdb.conn.QueryRow(`
+		SELECT MAX(i.versioned_resource_id)
+		FROM build_inputs i, builds b, jobs j
+		WHERE i.build_id = b.id
+		AND b.job_id = j.id
+		AND j.name = $1
+		AND j.pipeline_id = $2
+		AND i.name = $3
+	`, jobName, pdb.ID, inputName).Scan(&id)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return int(id.Int64), id.Valid, nil
+}
+
 func (pdb *pipelineDB) PauseJob(job string) error {
 	return pdb.updatePausedJob(job, true)
 }
@@ -1598,6 +1793,13 @@ func (pdb *pipelineDB) GetAllJobBuilds(job string) ([]Build, error) {
 	return bs, nil
 }
 
+func (pdb *pipelineDB) GetJobBuilds(job string, page Page) ([]Build, Pagination, error) {
+	return getBuildsWithPagination(pdb.conn, pdb.encryption, `
+		j.name = $1
+		AND j.pipeline_id = $2
+	`, []interface{}{job, pdb.ID}, page)
+}
+
 func (pdb *pipelineDB) GetJobFinishedAndNextBuild(job string) (*Build, *Build, error) {
 	var finished *Build
 	var next *Build
@@ -1664,6 +1866,7 @@ func (pdb *pipelineDB) getJob(tx *sql.Tx, name string) (SavedJob, error) {
 	}
 
 	job.PipelineName = pdb.Name
+	job.TeamName = pdb.TeamName
 
 	return job, nil
 }
@@ -1681,6 +1884,7 @@ func (pdb *pipelineDB) getJobByID(id int) (SavedJob, error) {
 	}
 
 	job.PipelineName = pdb.Name
+	job.TeamName = pdb.TeamName
 
 	return job, nil
 }
@@ -1691,11 +1895,12 @@ func (pdb *pipelineDB) scanBuild(row scannable) (Build, error) {
 	var jobID int
 	var status string
 	var scheduled bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, jobName, pipelineName, teamName sql.NullString
+	var nonce *string
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &jobName, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &nonce, &startTime, &endTime, &jobName, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, ErrNoBuild
@@ -1704,17 +1909,23 @@ func (pdb *pipelineDB) scanBuild(row scannable) (Build, error) {
 		return Build{}, err
 	}
 
+	metadata, err := decryptEngineMetadata(pdb.encryption, engineMetadata, nonce)
+	if err != nil {
+		return Build{}, err
+	}
+
 	build := Build{
 		ID:           id,
 		Name:         name,
 		JobID:        jobID,
 		JobName:      jobName.String,
 		PipelineName: pipelineName.String,
+		TeamName:     teamName.String,
 		Status:       Status(status),
 		Scheduled:    scheduled,
 
 		Engine:         engine.String,
-		EngineMetadata: engineMetadata.String,
+		EngineMetadata: metadata,
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/pivotal-golang/lager"
 )
 
@@ -11,8 +13,9 @@ import (
 
 type PipelineDBFactory interface {
 	Build(pipeline SavedPipeline) PipelineDB
-	BuildWithName(pipelineName string) (PipelineDB, error)
+	BuildWithTeamNameAndName(teamName string, pipelineName string) (PipelineDB, error)
 	BuildDefault() (PipelineDB, error)
+	BuildDefaultForTeam(teamName string) (PipelineDB, error)
 }
 
 type pipelineDBFactory struct {
@@ -21,6 +24,8 @@ type pipelineDBFactory struct {
 	conn        *sql.DB
 	bus         *notificationsBus
 	pipelinesDB PipelinesDB
+
+	encryption encryption.Strategy
 }
 
 func NewPipelineDBFactory(
@@ -28,6 +33,7 @@ func NewPipelineDBFactory(
 	sqldbConnection *sql.DB,
 	bus *notificationsBus,
 	pipelinesDB PipelinesDB,
+	encryptionStrategy encryption.Strategy,
 ) *pipelineDBFactory {
 	return &pipelineDBFactory{
 		logger: logger,
@@ -35,11 +41,13 @@ func NewPipelineDBFactory(
 		conn:        sqldbConnection,
 		bus:         bus,
 		pipelinesDB: pipelinesDB,
+
+		encryption: encryptionStrategy,
 	}
 }
 
-func (pdbf *pipelineDBFactory) BuildWithName(pipelineName string) (PipelineDB, error) {
-	savedPipeline, err := pdbf.pipelinesDB.GetPipelineByName(pipelineName)
+func (pdbf *pipelineDBFactory) BuildWithTeamNameAndName(teamName string, pipelineName string) (PipelineDB, error) {
+	savedPipeline, err := pdbf.pipelinesDB.GetPipelineByTeamNameAndName(teamName, pipelineName)
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +62,8 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 		conn: pdbf.conn,
 		bus:  pdbf.bus,
 
+		encryption: pdbf.encryption,
+
 		SavedPipeline: pipeline,
 	}
 }
@@ -61,21 +71,31 @@ func (pdbf *pipelineDBFactory) Build(pipeline SavedPipeline) PipelineDB {
 var ErrNoPipelines = errors.New("no pipelines configured")
 
 func (pdbf *pipelineDBFactory) BuildDefault() (PipelineDB, error) {
+	return pdbf.BuildDefaultForTeam(atc.DefaultTeamName)
+}
+
+func (pdbf *pipelineDBFactory) BuildDefaultForTeam(teamName string) (PipelineDB, error) {
 	orderedPipelines, err := pdbf.pipelinesDB.GetAllActivePipelines()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(orderedPipelines) < 1 {
-		return nil, ErrNoPipelines
-	}
+	for _, pipeline := range orderedPipelines {
+		if pipeline.TeamName != teamName {
+			continue
+		}
 
-	return &pipelineDB{
-		logger: pdbf.logger,
+		return &pipelineDB{
+			logger: pdbf.logger,
 
-		conn: pdbf.conn,
-		bus:  pdbf.bus,
+			conn: pdbf.conn,
+			bus:  pdbf.bus,
+
+			encryption: pdbf.encryption,
+
+			SavedPipeline: pipeline,
+		}, nil
+	}
 
-		SavedPipeline: orderedPipelines[0],
-	}, nil
+	return nil, ErrNoPipelines
 }
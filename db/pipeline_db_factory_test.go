@@ -4,7 +4,9 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/db/fakes"
 	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager/lagertest"
@@ -32,7 +34,7 @@ var _ = Describe("PipelineDBFactory", func() {
 
 		pipelinesDB = new(fakes.FakePipelinesDB)
 
-		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, pipelinesDB)
+		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, pipelinesDB, encryption.NoEncryption{})
 	})
 
 	AfterEach(func() {
@@ -46,22 +48,33 @@ var _ = Describe("PipelineDBFactory", func() {
 	})
 
 	Describe("default pipeline", func() {
-		It("is the first one returned from the DB", func() {
+		It("is the first one of the main team returned from the DB", func() {
+			savedOtherTeamPipeline := db.SavedPipeline{
+				ID:       3,
+				TeamName: "some-team",
+				Pipeline: db.Pipeline{
+					Name: "some-team-pipeline",
+				},
+			}
+
 			savedPipelineOne := db.SavedPipeline{
-				ID: 1,
+				ID:       1,
+				TeamName: atc.DefaultTeamName,
 				Pipeline: db.Pipeline{
 					Name: "a-pipeline",
 				},
 			}
 
 			savedPipelineTwo := db.SavedPipeline{
-				ID: 2,
+				ID:       2,
+				TeamName: atc.DefaultTeamName,
 				Pipeline: db.Pipeline{
 					Name: "another-pipeline",
 				},
 			}
 
 			pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
+				savedOtherTeamPipeline,
 				savedPipelineOne,
 				savedPipelineTwo,
 			}, nil)
@@ -72,6 +85,34 @@ var _ = Describe("PipelineDBFactory", func() {
 			Ω(defaultPipelineDB.GetPipelineName()).Should(Equal("a-pipeline"))
 		})
 
+		It("can be the first one of another team", func() {
+			savedPipeline := db.SavedPipeline{
+				ID:       1,
+				TeamName: atc.DefaultTeamName,
+				Pipeline: db.Pipeline{
+					Name: "a-pipeline",
+				},
+			}
+
+			savedOtherTeamPipeline := db.SavedPipeline{
+				ID:       2,
+				TeamName: "some-team",
+				Pipeline: db.Pipeline{
+					Name: "some-team-pipeline",
+				},
+			}
+
+			pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
+				savedPipeline,
+				savedOtherTeamPipeline,
+			}, nil)
+
+			teamPipelineDB, err := pipelineDBFactory.BuildDefaultForTeam("some-team")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(teamPipelineDB.GetPipelineName()).Should(Equal("some-team-pipeline"))
+		})
+
 		Context("when there are no pipelines", func() {
 			BeforeEach(func() {
 				pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{}, nil)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/event"
 	"github.com/lib/pq"
 	"github.com/pivotal-golang/lager/lagertest"
@@ -32,8 +33,8 @@ var _ = Describe("PipelineDB", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener)
 
-		sqlDB = db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus)
-		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB)
+		sqlDB = db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus, encryption.NoEncryption{})
+		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB, encryption.NoEncryption{})
 	})
 
 	AfterEach(func() {
@@ -139,14 +140,14 @@ var _ = Describe("PipelineDB", func() {
 	)
 
 	BeforeEach(func() {
-		_, err := sqlDB.SaveConfig("a-pipeline-name", config, 0, db.PipelineUnpaused)
+		_, err := sqlDB.SaveConfig(atc.DefaultTeamName, "a-pipeline-name", config, 0, db.PipelineUnpaused)
 		Ω(err).ShouldNot(HaveOccurred())
-		savedPipeline, err := sqlDB.GetPipelineByName("a-pipeline-name")
+		savedPipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, "a-pipeline-name")
 		Ω(err).ShouldNot(HaveOccurred())
 
-		_, err = sqlDB.SaveConfig("other-pipeline-name", otherConfig, 0, db.PipelineUnpaused)
+		_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "other-pipeline-name", otherConfig, 0, db.PipelineUnpaused)
 		Ω(err).ShouldNot(HaveOccurred())
-		otherSavedPipeline, err := sqlDB.GetPipelineByName("other-pipeline-name")
+		otherSavedPipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, "other-pipeline-name")
 		Ω(err).ShouldNot(HaveOccurred())
 
 		pipelineDB = pipelineDBFactory.Build(savedPipeline)
@@ -155,10 +156,10 @@ var _ = Describe("PipelineDB", func() {
 
 	Describe("destroying a pipeline", func() {
 		It("can be deleted", func() {
-			_, err := sqlDB.SaveConfig("a-pipeline-that-will-be-deleted", config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, "a-pipeline-that-will-be-deleted", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			fetchedPipeline, err := sqlDB.GetPipelineByName("a-pipeline-that-will-be-deleted")
+			fetchedPipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, "a-pipeline-that-will-be-deleted")
 			Ω(err).ShouldNot(HaveOccurred())
 
 			fetchedPipelineDB := pipelineDBFactory.Build(fetchedPipeline)
@@ -211,7 +212,7 @@ var _ = Describe("PipelineDB", func() {
 
 	Describe("Pausing and unpausing a pipeline", func() {
 		It("starts out as unpaused", func() {
-			pipeline, err := sqlDB.GetPipelineByName("a-pipeline-name")
+			pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, "a-pipeline-name")
 			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(pipeline.Paused).Should(BeFalse())
@@ -304,9 +305,9 @@ var _ = Describe("PipelineDB", func() {
 			})
 
 			By("being able to update the config with a valid config")
-			_, err = sqlDB.SaveConfig("a-pipeline-name", updatedConfig, configVersion, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "a-pipeline-name", updatedConfig, configVersion, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
-			_, err = sqlDB.SaveConfig("other-pipeline-name", updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "other-pipeline-name", updatedConfig, otherConfigVersion, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			By("returning the updated config")
@@ -445,7 +446,7 @@ var _ = Describe("PipelineDB", func() {
 					},
 				}
 
-				Ω(pipelineDB.GetLatestInputVersions(jobBuildInputs)).Should(Equal([]db.BuildInput{
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
 					{
 						Name:              "some-input-name",
 						VersionedResource: savedVR2.VersionedResource,
@@ -455,7 +456,7 @@ var _ = Describe("PipelineDB", func() {
 				err = pipelineDB.DisableVersionedResource(savedVR2.ID)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				Ω(pipelineDB.GetLatestInputVersions(jobBuildInputs)).Should(Equal([]db.BuildInput{
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
 					{
 						Name:              "some-input-name",
 						VersionedResource: savedVR1.VersionedResource,
@@ -466,13 +467,13 @@ var _ = Describe("PipelineDB", func() {
 				Ω(err).ShouldNot(HaveOccurred())
 
 				// no versions
-				_, err = pipelineDB.GetLatestInputVersions(jobBuildInputs)
+				_, err = pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)
 				Ω(err).Should(HaveOccurred())
 
 				err = pipelineDB.EnableVersionedResource(savedVR1.ID)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				Ω(pipelineDB.GetLatestInputVersions(jobBuildInputs)).Should(Equal([]db.BuildInput{
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
 					{
 						Name:              "some-input-name",
 						VersionedResource: savedVR1.VersionedResource,
@@ -482,7 +483,81 @@ var _ = Describe("PipelineDB", func() {
 				err = pipelineDB.EnableVersionedResource(savedVR2.ID)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				Ω(pipelineDB.GetLatestInputVersions(jobBuildInputs)).Should(Equal([]db.BuildInput{
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR2.VersionedResource,
+					},
+				}))
+			})
+		})
+
+		Describe("pinning and unpinning resources", func() {
+			var resource db.SavedResource
+			var savedVR1, savedVR2 db.SavedVersionedResource
+
+			jobBuildInputs := []atc.JobInput{
+				{
+					Name:     "some-input-name",
+					Resource: "some-resource",
+				},
+			}
+
+			BeforeEach(func() {
+				var err error
+				resource, err = pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				for i, savedVR := range []*db.SavedVersionedResource{&savedVR1, &savedVR2} {
+					err = pipelineDB.SaveResourceVersions(atc.ResourceConfig{
+						Name:   "some-resource",
+						Type:   "some-type",
+						Source: atc.Source{"some": "source"},
+					}, []atc.Version{{"version": fmt.Sprintf("%d", i+1)}})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					*savedVR, err = pipelineDB.GetLatestVersionedResource(resource)
+					Ω(err).ShouldNot(HaveOccurred())
+				}
+			})
+
+			It("starts out as unpinned", func() {
+				Ω(resource.Pinned()).Should(BeFalse())
+			})
+
+			It("returns an error if the version is bogus", func() {
+				err := pipelineDB.PinResource("some-resource", 42)
+				Ω(err).Should(Equal(db.ErrNoVersion))
+			})
+
+			It("returns an error if the version belongs to another resource", func() {
+				err := otherPipelineDB.PinResource("some-resource", savedVR1.ID)
+				Ω(err).Should(Equal(db.ErrNoVersion))
+			})
+
+			It("resolves the resource to its pinned version until it is unpinned", func() {
+				err := pipelineDB.PinResource("some-resource", savedVR1.ID)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				pinnedResource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(pinnedResource.PinnedVersionID).Should(Equal(savedVR1.ID))
+
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
+					{
+						Name:              "some-input-name",
+						VersionedResource: savedVR1.VersionedResource,
+					},
+				}))
+
+				err = pipelineDB.UnpinResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				unpinnedResource, err := pipelineDB.GetResource("some-resource")
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(unpinnedResource.Pinned()).Should(BeFalse())
+
+				Ω(pipelineDB.GetLatestInputVersions("some-job", jobBuildInputs)).Should(Equal([]db.BuildInput{
 					{
 						Name:              "some-input-name",
 						VersionedResource: savedVR2.VersionedResource,
@@ -539,6 +614,42 @@ var _ = Describe("PipelineDB", func() {
 			builds, err := pipelineDB.GetAllJobBuilds("some-job")
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(builds).Should(BeEmpty())
+
+			builds, pagination, err := pipelineDB.GetJobBuilds("some-job", db.Page{Limit: 10})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(builds).Should(BeEmpty())
+			Ω(pagination).Should(Equal(db.Pagination{}))
+		})
+
+		It("can page through the builds of a job", func() {
+			build1, err := pipelineDB.CreateJobBuild("some-job")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = pipelineDB.CreateJobBuild("some-other-job")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			build2, err := pipelineDB.CreateJobBuild("some-job")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			build3, err := pipelineDB.CreateJobBuild("some-job")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			builds, pagination, err := pipelineDB.GetJobBuilds("some-job", db.Page{Limit: 2})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(builds).Should(Equal([]db.Build{build3, build2}))
+			Ω(pagination.Previous).Should(BeNil())
+			Ω(pagination.Next).Should(Equal(&db.Page{Since: build2.ID, Limit: 2}))
+
+			builds, pagination, err = pipelineDB.GetJobBuilds("some-job", *pagination.Next)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(builds).Should(Equal([]db.Build{build1}))
+			Ω(pagination.Previous).Should(Equal(&db.Page{Until: build1.ID, Limit: 2}))
+			Ω(pagination.Next).Should(BeNil())
+
+			builds, pagination, err = pipelineDB.GetJobBuilds("some-job", *pagination.Previous)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(builds).Should(Equal([]db.Build{build3, build2}))
+			Ω(pagination.Previous).Should(BeNil())
 		})
 
 		It("initially has no current build for a job", func() {
@@ -1629,7 +1740,7 @@ var _ = Describe("PipelineDB", func() {
 				_, err = otherPipelineDB.CreateJobBuild("shared-job")
 				Ω(err).ShouldNot(HaveOccurred())
 
-				_, err = pipelineDB.GetLatestInputVersions([]atc.JobInput{
+				_, err = pipelineDB.GetLatestInputVersions("a-job", []atc.JobInput{
 					{
 						Name:     "input-1",
 						Resource: "resource-1",
@@ -1698,7 +1809,7 @@ var _ = Describe("PipelineDB", func() {
 				})
 				Ω(err).ShouldNot(HaveOccurred())
 
-				Ω(pipelineDB.GetLatestInputVersions([]atc.JobInput{
+				Ω(pipelineDB.GetLatestInputVersions("a-job", []atc.JobInput{
 					{
 						Name:     "input-1",
 						Resource: "resource-1",
@@ -1752,7 +1863,7 @@ var _ = Describe("PipelineDB", func() {
 
 				// do NOT save resource-2 as an output of job-2
 
-				Ω(pipelineDB.GetLatestInputVersions([]atc.JobInput{
+				Ω(pipelineDB.GetLatestInputVersions("a-job", []atc.JobInput{
 					{
 						Name:     "input-1",
 						Resource: "resource-1",
@@ -1782,7 +1893,7 @@ var _ = Describe("PipelineDB", func() {
 				})
 				Ω(err).ShouldNot(HaveOccurred())
 
-				Ω(pipelineDB.GetLatestInputVersions([]atc.JobInput{
+				Ω(pipelineDB.GetLatestInputVersions("a-job", []atc.JobInput{
 					{
 						Name:     "input-1",
 						Resource: "resource-1",
@@ -1836,7 +1947,7 @@ var _ = Describe("PipelineDB", func() {
 					})
 					Ω(err).ShouldNot(HaveOccurred())
 
-					Ω(pipelineDB.GetLatestInputVersions([]atc.JobInput{
+					Ω(pipelineDB.GetLatestInputVersions("a-job", []atc.JobInput{
 						{
 							Name:     "input-1",
 							Resource: "resource-1",
@@ -1859,6 +1970,153 @@ var _ = Describe("PipelineDB", func() {
 					}))
 				}
 			})
+
+			Describe("with version: every", func() {
+				var savedVRs []db.SavedVersionedResource
+
+				everyInput := []atc.JobInput{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Version:  &atc.VersionConfig{Every: true},
+					},
+				}
+
+				BeforeEach(func() {
+					savedVRs = nil
+
+					outputBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					for _, version := range []string{"1", "2", "3"} {
+						savedVR, err := pipelineDB.SaveBuildOutput(outputBuild.ID, db.VersionedResource{
+							Resource: "some-resource",
+							Type:     "some-type",
+							Version:  db.Version{"version": version},
+						})
+						Ω(err).ShouldNot(HaveOccurred())
+
+						savedVRs = append(savedVRs, savedVR)
+					}
+				})
+
+				It("resolves to the latest version when the job has never built with the input", func() {
+					Ω(pipelineDB.GetLatestInputVersions("some-job", everyInput)).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[2].VersionedResource},
+					}))
+				})
+
+				It("resolves to the latest version when the input has been renamed since the job built with it", func() {
+					build, err := pipelineDB.CreateJobBuild("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name:              "some-old-input",
+						VersionedResource: savedVRs[0].VersionedResource,
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(pipelineDB.GetLatestInputVersions("some-job", everyInput)).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[2].VersionedResource},
+					}))
+				})
+
+				It("resolves to the oldest version the job has not built with yet", func() {
+					build, err := pipelineDB.CreateJobBuild("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name:              "some-input",
+						VersionedResource: savedVRs[0].VersionedResource,
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(pipelineDB.GetLatestInputVersions("some-job", everyInput)).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[1].VersionedResource},
+					}))
+
+					By("not considering the builds of other jobs")
+					Ω(pipelineDB.GetLatestInputVersions("some-other-job", everyInput)).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[2].VersionedResource},
+					}))
+				})
+
+				It("resolves to the latest version once every version has been built", func() {
+					build, err := pipelineDB.CreateJobBuild("some-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = pipelineDB.SaveBuildInput(build.ID, db.BuildInput{
+						Name:              "some-input",
+						VersionedResource: savedVRs[2].VersionedResource,
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(pipelineDB.GetLatestInputVersions("some-job", everyInput)).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[2].VersionedResource},
+					}))
+				})
+			})
+
+			Describe("with a specific version", func() {
+				It("resolves to that version if it exists", func() {
+					var savedVRs []db.SavedVersionedResource
+
+					outputBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					for _, version := range []string{"1", "2"} {
+						savedVR, err := pipelineDB.SaveBuildOutput(outputBuild.ID, db.VersionedResource{
+							Resource: "some-resource",
+							Type:     "some-type",
+							Version:  db.Version{"version": version},
+						})
+						Ω(err).ShouldNot(HaveOccurred())
+
+						savedVRs = append(savedVRs, savedVR)
+					}
+
+					Ω(pipelineDB.GetLatestInputVersions("some-job", []atc.JobInput{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "1"}},
+						},
+					})).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVRs[0].VersionedResource},
+					}))
+
+					_, err = pipelineDB.GetLatestInputVersions("some-job", []atc.JobInput{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"version": "3"}},
+						},
+					})
+					Ω(err).Should(Equal(db.ErrNoVersions))
+				})
+
+				It("matches on the given fields regardless of their order or any other fields", func() {
+					outputBuild, err := pipelineDB.CreateJobBuild("some-other-job")
+					Ω(err).ShouldNot(HaveOccurred())
+
+					savedVR, err := pipelineDB.SaveBuildOutput(outputBuild.ID, db.VersionedResource{
+						Resource: "some-resource",
+						Type:     "some-type",
+						Version:  db.Version{"ref": "abc", "branch": "master", "timestamp": "123"},
+					})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(pipelineDB.GetLatestInputVersions("some-job", []atc.JobInput{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Version:  &atc.VersionConfig{Pinned: atc.Version{"branch": "master", "ref": "abc"}},
+						},
+					})).Should(Equal([]db.BuildInput{
+						{Name: "some-input", VersionedResource: savedVR.VersionedResource},
+					}))
+				})
+			})
 		})
 
 		It("can report a job's latest running and finished builds", func() {
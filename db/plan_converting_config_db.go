@@ -6,8 +6,8 @@ type PlanConvertingConfigDB struct {
 	NestedDB ConfigDB
 }
 
-func (db PlanConvertingConfigDB) GetConfig(pipelineName string) (atc.Config, ConfigVersion, error) {
-	config, version, err := db.NestedDB.GetConfig(pipelineName)
+func (db PlanConvertingConfigDB) GetConfig(teamName string, pipelineName string) (atc.Config, ConfigVersion, error) {
+	config, version, err := db.NestedDB.GetConfig(teamName, pipelineName)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -15,8 +15,8 @@ func (db PlanConvertingConfigDB) GetConfig(pipelineName string) (atc.Config, Con
 	return db.convertJobsToPlan(config), version, nil
 }
 
-func (db PlanConvertingConfigDB) SaveConfig(pipelineName string, config atc.Config, version ConfigVersion, pausedState PipelinePausedState) (bool, error) {
-	return db.NestedDB.SaveConfig(pipelineName, db.convertJobsToPlan(config), version, pausedState)
+func (db PlanConvertingConfigDB) SaveConfig(teamName string, pipelineName string, config atc.Config, version ConfigVersion, pausedState PipelinePausedState) (bool, error) {
+	return db.NestedDB.SaveConfig(teamName, pipelineName, db.convertJobsToPlan(config), version, pausedState)
 }
 
 func (db PlanConvertingConfigDB) convertJobsToPlan(config atc.Config) atc.Config {
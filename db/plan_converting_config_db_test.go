@@ -77,13 +77,14 @@ var _ = Describe("PlanConvertingConfigDB", func() {
 		var getErr error
 
 		JustBeforeEach(func() {
-			gotConfig, gotVersion, getErr = configDB.GetConfig(pipelineName)
+			gotConfig, gotVersion, getErr = configDB.GetConfig(atc.DefaultTeamName, pipelineName)
 		})
 
 		It("calls GetConfig with the correct arguments", func() {
 			Ω(nestedDB.GetConfigCallCount()).Should(Equal(1))
 
-			name := nestedDB.GetConfigArgsForCall(0)
+			teamName, name := nestedDB.GetConfigArgsForCall(0)
+			Ω(teamName).Should(Equal(atc.DefaultTeamName))
 			Ω(name).Should(Equal(pipelineName))
 		})
 
@@ -150,7 +151,7 @@ var _ = Describe("PlanConvertingConfigDB", func() {
 		})
 
 		JustBeforeEach(func() {
-			_, saveErr = configDB.SaveConfig(pipelineName, configToSave, versionToSave, pausedState)
+			_, saveErr = configDB.SaveConfig(atc.DefaultTeamName, pipelineName, configToSave, versionToSave, pausedState)
 		})
 
 		Context("when the given config contains jobs with inputs/outputs/build", func() {
@@ -165,7 +166,8 @@ var _ = Describe("PlanConvertingConfigDB", func() {
 			It("converts them to a plan before saving in the nested config db", func() {
 				Ω(nestedDB.SaveConfigCallCount()).Should(Equal(1))
 
-				name, savedConfig, savedID, savedPausedState := nestedDB.SaveConfigArgsForCall(0)
+				teamName, name, savedConfig, savedID, savedPausedState := nestedDB.SaveConfigArgsForCall(0)
+				Ω(teamName).Should(Equal(atc.DefaultTeamName))
 				Ω(name).Should(Equal(pipelineName))
 				Ω(savedConfig).Should(Equal(planBasedConfig))
 				Ω(savedID).Should(Equal(ConfigVersion(42)))
@@ -197,7 +199,8 @@ var _ = Describe("PlanConvertingConfigDB", func() {
 			It("passes them through to the nested config db", func() {
 				Ω(nestedDB.SaveConfigCallCount()).Should(Equal(1))
 
-				savedName, savedConfig, savedID, savedPausedState := nestedDB.SaveConfigArgsForCall(0)
+				savedTeamName, savedName, savedConfig, savedID, savedPausedState := nestedDB.SaveConfigArgsForCall(0)
+				Ω(savedTeamName).Should(Equal(atc.DefaultTeamName))
 				Ω(savedName).Should(Equal(pipelineName))
 				Ω(savedConfig).Should(Equal(planBasedConfig))
 				Ω(savedID).Should(Equal(ConfigVersion(42)))
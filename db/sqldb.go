@@ -11,6 +11,7 @@ import (
 	"github.com/pivotal-golang/lager"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/db/encryption"
 	"github.com/concourse/atc/event"
 )
 
@@ -19,39 +20,101 @@ type SQLDB struct {
 
 	conn *sql.DB
 	bus  *notificationsBus
+
+	encryption encryption.Strategy
 }
 
-const buildColumns = "id, name, job_id, status, scheduled, engine, engine_metadata, start_time, end_time"
-const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.start_time, b.end_time, j.name as job_name, p.name as pipeline_name"
+const buildColumns = "id, name, job_id, status, scheduled, engine, engine_metadata, nonce, start_time, end_time"
+const qualifiedBuildColumns = "b.id, b.name, b.job_id, b.status, b.scheduled, b.engine, b.engine_metadata, b.nonce, b.start_time, b.end_time, j.name as job_name, p.name as pipeline_name, (SELECT t.name FROM teams t WHERE t.id = b.team_id) as team_name"
+
+const pipelineColumns = "p.id, p.name, p.config, p.nonce, p.version, p.paused, t.name as team_name"
+
+// retiredWorkerState marks the tombstone left behind by a retired worker, so
+// that a stray heartbeat does not bring it back as running.
+const retiredWorkerState = "retired"
+
+// DeadWorkerExpiry is how long stalled workers, and the tombstones of retired
+// workers, are kept after their last heartbeat.
+const DeadWorkerExpiry = 24 * time.Hour
+
+const workerColumns = "w.name, w.addr, w.active_containers, w.max_containers, w.resource_types, w.platform, w.tags, COALESCE(t.name, ''), w.state, w.registered_at, w.last_heartbeat_at"
 
 func NewSQL(
 	logger lager.Logger,
 	sqldbConnection *sql.DB,
 	bus *notificationsBus,
+	encryptionStrategy encryption.Strategy,
 ) *SQLDB {
 	return &SQLDB{
 		logger: logger,
 
 		conn: sqldbConnection,
 		bus:  bus,
+
+		encryption: encryptionStrategy,
 	}
 }
 
-func (db *SQLDB) GetPipelineByName(pipelineName string) (SavedPipeline, error) {
-	row := db.conn.QueryRow(`
-		SELECT id, name, config, version, paused
-		FROM pipelines
+func (db *SQLDB) GetTeams() ([]SavedTeam, error) {
+	rows, err := db.conn.Query(`
+		SELECT id, name
+		FROM teams
+		ORDER BY name ASC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	teams := []SavedTeam{}
+
+	for rows.Next() {
+		team, err := scanTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+func (db *SQLDB) GetTeamByName(teamName string) (SavedTeam, error) {
+	return scanTeam(db.conn.QueryRow(`
+		SELECT id, name
+		FROM teams
 		WHERE name = $1
-	`, pipelineName)
+	`, teamName))
+}
 
-	return scanPipeline(row)
+func (db *SQLDB) SaveTeam(team Team) (SavedTeam, error) {
+	return scanTeam(db.conn.QueryRow(`
+		INSERT INTO teams (name)
+		VALUES ($1)
+		RETURNING id, name
+	`, team.Name))
+}
+
+func (db *SQLDB) GetPipelineByTeamNameAndName(teamName string, pipelineName string) (SavedPipeline, error) {
+	row := db.conn.QueryRow(`
+		SELECT `+pipelineColumns+`
+		FROM pipelines p
+		INNER JOIN teams t ON p.team_id = t.id
+		WHERE t.name = $1
+		AND p.name = $2
+	`, teamName, pipelineName)
+
+	return scanPipeline(row, db.encryption)
 }
 
 func (db *SQLDB) GetAllActivePipelines() ([]SavedPipeline, error) {
 	rows, err := db.conn.Query(`
-		SELECT id, name, config, version, paused
-		FROM pipelines
-		ORDER BY ordering
+		SELECT ` + pipelineColumns + `
+		FROM pipelines p
+		INNER JOIN teams t ON p.team_id = t.id
+		ORDER BY p.ordering
 	`)
 	if err != nil {
 		return nil, err
@@ -63,7 +126,7 @@ func (db *SQLDB) GetAllActivePipelines() ([]SavedPipeline, error) {
 
 	for rows.Next() {
 
-		pipeline, err := scanPipeline(rows)
+		pipeline, err := scanPipeline(rows, db.encryption)
 
 		if err != nil {
 			return nil, err
@@ -75,7 +138,7 @@ func (db *SQLDB) GetAllActivePipelines() ([]SavedPipeline, error) {
 	return pipelines, nil
 }
 
-func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
+func (db *SQLDB) OrderPipelines(teamName string, pipelineNames []string) error {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return err
@@ -83,12 +146,25 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 
 	defer tx.Rollback()
 
+	var teamID int
+
+	err = tx.QueryRow(`
+			SELECT id
+			FROM teams
+			WHERE name = $1
+	`, teamName).Scan(&teamID)
+
+	if err != nil {
+		return err
+	}
+
 	var pipelineCount int
 
 	err = tx.QueryRow(`
 			SELECT COUNT(1)
 			FROM pipelines
-	`).Scan(&pipelineCount)
+			WHERE team_id = $1
+	`, teamID).Scan(&pipelineCount)
 
 	if err != nil {
 		return err
@@ -97,7 +173,8 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 	_, err = tx.Exec(`
 		UPDATE pipelines
 		SET ordering = $1
-	`, pipelineCount+1)
+		WHERE team_id = $2
+	`, pipelineCount+1, teamID)
 
 	if err != nil {
 		return err
@@ -108,7 +185,8 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 			UPDATE pipelines
 			SET ordering = $1
 			WHERE name = $2
-		`, i, name)
+			AND team_id = $3
+		`, i, name, teamID)
 
 		if err != nil {
 			return err
@@ -119,15 +197,16 @@ func (db *SQLDB) OrderPipelines(pipelineNames []string) error {
 }
 
 func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, error) {
-	var configBlob []byte
+	var configBlob string
+	var nonce *string
 	var version int
 	err := db.conn.QueryRow(`
-			SELECT p.config, p.version
+			SELECT p.config, p.nonce, p.version
 			FROM builds b
 			INNER JOIN jobs j ON b.job_id = j.id
 			INNER JOIN pipelines p ON j.pipeline_id = p.id
 			WHERE b.ID = $1
-		`, buildID).Scan(&configBlob, &version)
+		`, buildID).Scan(&configBlob, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, nil
@@ -136,8 +215,7 @@ func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, err
 		}
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	config, err := decryptConfig(db.encryption, configBlob, nonce)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -145,14 +223,17 @@ func (db *SQLDB) GetConfigByBuildID(buildID int) (atc.Config, ConfigVersion, err
 	return config, ConfigVersion(version), nil
 }
 
-func (db *SQLDB) GetConfig(pipelineName string) (atc.Config, ConfigVersion, error) {
-	var configBlob []byte
+func (db *SQLDB) GetConfig(teamName string, pipelineName string) (atc.Config, ConfigVersion, error) {
+	var configBlob string
+	var nonce *string
 	var version int
 	err := db.conn.QueryRow(`
-		SELECT config, version
-		FROM pipelines
-		WHERE name = $1
-	`, pipelineName).Scan(&configBlob, &version)
+		SELECT p.config, p.nonce, p.version
+		FROM pipelines p
+		INNER JOIN teams t ON p.team_id = t.id
+		WHERE t.name = $1
+		AND p.name = $2
+	`, teamName, pipelineName).Scan(&configBlob, &nonce, &version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return atc.Config{}, 0, nil
@@ -161,8 +242,7 @@ func (db *SQLDB) GetConfig(pipelineName string) (atc.Config, ConfigVersion, erro
 		}
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	config, err := decryptConfig(db.encryption, configBlob, nonce)
 	if err != nil {
 		return atc.Config{}, 0, err
 	}
@@ -194,12 +274,17 @@ func (state PipelinePausedState) Bool() *bool {
 	}
 }
 
-func (db *SQLDB) SaveConfig(pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState) (bool, error) {
+func (db *SQLDB) SaveConfig(teamName string, pipelineName string, config atc.Config, from ConfigVersion, pausedState PipelinePausedState) (bool, error) {
 	payload, err := json.Marshal(config)
 	if err != nil {
 		return false, err
 	}
 
+	encryptedPayload, nonce, err := db.encryption.Encrypt(payload)
+	if err != nil {
+		return false, err
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return false, err
@@ -207,12 +292,27 @@ func (db *SQLDB) SaveConfig(pipelineName string, config atc.Config, from ConfigV
 
 	defer tx.Rollback()
 
+	var teamID int
+	err = tx.QueryRow(`
+		SELECT id
+		FROM teams
+		WHERE name = $1
+	`, teamName).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, ErrNoTeam
+		}
+
+		return false, err
+	}
+
 	var existingConfig int
 	err = tx.QueryRow(`
 		SELECT COUNT(1)
 		FROM pipelines
 		WHERE name = $1
-	`, pipelineName).Scan(&existingConfig)
+		AND team_id = $2
+	`, pipelineName, teamID).Scan(&existingConfig)
 	if err != nil {
 		return false, err
 	}
@@ -222,17 +322,19 @@ func (db *SQLDB) SaveConfig(pipelineName string, config atc.Config, from ConfigV
 	if pausedState == PipelineNoChange {
 		result, err = tx.Exec(`
 				UPDATE pipelines
-				SET config = $1, version = nextval('config_version_seq')
-				WHERE name = $2
-					AND version = $3
-			`, payload, pipelineName, from)
+				SET config = $1, nonce = $2, encryption_key_id = $3, version = nextval('config_version_seq')
+				WHERE name = $4
+					AND team_id = $5
+					AND version = $6
+			`, encryptedPayload, nonce, db.encryption.KeyID(), pipelineName, teamID, from)
 	} else {
 		result, err = tx.Exec(`
 				UPDATE pipelines
-				SET config = $1, version = nextval('config_version_seq'), paused = $2
-				WHERE name = $3
-					AND version = $4
-			`, payload, pausedState.Bool(), pipelineName, from)
+				SET config = $1, nonce = $2, encryption_key_id = $3, version = nextval('config_version_seq'), paused = $4
+				WHERE name = $5
+					AND team_id = $6
+					AND version = $7
+			`, encryptedPayload, nonce, db.encryption.KeyID(), pausedState.Bool(), pipelineName, teamID, from)
 	}
 
 	if err != nil {
@@ -257,9 +359,9 @@ func (db *SQLDB) SaveConfig(pipelineName string, config atc.Config, from ConfigV
 			created = true
 
 			_, err := tx.Exec(`
-			INSERT INTO pipelines (name, config, version, ordering, paused)
-			VALUES ($1, $2, nextval('config_version_seq'), (SELECT COUNT(1) + 1 FROM pipelines), $3)
-		`, pipelineName, payload, pausedState.Bool())
+			INSERT INTO pipelines (name, config, nonce, encryption_key_id, version, ordering, paused, team_id)
+			VALUES ($1, $2, $3, $4, nextval('config_version_seq'), (SELECT COUNT(1) + 1 FROM pipelines WHERE team_id = $6), $5, $6)
+		`, pipelineName, encryptedPayload, nonce, db.encryption.KeyID(), pausedState.Bool(), teamID)
 			if err != nil {
 				return false, err
 			}
@@ -322,32 +424,10 @@ func (db *SQLDB) GetPipe(pipeGUID string) (Pipe, error) {
 	return pipe, nil
 }
 
-func (db *SQLDB) GetAllBuilds() ([]Build, error) {
-	rows, err := db.conn.Query(`
-		SELECT ` + qualifiedBuildColumns + `
-		FROM builds b
-		LEFT OUTER JOIN jobs j ON b.job_id = j.id
-		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
-		ORDER BY b.id DESC
-	`)
-	if err != nil {
-		return nil, err
-	}
-
-	defer rows.Close()
-
-	bs := []Build{}
-
-	for rows.Next() {
-		build, err := scanBuild(rows)
-		if err != nil {
-			return nil, err
-		}
-
-		bs = append(bs, build)
-	}
-
-	return bs, nil
+func (db *SQLDB) GetBuilds(teamName string, page Page) ([]Build, Pagination, error) {
+	return getBuildsWithPagination(db.conn, db.encryption, `
+		($1 = '' OR b.team_id = (SELECT id FROM teams WHERE name = $1))
+	`, []interface{}{teamName}, page)
 }
 
 func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
@@ -367,7 +447,7 @@ func (db *SQLDB) GetAllStartedBuilds() ([]Build, error) {
 	bs := []Build{}
 
 	for rows.Next() {
-		build, err := scanBuild(rows)
+		build, err := scanBuild(rows, db.encryption)
 		if err != nil {
 			return nil, err
 		}
@@ -385,10 +465,10 @@ func (db *SQLDB) GetBuild(buildID int) (Build, error) {
 		LEFT OUTER JOIN jobs j ON b.job_id = j.id
 		LEFT OUTER JOIN pipelines p ON j.pipeline_id = p.id
 		WHERE b.id = $1
-	`, buildID))
+	`, buildID), db.encryption)
 }
 
-func (db *SQLDB) CreateOneOffBuild() (Build, error) {
+func (db *SQLDB) CreateOneOffBuild(teamName string) (Build, error) {
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return Build{}, err
@@ -396,11 +476,25 @@ func (db *SQLDB) CreateOneOffBuild() (Build, error) {
 
 	defer tx.Rollback()
 
+	var teamID int
+	err = tx.QueryRow(`
+		SELECT id
+		FROM teams
+		WHERE name = $1
+	`, teamName).Scan(&teamID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Build{}, ErrNoTeam
+		}
+
+		return Build{}, err
+	}
+
 	build, err := scanBuild(tx.QueryRow(`
-		INSERT INTO builds (name, status)
-		VALUES (nextval('one_off_name'), 'pending')
-		RETURNING ` + buildColumns + `, null, null
-	`))
+		INSERT INTO builds (name, status, team_id)
+		VALUES (nextval('one_off_name'), 'pending', $1)
+		RETURNING `+buildColumns+`, null, null, $2::text
+	`, teamID, teamName), db.encryption)
 	if err != nil {
 		return Build{}, err
 	}
@@ -421,6 +515,11 @@ func (db *SQLDB) CreateOneOffBuild() (Build, error) {
 }
 
 func (db *SQLDB) StartBuild(buildID int, engine, metadata string) (bool, error) {
+	encryptedMetadata, nonce, err := db.encryption.Encrypt([]byte(metadata))
+	if err != nil {
+		return false, err
+	}
+
 	tx, err := db.conn.Begin()
 	if err != nil {
 		return false, err
@@ -432,11 +531,11 @@ func (db *SQLDB) StartBuild(buildID int, engine, metadata string) (bool, error)
 
 	err = tx.QueryRow(`
 		UPDATE builds
-		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3
+		SET status = 'started', start_time = now(), engine = $2, engine_metadata = $3, nonce = $4, encryption_key_id = $5
 		WHERE id = $1
 		AND status = 'pending'
 		RETURNING start_time
-	`, buildID, engine, metadata).Scan(&startTime)
+	`, buildID, engine, encryptedMetadata, nonce, db.encryption.KeyID()).Scan(&startTime)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -528,7 +627,7 @@ func (db *SQLDB) ErrorBuild(buildID int, cause error) error {
 }
 
 func (db *SQLDB) SaveBuildInput(buildID int, input BuildInput) (SavedVersionedResource, error) {
-	pipelineDBFactory := NewPipelineDBFactory(db.logger, db.conn, db.bus, db)
+	pipelineDBFactory := NewPipelineDBFactory(db.logger, db.conn, db.bus, db, db.encryption)
 	pipelineDB, err := pipelineDBFactory.BuildWithName(input.VersionedResource.PipelineName)
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -538,7 +637,7 @@ func (db *SQLDB) SaveBuildInput(buildID int, input BuildInput) (SavedVersionedRe
 }
 
 func (db *SQLDB) SaveBuildOutput(buildID int, vr VersionedResource) (SavedVersionedResource, error) {
-	pipelineDBFactory := NewPipelineDBFactory(db.logger, db.conn, db.bus, db)
+	pipelineDBFactory := NewPipelineDBFactory(db.logger, db.conn, db.bus, db, db.encryption)
 	pipelineDB, err := pipelineDBFactory.BuildWithName(vr.PipelineName)
 	if err != nil {
 		return SavedVersionedResource{}, err
@@ -548,11 +647,16 @@ func (db *SQLDB) SaveBuildOutput(buildID int, vr VersionedResource) (SavedVersio
 }
 
 func (db *SQLDB) SaveBuildEngineMetadata(buildID int, engineMetadata string) error {
-	_, err := db.conn.Exec(`
+	encryptedMetadata, nonce, err := db.encryption.Encrypt([]byte(engineMetadata))
+	if err != nil {
+		return err
+	}
+
+	_, err = db.conn.Exec(`
 		UPDATE builds
-		SET engine_metadata = $2
+		SET engine_metadata = $2, nonce = $3, encryption_key_id = $4
 		WHERE id = $1
-	`, buildID, engineMetadata)
+	`, buildID, encryptedMetadata, nonce, db.encryption.KeyID())
 	if err != nil {
 		return err
 	}
@@ -634,6 +738,39 @@ func (db *SQLDB) SaveBuildEvent(buildID int, event atc.Event) error {
 	return nil
 }
 
+func (db *SQLDB) ReapBuildEvents(pipelineID int, jobName string, retention atc.BuildLogRetention) (int64, error) {
+	// a build is kept if it satisfies either criterion; a criterion of 0 is
+	// disabled, so the build must be kept by the other one
+	result, err := db.conn.Exec(`
+		DELETE FROM build_events
+		WHERE build_id IN (
+			SELECT b.id
+			FROM builds b
+			INNER JOIN jobs j ON j.id = b.job_id
+			WHERE j.pipeline_id = $1
+			AND j.name = $2
+			AND b.completed
+			AND (
+				$3::int = 0 OR b.id NOT IN (
+					SELECT kept.id
+					FROM builds kept
+					WHERE kept.job_id = j.id
+					ORDER BY kept.id DESC
+					LIMIT $3::int
+				)
+			)
+			AND (
+				$4::int = 0 OR b.end_time < now() - ($4::int * interval '1 day')
+			)
+		)
+	`, pipelineID, jobName, retention.Builds, retention.Days)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 type nonOneRowAffectedError struct {
 	RowsAffected int64
 }
@@ -749,12 +886,60 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) error {
 		return err
 	}
 
+	// a stalled worker that heartbeats again is running, unless told otherwise
+	const updatedState = `CASE
+		WHEN $6 <> '' THEN $6
+		WHEN state = '` + string(atc.WorkerStateStalled) + `' THEN '` + string(atc.WorkerStateRunning) + `'
+		ELSE state
+	END`
+
+	const insertedState = `COALESCE(NULLIF($6, ''), '` + string(atc.WorkerStateRunning) + `')`
+
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	var existingState string
+	err = tx.QueryRow(`
+		SELECT state
+		FROM workers
+		WHERE name = $1
+		FOR UPDATE
+	`, info.Name).Scan(&existingState)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	// only explicitly registering a retired worker as running brings it back
+	if existingState == retiredWorkerState && info.State != atc.WorkerStateRunning {
+		return ErrWorkerRetired
+	}
+
+	var teamID sql.NullInt64
+	if info.Team != "" {
+		err = tx.QueryRow(`
+			SELECT id
+			FROM teams
+			WHERE name = $1
+		`, info.Team).Scan(&teamID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return ErrNoTeam
+			}
+
+			return err
+		}
+	}
+
 	if ttl == 0 {
-		result, err := db.conn.Exec(`
+		result, err := tx.Exec(`
 			UPDATE workers
-			SET expires = NULL, active_containers = $2, resource_types = $3, platform = $4, tags = $5
-			WHERE addr = $1
-		`, info.Addr, info.ActiveContainers, resourceTypes, info.Platform, tags)
+			SET addr = $9, last_heartbeat_at = NOW(), expires = NULL, active_containers = $2, resource_types = $3, platform = $4, tags = $5, state = `+updatedState+`, team_id = $7, max_containers = $8
+			WHERE name = $1
+		`, info.Name, info.ActiveContainers, resourceTypes, info.Platform, tags, string(info.State), teamID, info.MaxContainers, info.Addr)
 		if err != nil {
 			return err
 		}
@@ -765,24 +950,22 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) error {
 		}
 
 		if affected == 0 {
-			_, err := db.conn.Exec(`
-				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags)
-				VALUES ($1, NULL, $2, $3, $4, $5)
-			`, info.Addr, info.ActiveContainers, resourceTypes, info.Platform, tags)
+			_, err := tx.Exec(`
+				INSERT INTO workers (name, addr, expires, active_containers, resource_types, platform, tags, state, team_id, max_containers)
+				VALUES ($1, $9, NULL, $2, $3, $4, $5, `+insertedState+`, $7, $8)
+			`, info.Name, info.ActiveContainers, resourceTypes, info.Platform, tags, string(info.State), teamID, info.MaxContainers, info.Addr)
 			if err != nil {
 				return err
 			}
 		}
-
-		return nil
 	} else {
 		interval := fmt.Sprintf("%d second", int(ttl.Seconds()))
 
-		result, err := db.conn.Exec(`
+		result, err := tx.Exec(`
 			UPDATE workers
-			SET expires = NOW() + $2::INTERVAL, active_containers = $3, resource_types = $4, platform = $5, tags = $6
-			WHERE addr = $1
-		`, info.Addr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags)
+			SET addr = $10, last_heartbeat_at = NOW(), expires = NOW() + $8::INTERVAL, active_containers = $2, resource_types = $3, platform = $4, tags = $5, state = `+updatedState+`, team_id = $7, max_containers = $9
+			WHERE name = $1
+		`, info.Name, info.ActiveContainers, resourceTypes, info.Platform, tags, string(info.State), teamID, interval, info.MaxContainers, info.Addr)
 		if err != nil {
 			return err
 		}
@@ -793,35 +976,87 @@ func (db *SQLDB) SaveWorker(info WorkerInfo, ttl time.Duration) error {
 		}
 
 		if affected == 0 {
-			_, err := db.conn.Exec(`
-				INSERT INTO workers (addr, expires, active_containers, resource_types, platform, tags)
-				VALUES ($1, NOW() + $2::INTERVAL, $3, $4, $5, $6)
-			`, info.Addr, interval, info.ActiveContainers, resourceTypes, info.Platform, tags)
+			_, err := tx.Exec(`
+				INSERT INTO workers (name, addr, expires, active_containers, resource_types, platform, tags, state, team_id, max_containers)
+				VALUES ($1, $10, NOW() + $8::INTERVAL, $2, $3, $4, $5, `+insertedState+`, $7, $9)
+			`, info.Name, info.ActiveContainers, resourceTypes, info.Platform, tags, string(info.State), teamID, interval, info.MaxContainers, info.Addr)
 			if err != nil {
 				return err
 			}
 		}
+	}
 
-		return nil
+	// a retiring worker is done once its containers are gone
+	_, err = tx.Exec(`
+		UPDATE workers
+		SET state = $3, expires = NULL
+		WHERE name = $1
+		AND state = $2
+		AND active_containers = 0
+	`, info.Name, string(atc.WorkerStateRetiring), retiredWorkerState)
+	if err != nil {
+		return err
 	}
+
+	return tx.Commit()
 }
 
 func (db *SQLDB) Workers() ([]WorkerInfo, error) {
-	// reap expired workers
+	// reap expired workers that were landing
 	_, err := db.conn.Exec(`
 		DELETE FROM workers
 		WHERE expires IS NOT NULL
 		AND expires < NOW()
-	`)
+		AND state = $1
+	`, string(atc.WorkerStateLanding))
+	if err != nil {
+		return nil, err
+	}
+
+	// retire expired workers that were retiring, and retiring workers that
+	// have no containers left
+	_, err = db.conn.Exec(`
+		UPDATE workers
+		SET state = $2, expires = NULL
+		WHERE state = $1
+		AND (
+			(expires IS NOT NULL AND expires < NOW())
+			OR active_containers = 0
+		)
+	`, string(atc.WorkerStateRetiring), retiredWorkerState)
+	if err != nil {
+		return nil, err
+	}
+
+	// forget stalled and retired workers that have not been heard from in a
+	// long time
+	_, err = db.conn.Exec(`
+		DELETE FROM workers
+		WHERE state IN ($1, $2)
+		AND last_heartbeat_at < NOW() - $3::INTERVAL
+	`, string(atc.WorkerStateStalled), retiredWorkerState, fmt.Sprintf("%d second", int(DeadWorkerExpiry.Seconds())))
+	if err != nil {
+		return nil, err
+	}
+
+	// any other expired workers went away unexpectedly
+	_, err = db.conn.Exec(`
+		UPDATE workers
+		SET state = $1, expires = NULL
+		WHERE expires IS NOT NULL
+		AND expires < NOW()
+	`, string(atc.WorkerStateStalled))
 	if err != nil {
 		return nil, err
 	}
 
 	// select remaining workers
 	rows, err := db.conn.Query(`
-		SELECT addr, active_containers, resource_types, platform, tags
-		FROM workers
-	`)
+		SELECT `+workerColumns+`
+		FROM workers w
+		LEFT OUTER JOIN teams t ON w.team_id = t.id
+		WHERE w.state <> $1
+	`, retiredWorkerState)
 	if err != nil {
 		return nil, err
 	}
@@ -830,22 +1065,7 @@ func (db *SQLDB) Workers() ([]WorkerInfo, error) {
 
 	infos := []WorkerInfo{}
 	for rows.Next() {
-		info := WorkerInfo{}
-
-		var resourceTypes []byte
-		var tags []byte
-
-		err := rows.Scan(&info.Addr, &info.ActiveContainers, &resourceTypes, &info.Platform, &tags)
-		if err != nil {
-			return nil, err
-		}
-
-		err = json.Unmarshal(resourceTypes, &info.ResourceTypes)
-		if err != nil {
-			return nil, err
-		}
-
-		err = json.Unmarshal(tags, &info.Tags)
+		info, err := scanWorker(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -856,6 +1076,16 @@ func (db *SQLDB) Workers() ([]WorkerInfo, error) {
 	return infos, nil
 }
 
+func (db *SQLDB) GetWorker(name string) (WorkerInfo, error) {
+	return scanWorker(db.conn.QueryRow(`
+		SELECT `+workerColumns+`
+		FROM workers w
+		LEFT OUTER JOIN teams t ON w.team_id = t.id
+		WHERE w.name = $1
+		AND w.state <> $2
+	`, name, retiredWorkerState))
+}
+
 type txLock struct {
 	tx         *sql.Tx
 	db         *SQLDB
@@ -920,27 +1150,29 @@ type scannable interface {
 	Scan(destinations ...interface{}) error
 }
 
-func scanPipeline(rows scannable) (SavedPipeline, error) {
+func scanPipeline(rows scannable, strategy encryption.Strategy) (SavedPipeline, error) {
 	var id int
 	var name string
-	var configBlob []byte
+	var configBlob string
+	var nonce *string
 	var version int
 	var paused bool
+	var teamName string
 
-	err := rows.Scan(&id, &name, &configBlob, &version, &paused)
+	err := rows.Scan(&id, &name, &configBlob, &nonce, &version, &paused, &teamName)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
 
-	var config atc.Config
-	err = json.Unmarshal(configBlob, &config)
+	config, err := decryptConfig(strategy, configBlob, nonce)
 	if err != nil {
 		return SavedPipeline{}, err
 	}
 
 	return SavedPipeline{
-		ID:     id,
-		Paused: paused,
+		ID:       id,
+		Paused:   paused,
+		TeamName: teamName,
 		Pipeline: Pipeline{
 			Name:    name,
 			Config:  config,
@@ -949,17 +1181,94 @@ func scanPipeline(rows scannable) (SavedPipeline, error) {
 	}, nil
 }
 
-func scanBuild(row scannable) (Build, error) {
+func decryptConfig(strategy encryption.Strategy, configBlob string, nonce *string) (atc.Config, error) {
+	payload, err := strategy.Decrypt(configBlob, nonce)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	var config atc.Config
+	err = json.Unmarshal(payload, &config)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	return config, nil
+}
+
+func decryptSource(strategy encryption.Strategy, sourceBlob string, nonce *string) (Source, error) {
+	payload, err := strategy.Decrypt(sourceBlob, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var source Source
+	err = json.Unmarshal(payload, &source)
+	if err != nil {
+		return nil, err
+	}
+
+	return source, nil
+}
+
+func scanTeam(row scannable) (SavedTeam, error) {
+	var team SavedTeam
+
+	err := row.Scan(&team.ID, &team.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return SavedTeam{}, ErrNoTeam
+		}
+
+		return SavedTeam{}, err
+	}
+
+	return team, nil
+}
+
+func scanWorker(row scannable) (WorkerInfo, error) {
+	info := WorkerInfo{}
+
+	var resourceTypes []byte
+	var tags []byte
+	var state string
+
+	err := row.Scan(&info.Name, &info.Addr, &info.ActiveContainers, &info.MaxContainers, &resourceTypes, &info.Platform, &tags, &info.Team, &state, &info.RegisteredAt, &info.LastHeartbeatAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return WorkerInfo{}, ErrNoWorker
+		}
+
+		return WorkerInfo{}, err
+	}
+
+	info.State = atc.WorkerState(state)
+
+	err = json.Unmarshal(resourceTypes, &info.ResourceTypes)
+	if err != nil {
+		return WorkerInfo{}, err
+	}
+
+	err = json.Unmarshal(tags, &info.Tags)
+	if err != nil {
+		return WorkerInfo{}, err
+	}
+
+	return info, nil
+}
+
+func scanBuild(row scannable, strategy encryption.Strategy) (Build, error) {
 	var id int
 	var name string
 	var jobID sql.NullInt64
 	var status string
 	var scheduled bool
-	var engine, engineMetadata, jobName, pipelineName sql.NullString
+	var engine, engineMetadata, jobName, pipelineName, teamName sql.NullString
+	var nonce *string
 	var startTime pq.NullTime
 	var endTime pq.NullTime
 
-	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &startTime, &endTime, &jobName, &pipelineName)
+	err := row.Scan(&id, &name, &jobID, &status, &scheduled, &engine, &engineMetadata, &nonce, &startTime, &endTime, &jobName, &pipelineName, &teamName)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return Build{}, ErrNoBuild
@@ -968,6 +1277,11 @@ func scanBuild(row scannable) (Build, error) {
 		return Build{}, err
 	}
 
+	metadata, err := decryptEngineMetadata(strategy, engineMetadata, nonce)
+	if err != nil {
+		return Build{}, err
+	}
+
 	build := Build{
 		ID:        id,
 		Name:      name,
@@ -975,10 +1289,12 @@ func scanBuild(row scannable) (Build, error) {
 		Scheduled: scheduled,
 
 		Engine:         engine.String,
-		EngineMetadata: engineMetadata.String,
+		EngineMetadata: metadata,
 
 		StartTime: startTime.Time,
 		EndTime:   endTime.Time,
+
+		TeamName: teamName.String,
 	}
 
 	if jobID.Valid {
@@ -990,6 +1306,19 @@ func scanBuild(row scannable) (Build, error) {
 	return build, nil
 }
 
+func decryptEngineMetadata(strategy encryption.Strategy, engineMetadata sql.NullString, nonce *string) (string, error) {
+	if !engineMetadata.Valid {
+		return "", nil
+	}
+
+	metadata, err := strategy.Decrypt(engineMetadata.String, nonce)
+	if err != nil {
+		return "", err
+	}
+
+	return string(metadata), nil
+}
+
 func buildEventsChannel(buildID int) string {
 	return fmt.Sprintf("build_events_%d", buildID)
 }
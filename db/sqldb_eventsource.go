@@ -140,10 +140,11 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 			continue
 		}
 
+		// -1 if the build has no events, e.g. because they have been reaped
 		var completed bool
-		var lastEventID uint
+		var lastEventID int
 		err = source.conn.QueryRow(`
-			SELECT builds.completed, coalesce(max(build_events.event_id), 0)
+			SELECT builds.completed, coalesce(max(build_events.event_id), -1)
 			FROM builds
 			LEFT JOIN build_events
 			ON build_events.build_id = builds.id
@@ -156,7 +157,7 @@ func (source *sqldbBuildEventSource) collectEvents(cursor uint) {
 			return
 		}
 
-		if completed && cursor > lastEventID {
+		if completed && int(cursor) > lastEventID {
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
 			return
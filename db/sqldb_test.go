@@ -2,6 +2,7 @@ package db_test
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/lib/pq"
@@ -11,6 +12,8 @@ import (
 
 	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
+	"github.com/concourse/atc/db/encryption"
+	"github.com/concourse/atc/event"
 )
 
 var _ = Describe("SQL DB", func() {
@@ -31,12 +34,12 @@ var _ = Describe("SQL DB", func() {
 		Eventually(listener.Ping, 5*time.Second).ShouldNot(HaveOccurred())
 		bus := db.NewNotificationsBus(listener)
 
-		sqlDB = db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus)
+		sqlDB = db.NewSQL(lagertest.NewTestLogger("test"), dbConn, bus, encryption.NoEncryption{})
 
-		sqlDB.SaveConfig("some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
-		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB)
+		sqlDB.SaveConfig(atc.DefaultTeamName, "some-pipeline", atc.Config{}, db.ConfigVersion(1), db.PipelineUnpaused)
+		pipelineDBFactory = db.NewPipelineDBFactory(lagertest.NewTestLogger("test"), dbConn, bus, sqlDB, encryption.NoEncryption{})
 
-		pipelineDB, err = pipelineDBFactory.BuildWithName("some-pipeline")
+		pipelineDB, err = pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, "some-pipeline")
 		Ω(err).ShouldNot(HaveOccurred())
 
 		dbSharedBehaviorInput.DB = sqlDB
@@ -57,6 +60,184 @@ var _ = Describe("SQL DB", func() {
 	Describe("has a job service", jobService(&dbSharedBehaviorInput))
 	Describe("Can schedule builds with serial groups", serialGroupsBehavior(&dbSharedBehaviorInput))
 
+	Describe("teams", func() {
+		It("starts with the main team", func() {
+			teams, err := sqlDB.GetTeams()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(teams).Should(HaveLen(1))
+			Ω(teams[0].Name).Should(Equal(atc.DefaultTeamName))
+		})
+
+		It("can save and look up teams", func() {
+			_, err := sqlDB.GetTeamByName("some-team")
+			Ω(err).Should(Equal(db.ErrNoTeam))
+
+			savedTeam, err := sqlDB.SaveTeam(db.Team{Name: "some-team"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(savedTeam.ID).ShouldNot(BeZero())
+			Ω(savedTeam.Name).Should(Equal("some-team"))
+
+			Ω(sqlDB.GetTeamByName("some-team")).Should(Equal(savedTeam))
+
+			teams, err := sqlDB.GetTeams()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(teams).Should(HaveLen(2))
+		})
+
+		It("scopes pipelines to their team", func() {
+			_, err := sqlDB.SaveTeam(db.Team{Name: "some-team"})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			_, err = sqlDB.SaveConfig("some-team", "some-pipeline", atc.Config{
+				Jobs: atc.JobConfigs{{Name: "some-team-job"}},
+			}, 0, db.PipelineUnpaused)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			mainConfig, _, err := sqlDB.GetConfig(atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(mainConfig.Jobs).Should(BeEmpty())
+
+			teamConfig, _, err := sqlDB.GetConfig("some-team", "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(teamConfig.Jobs).Should(HaveLen(1))
+
+			teamPipeline, err := sqlDB.GetPipelineByTeamNameAndName("some-team", "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(teamPipeline.TeamName).Should(Equal("some-team"))
+		})
+
+		It("does not save configs for teams that do not exist", func() {
+			_, err := sqlDB.SaveConfig("bogus-team", "some-pipeline", atc.Config{}, 0, db.PipelineUnpaused)
+			Ω(err).Should(Equal(db.ErrNoTeam))
+		})
+	})
+
+	Describe("forgetting dead workers", func() {
+		backdateHeartbeat := func(name string, age time.Duration) {
+			_, err := dbConn.Exec(`
+				UPDATE workers
+				SET last_heartbeat_at = NOW() - $2::INTERVAL
+				WHERE name = $1
+			`, name, fmt.Sprintf("%d second", int(age.Seconds())))
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			err := sqlDB.SaveWorker(db.WorkerInfo{
+				Name:  "stalled-worker",
+				Addr:  "1.2.3.4:7777",
+				State: atc.WorkerStateStalled,
+			}, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = sqlDB.SaveWorker(db.WorkerInfo{
+				Name:  "retired-worker",
+				Addr:  "1.2.3.4:8888",
+				State: atc.WorkerStateRetiring,
+			}, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("keeps stalled workers and retired workers' tombstones until they expire", func() {
+			backdateHeartbeat("stalled-worker", db.DeadWorkerExpiry-time.Hour)
+			backdateHeartbeat("retired-worker", db.DeadWorkerExpiry-time.Hour)
+
+			workers, err := sqlDB.Workers()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(workers).Should(HaveLen(1))
+			Ω(workers[0].Name).Should(Equal("stalled-worker"))
+
+			err = sqlDB.SaveWorker(db.WorkerInfo{Name: "retired-worker", Addr: "1.2.3.4:8888"}, 0)
+			Ω(err).Should(Equal(db.ErrWorkerRetired))
+		})
+
+		It("forgets them once they expire", func() {
+			backdateHeartbeat("stalled-worker", db.DeadWorkerExpiry+time.Hour)
+			backdateHeartbeat("retired-worker", db.DeadWorkerExpiry+time.Hour)
+
+			workers, err := sqlDB.Workers()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(workers).Should(BeEmpty())
+
+			err = sqlDB.SaveWorker(db.WorkerInfo{Name: "retired-worker", Addr: "1.2.3.4:8888"}, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("reaping build events", func() {
+		var builds []db.Build
+
+		BeforeEach(func() {
+			builds = []db.Build{}
+
+			for i := 0; i < 3; i++ {
+				build, err := pipelineDB.CreateJobBuild("some-job")
+				Ω(err).ShouldNot(HaveOccurred())
+
+				err = sqlDB.SaveBuildEvent(build.ID, event.Log{Payload: "some log"})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				builds = append(builds, build)
+			}
+
+			err := sqlDB.FinishBuild(builds[0].ID, db.StatusSucceeded)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			err = sqlDB.FinishBuild(builds[1].ID, db.StatusFailed)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("reaps the events of completed builds beyond the most recent ones", func() {
+			// each finished build has a log event and a status event
+			reaped, err := sqlDB.ReapBuildEvents(1, "some-job", atc.BuildLogRetention{Builds: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reaped).Should(Equal(int64(4)))
+
+			By("keeping the build itself")
+			build, err := sqlDB.GetBuild(builds[0].ID)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(build.Status).Should(Equal(db.StatusSucceeded))
+
+			By("ending the event stream of reaped builds")
+			events, err := sqlDB.GetBuildEvents(builds[0].ID, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			defer events.Close()
+
+			_, err = events.Next()
+			Ω(err).Should(Equal(db.ErrEndOfBuildEventStream))
+
+			By("not reaping them again")
+			reaped, err = sqlDB.ReapBuildEvents(1, "some-job", atc.BuildLogRetention{Builds: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reaped).Should(BeZero())
+		})
+
+		It("does not reap the events of builds that are still running", func() {
+			_, err := sqlDB.ReapBuildEvents(1, "some-job", atc.BuildLogRetention{Builds: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			events, err := sqlDB.GetBuildEvents(builds[2].ID, 0)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			defer events.Close()
+
+			Ω(events.Next()).Should(Equal(event.Log{Payload: "some log"}))
+		})
+
+		It("keeps builds that finished within the given number of days", func() {
+			reaped, err := sqlDB.ReapBuildEvents(1, "some-job", atc.BuildLogRetention{Days: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reaped).Should(BeZero())
+		})
+
+		It("keeps builds satisfying either criterion", func() {
+			reaped, err := sqlDB.ReapBuildEvents(1, "some-job", atc.BuildLogRetention{Builds: 1, Days: 1})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(reaped).Should(BeZero())
+		})
+	})
+
 	Describe("config", func() {
 		config := atc.Config{
 			Groups: atc.GroupConfigs{
@@ -150,36 +331,36 @@ var _ = Describe("SQL DB", func() {
 			})
 
 			It("returns true for created", func() {
-				created, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+				created, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineNoChange)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(created).Should(BeTrue())
 			})
 
 			It("can be saved as paused", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelinePaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				Ω(pipeline.Paused).Should(BeTrue())
 			})
 
 			It("can be saved as unpaused", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				Ω(pipeline.Paused).Should(BeFalse())
 			})
 
 			It("defaults to paused", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineNoChange)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
 				Ω(pipeline.Paused).Should(BeTrue())
@@ -194,90 +375,90 @@ var _ = Describe("SQL DB", func() {
 			})
 
 			It("it returns created as false", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineNoChange)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineNoChange)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				_, configVersion, err := sqlDB.GetConfig(pipelineName)
+				_, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				created, err := sqlDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+				created, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, configVersion, db.PipelineNoChange)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(created).Should(BeFalse())
 			})
 
 			It("updating from paused to unpaused", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelinePaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(pipeline.Paused).Should(BeTrue())
 
-				_, configVersion, err := sqlDB.GetConfig(pipelineName)
+				_, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				_, err = sqlDB.SaveConfig(pipelineName, config, configVersion, db.PipelineUnpaused)
+				_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, configVersion, db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err = sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err = sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(pipeline.Paused).Should(BeFalse())
 			})
 
 			It("updating from unpaused to paused", func() {
-				_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+				_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(pipeline.Paused).Should(BeFalse())
 
-				_, configVersion, err := sqlDB.GetConfig(pipelineName)
+				_, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				_, err = sqlDB.SaveConfig(pipelineName, config, configVersion, db.PipelinePaused)
+				_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, configVersion, db.PipelinePaused)
 				Ω(err).ShouldNot(HaveOccurred())
 
-				pipeline, err = sqlDB.GetPipelineByName(pipelineName)
+				pipeline, err = sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 				Ω(err).ShouldNot(HaveOccurred())
 				Ω(pipeline.Paused).Should(BeTrue())
 			})
 
 			Context("updating with no change", func() {
 				It("maintains paused if the pipeline is paused", func() {
-					_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelinePaused)
+					_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelinePaused)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+					pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 					Ω(pipeline.Paused).Should(BeTrue())
 
-					_, configVersion, err := sqlDB.GetConfig(pipelineName)
+					_, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					_, err = sqlDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+					_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, configVersion, db.PipelineNoChange)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					pipeline, err = sqlDB.GetPipelineByName(pipelineName)
+					pipeline, err = sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 					Ω(pipeline.Paused).Should(BeTrue())
 				})
 
 				It("maintains unpaused if the pipeline is unpaused", func() {
-					_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+					_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+					pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 					Ω(pipeline.Paused).Should(BeFalse())
 
-					_, configVersion, err := sqlDB.GetConfig(pipelineName)
+					_, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					_, err = sqlDB.SaveConfig(pipelineName, config, configVersion, db.PipelineNoChange)
+					_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, configVersion, db.PipelineNoChange)
 					Ω(err).ShouldNot(HaveOccurred())
 
-					pipeline, err = sqlDB.GetPipelineByName(pipelineName)
+					pipeline, err = sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 					Ω(err).ShouldNot(HaveOccurred())
 					Ω(pipeline.Paused).Should(BeFalse())
 				})
@@ -288,18 +469,18 @@ var _ = Describe("SQL DB", func() {
 			pipelineName := "a-pipeline-name"
 			otherPipelineName := "an-other-pipeline-name"
 
-			_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
-			_, err = sqlDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			pipeline, err := sqlDB.GetPipelineByName(pipelineName)
+			pipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, pipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(pipeline.Name).Should(Equal(pipelineName))
 			Ω(pipeline.Config).Should(Equal(config))
 			Ω(pipeline.ID).ShouldNot(Equal(0))
 
-			otherPipeline, err := sqlDB.GetPipelineByName(otherPipelineName)
+			otherPipeline, err := sqlDB.GetPipelineByTeamNameAndName(atc.DefaultTeamName, otherPipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(otherPipeline.Name).Should(Equal(otherPipelineName))
 			Ω(otherPipeline.Config).Should(Equal(otherConfig))
@@ -307,22 +488,22 @@ var _ = Describe("SQL DB", func() {
 		})
 
 		It("can order pipelines", func() {
-			_, err := sqlDB.SaveConfig("pipeline-1", config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-1", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig("pipeline-2", config, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-2", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig("pipeline-3", config, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-3", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig("pipeline-4", config, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-4", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig("pipeline-5", config, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-5", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			err = sqlDB.OrderPipelines([]string{
+			err = sqlDB.OrderPipelines(atc.DefaultTeamName, []string{
 				"pipeline-4",
 				"pipeline-3",
 				"pipeline-5",
@@ -332,7 +513,7 @@ var _ = Describe("SQL DB", func() {
 			})
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig("pipeline-6", config, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, "pipeline-6", config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			pipelines, err := sqlDB.GetAllActivePipelines()
@@ -340,7 +521,8 @@ var _ = Describe("SQL DB", func() {
 
 			Ω(pipelines).Should(Equal([]db.SavedPipeline{
 				{
-					ID: 5,
+					ID:       5,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-4",
 						Config:  config,
@@ -348,7 +530,8 @@ var _ = Describe("SQL DB", func() {
 					},
 				},
 				{
-					ID: 4,
+					ID:       4,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-3",
 						Config:  config,
@@ -356,7 +539,8 @@ var _ = Describe("SQL DB", func() {
 					},
 				},
 				{
-					ID: 6,
+					ID:       6,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-5",
 						Config:  config,
@@ -364,7 +548,8 @@ var _ = Describe("SQL DB", func() {
 					},
 				},
 				{
-					ID: 2,
+					ID:       2,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-1",
 						Config:  config,
@@ -372,7 +557,8 @@ var _ = Describe("SQL DB", func() {
 					},
 				},
 				{
-					ID: 3,
+					ID:       3,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-2",
 						Config:  config,
@@ -382,7 +568,8 @@ var _ = Describe("SQL DB", func() {
 
 				// pipelines not mentioned are put at the bottom
 				{
-					ID: 1,
+					ID:       1,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "some-pipeline",
 						Version: db.ConfigVersion(1),
@@ -391,7 +578,8 @@ var _ = Describe("SQL DB", func() {
 
 				// newly added pipelines appear at the bottom
 				{
-					ID: 7,
+					ID:       7,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "pipeline-6",
 						Config:  config,
@@ -405,13 +593,13 @@ var _ = Describe("SQL DB", func() {
 			pipelineName := "a-pipeline-name"
 			otherPipelineName := "an-other-pipeline-name"
 
-			_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			err = sqlDB.OrderPipelines([]string{
+			err = sqlDB.OrderPipelines(atc.DefaultTeamName, []string{
 				"some-pipeline",
 				pipelineName,
 				otherPipelineName,
@@ -423,14 +611,16 @@ var _ = Describe("SQL DB", func() {
 
 			Ω(pipelines).Should(Equal([]db.SavedPipeline{
 				{
-					ID: 1,
+					ID:       1,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    "some-pipeline",
 						Version: db.ConfigVersion(1),
 					},
 				},
 				{
-					ID: 2,
+					ID:       2,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    pipelineName,
 						Config:  config,
@@ -438,7 +628,8 @@ var _ = Describe("SQL DB", func() {
 					},
 				},
 				{
-					ID: 3,
+					ID:       3,
+					TeamName: atc.DefaultTeamName,
 					Pipeline: db.Pipeline{
 						Name:    otherPipelineName,
 						Config:  otherConfig,
@@ -449,9 +640,9 @@ var _ = Describe("SQL DB", func() {
 		})
 
 		It("can lookup configs by build id", func() {
-			_, err := sqlDB.SaveConfig("my-pipeline", config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, "my-pipeline", config, 0, db.PipelineUnpaused)
 
-			myPipelineDB, err := pipelineDBFactory.BuildWithName("my-pipeline")
+			myPipelineDB, err := pipelineDBFactory.BuildWithTeamNameAndName(atc.DefaultTeamName, "my-pipeline")
 			Ω(err).ShouldNot(HaveOccurred())
 
 			build, err := myPipelineDB.CreateJobBuild("some-job")
@@ -466,23 +657,23 @@ var _ = Describe("SQL DB", func() {
 			otherPipelineName := "an-other-pipeline-name"
 
 			By("initially being empty")
-			Ω(sqlDB.GetConfig(pipelineName)).Should(BeZero())
-			Ω(sqlDB.GetConfig(otherPipelineName)).Should(BeZero())
+			Ω(sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)).Should(BeZero())
+			Ω(sqlDB.GetConfig(atc.DefaultTeamName, otherPipelineName)).Should(BeZero())
 
 			By("being able to save the config")
-			_, err := sqlDB.SaveConfig(pipelineName, config, 0, db.PipelineUnpaused)
+			_, err := sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, config, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
-			_, err = sqlDB.SaveConfig(otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, otherConfig, 0, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			By("returning the saved config to later gets")
-			returnedConfig, configVersion, err := sqlDB.GetConfig(pipelineName)
+			returnedConfig, configVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(returnedConfig).Should(Equal(config))
 			Ω(configVersion).ShouldNot(Equal(db.ConfigVersion(0)))
 
-			otherReturnedConfig, otherConfigVersion, err := sqlDB.GetConfig(otherPipelineName)
+			otherReturnedConfig, otherConfigVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, otherPipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(otherReturnedConfig).Should(Equal(otherConfig))
 			Ω(otherConfigVersion).ShouldNot(Equal(db.ConfigVersion(0)))
@@ -517,31 +708,31 @@ var _ = Describe("SQL DB", func() {
 			})
 
 			By("not allowing non-sequential updates")
-			_, err = sqlDB.SaveConfig(pipelineName, updatedConfig, configVersion-1, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, updatedConfig, configVersion-1, db.PipelineUnpaused)
 			Ω(err).Should(Equal(db.ErrConfigComparisonFailed))
 
-			_, err = sqlDB.SaveConfig(pipelineName, updatedConfig, configVersion+10, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, updatedConfig, configVersion+10, db.PipelineUnpaused)
 			Ω(err).Should(Equal(db.ErrConfigComparisonFailed))
 
-			_, err = sqlDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion-1, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, updatedConfig, otherConfigVersion-1, db.PipelineUnpaused)
 			Ω(err).Should(Equal(db.ErrConfigComparisonFailed))
 
-			_, err = sqlDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion+10, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, updatedConfig, otherConfigVersion+10, db.PipelineUnpaused)
 			Ω(err).Should(Equal(db.ErrConfigComparisonFailed))
 
 			By("being able to update the config with a valid con")
-			_, err = sqlDB.SaveConfig(pipelineName, updatedConfig, configVersion, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, pipelineName, updatedConfig, configVersion, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
-			_, err = sqlDB.SaveConfig(otherPipelineName, updatedConfig, otherConfigVersion, db.PipelineUnpaused)
+			_, err = sqlDB.SaveConfig(atc.DefaultTeamName, otherPipelineName, updatedConfig, otherConfigVersion, db.PipelineUnpaused)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			By("returning the updated config")
-			returnedConfig, newConfigVersion, err := sqlDB.GetConfig(pipelineName)
+			returnedConfig, newConfigVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, pipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(returnedConfig).Should(Equal(updatedConfig))
 			Ω(newConfigVersion).ShouldNot(Equal(configVersion))
 
-			otherReturnedConfig, newOtherConfigVersion, err := sqlDB.GetConfig(otherPipelineName)
+			otherReturnedConfig, newOtherConfigVersion, err := sqlDB.GetConfig(atc.DefaultTeamName, otherPipelineName)
 			Ω(err).ShouldNot(HaveOccurred())
 			Ω(otherReturnedConfig).Should(Equal(updatedConfig))
 			Ω(newOtherConfigVersion).ShouldNot(Equal(otherConfigVersion))
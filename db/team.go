@@ -0,0 +1,11 @@
+package db
+
+type Team struct {
+	Name string
+}
+
+type SavedTeam struct {
+	ID int
+
+	Team
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"sync"
 
 	"os"
 
@@ -18,6 +19,10 @@ import (
 
 type execMetadata struct {
 	Plan atc.Plan
+
+	// ImageVersions are the versions the build's task images are pinned to,
+	// so that a resumed build keeps using the same ones.
+	ImageVersions map[string]atc.Version `json:",omitempty"`
 }
 
 type execEngine struct {
@@ -40,10 +45,13 @@ func (engine *execEngine) Name() string {
 
 func (engine *execEngine) CreateBuild(model db.Build, plan atc.Plan) (Build, error) {
 	return &execBuild{
-		buildID:  model.ID,
-		db:       engine.db,
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID),
+		buildID:      model.ID,
+		teamName:     model.TeamName,
+		pipelineName: model.PipelineName,
+		jobName:      model.JobName,
+		db:           engine.db,
+		factory:      engine.factory,
+		delegate:     engine.delegateFactory.Delegate(model.ID),
 		metadata: execMetadata{
 			Plan: plan,
 		},
@@ -60,30 +68,39 @@ func (engine *execEngine) LookupBuild(model db.Build) (Build, error) {
 	}
 
 	return &execBuild{
-		buildID:  model.ID,
-		db:       engine.db,
-		factory:  engine.factory,
-		delegate: engine.delegateFactory.Delegate(model.ID),
-		metadata: metadata,
+		buildID:      model.ID,
+		teamName:     model.TeamName,
+		pipelineName: model.PipelineName,
+		jobName:      model.JobName,
+		db:           engine.db,
+		factory:      engine.factory,
+		delegate:     engine.delegateFactory.Delegate(model.ID),
+		metadata:     metadata,
 
 		signals: make(chan os.Signal, 1),
 	}, nil
 }
 
 type execBuild struct {
-	buildID int
-	db      EngineDB
+	buildID      int
+	teamName     string
+	pipelineName string
+	jobName      string
+	db           EngineDB
 
 	factory  exec.Factory
 	delegate BuildDelegate
 
 	signals chan os.Signal
 
-	metadata execMetadata
+	metadata  execMetadata
+	metadataL sync.Mutex
 }
 
 func (build *execBuild) Metadata() string {
+	build.metadataL.Lock()
 	payload, err := json.Marshal(build.metadata)
+	build.metadataL.Unlock()
 	if err != nil {
 		panic("failed to marshal build metadata: " + err.Error())
 	}
@@ -97,8 +114,20 @@ func (build *execBuild) Abort() error {
 }
 
 func (build *execBuild) Resume(logger lager.Logger) {
+	repo := exec.NewSourceRepository()
+	repo.PersistImageVersions(build.metadata.ImageVersions, func(versions map[string]atc.Version) {
+		build.metadataL.Lock()
+		build.metadata.ImageVersions = versions
+		build.metadataL.Unlock()
+
+		err := build.db.SaveBuildEngineMetadata(build.buildID, build.Metadata())
+		if err != nil {
+			logger.Error("failed-to-save-image-versions", err)
+		}
+	})
+
 	stepFactory, _ := build.buildStepFactory(logger, build.metadata.Plan, event.OriginLocation{ID: 1}, "")
-	source := stepFactory.Using(&exec.NoopStep{}, exec.NewSourceRepository())
+	source := stepFactory.Using(&exec.NoopStep{}, repo)
 
 	defer source.Release()
 
@@ -258,6 +287,8 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan, loc
 			exec.Privileged(plan.Task.Privileged),
 			plan.Task.Tags,
 			configSource,
+			exec.InputMapping(plan.Task.InputMapping),
+			exec.OutputMapping(plan.Task.OutputMapping),
 		), event.SingleIncrement
 	}
 
@@ -330,7 +361,10 @@ func (build *execBuild) buildStepFactory(logger lager.Logger, plan atc.Plan, loc
 
 func (build *execBuild) taskIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
-		BuildID: build.buildID,
+		BuildID:      build.buildID,
+		TeamName:     build.teamName,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
 
 		Type:         "task",
 		Name:         name,
@@ -341,6 +375,9 @@ func (build *execBuild) taskIdentifier(name string, location event.OriginLocatio
 func (build *execBuild) getIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
 		BuildID:      build.buildID,
+		TeamName:     build.teamName,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
 		Type:         "get",
 		Name:         name,
 		StepLocation: location.ID,
@@ -349,7 +386,10 @@ func (build *execBuild) getIdentifier(name string, location event.OriginLocation
 
 func (build *execBuild) putIdentifier(name string, location event.OriginLocation) worker.Identifier {
 	return worker.Identifier{
-		BuildID: build.buildID,
+		BuildID:      build.buildID,
+		TeamName:     build.teamName,
+		PipelineName: build.pipelineName,
+		JobName:      build.jobName,
 
 		Type:         "put",
 		Name:         name,
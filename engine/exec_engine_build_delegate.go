@@ -54,6 +54,9 @@ type delegate struct {
 
 	implicitOutputs map[string]implicitOutput
 
+	redactor *redactor
+	writers  []*dbEventWriter
+
 	lock sync.Mutex
 }
 
@@ -64,10 +67,14 @@ func newBuildDelegate(db EngineDB, buildID int) BuildDelegate {
 		buildID: buildID,
 
 		implicitOutputs: make(map[string]implicitOutput),
+
+		redactor: &redactor{secrets: map[string]struct{}{}},
 	}
 }
 
 func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, location event.OriginLocation, hook string) exec.GetDelegate {
+	delegate.redactor.add(sensitiveValues(plan.Source, plan.Sensitive)...)
+
 	return &inputDelegate{
 		logger:   logger,
 		plan:     plan,
@@ -78,6 +85,8 @@ func (delegate *delegate) InputDelegate(logger lager.Logger, plan atc.GetPlan, l
 }
 
 func (delegate *delegate) OutputDelegate(logger lager.Logger, plan atc.PutPlan, location event.OriginLocation, hook string) exec.PutDelegate {
+	delegate.redactor.add(sensitiveValues(plan.Source, plan.Sensitive)...)
+
 	return &outputDelegate{
 		logger:   logger,
 		plan:     plan,
@@ -98,6 +107,8 @@ func (delegate *delegate) ExecutionDelegate(logger lager.Logger, plan atc.TaskPl
 }
 
 func (delegate *delegate) Finish(logger lager.Logger, err error, succeeded exec.Success, aborted bool) {
+	delegate.flushWriters()
+
 	if aborted {
 		delegate.saveStatus(logger, atc.StatusAborted)
 
@@ -159,6 +170,27 @@ func (delegate *delegate) saveStart(logger lager.Logger, origin event.Origin) {
 	}
 }
 
+func (delegate *delegate) saveWaitingForWorker(logger lager.Logger, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.WaitingForWorker{
+		Time:   time.Now().Unix(),
+		Origin: origin,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-waiting-for-worker-event", err)
+	}
+}
+
+func (delegate *delegate) saveImageVersion(logger lager.Logger, info exec.VersionInfo, origin event.Origin) {
+	err := delegate.db.SaveBuildEvent(delegate.buildID, event.ImageVersion{
+		Origin:          origin,
+		FetchedVersion:  info.Version,
+		FetchedMetadata: info.Metadata,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-image-version-event", err)
+	}
+}
+
 func (delegate *delegate) saveFinish(logger lager.Logger, status exec.ExitStatus, origin event.Origin) {
 	err := delegate.db.SaveBuildEvent(delegate.buildID, event.FinishTask{
 		ExitStatus: int(status),
@@ -289,10 +321,51 @@ func (delegate *delegate) saveImplicitOutput(logger lager.Logger, plan atc.GetPl
 }
 
 func (delegate *delegate) eventWriter(origin event.Origin) io.Writer {
-	return &dbEventWriter{
-		db:      delegate.db,
-		buildID: delegate.buildID,
-		origin:  origin,
+	writer := &dbEventWriter{
+		db:       delegate.db,
+		buildID:  delegate.buildID,
+		origin:   origin,
+		redactor: delegate.redactor,
+	}
+
+	delegate.lock.Lock()
+	delegate.writers = append(delegate.writers, writer)
+	delegate.lock.Unlock()
+
+	return writer
+}
+
+// flushWriters saves whatever output the writers are still holding back,
+// e.g. the start of what looked like a secret when the process exited.
+func (delegate *delegate) flushWriters() {
+	delegate.lock.Lock()
+	writers := delegate.writers
+	delegate.writers = nil
+	delegate.lock.Unlock()
+
+	for _, writer := range writers {
+		writer.flush()
+	}
+}
+
+// flushStepWriters flushes the writers of the step with the given origin, so
+// that its output is saved before the events that follow it.
+func (delegate *delegate) flushStepWriters(origin event.Origin) {
+	var writers []*dbEventWriter
+
+	delegate.lock.Lock()
+	for _, writer := range delegate.writers {
+		if writer.origin.Type == origin.Type &&
+			writer.origin.Name == origin.Name &&
+			writer.origin.Location == origin.Location &&
+			writer.origin.Hook == origin.Hook {
+			writers = append(writers, writer)
+		}
+	}
+	delegate.lock.Unlock()
+
+	for _, writer := range writers {
+		writer.flush()
 	}
 }
 
@@ -306,6 +379,13 @@ type inputDelegate struct {
 }
 
 func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	input.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypeGet,
+		Name:     input.plan.Name,
+		Location: input.location,
+		Hook:     input.hook,
+	})
+
 	input.delegate.saveInput(input.logger, status, input.plan, info, event.Origin{
 		Type:     event.OriginTypeGet,
 		Name:     input.plan.Name,
@@ -320,7 +400,25 @@ func (input *inputDelegate) Completed(status exec.ExitStatus, info *exec.Version
 	input.logger.Info("finished", lager.Data{"version-info": info})
 }
 
+func (input *inputDelegate) WaitingForWorker() {
+	input.delegate.saveWaitingForWorker(input.logger, event.Origin{
+		Type:     event.OriginTypeGet,
+		Name:     input.plan.Name,
+		Location: input.location,
+		Hook:     input.hook,
+	})
+
+	input.logger.Info("waiting-for-worker")
+}
+
 func (input *inputDelegate) Failed(err error) {
+	input.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypeGet,
+		Name:     input.plan.Name,
+		Location: input.location,
+		Hook:     input.hook,
+	})
+
 	input.delegate.saveErr(input.logger, err, event.Origin{
 		Type:     event.OriginTypeGet,
 		Name:     input.plan.Name,
@@ -331,6 +429,10 @@ func (input *inputDelegate) Failed(err error) {
 	input.logger.Error("errored", err)
 }
 
+func (input *inputDelegate) CredentialsResolved(values []string) {
+	input.delegate.redactor.add(values...)
+}
+
 func (input *inputDelegate) Stdout() io.Writer {
 	return input.delegate.eventWriter(event.Origin{
 		Type:     event.OriginTypeGet,
@@ -362,6 +464,13 @@ type outputDelegate struct {
 }
 
 func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.VersionInfo) {
+	output.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypePut,
+		Name:     output.plan.Name,
+		Location: output.location,
+		Hook:     output.hook,
+	})
+
 	output.delegate.unregisterImplicitOutput(output.plan.Resource)
 	output.delegate.saveOutput(output.logger, status, output.plan, info, event.Origin{
 		Type:     event.OriginTypePut,
@@ -373,7 +482,25 @@ func (output *outputDelegate) Completed(status exec.ExitStatus, info *exec.Versi
 	output.logger.Info("finished", lager.Data{"version-info": info})
 }
 
+func (output *outputDelegate) WaitingForWorker() {
+	output.delegate.saveWaitingForWorker(output.logger, event.Origin{
+		Type:     event.OriginTypePut,
+		Name:     output.plan.Name,
+		Location: output.location,
+		Hook:     output.hook,
+	})
+
+	output.logger.Info("waiting-for-worker")
+}
+
 func (output *outputDelegate) Failed(err error) {
+	output.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypePut,
+		Name:     output.plan.Name,
+		Location: output.location,
+		Hook:     output.hook,
+	})
+
 	output.delegate.saveErr(output.logger, err, event.Origin{
 		Type:     event.OriginTypePut,
 		Name:     output.plan.Name,
@@ -384,6 +511,10 @@ func (output *outputDelegate) Failed(err error) {
 	output.logger.Error("errored", err)
 }
 
+func (output *outputDelegate) CredentialsResolved(values []string) {
+	output.delegate.redactor.add(values...)
+}
+
 func (output *outputDelegate) Stdout() io.Writer {
 	return output.delegate.eventWriter(event.Origin{
 		Type:     event.OriginTypePut,
@@ -416,6 +547,10 @@ type executionDelegate struct {
 }
 
 func (execution *executionDelegate) Initializing(config atc.TaskConfig) {
+	for _, value := range config.Params {
+		execution.delegate.redactor.add(value)
+	}
+
 	execution.delegate.saveInitialize(execution.logger, config, event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
@@ -436,6 +571,13 @@ func (execution *executionDelegate) Started() {
 }
 
 func (execution *executionDelegate) Finished(status exec.ExitStatus) {
+	execution.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypeTask,
+		Name:     execution.plan.Name,
+		Location: execution.location,
+		Hook:     execution.hook,
+	})
+
 	execution.delegate.saveFinish(execution.logger, status, event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
@@ -444,7 +586,36 @@ func (execution *executionDelegate) Finished(status exec.ExitStatus) {
 	})
 }
 
+func (execution *executionDelegate) WaitingForWorker() {
+	execution.delegate.saveWaitingForWorker(execution.logger, event.Origin{
+		Type:     event.OriginTypeTask,
+		Name:     execution.plan.Name,
+		Location: execution.location,
+		Hook:     execution.hook,
+	})
+
+	execution.logger.Info("waiting-for-worker")
+}
+
+func (execution *executionDelegate) ImageVersionDetermined(info exec.VersionInfo) {
+	execution.delegate.saveImageVersion(execution.logger, info, event.Origin{
+		Type:     event.OriginTypeTask,
+		Name:     execution.plan.Name,
+		Location: execution.location,
+		Hook:     execution.hook,
+	})
+
+	execution.logger.Info("image-version-determined", lager.Data{"version": info.Version})
+}
+
 func (execution *executionDelegate) Failed(err error) {
+	execution.delegate.flushStepWriters(event.Origin{
+		Type:     event.OriginTypeTask,
+		Name:     execution.plan.Name,
+		Location: execution.location,
+		Hook:     execution.hook,
+	})
+
 	execution.delegate.saveErr(execution.logger, err, event.Origin{
 		Type:     event.OriginTypeTask,
 		Name:     execution.plan.Name,
@@ -454,6 +625,10 @@ func (execution *executionDelegate) Failed(err error) {
 	execution.logger.Error("errored", err)
 }
 
+func (execution *executionDelegate) CredentialsResolved(values []string) {
+	execution.delegate.redactor.add(values...)
+}
+
 func (execution *executionDelegate) Stdout() io.Writer {
 	return execution.delegate.eventWriter(event.Origin{
 		Type:     event.OriginTypeTask,
@@ -480,10 +655,16 @@ type dbEventWriter struct {
 
 	origin event.Origin
 
+	redactor *redactor
+
 	dangling []byte
+	lock     sync.Mutex
 }
 
 func (writer *dbEventWriter) Write(data []byte) (int, error) {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
 	text := append(writer.dangling, data...)
 
 	checkEncoding, _ := utf8.DecodeLastRune(text)
@@ -492,14 +673,141 @@ func (writer *dbEventWriter) Write(data []byte) (int, error) {
 		return len(data), nil
 	}
 
+	// hold back the start of a secret until we see whether the next write
+	// completes it
+	payload, held := writer.redactor.redact(string(text), true)
+
+	writer.dangling = []byte(held)
+
+	if payload != "" {
+		writer.save(payload)
+	}
+
+	return len(data), nil
+}
+
+func (writer *dbEventWriter) flush() {
+	writer.lock.Lock()
+	defer writer.lock.Unlock()
+
+	if len(writer.dangling) == 0 {
+		return
+	}
+
+	payload, _ := writer.redactor.redact(string(writer.dangling), false)
+
 	writer.dangling = nil
 
+	writer.save(payload)
+}
+
+func (writer *dbEventWriter) save(payload string) {
 	writer.db.SaveBuildEvent(writer.buildID, event.Log{
-		Payload: string(text),
+		Payload: payload,
 		Origin:  writer.origin,
 	})
+}
 
-	return len(data), nil
+const redactedValue = "((redacted))"
+
+// values shorter than this are too likely to appear in output by chance
+const minRedactedLength = 4
+
+// redactor masks the secrets known to a build in its output.
+type redactor struct {
+	secrets map[string]struct{}
+	lock    sync.RWMutex
+}
+
+func (r *redactor) add(values ...string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, value := range values {
+		if len(value) >= minRedactedLength {
+			r.secrets[value] = struct{}{}
+		}
+	}
+}
+
+// redact replaces each secret in the text. If hold is true, any trailing part
+// of the text that could be the start of a secret is returned separately
+// rather than redacted, so that it can be prepended to the next write.
+func (r *redactor) redact(text string, hold bool) (string, string) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if len(r.secrets) == 0 {
+		return text, ""
+	}
+
+	redacted := make([]byte, 0, len(text))
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		match := ""
+		partial := false
+		for secret := range r.secrets {
+			if strings.HasPrefix(rest, secret) {
+				if len(secret) > len(match) {
+					match = secret
+				}
+			} else if hold && strings.HasPrefix(secret, rest) {
+				partial = true
+			}
+		}
+
+		// a longer secret may still be coming, so hold back even if a shorter
+		// one matches
+		if partial {
+			return string(redacted), rest
+		}
+
+		if match != "" {
+			redacted = append(redacted, redactedValue...)
+			i += len(match)
+			continue
+		}
+
+		redacted = append(redacted, text[i])
+		i++
+	}
+
+	return string(redacted), ""
+}
+
+// sensitiveValues returns the strings found under the given fields of a
+// resource's source.
+func sensitiveValues(source atc.Source, fields []string) []string {
+	values := []string{}
+
+	for _, field := range fields {
+		values = appendStrings(values, source[field])
+	}
+
+	return values
+}
+
+func appendStrings(values []string, value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		values = append(values, v)
+	case map[string]interface{}:
+		for _, val := range v {
+			values = appendStrings(values, val)
+		}
+	case map[interface{}]interface{}:
+		for _, val := range v {
+			values = appendStrings(values, val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			values = appendStrings(values, val)
+		}
+	}
+
+	return values
 }
 
 func vrFromInput(pipelineName string, got event.FinishGet) db.VersionedResource {
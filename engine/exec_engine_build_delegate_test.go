@@ -47,6 +47,18 @@ var _ = Describe("BuildDelegate", func() {
 		}
 	})
 
+	savedLogs := func() []string {
+		payloads := []string{}
+		for i := 0; i < fakeDB.SaveBuildEventCallCount(); i++ {
+			_, savedEvent := fakeDB.SaveBuildEventArgsForCall(i)
+			if log, ok := savedEvent.(event.Log); ok {
+				payloads = append(payloads, log.Payload)
+			}
+		}
+
+		return payloads
+	}
+
 	Describe("InputDelegate", func() {
 		var (
 			getPlan atc.GetPlan
@@ -368,6 +380,27 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				inputDelegate.WaitingForWorker()
+			})
+
+			It("saves a waiting-for-worker event", func() {
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(BeAssignableToTypeOf(event.WaitingForWorker{}))
+				Ω(savedEvent.(event.WaitingForWorker).Time).Should(BeNumerically("~", time.Now().Unix(), 1))
+				Ω(savedEvent.(event.WaitingForWorker).Origin).Should(Equal(event.Origin{
+					Type:     event.OriginTypeGet,
+					Name:     "some-input",
+					Location: location,
+					Hook:     "some-input-hook",
+				}))
+			})
+		})
+
 		Describe("Failed", func() {
 			JustBeforeEach(func() {
 				inputDelegate.Failed(errors.New("nope"))
@@ -420,6 +453,40 @@ var _ = Describe("BuildDelegate", func() {
 					Payload: "some stdout",
 				}))
 			})
+
+			Context("when the resource marks fields of its source as sensitive", func() {
+				BeforeEach(func() {
+					getPlan.Source = atc.Source{
+						"uri":         "https://example.com",
+						"private_key": "some-private-key",
+						"credentials": map[string]interface{}{"token": "some-token"},
+					}
+					getPlan.Sensitive = []string{"private_key", "credentials"}
+
+					writer = delegate.InputDelegate(logger, getPlan, location, "some-input-hook").Stdout()
+				})
+
+				It("redacts their values from the output", func() {
+					_, err := writer.Write([]byte("cloning https://example.com with some-private-key and some-token"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(Equal([]string{"cloning https://example.com with ((redacted)) and ((redacted))"}))
+				})
+
+				It("saves anything held back before the input's finish event", func() {
+					inputDelegate = delegate.InputDelegate(logger, getPlan, location, "some-input-hook")
+
+					_, err := writer.Write([]byte("cloning with some-pri"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					inputDelegate.Completed(0, nil)
+
+					Ω(savedLogs()).Should(Equal([]string{"cloning with ", "some-pri"}))
+
+					_, lastEvent := fakeDB.SaveBuildEventArgsForCall(fakeDB.SaveBuildEventCallCount() - 1)
+					Ω(lastEvent).Should(BeAssignableToTypeOf(event.FinishGet{}))
+				})
+			})
 		})
 
 		Describe("Stderr", func() {
@@ -499,6 +566,53 @@ var _ = Describe("BuildDelegate", func() {
 			})
 		})
 
+		Describe("WaitingForWorker", func() {
+			JustBeforeEach(func() {
+				executionDelegate.WaitingForWorker()
+			})
+
+			It("saves a waiting-for-worker event", func() {
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(BeAssignableToTypeOf(event.WaitingForWorker{}))
+				Ω(savedEvent.(event.WaitingForWorker).Time).Should(BeNumerically("~", time.Now().Unix(), 1))
+				Ω(savedEvent.(event.WaitingForWorker).Origin).Should(Equal(event.Origin{
+					Type:     event.OriginTypeTask,
+					Name:     "some-task",
+					Location: location,
+					Hook:     "some-task-hook",
+				}))
+			})
+		})
+
+		Describe("ImageVersionDetermined", func() {
+			JustBeforeEach(func() {
+				executionDelegate.ImageVersionDetermined(exec.VersionInfo{
+					Version:  atc.Version{"digest": "sha256:some-digest"},
+					Metadata: []atc.MetadataField{{"tag", "latest"}},
+				})
+			})
+
+			It("saves an image-version event", func() {
+				Ω(fakeDB.SaveBuildEventCallCount()).Should(Equal(1))
+
+				buildID, savedEvent := fakeDB.SaveBuildEventArgsForCall(0)
+				Ω(buildID).Should(Equal(42))
+				Ω(savedEvent).Should(Equal(event.ImageVersion{
+					Origin: event.Origin{
+						Type:     event.OriginTypeTask,
+						Name:     "some-task",
+						Location: location,
+						Hook:     "some-task-hook",
+					},
+					FetchedVersion:  atc.Version{"digest": "sha256:some-digest"},
+					FetchedMetadata: []atc.MetadataField{{"tag", "latest"}},
+				}))
+			})
+		})
+
 		Describe("Started", func() {
 			JustBeforeEach(func() {
 				executionDelegate.Started()
@@ -669,6 +783,100 @@ var _ = Describe("BuildDelegate", func() {
 					Payload: "some stdout",
 				}))
 			})
+
+			Context("when the task's params are known", func() {
+				BeforeEach(func() {
+					executionDelegate.Initializing(atc.TaskConfig{
+						Params: map[string]string{"PASSWORD": "some-password"},
+					})
+				})
+
+				It("redacts them from the output", func() {
+					_, err := writer.Write([]byte("the password is some-password!"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(Equal([]string{"the password is ((redacted))!"}))
+				})
+
+				It("redacts them when split across writes", func() {
+					_, err := writer.Write([]byte("the password is some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = writer.Write([]byte("ssword!"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(Equal([]string{"the password is ", "((redacted))!"}))
+				})
+
+				It("saves what looked like the start of a secret once it is not", func() {
+					_, err := writer.Write([]byte("some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(BeEmpty())
+
+					_, err = writer.Write([]byte("rty"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(Equal([]string{"some-party"}))
+				})
+
+				It("saves anything held back when the build finishes", func() {
+					_, err := writer.Write([]byte("bye some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					delegate.Finish(logger, nil, true, false)
+
+					Ω(savedLogs()).Should(Equal([]string{"bye ", "some-pa"}))
+				})
+
+				It("saves anything held back before the task's finish event", func() {
+					_, err := writer.Write([]byte("bye some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					executionDelegate.Finished(0)
+
+					Ω(savedLogs()).Should(Equal([]string{"bye ", "some-pa"}))
+
+					_, lastEvent := fakeDB.SaveBuildEventArgsForCall(fakeDB.SaveBuildEventCallCount() - 1)
+					Ω(lastEvent).Should(BeAssignableToTypeOf(event.FinishTask{}))
+				})
+
+				It("saves anything held back before the task's error event", func() {
+					_, err := writer.Write([]byte("bye some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					executionDelegate.Failed(errors.New("nope"))
+
+					Ω(savedLogs()).Should(Equal([]string{"bye ", "some-pa"}))
+
+					_, lastEvent := fakeDB.SaveBuildEventArgsForCall(fakeDB.SaveBuildEventCallCount() - 1)
+					Ω(lastEvent).Should(BeAssignableToTypeOf(event.Error{}))
+				})
+
+				It("does not save another step's held back output when the task finishes", func() {
+					otherWriter := delegate.ExecutionDelegate(logger, atc.TaskPlan{Name: "some-other-task"}, location, "").Stdout()
+
+					_, err := otherWriter.Write([]byte("some-pa"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					executionDelegate.Finished(0)
+
+					Ω(savedLogs()).Should(BeEmpty())
+				})
+			})
+
+			Context("when credentials are resolved", func() {
+				BeforeEach(func() {
+					executionDelegate.CredentialsResolved([]string{"some-credential", "abc"})
+				})
+
+				It("redacts them from the output", func() {
+					_, err := writer.Write([]byte("some-credential abc"))
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(savedLogs()).Should(Equal([]string{"((redacted)) abc"}))
+				})
+			})
 		})
 
 		Describe("Stderr", func() {
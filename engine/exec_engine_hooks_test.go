@@ -172,7 +172,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the steps correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(3))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-1")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -209,7 +209,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					}))
 					Ω(hook).Should(Equal("success"))
 
-					sourceName, workerID, delegate, _, _, _ = fakeFactory.TaskArgsForCall(1)
+					sourceName, workerID, delegate, _, _, _, _, _ = fakeFactory.TaskArgsForCall(1)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-2")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -227,7 +227,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 					}))
 					Ω(hook).Should(Equal(""))
 
-					sourceName, workerID, delegate, _, _, _ = fakeFactory.TaskArgsForCall(2)
+					sourceName, workerID, delegate, _, _, _, _, _ = fakeFactory.TaskArgsForCall(2)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task-3")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -311,7 +311,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the completion hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(2)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(2)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-completion-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -332,7 +332,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the failure hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(0)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-failure-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -353,7 +353,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the success hook correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(1)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(1)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-success-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -374,7 +374,7 @@ var _ = Describe("Exec Engine With Hooks", func() {
 
 				It("constructs the next step correctly", func() {
 					Ω(fakeFactory.TaskCallCount()).Should(Equal(4))
-					sourceName, workerID, delegate, _, _, _ := fakeFactory.TaskArgsForCall(3)
+					sourceName, workerID, delegate, _, _, _, _, _ := fakeFactory.TaskArgsForCall(3)
 					Ω(sourceName).Should(Equal(exec.SourceName("some-next-task")))
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      84,
@@ -70,7 +70,12 @@ var _ = Describe("ExecEngine", func() {
 		BeforeEach(func() {
 			logger = lagertest.NewTestLogger("test")
 
-			buildModel = db.Build{ID: 42}
+			buildModel = db.Build{
+				ID:           42,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
+			}
 
 			taskConfig = &atc.TaskConfig{
 				Image:  "some-image",
@@ -179,6 +184,9 @@ var _ = Describe("ExecEngine", func() {
 
 											Config:     taskConfig,
 											ConfigPath: taskConfigPath,
+
+											InputMapping:  map[string]string{"some-input": "some-mapped-input"},
+											OutputMapping: map[string]string{"some-output": "some-mapped-output"},
 										},
 									},
 									B: atc.Plan{
@@ -255,6 +263,9 @@ var _ = Describe("ExecEngine", func() {
 					workerID, delegate, resourceConfig, tags, params := fakeFactory.PutArgsForCall(0)
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      42,
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
 						Type:         worker.ContainerTypePut,
 						Name:         "some-output-resource",
 						StepLocation: 6,
@@ -269,6 +280,9 @@ var _ = Describe("ExecEngine", func() {
 					workerID, delegate, resourceConfig, tags, params = fakeFactory.PutArgsForCall(1)
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      42,
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
 						Type:         worker.ContainerTypePut,
 						Name:         "some-output-resource-2",
 						StepLocation: 8,
@@ -287,6 +301,9 @@ var _ = Describe("ExecEngine", func() {
 					sourceName, workerID, delegate, resourceConfig, tags, params := fakeFactory.DependentGetArgsForCall(0)
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      42,
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
 						Type:         worker.ContainerTypeGet,
 						Name:         "some-put",
 						StepLocation: 7,
@@ -312,6 +329,9 @@ var _ = Describe("ExecEngine", func() {
 					sourceName, workerID, delegate, resourceConfig, tags, params = fakeFactory.DependentGetArgsForCall(1)
 					Ω(workerID).Should(Equal(worker.Identifier{
 						BuildID:      42,
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
 						Type:         worker.ContainerTypeGet,
 						Name:         "some-put-2",
 						StepLocation: 9,
@@ -345,6 +365,9 @@ var _ = Describe("ExecEngine", func() {
 			Ω(sourceName).Should(Equal(exec.SourceName("some-input")))
 			Ω(workerID).Should(Equal(worker.Identifier{
 				BuildID:      42,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
 				Type:         worker.ContainerTypeGet,
 				Name:         "some-input",
 				StepLocation: 2,
@@ -371,10 +394,13 @@ var _ = Describe("ExecEngine", func() {
 		It("constructs tasks correctly", func() {
 			Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
 
-			sourceName, workerID, delegate, privileged, tags, configSource := fakeFactory.TaskArgsForCall(0)
+			sourceName, workerID, delegate, privileged, tags, configSource, inputMapping, outputMapping := fakeFactory.TaskArgsForCall(0)
 			Ω(sourceName).Should(Equal(exec.SourceName("some-task")))
 			Ω(workerID).Should(Equal(worker.Identifier{
 				BuildID:      42,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				JobName:      "some-job",
 				Type:         worker.ContainerTypeTask,
 				Name:         "some-task",
 				StepLocation: 3,
@@ -383,6 +409,8 @@ var _ = Describe("ExecEngine", func() {
 			Ω(privileged).Should(Equal(exec.Privileged(false)))
 			Ω(tags).Should(BeEmpty())
 			Ω(configSource).ShouldNot(BeNil())
+			Ω(inputMapping).Should(Equal(exec.InputMapping{"some-input": "some-mapped-input"}))
+			Ω(outputMapping).Should(Equal(exec.OutputMapping{"some-output": "some-mapped-output"}))
 		})
 
 		Context("constructing outputs", func() {
@@ -392,6 +420,9 @@ var _ = Describe("ExecEngine", func() {
 				workerID, delegate, resourceConfig, tags, params := fakeFactory.PutArgsForCall(0)
 				Ω(workerID).Should(Equal(worker.Identifier{
 					BuildID:      42,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
 					Type:         worker.ContainerTypePut,
 					Name:         "some-output-resource",
 					StepLocation: 5,
@@ -410,6 +441,9 @@ var _ = Describe("ExecEngine", func() {
 				sourceName, workerID, delegate, resourceConfig, tags, params := fakeFactory.DependentGetArgsForCall(0)
 				Ω(workerID).Should(Equal(worker.Identifier{
 					BuildID:      42,
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					JobName:      "some-job",
 					Type:         worker.ContainerTypeGet,
 					Name:         "some-put",
 					StepLocation: 6,
@@ -464,7 +498,7 @@ var _ = Describe("ExecEngine", func() {
 			It("constructs the task step privileged", func() {
 				Ω(fakeFactory.TaskCallCount()).Should(Equal(1))
 
-				_, _, _, privileged, _, _ := fakeFactory.TaskArgsForCall(0)
+				_, _, _, privileged, _, _, _, _ := fakeFactory.TaskArgsForCall(0)
 				Ω(privileged).Should(Equal(exec.Privileged(true)))
 			})
 		})
@@ -724,6 +758,55 @@ var _ = Describe("ExecEngine", func() {
 			})
 		})
 	})
+
+	Describe("resuming a build whose task images were pinned", func() {
+		var repo *exec.SourceRepository
+
+		BeforeEach(func() {
+			fakeDelegateFactory.DelegateReturns(new(fakes.FakeBuildDelegate))
+
+			taskStep := new(execfakes.FakeStep)
+			taskStep.ResultStub = successResult(true)
+
+			taskStepFactory := new(execfakes.FakeStepFactory)
+			taskStepFactory.UsingStub = func(prev exec.Step, r *exec.SourceRepository) exec.Step {
+				repo = r
+				return taskStep
+			}
+
+			fakeFactory.TaskReturns(taskStepFactory)
+
+			build, err := execEngine.LookupBuild(db.Build{
+				ID:             42,
+				EngineMetadata: `{"Plan":{"task":{"name":"some-task","privileged":false,"config_path":"some/task.yml"}},"ImageVersions":{"some-image":{"v":"1"}}}`,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+
+			build.Resume(lagertest.NewTestLogger("test"))
+		})
+
+		It("keeps using the pinned versions", func() {
+			version, found := repo.ImageVersion("some-image")
+			Ω(found).Should(BeTrue())
+			Ω(version).Should(Equal(atc.Version{"v": "1"}))
+		})
+
+		It("saves versions pinned later in the build's metadata", func() {
+			repo.PinImageVersion("some-other-image", atc.Version{"v": "2"})
+
+			Ω(fakeDB.SaveBuildEngineMetadataCallCount()).Should(Equal(1))
+
+			buildID, metadata := fakeDB.SaveBuildEngineMetadataArgsForCall(0)
+			Ω(buildID).Should(Equal(42))
+			Ω(metadata).Should(MatchJSON(`{
+				"Plan": {"task": {"name": "some-task", "privileged": false, "config_path": "some/task.yml"}},
+				"ImageVersions": {
+					"some-image": {"v": "1"},
+					"some-other-image": {"v": "2"}
+				}
+			}`))
+		})
+	})
 })
 
 func successResult(result exec.Success) func(dest interface{}) bool {
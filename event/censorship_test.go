@@ -43,6 +43,15 @@ var _ = Describe("Censorship", func() {
 		Error{
 			Message: "some error",
 		},
+		ImageVersion{
+			Origin: Origin{
+				Type:     OriginTypeTask,
+				Name:     "build",
+				Location: OriginLocation{ID: 1},
+			},
+			FetchedVersion:  atc.Version{"digest": "sha256:some-digest"},
+			FetchedMetadata: []atc.MetadataField{{"public", "data"}},
+		},
 	} {
 		event := e
 
@@ -97,6 +106,29 @@ var _ = Describe("Censorship", func() {
 		})
 	})
 
+	Describe("InitializeTask with an image resource", func() {
+		It("censors the image resource's source", func() {
+			imageResource := &atc.TaskImageConfig{
+				Type:   "docker-image",
+				Source: atc.Source{"password": "secret"},
+			}
+
+			Ω(InitializeTask{
+				TaskConfig: atc.TaskConfig{
+					ImageResource: imageResource,
+				},
+			}.Censored()).Should(Equal(InitializeTask{
+				TaskConfig: atc.TaskConfig{
+					ImageResource: &atc.TaskImageConfig{
+						Type: "docker-image",
+					},
+				},
+			}))
+
+			Ω(imageResource.Source).Should(Equal(atc.Source{"password": "secret"}))
+		})
+	})
+
 	Describe("InputV20", func() {
 		It("censors source and params", func() {
 			Ω(InputV20{
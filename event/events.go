@@ -30,6 +30,13 @@ func (InitializeTask) EventType() atc.EventType  { return EventTypeInitializeTas
 func (InitializeTask) Version() atc.EventVersion { return "2.0" }
 func (e InitializeTask) Censored() atc.Event {
 	e.TaskConfig.Params = nil
+
+	if e.TaskConfig.ImageResource != nil {
+		imageResource := *e.TaskConfig.ImageResource
+		imageResource.Source = nil
+		e.TaskConfig.ImageResource = &imageResource
+	}
+
 	return e
 }
 
@@ -42,6 +49,25 @@ func (StartTask) EventType() atc.EventType  { return EventTypeStartTask }
 func (StartTask) Version() atc.EventVersion { return "2.0" }
 func (e StartTask) Censored() atc.Event     { return e }
 
+type WaitingForWorker struct {
+	Time   int64  `json:"time"`
+	Origin Origin `json:"origin"`
+}
+
+func (WaitingForWorker) EventType() atc.EventType  { return EventTypeWaitingForWorker }
+func (WaitingForWorker) Version() atc.EventVersion { return "1.0" }
+func (e WaitingForWorker) Censored() atc.Event     { return e }
+
+type ImageVersion struct {
+	Origin          Origin              `json:"origin"`
+	FetchedVersion  atc.Version         `json:"version"`
+	FetchedMetadata []atc.MetadataField `json:"metadata,omitempty"`
+}
+
+func (ImageVersion) EventType() atc.EventType  { return EventTypeImageVersion }
+func (ImageVersion) Version() atc.EventVersion { return "1.0" }
+func (e ImageVersion) Censored() atc.Event     { return e }
+
 type Status struct {
 	Status atc.BuildStatus `json:"status"`
 	Time   int64           `json:"time"`
@@ -41,6 +41,8 @@ func init() {
 	registerEvent(Status{})
 	registerEvent(Log{})
 	registerEvent(Error{})
+	registerEvent(WaitingForWorker{})
+	registerEvent(ImageVersion{})
 
 	// deprecated:
 	registerEvent(InputV10{})
@@ -28,4 +28,10 @@ const (
 
 	// error occurred
 	EventTypeError atc.EventType = "error"
+
+	// step is waiting for a worker with capacity
+	EventTypeWaitingForWorker atc.EventType = "waiting-for-worker"
+
+	// task image fetched from its image resource
+	EventTypeImageVersion atc.EventType = "image-version"
 )
@@ -6,8 +6,10 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/fakes"
 	"github.com/concourse/atc/resource"
@@ -17,6 +19,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -28,6 +31,7 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeTracker      *rfakes.FakeTracker
 		fakeWorkerClient *wfakes.FakeClient
+		fakeClock        *fakeclock.FakeClock
 
 		factory Factory
 
@@ -44,8 +48,9 @@ var _ = Describe("GardenFactory", func() {
 	BeforeEach(func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" })
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" }, fakeClock, creds.NoopManager{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
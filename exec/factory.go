@@ -13,7 +13,7 @@ type Factory interface {
 	Get(SourceName, worker.Identifier, GetDelegate, atc.ResourceConfig, atc.Params, atc.Tags, atc.Version) StepFactory
 	Put(worker.Identifier, PutDelegate, atc.ResourceConfig, atc.Tags, atc.Params) StepFactory
 	// Delete(atc.ResourceConfig, atc.Params, atc.Version) Step
-	Task(SourceName, worker.Identifier, TaskDelegate, Privileged, atc.Tags, TaskConfigSource) StepFactory
+	Task(SourceName, worker.Identifier, TaskDelegate, Privileged, atc.Tags, TaskConfigSource, InputMapping, OutputMapping) StepFactory
 
 	DependentGet(SourceName, worker.Identifier, GetDelegate, atc.ResourceConfig, atc.Tags, atc.Params) StepFactory
 }
@@ -22,6 +22,9 @@ type Factory interface {
 
 type TaskDelegate interface {
 	Initializing(atc.TaskConfig)
+	WaitingForWorker()
+	ImageVersionDetermined(VersionInfo)
+	CredentialsResolved([]string)
 	Started()
 
 	Finished(ExitStatus)
@@ -32,6 +35,8 @@ type TaskDelegate interface {
 }
 
 type ResourceDelegate interface {
+	WaitingForWorker()
+	CredentialsResolved([]string)
 	Completed(ExitStatus, *VersionInfo)
 	Failed(error)
 
@@ -58,6 +63,14 @@ type HijackedProcess interface {
 
 type Privileged bool
 
+// InputMapping maps the names of a task's inputs to the names of the
+// artifacts to use for them.
+type InputMapping map[string]string
+
+// OutputMapping maps the names of a task's outputs to the names to register
+// them under.
+type OutputMapping map[string]string
+
 type IOConfig struct {
 	Stdin  io.Reader
 	Stdout io.Writer
@@ -36,7 +36,7 @@ type FakeFactory struct {
 	putReturns struct {
 		result1 exec.StepFactory
 	}
-	TaskStub        func(exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource) exec.StepFactory
+	TaskStub        func(exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, exec.InputMapping, exec.OutputMapping) exec.StepFactory
 	taskMutex       sync.RWMutex
 	taskArgsForCall []struct {
 		arg1 exec.SourceName
@@ -45,6 +45,8 @@ type FakeFactory struct {
 		arg4 exec.Privileged
 		arg5 atc.Tags
 		arg6 exec.TaskConfigSource
+		arg7 exec.InputMapping
+		arg8 exec.OutputMapping
 	}
 	taskReturns struct {
 		result1 exec.StepFactory
@@ -138,7 +140,7 @@ func (fake *FakeFactory) PutReturns(result1 exec.StepFactory) {
 	}{result1}
 }
 
-func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3 exec.TaskDelegate, arg4 exec.Privileged, arg5 atc.Tags, arg6 exec.TaskConfigSource) exec.StepFactory {
+func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3 exec.TaskDelegate, arg4 exec.Privileged, arg5 atc.Tags, arg6 exec.TaskConfigSource, arg7 exec.InputMapping, arg8 exec.OutputMapping) exec.StepFactory {
 	fake.taskMutex.Lock()
 	fake.taskArgsForCall = append(fake.taskArgsForCall, struct {
 		arg1 exec.SourceName
@@ -147,10 +149,12 @@ func (fake *FakeFactory) Task(arg1 exec.SourceName, arg2 worker.Identifier, arg3
 		arg4 exec.Privileged
 		arg5 atc.Tags
 		arg6 exec.TaskConfigSource
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
+		arg7 exec.InputMapping
+		arg8 exec.OutputMapping
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.taskMutex.Unlock()
 	if fake.TaskStub != nil {
-		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return fake.TaskStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	} else {
 		return fake.taskReturns.result1
 	}
@@ -162,10 +166,10 @@ func (fake *FakeFactory) TaskCallCount() int {
 	return len(fake.taskArgsForCall)
 }
 
-func (fake *FakeFactory) TaskArgsForCall(i int) (exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource) {
+func (fake *FakeFactory) TaskArgsForCall(i int) (exec.SourceName, worker.Identifier, exec.TaskDelegate, exec.Privileged, atc.Tags, exec.TaskConfigSource, exec.InputMapping, exec.OutputMapping) {
 	fake.taskMutex.RLock()
 	defer fake.taskMutex.RUnlock()
-	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6
+	return fake.taskArgsForCall[i].arg1, fake.taskArgsForCall[i].arg2, fake.taskArgsForCall[i].arg3, fake.taskArgsForCall[i].arg4, fake.taskArgsForCall[i].arg5, fake.taskArgsForCall[i].arg6, fake.taskArgsForCall[i].arg7, fake.taskArgsForCall[i].arg8
 }
 
 func (fake *FakeFactory) TaskReturns(result1 exec.StepFactory) {
@@ -9,6 +9,14 @@ import (
 )
 
 type FakePutDelegate struct {
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	CredentialsResolvedStub        func([]string)
+	credentialsResolvedMutex       sync.RWMutex
+	credentialsResolvedArgsForCall []struct {
+		arg1 []string
+	}
 	CompletedStub        func(exec.ExitStatus, *exec.VersionInfo)
 	completedMutex       sync.RWMutex
 	completedArgsForCall []struct {
@@ -34,6 +42,44 @@ type FakePutDelegate struct {
 	}
 }
 
+func (fake *FakePutDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakePutDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakePutDelegate) CredentialsResolved(arg1 []string) {
+	fake.credentialsResolvedMutex.Lock()
+	fake.credentialsResolvedArgsForCall = append(fake.credentialsResolvedArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	fake.credentialsResolvedMutex.Unlock()
+	if fake.CredentialsResolvedStub != nil {
+		fake.CredentialsResolvedStub(arg1)
+	}
+}
+
+func (fake *FakePutDelegate) CredentialsResolvedCallCount() int {
+	fake.credentialsResolvedMutex.RLock()
+	defer fake.credentialsResolvedMutex.RUnlock()
+	return len(fake.credentialsResolvedArgsForCall)
+}
+
+func (fake *FakePutDelegate) CredentialsResolvedArgsForCall(i int) []string {
+	fake.credentialsResolvedMutex.RLock()
+	defer fake.credentialsResolvedMutex.RUnlock()
+	return fake.credentialsResolvedArgsForCall[i].arg1
+}
+
 func (fake *FakePutDelegate) Completed(arg1 exec.ExitStatus, arg2 *exec.VersionInfo) {
 	fake.completedMutex.Lock()
 	fake.completedArgsForCall = append(fake.completedArgsForCall, struct {
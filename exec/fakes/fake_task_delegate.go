@@ -15,6 +15,19 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 atc.TaskConfig
 	}
+	WaitingForWorkerStub        func()
+	waitingForWorkerMutex       sync.RWMutex
+	waitingForWorkerArgsForCall []struct{}
+	ImageVersionDeterminedStub        func(exec.VersionInfo)
+	imageVersionDeterminedMutex       sync.RWMutex
+	imageVersionDeterminedArgsForCall []struct {
+		arg1 exec.VersionInfo
+	}
+	CredentialsResolvedStub        func([]string)
+	credentialsResolvedMutex       sync.RWMutex
+	credentialsResolvedArgsForCall []struct {
+		arg1 []string
+	}
 	StartedStub        func()
 	startedMutex       sync.RWMutex
 	startedArgsForCall []struct{}
@@ -65,6 +78,67 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) atc.TaskConfig {
 	return fake.initializingArgsForCall[i].arg1
 }
 
+func (fake *FakeTaskDelegate) WaitingForWorker() {
+	fake.waitingForWorkerMutex.Lock()
+	fake.waitingForWorkerArgsForCall = append(fake.waitingForWorkerArgsForCall, struct{}{})
+	fake.waitingForWorkerMutex.Unlock()
+	if fake.WaitingForWorkerStub != nil {
+		fake.WaitingForWorkerStub()
+	}
+}
+
+func (fake *FakeTaskDelegate) WaitingForWorkerCallCount() int {
+	fake.waitingForWorkerMutex.RLock()
+	defer fake.waitingForWorkerMutex.RUnlock()
+	return len(fake.waitingForWorkerArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageVersionDetermined(arg1 exec.VersionInfo) {
+	fake.imageVersionDeterminedMutex.Lock()
+	fake.imageVersionDeterminedArgsForCall = append(fake.imageVersionDeterminedArgsForCall, struct {
+		arg1 exec.VersionInfo
+	}{arg1})
+	fake.imageVersionDeterminedMutex.Unlock()
+	if fake.ImageVersionDeterminedStub != nil {
+		fake.ImageVersionDeterminedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) ImageVersionDeterminedCallCount() int {
+	fake.imageVersionDeterminedMutex.RLock()
+	defer fake.imageVersionDeterminedMutex.RUnlock()
+	return len(fake.imageVersionDeterminedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ImageVersionDeterminedArgsForCall(i int) exec.VersionInfo {
+	fake.imageVersionDeterminedMutex.RLock()
+	defer fake.imageVersionDeterminedMutex.RUnlock()
+	return fake.imageVersionDeterminedArgsForCall[i].arg1
+}
+
+func (fake *FakeTaskDelegate) CredentialsResolved(arg1 []string) {
+	fake.credentialsResolvedMutex.Lock()
+	fake.credentialsResolvedArgsForCall = append(fake.credentialsResolvedArgsForCall, struct {
+		arg1 []string
+	}{arg1})
+	fake.credentialsResolvedMutex.Unlock()
+	if fake.CredentialsResolvedStub != nil {
+		fake.CredentialsResolvedStub(arg1)
+	}
+}
+
+func (fake *FakeTaskDelegate) CredentialsResolvedCallCount() int {
+	fake.credentialsResolvedMutex.RLock()
+	defer fake.credentialsResolvedMutex.RUnlock()
+	return len(fake.credentialsResolvedArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) CredentialsResolvedArgsForCall(i int) []string {
+	fake.credentialsResolvedMutex.RLock()
+	defer fake.credentialsResolvedMutex.RUnlock()
+	return fake.credentialsResolvedArgsForCall[i].arg1
+}
+
 func (fake *FakeTaskDelegate) Started() {
 	fake.startedMutex.Lock()
 	fake.startedArgsForCall = append(fake.startedArgsForCall, struct{}{})
@@ -5,8 +5,10 @@ import (
 	"path/filepath"
 
 	"github.com/cloudfoundry-incubator/garden"
+	"github.com/pivotal-golang/clock"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
 )
@@ -15,6 +17,8 @@ type gardenFactory struct {
 	workerClient    worker.Client
 	resourceTracker resource.Tracker
 	uuidGenerator   UUIDGenFunc
+	clock           clock.Clock
+	creds           creds.Manager
 }
 
 type UUIDGenFunc func() string
@@ -23,11 +27,15 @@ func NewGardenFactory(
 	workerClient worker.Client,
 	resourceTracker resource.Tracker,
 	uuidGenerator UUIDGenFunc,
+	clock clock.Clock,
+	creds creds.Manager,
 ) Factory {
 	return &gardenFactory{
 		workerClient:    workerClient,
 		resourceTracker: resourceTracker,
 		uuidGenerator:   uuidGenerator,
+		clock:           clock,
+		creds:           creds,
 	}
 }
 
@@ -45,12 +53,17 @@ func (factory *gardenFactory) DependentGet(sourceName SourceName, id worker.Iden
 		Tracker: factory.resourceTracker,
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
+		Clock:   factory.clock,
 
-		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
+		Source: config.Source,
+		Params: params,
+		Creds:  factory.creds,
+
+		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo, source atc.Source, params atc.Params) resource.VersionedSource {
 			return r.Get(resource.IOConfig{
 				Stdout: delegate.Stdout(),
 				Stderr: delegate.Stderr(),
-			}, config.Source, params, vi.Version)
+			}, source, params, vi.Version)
 		},
 	}
 }
@@ -64,17 +77,24 @@ func (factory *gardenFactory) Get(sourceName SourceName, id worker.Identifier, d
 			Ephemeral: false,
 		},
 
+		CacheVersion: version,
+
 		Delegate: delegate,
 
 		Tracker: factory.resourceTracker,
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
+		Clock:   factory.clock,
+
+		Source: config.Source,
+		Params: params,
+		Creds:  factory.creds,
 
-		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
+		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo, source atc.Source, params atc.Params) resource.VersionedSource {
 			return r.Get(resource.IOConfig{
 				Stdout: delegate.Stdout(),
 				Stderr: delegate.Stderr(),
-			}, config.Source, params, version)
+			}, source, params, version)
 		},
 	}
 }
@@ -90,17 +110,22 @@ func (factory *gardenFactory) Put(id worker.Identifier, delegate PutDelegate, co
 		Tracker: factory.resourceTracker,
 		Type:    resource.ResourceType(config.Type),
 		Tags:    tags,
+		Clock:   factory.clock,
 
-		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo) resource.VersionedSource {
+		Source: config.Source,
+		Params: params,
+		Creds:  factory.creds,
+
+		Action: func(r resource.Resource, s ArtifactSource, vi VersionInfo, source atc.Source, params atc.Params) resource.VersionedSource {
 			return r.Put(resource.IOConfig{
 				Stdout: delegate.Stdout(),
 				Stderr: delegate.Stderr(),
-			}, config.Source, params, resourceSource{s})
+			}, source, params, resourceSource{s})
 		},
 	}
 }
 
-func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier, delegate TaskDelegate, privileged Privileged, tags atc.Tags, configSource TaskConfigSource) StepFactory {
+func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier, delegate TaskDelegate, privileged Privileged, tags atc.Tags, configSource TaskConfigSource, inputMapping InputMapping, outputMapping OutputMapping) StepFactory {
 
 	artifactsRoot := filepath.Join("/tmp", "build", factory.uuidGenerator())
 
@@ -115,7 +140,13 @@ func (factory *gardenFactory) Task(sourceName SourceName, id worker.Identifier,
 		Privileged:   privileged,
 		ConfigSource: configSource,
 
+		InputMapping:  inputMapping,
+		OutputMapping: outputMapping,
+
 		WorkerClient: factory.workerClient,
+		Tracker:      factory.resourceTracker,
+		Clock:        factory.clock,
+		Creds:        factory.creds,
 
 		artifactsRoot: artifactsRoot,
 	}
@@ -6,8 +6,12 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	cfakes "github.com/concourse/atc/creds/fakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/fakes"
 	"github.com/concourse/atc/resource"
@@ -17,6 +21,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -24,6 +29,8 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeTracker      *rfakes.FakeTracker
 		fakeWorkerClient *wfakes.FakeClient
+		fakeClock        *fakeclock.FakeClock
+		fakeCreds        *cfakes.FakeManager
 
 		factory Factory
 
@@ -40,8 +47,10 @@ var _ = Describe("GardenFactory", func() {
 	BeforeEach(func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		fakeCreds = new(cfakes.FakeManager)
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" })
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" }, fakeClock, fakeCreds)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -111,11 +120,25 @@ var _ = Describe("GardenFactory", func() {
 				Ω(sid).Should(Equal(resource.Session{
 					ID:        identifier,
 					Ephemeral: false,
+					CacheKey:  resource.CacheKey("some-resource-type", atc.Source{"some": "source"}, params, version),
 				}))
 				Ω(typ).Should(Equal(resource.ResourceType("some-resource-type")))
 				Ω(tags).Should(ConsistOf("some", "tags"))
 			})
 
+			Context("when no version is specified", func() {
+				BeforeEach(func() {
+					version = nil
+				})
+
+				It("does not reuse an earlier fetch, as the version may change", func() {
+					Ω(fakeTracker.InitCallCount()).Should(Equal(1))
+
+					sid, _, _ := fakeTracker.InitArgsForCall(0)
+					Ω(sid.CacheKey).Should(BeEmpty())
+				})
+			})
+
 			It("gets the resource with the correct source, params, and version", func() {
 				Ω(fakeResource.GetCallCount()).Should(Equal(1))
 
@@ -125,6 +148,101 @@ var _ = Describe("GardenFactory", func() {
 				Ω(gotVersion).Should(Equal(version))
 			})
 
+			Context("when the source and params refer to credentials", func() {
+				BeforeEach(func() {
+					resourceConfig.Source = atc.Source{"password": "((some-password))"}
+					params = atc.Params{"token": "((some-token))"}
+
+					fakeCreds.GetStub = func(name string) (string, bool, error) {
+						return "resolved-" + strings.TrimPrefix(name, "main/"), true, nil
+					}
+				})
+
+				It("looks them up among the team's credentials", func() {
+					Ω(fakeCreds.GetCallCount()).Should(Equal(2))
+
+					names := []string{}
+					for i := 0; i < fakeCreds.GetCallCount(); i++ {
+						names = append(names, fakeCreds.GetArgsForCall(i))
+					}
+
+					Ω(names).Should(ConsistOf("main/some-password", "main/some-token"))
+				})
+
+				It("gets the resource with them resolved", func() {
+					Ω(fakeResource.GetCallCount()).Should(Equal(1))
+
+					_, gotSource, gotParams, _ := fakeResource.GetArgsForCall(0)
+					Ω(gotSource).Should(Equal(atc.Source{"password": "resolved-some-password"}))
+					Ω(gotParams).Should(Equal(atc.Params{"token": "resolved-some-token"}))
+				})
+
+				It("tells the delegate which credentials were resolved", func() {
+					Ω(getDelegate.CredentialsResolvedCallCount()).Should(Equal(1))
+					Ω(getDelegate.CredentialsResolvedArgsForCall(0)).Should(ConsistOf("resolved-some-password", "resolved-some-token"))
+				})
+
+				It("keys the cache by what they resolved to", func() {
+					sid, _, _ := fakeTracker.InitArgsForCall(0)
+					Ω(sid.CacheKey).Should(Equal(resource.CacheKey("some-resource-type", atc.Source{"password": "resolved-some-password"}, atc.Params{"token": "resolved-some-token"}, version)))
+				})
+
+				Context("when another team's credentials of the same name differ", func() {
+					var otherKey string
+
+					BeforeEach(func() {
+						otherDelegate := new(fakes.FakeGetDelegate)
+						otherDelegate.StdoutReturns(stdoutBuf)
+						otherDelegate.StderrReturns(stderrBuf)
+
+						otherCreds := new(cfakes.FakeManager)
+						otherCreds.GetStub = func(name string) (string, bool, error) {
+							return "other-" + strings.TrimPrefix(name, "some-other-team/"), true, nil
+						}
+
+						otherTracker := new(rfakes.FakeTracker)
+						otherTracker.InitReturns(fakeResource, nil)
+
+						otherFactory := NewGardenFactory(fakeWorkerClient, otherTracker, func() string {
+							return "a-random-guid"
+						}, fakeClock, otherCreds)
+
+						otherIdentifier := identifier
+						otherIdentifier.TeamName = "some-other-team"
+
+						otherStep := otherFactory.Get(sourceName, otherIdentifier, otherDelegate, resourceConfig, params, tags, version).Using(inStep, repo)
+						Eventually(ifrit.Invoke(otherStep).Wait()).Should(Receive(BeNil()))
+
+						Ω(otherTracker.InitCallCount()).Should(Equal(1))
+						sid, _, _ := otherTracker.InitArgsForCall(0)
+						otherKey = sid.CacheKey
+					})
+
+					It("does not share the cache with them", func() {
+						sid, _, _ := fakeTracker.InitArgsForCall(0)
+						Ω(sid.CacheKey).ShouldNot(BeEmpty())
+						Ω(otherKey).ShouldNot(BeEmpty())
+						Ω(sid.CacheKey).ShouldNot(Equal(otherKey))
+					})
+				})
+
+				Context("when a credential is undefined", func() {
+					BeforeEach(func() {
+						fakeCreds.GetStub = nil
+						fakeCreds.GetReturns("", false, nil)
+					})
+
+					It("fails without initializing the resource", func() {
+						Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedCredentialsError{
+							Names: []string{"some-password"},
+						})))
+
+						Ω(fakeTracker.InitCallCount()).Should(BeZero())
+						Ω(getDelegate.FailedCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
 			It("gets the resource with the io config forwarded", func() {
 				Ω(fakeResource.GetCallCount()).Should(Equal(1))
 
@@ -405,6 +523,58 @@ var _ = Describe("GardenFactory", func() {
 			})
 		})
 
+		Context("when all workers are at capacity", func() {
+			var fakeResource *rfakes.FakeResource
+
+			BeforeEach(func() {
+				fakeResource = new(rfakes.FakeResource)
+				fakeResource.GetReturns(new(rfakes.FakeVersionedSource))
+
+				fakeTracker.InitStub = func(resource.Session, resource.ResourceType, atc.Tags) (resource.Resource, error) {
+					if fakeTracker.InitCallCount() < 3 {
+						return nil, worker.NoCapacityError{}
+					}
+
+					return fakeResource, nil
+				}
+			})
+
+			It("retries until a worker has capacity", func() {
+				Eventually(func() int {
+					fakeClock.Increment(10 * time.Second)
+					return fakeTracker.InitCallCount()
+				}).Should(Equal(3))
+
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+				Ω(fakeResource.GetCallCount()).Should(Equal(1))
+			})
+
+			It("tells the delegate it is waiting only once", func() {
+				Eventually(func() int {
+					fakeClock.Increment(10 * time.Second)
+					return fakeTracker.InitCallCount()
+				}).Should(Equal(3))
+
+				Ω(getDelegate.WaitingForWorkerCallCount()).Should(Equal(1))
+			})
+
+			Context("when interrupted while waiting", func() {
+				BeforeEach(func() {
+					fakeTracker.InitStub = nil
+					fakeTracker.InitReturns(nil, worker.NoCapacityError{})
+				})
+
+				JustBeforeEach(func() {
+					Eventually(getDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+				})
+
+				It("exits with ErrInterrupted", func() {
+					process.Signal(os.Interrupt)
+					Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+				})
+			})
+		})
+
 		Context("when the tracker fails to initialize the resource", func() {
 			disaster := errors.New("nope")
 
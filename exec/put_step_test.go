@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/fakes"
 	"github.com/concourse/atc/resource"
@@ -15,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -22,6 +25,7 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeTracker      *rfakes.FakeTracker
 		fakeWorkerClient *wfakes.FakeClient
+		fakeClock        *fakeclock.FakeClock
 
 		factory Factory
 
@@ -36,8 +40,9 @@ var _ = Describe("GardenFactory", func() {
 	BeforeEach(func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 
-		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" })
+		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string { return "" }, fakeClock, creds.NoopManager{})
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -6,7 +6,9 @@ import (
 	"os"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/resource"
+	"github.com/pivotal-golang/clock"
 )
 
 type resourceStep struct {
@@ -19,8 +21,18 @@ type resourceStep struct {
 	Tracker resource.Tracker
 	Type    resource.ResourceType
 	Tags    atc.Tags
+	Clock   clock.Clock
 
-	Action func(resource.Resource, ArtifactSource, VersionInfo) resource.VersionedSource
+	// resolved through Creds before being given to Action
+	Source atc.Source
+	Params atc.Params
+	Creds  creds.Manager
+
+	// if set, the bits fetched are cached for later gets of the same version;
+	// without a version, what gets fetched may change from one get to the next
+	CacheVersion atc.Version
+
+	Action func(resource.Resource, ArtifactSource, VersionInfo, atc.Source, atc.Params) resource.VersionedSource
 
 	PreviousStep Step
 	Repository   *SourceRepository
@@ -42,7 +54,33 @@ func (step resourceStep) Using(prev Step, repo *SourceRepository) Step {
 }
 
 func (ras *resourceStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
-	trackedResource, err := ras.Tracker.Init(ras.Session, ras.Type, ras.Tags)
+	recorder := creds.NewRecordingManager(creds.Scoped(ras.Creds, ras.Session.ID.TeamName, ras.Session.ID.PipelineName))
+
+	source, err := creds.ResolveSource(recorder, ras.Source)
+	if err != nil {
+		return err
+	}
+
+	params, err := creds.ResolveParams(recorder, ras.Params)
+	if err != nil {
+		return err
+	}
+
+	ras.Delegate.CredentialsResolved(recorder.Values())
+
+	// keyed by the resolved source, so that the same source text resolving
+	// to different credentials never shares what was fetched
+	if ras.CacheVersion != nil {
+		ras.Session.CacheKey = resource.CacheKey(ras.Type, source, params, ras.CacheVersion)
+	}
+
+	var trackedResource resource.Resource
+
+	err = waitForWorker(signals, ras.Clock, ras.Delegate.WaitingForWorker, func() error {
+		var err error
+		trackedResource, err = ras.Tracker.Init(ras.Session, ras.Type, ras.Tags)
+		return err
+	})
 	if err != nil {
 		return err
 	}
@@ -52,7 +90,7 @@ func (ras *resourceStep) Run(signals <-chan os.Signal, ready chan<- struct{}) er
 	ras.PreviousStep.Result(&versionInfo)
 
 	ras.Resource = trackedResource
-	ras.VersionedSource = ras.Action(trackedResource, ras.Repository, versionInfo)
+	ras.VersionedSource = ras.Action(trackedResource, ras.Repository, versionInfo, source, params)
 
 	err = ras.VersionedSource.Run(signals, ready)
 
@@ -109,6 +147,10 @@ type fileReadCloser struct {
 	io.Closer
 }
 
+func (ras *resourceStep) WorkerName() string {
+	return ras.Resource.WorkerName()
+}
+
 func (ras *resourceStep) StreamTo(destination ArtifactDestination) error {
 	out, err := ras.VersionedSource.StreamOut(".")
 	if err != nil {
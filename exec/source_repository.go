@@ -4,16 +4,27 @@ import (
 	"io"
 	"strings"
 	"sync"
+
+	"github.com/concourse/atc"
 )
 
 type SourceRepository struct {
 	repo  map[SourceName]ArtifactSource
 	repoL sync.RWMutex
+
+	imageVersions     map[string]atc.Version
+	imageVersionsL    sync.RWMutex
+	saveImageVersions ImageVersionsSaver
 }
 
+// ImageVersionsSaver is given every image version pinned so far, each time
+// another one is pinned.
+type ImageVersionsSaver func(map[string]atc.Version)
+
 func NewSourceRepository() *SourceRepository {
 	return &SourceRepository{
-		repo: make(map[SourceName]ArtifactSource),
+		repo:          make(map[SourceName]ArtifactSource),
+		imageVersions: make(map[string]atc.Version),
 	}
 }
 
@@ -67,6 +78,75 @@ func (repo *SourceRepository) StreamFile(path string) (io.ReadCloser, error) {
 	return nil, FileNotFoundError{Path: path}
 }
 
+// sources that were produced on a worker, e.g. by get steps, say which one
+type workerLocatedSource interface {
+	WorkerName() string
+}
+
+// WorkerNames returns the workers holding the registered sources, with one
+// entry for each source that lives on a worker.
+func (repo *SourceRepository) WorkerNames() []string {
+	names := []string{}
+
+	repo.repoL.RLock()
+	for _, source := range repo.repo {
+		if located, ok := source.(workerLocatedSource); ok {
+			names = append(names, located.WorkerName())
+		}
+	}
+	repo.repoL.RUnlock()
+
+	return names
+}
+
+// ImageVersion returns the version pinned for the image with the given key,
+// if any.
+func (repo *SourceRepository) ImageVersion(key string) (atc.Version, bool) {
+	repo.imageVersionsL.RLock()
+	version, found := repo.imageVersions[key]
+	repo.imageVersionsL.RUnlock()
+	return version, found
+}
+
+// PinImageVersion pins the image with the given key to a version for the rest
+// of the build, unless another version got pinned first, and returns the
+// pinned version.
+func (repo *SourceRepository) PinImageVersion(key string, version atc.Version) atc.Version {
+	repo.imageVersionsL.Lock()
+	defer repo.imageVersionsL.Unlock()
+
+	if pinned, found := repo.imageVersions[key]; found {
+		return pinned
+	}
+
+	repo.imageVersions[key] = version
+
+	if repo.saveImageVersions != nil {
+		pinned := make(map[string]atc.Version, len(repo.imageVersions))
+		for k, v := range repo.imageVersions {
+			pinned[k] = v
+		}
+
+		repo.saveImageVersions(pinned)
+	}
+
+	return version
+}
+
+// PersistImageVersions pins the given image versions, e.g. those saved by a
+// build before it was resumed, and has any versions pinned later saved, so
+// that the build keeps using the same images however often it is resumed.
+func (repo *SourceRepository) PersistImageVersions(pinned map[string]atc.Version, save ImageVersionsSaver) {
+	repo.imageVersionsL.Lock()
+	defer repo.imageVersionsL.Unlock()
+
+	for key, version := range pinned {
+		repo.imageVersions[key] = version
+	}
+
+	repo.saveImageVersions = save
+}
+
 type subdirectoryDestination struct {
 	destination  ArtifactDestination
 	subdirectory string
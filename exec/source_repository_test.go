@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/fakes"
 
@@ -28,6 +29,68 @@ var _ = Describe("SourceRepository", func() {
 		Ω(found).Should(BeFalse())
 	})
 
+	Describe("pinning image versions", func() {
+		It("initially has no versions pinned", func() {
+			_, found := repo.ImageVersion("some-image")
+			Ω(found).Should(BeFalse())
+		})
+
+		It("keeps the first version pinned for each image", func() {
+			Ω(repo.PinImageVersion("some-image", atc.Version{"v": "1"})).Should(Equal(atc.Version{"v": "1"}))
+			Ω(repo.PinImageVersion("some-image", atc.Version{"v": "2"})).Should(Equal(atc.Version{"v": "1"}))
+			Ω(repo.PinImageVersion("some-other-image", atc.Version{"v": "3"})).Should(Equal(atc.Version{"v": "3"}))
+
+			version, found := repo.ImageVersion("some-image")
+			Ω(found).Should(BeTrue())
+			Ω(version).Should(Equal(atc.Version{"v": "1"}))
+		})
+
+		Context("when the versions are persisted", func() {
+			var saved []map[string]atc.Version
+
+			BeforeEach(func() {
+				saved = nil
+
+				repo.PersistImageVersions(map[string]atc.Version{
+					"some-image": {"v": "1"},
+				}, func(versions map[string]atc.Version) {
+					saved = append(saved, versions)
+				})
+			})
+
+			It("starts out with the given versions pinned", func() {
+				version, found := repo.ImageVersion("some-image")
+				Ω(found).Should(BeTrue())
+				Ω(version).Should(Equal(atc.Version{"v": "1"}))
+
+				Ω(repo.PinImageVersion("some-image", atc.Version{"v": "2"})).Should(Equal(atc.Version{"v": "1"}))
+			})
+
+			It("saves all of the pinned versions whenever another is pinned", func() {
+				repo.PinImageVersion("some-image", atc.Version{"v": "2"})
+				Ω(saved).Should(BeEmpty())
+
+				repo.PinImageVersion("some-other-image", atc.Version{"v": "3"})
+				Ω(saved).Should(Equal([]map[string]atc.Version{
+					{
+						"some-image":       {"v": "1"},
+						"some-other-image": {"v": "3"},
+					},
+				}))
+			})
+		})
+	})
+
+	Describe("WorkerNames", func() {
+		It("returns the workers of the sources that live on one", func() {
+			repo.RegisterSource("first-source", workerLocatedSource{new(fakes.FakeArtifactSource), "some-worker"})
+			repo.RegisterSource("second-source", new(fakes.FakeArtifactSource))
+			repo.RegisterSource("third-source", workerLocatedSource{new(fakes.FakeArtifactSource), "some-worker"})
+
+			Ω(repo.WorkerNames()).Should(ConsistOf("some-worker", "some-worker"))
+		})
+	})
+
 	Context("when a source is registered", func() {
 		var firstSource *fakes.FakeArtifactSource
 
@@ -183,3 +246,13 @@ var _ = Describe("SourceRepository", func() {
 		})
 	})
 })
+
+type workerLocatedSource struct {
+	*fakes.FakeArtifactSource
+
+	workerName string
+}
+
+func (source workerLocatedSource) WorkerName() string {
+	return source.workerName
+}
@@ -0,0 +1,273 @@
+package exec
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/resource"
+	"github.com/concourse/atc/worker"
+)
+
+// ImageRootFSFile is the file an image resource must fetch: a tarball of the
+// image's filesystem, which is unpacked and used as the task's rootfs. This is
+// what the docker-image resource fetches when given the imageParams.
+const ImageRootFSFile = "rootfs.tar"
+
+// ImageMetadataFile is an optional file fetched alongside the rootfs, saying
+// how processes should be run in the image.
+const ImageMetadataFile = "metadata.json"
+
+// imageRootFSDir is where the fetched rootfs is unpacked, within the image
+// resource's fetched bits.
+const imageRootFSDir = "rootfs"
+
+// imageRootFSPropertyName records on the image resource's container where its
+// rootfs was unpacked, so that it is only unpacked once.
+const imageRootFSPropertyName = "concourse:image-rootfs"
+
+var ErrNoImageVersions = errors.New("image resource has no versions")
+
+var ErrNoImageRootFS = errors.New("image resource did not fetch a '" + ImageRootFSFile + "' file")
+
+var imageParams = atc.Params{"rootfs": true}
+
+type imageMetadata struct {
+	Env  []string `json:"env"`
+	User string   `json:"user"`
+}
+
+// fetchedImage is an image fetched onto a worker. Its resource has to be
+// released once the task's container no longer needs the rootfs.
+type fetchedImage struct {
+	resource resource.Resource
+
+	rootFSPath string
+	workerName string
+	metadata   imageMetadata
+}
+
+func (image fetchedImage) Release() {
+	image.resource.Release()
+}
+
+// fetchImage gets the task's image resource and unpacks the rootfs it fetched
+// on the worker it was fetched onto. The first task in a build to use an image
+// resource pins it to its latest version; any later tasks of the build use the
+// same version.
+func (step *taskStep) fetchImage(signals <-chan os.Signal, config atc.TaskImageConfig, tags atc.Tags) (fetchedImage, error) {
+	typ := resource.ResourceType(config.Type)
+
+	key := resource.CacheKey(typ, config.Source, imageParams, nil)
+
+	version, found := step.repo.ImageVersion(key)
+	if !found {
+		latest, err := step.checkImage(signals, config, tags)
+		if err != nil {
+			return fetchedImage{}, err
+		}
+
+		version = step.repo.PinImageVersion(key, latest)
+	}
+
+	getID := step.WorkerID
+	getID.Type = worker.ContainerTypeGet
+
+	var imageResource resource.Resource
+	err := waitForWorker(signals, step.Clock, step.Delegate.WaitingForWorker, func() error {
+		var err error
+		imageResource, err = step.Tracker.Init(resource.Session{
+			ID:       getID,
+			CacheKey: resource.CacheKey(typ, config.Source, imageParams, version),
+		}, typ, tags)
+		return err
+	})
+	if err != nil {
+		return fetchedImage{}, err
+	}
+
+	image, err := step.unpackImage(signals, imageResource, config, version)
+	if err != nil {
+		imageResource.Release()
+		return fetchedImage{}, err
+	}
+
+	return image, nil
+}
+
+func (step *taskStep) unpackImage(signals <-chan os.Signal, imageResource resource.Resource, config atc.TaskImageConfig, version atc.Version) (fetchedImage, error) {
+	image := imageResource.Get(resource.IOConfig{
+		Stdout: step.Delegate.Stdout(),
+		Stderr: step.Delegate.Stderr(),
+	}, config.Source, imageParams, version)
+
+	err := image.Run(signals, make(chan struct{}))
+	if err != nil {
+		return fetchedImage{}, err
+	}
+
+	step.Delegate.ImageVersionDetermined(VersionInfo{
+		Version:  image.Version(),
+		Metadata: image.Metadata(),
+	})
+
+	rootFSDir, err := imageResource.Property(imageRootFSPropertyName)
+	if err != nil {
+		// not unpacked in this container yet. tasks may already be running on a
+		// rootfs unpacked by a concurrent fetch, so unpack into a directory of
+		// our own rather than over theirs.
+		rootFSDir = path.Join(imageRootFSDir, path.Base(step.artifactsRoot))
+
+		err = unpackRootFS(image, rootFSDir)
+		if err != nil {
+			return fetchedImage{}, err
+		}
+
+		err = imageResource.SetProperty(imageRootFSPropertyName, rootFSDir)
+		if err != nil {
+			return fetchedImage{}, err
+		}
+	}
+
+	rootFSPath, err := image.HostPath(rootFSDir)
+	if err != nil {
+		return fetchedImage{}, err
+	}
+
+	metadata, err := readImageMetadata(image)
+	if err != nil {
+		return fetchedImage{}, err
+	}
+
+	return fetchedImage{
+		resource: imageResource,
+
+		rootFSPath: "raw://" + rootFSPath,
+		workerName: imageResource.WorkerName(),
+		metadata:   metadata,
+	}, nil
+}
+
+func unpackRootFS(image resource.VersionedSource, dir string) error {
+	rootFS, err := image.StreamOut(ImageRootFSFile)
+	if err != nil {
+		return err
+	}
+
+	defer rootFS.Close()
+
+	rootFSReader := tar.NewReader(rootFS)
+
+	_, err = rootFSReader.Next()
+	if err != nil {
+		return ErrNoImageRootFS
+	}
+
+	// the file is itself a tarball, which streaming in unpacks
+	return image.StreamIn(dir, rootFSReader)
+}
+
+// holdImage keeps alive the container of the image a re-attached task is
+// running on, so that its rootfs is not evicted from under it. Its version was
+// pinned when the task was first run.
+func (step *taskStep) holdImage(config atc.TaskImageConfig, tags atc.Tags) (resource.Resource, bool) {
+	typ := resource.ResourceType(config.Type)
+
+	version, found := step.repo.ImageVersion(resource.CacheKey(typ, config.Source, imageParams, nil))
+	if !found {
+		return nil, false
+	}
+
+	getID := step.WorkerID
+	getID.Type = worker.ContainerTypeGet
+
+	imageResource, err := step.Tracker.Init(resource.Session{
+		ID:       getID,
+		CacheKey: resource.CacheKey(typ, config.Source, imageParams, version),
+	}, typ, tags)
+	if err != nil {
+		return nil, false
+	}
+
+	return imageResource, true
+}
+
+// envWithout returns the image's environment, leaving out the variables that
+// the params set instead.
+func (metadata imageMetadata) envWithout(params map[string]string) []string {
+	env := []string{}
+
+	for _, kv := range metadata.Env {
+		name := strings.SplitN(kv, "=", 2)[0]
+
+		if _, found := params[name]; !found {
+			env = append(env, kv)
+		}
+	}
+
+	return env
+}
+
+func readImageMetadata(image resource.VersionedSource) (imageMetadata, error) {
+	var metadata imageMetadata
+
+	out, err := image.StreamOut(ImageMetadataFile)
+	if err != nil {
+		return metadata, err
+	}
+
+	defer out.Close()
+
+	tarReader := tar.NewReader(out)
+
+	_, err = tarReader.Next()
+	if err != nil {
+		// no metadata; run processes as by default
+		return metadata, nil
+	}
+
+	err = json.NewDecoder(tarReader).Decode(&metadata)
+	if err != nil {
+		return metadata, fmt.Errorf("malformed image metadata: %s", err)
+	}
+
+	return metadata, nil
+}
+
+func (step *taskStep) checkImage(signals <-chan os.Signal, config atc.TaskImageConfig, tags atc.Tags) (atc.Version, error) {
+	// the source is left out, as it may contain resolved credentials
+	checkID := step.WorkerID
+	checkID.Type = worker.ContainerTypeCheck
+	checkID.CheckType = config.Type
+
+	var checkingResource resource.Resource
+	err := waitForWorker(signals, step.Clock, step.Delegate.WaitingForWorker, func() error {
+		var err error
+		checkingResource, err = step.Tracker.Init(resource.Session{
+			ID:        checkID,
+			Ephemeral: true,
+		}, resource.ResourceType(config.Type), tags)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	defer checkingResource.Release()
+
+	versions, err := checkingResource.Check(config.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		return nil, ErrNoImageVersions
+	}
+
+	return versions[len(versions)-1], nil
+}
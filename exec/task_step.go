@@ -3,6 +3,7 @@ package exec
 import (
 	"archive/tar"
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,20 +13,37 @@ import (
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/clock"
 )
 
 const taskProcessPropertyName = "concourse:task-process"
+const taskOutputsPropertyName = "concourse:task-outputs"
 const taskExitStatusPropertyName = "concourse:exit-status"
 
 var ErrInterrupted = errors.New("interrupted")
 
 type MissingInputsError struct {
 	Inputs []string
+
+	// the names of the artifacts the mapped inputs were looked up by, keyed
+	// by input name
+	MappedInputs map[string]string
 }
 
 func (err MissingInputsError) Error() string {
-	return fmt.Sprintf("missing inputs: %s", strings.Join(err.Inputs, ", "))
+	names := make([]string, len(err.Inputs))
+	for i, input := range err.Inputs {
+		if mapped, found := err.MappedInputs[input]; found {
+			names[i] = fmt.Sprintf("%s (mapped to %s)", input, mapped)
+		} else {
+			names[i] = input
+		}
+	}
+
+	return fmt.Sprintf("missing inputs: %s", strings.Join(names, ", "))
 }
 
 type taskStep struct {
@@ -39,7 +57,13 @@ type taskStep struct {
 	Tags         atc.Tags
 	ConfigSource TaskConfigSource
 
+	InputMapping  InputMapping
+	OutputMapping OutputMapping
+
 	WorkerClient worker.Client
+	Tracker      resource.Tracker
+	Clock        clock.Clock
+	Creds        creds.Manager
 
 	prev Step
 	repo *SourceRepository
@@ -47,6 +71,7 @@ type taskStep struct {
 	container     worker.Container
 	process       garden.Process
 	artifactsRoot string
+	outputs       []atc.TaskOutputConfig
 
 	exitStatus int
 }
@@ -82,6 +107,13 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 				return err
 			}
 
+			err = step.recoverOutputs()
+			if err != nil {
+				return err
+			}
+
+			step.registerSources()
+
 			return nil
 		}
 
@@ -98,6 +130,36 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		// the values to redact from its output did not survive the previous
+		// session, so resolve them again before any of it is saved
+		config, err := step.ConfigSource.FetchConfig(step.repo)
+		if err != nil {
+			return err
+		}
+
+		recorder := creds.NewRecordingManager(creds.Scoped(step.Creds, step.WorkerID.TeamName, step.WorkerID.PipelineName))
+
+		config, err = creds.ResolveTaskConfig(recorder, config)
+		if err != nil {
+			return err
+		}
+
+		step.Delegate.CredentialsResolved(append(paramValues(config.Params), recorder.Values()...))
+
+		if config.ImageResource != nil {
+			// if it cannot be held, the task carries on; its rootfs just may
+			// not outlive the image's cache
+			imageResource, held := step.holdImage(*config.ImageResource, step.mergeTags(step.Tags, config.Tags))
+			if held {
+				defer imageResource.Release()
+			}
+		}
+
+		err = step.recoverOutputs()
+		if err != nil {
+			return err
+		}
+
 		step.process, err = step.container.Attach(processID, processIO)
 		if err != nil {
 			return err
@@ -114,15 +176,57 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 
 		step.Delegate.Initializing(config)
 
-		step.container, err = step.WorkerClient.CreateContainer(
-			step.WorkerID,
-			worker.TaskContainerSpec{
-				Platform:   config.Platform,
-				Tags:       tags,
-				Image:      config.Image,
-				Privileged: bool(step.Privileged),
-			},
-		)
+		// only now, so that the resolved credentials are never saved
+		recorder := creds.NewRecordingManager(creds.Scoped(step.Creds, step.WorkerID.TeamName, step.WorkerID.PipelineName))
+
+		config, err = creds.ResolveTaskConfig(recorder, config)
+		if err != nil {
+			return err
+		}
+
+		step.Delegate.CredentialsResolved(recorder.Values())
+
+		image := config.Image
+		imageWorker := ""
+		env := step.envForParams(config.Params)
+		user := "root"
+
+		if config.ImageResource != nil {
+			fetched, err := step.fetchImage(signals, *config.ImageResource, tags)
+			if err != nil {
+				return err
+			}
+
+			// the container's rootfs lives in the image resource's container
+			defer fetched.Release()
+
+			image = fetched.rootFSPath
+			imageWorker = fetched.workerName
+
+			env = append(fetched.metadata.envWithout(config.Params), env...)
+
+			if fetched.metadata.User != "" {
+				user = fetched.metadata.User
+			}
+		}
+
+		err = waitForWorker(signals, step.Clock, step.Delegate.WaitingForWorker, func() error {
+			var err error
+			step.container, err = step.WorkerClient.CreateContainer(
+				step.WorkerID,
+				worker.TaskContainerSpec{
+					Platform:   config.Platform,
+					Tags:       tags,
+					Image:      image,
+					Privileged: bool(step.Privileged),
+
+					ImageWorker: imageWorker,
+
+					ArtifactWorkers: step.repo.WorkerNames(),
+				},
+			)
+			return err
+		})
 		if err != nil {
 			return err
 		}
@@ -132,6 +236,27 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 			return err
 		}
 
+		err = step.ensureOutputDirsExist(config.Outputs)
+		if err != nil {
+			return err
+		}
+
+		step.outputs = config.Outputs
+
+		// so that they can be registered after re-attaching
+		outputsValue, err := json.Marshal(taskOutputs{
+			ArtifactsRoot: step.artifactsRoot,
+			Outputs:       step.outputs,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = step.container.SetProperty(taskOutputsPropertyName, string(outputsValue))
+		if err != nil {
+			return err
+		}
+
 		err = step.collectInputs(config.Inputs)
 		if err != nil {
 			return err
@@ -142,10 +267,10 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		step.process, err = step.container.Run(garden.ProcessSpec{
 			Path: config.Run.Path,
 			Args: config.Run.Args,
-			Env:  step.envForParams(config.Params),
+			Env:  env,
 
 			Dir:  step.artifactsRoot,
-			User: "root",
+			User: user,
 			TTY:  &garden.TTYSpec{},
 		}, processIO)
 		if err != nil {
@@ -179,7 +304,7 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 		return ErrInterrupted
 
 	case status := <-waitExitStatus:
-		step.repo.RegisterSource(step.SourceName, step)
+		step.registerSources()
 
 		step.exitStatus = status
 
@@ -199,6 +324,55 @@ func (step *taskStep) Run(signals <-chan os.Signal, ready chan<- struct{}) error
 	}
 }
 
+func (step *taskStep) registerSources() {
+	step.repo.RegisterSource(step.SourceName, step)
+
+	for _, output := range step.outputs {
+		step.repo.RegisterSource(step.mappedOutputName(output.Name), taskOutputSource{
+			step: step,
+			path: outputPath(output),
+		})
+	}
+}
+
+// taskOutputs is saved as a property of the task's container.
+type taskOutputs struct {
+	ArtifactsRoot string                 `json:"artifacts_root"`
+	Outputs       []atc.TaskOutputConfig `json:"outputs"`
+}
+
+// recoverOutputs restores the outputs declared by the task's config, and the
+// directory they are relative to, from the container it was run in.
+// Containers created before outputs were recorded have none.
+func (step *taskStep) recoverOutputs() error {
+	outputsProp, err := step.container.Property(taskOutputsPropertyName)
+	if err != nil {
+		return nil
+	}
+
+	var outputs taskOutputs
+	err = json.Unmarshal([]byte(outputsProp), &outputs)
+	if err != nil {
+		return err
+	}
+
+	step.artifactsRoot = outputs.ArtifactsRoot
+	step.outputs = outputs.Outputs
+
+	return nil
+}
+
+// paramValues returns the values of the task's params, which are redacted
+// from its output along with any credentials they were resolved from.
+func paramValues(params map[string]string) []string {
+	values := make([]string, 0, len(params))
+	for _, value := range params {
+		values = append(values, value)
+	}
+
+	return values
+}
+
 func (step *taskStep) Result(x interface{}) bool {
 	switch v := x.(type) {
 	case *Success:
@@ -223,8 +397,16 @@ func (step *taskStep) Release() error {
 }
 
 func (step *taskStep) StreamFile(source string) (io.ReadCloser, error) {
+	return step.streamFile(step.artifactsRoot, source)
+}
+
+func (step *taskStep) StreamTo(destination ArtifactDestination) error {
+	return step.streamDirTo(step.artifactsRoot, destination)
+}
+
+func (step *taskStep) streamFile(dir string, source string) (io.ReadCloser, error) {
 	out, err := step.container.StreamOut(garden.StreamOutSpec{
-		Path: path.Join(step.artifactsRoot, source),
+		Path: path.Join(dir, source),
 	})
 
 	if err != nil {
@@ -244,9 +426,9 @@ func (step *taskStep) StreamFile(source string) (io.ReadCloser, error) {
 	}, nil
 }
 
-func (step *taskStep) StreamTo(destination ArtifactDestination) error {
+func (step *taskStep) streamDirTo(dir string, destination ArtifactDestination) error {
 	out, err := step.container.StreamOut(garden.StreamOutSpec{
-		Path: step.artifactsRoot + "/",
+		Path: dir + "/",
 	})
 	if err != nil {
 		return err
@@ -274,6 +456,27 @@ func (step *taskStep) ensureBuildDirExists(container garden.Container) error {
 	return nil
 }
 
+func (step *taskStep) ensureOutputDirsExist(outputs []atc.TaskOutputConfig) error {
+	for _, output := range outputs {
+		emptyTar := new(bytes.Buffer)
+
+		err := tar.NewWriter(emptyTar).Close()
+		if err != nil {
+			return err
+		}
+
+		err = step.container.StreamIn(garden.StreamInSpec{
+			Path:      path.Join(step.artifactsRoot, outputPath(output)),
+			TarStream: emptyTar,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (step *taskStep) collectInputs(inputs []atc.TaskInputConfig) error {
 	type inputPair struct {
 		source      ArtifactSource
@@ -282,11 +485,26 @@ func (step *taskStep) collectInputs(inputs []atc.TaskInputConfig) error {
 
 	inputMappings := []inputPair{}
 
-	var missingInputs []string
+	missingInputs := MissingInputsError{}
 	for _, input := range inputs {
-		source, found := step.repo.SourceFor(SourceName(input.Name))
+		sourceName := input.Name
+		mapped, isMapped := step.InputMapping[input.Name]
+		if isMapped {
+			sourceName = mapped
+		}
+
+		source, found := step.repo.SourceFor(SourceName(sourceName))
 		if !found {
-			missingInputs = append(missingInputs, input.Name)
+			missingInputs.Inputs = append(missingInputs.Inputs, input.Name)
+
+			if isMapped {
+				if missingInputs.MappedInputs == nil {
+					missingInputs.MappedInputs = map[string]string{}
+				}
+
+				missingInputs.MappedInputs[input.Name] = mapped
+			}
+
 			continue
 		}
 
@@ -303,13 +521,21 @@ func (step *taskStep) collectInputs(inputs []atc.TaskInputConfig) error {
 		}
 	}
 
-	if len(missingInputs) > 0 {
-		return MissingInputsError{missingInputs}
+	if len(missingInputs.Inputs) > 0 {
+		return missingInputs
 	}
 
 	return nil
 }
 
+func (step *taskStep) mappedOutputName(name string) SourceName {
+	if mapped, found := step.OutputMapping[name]; found {
+		return SourceName(mapped)
+	}
+
+	return SourceName(name)
+}
+
 func (taskStep) mergeTags(tagsOne []string, tagsTwo []string) []string {
 	var ret []string
 
@@ -365,3 +591,26 @@ func (dest *containerDestination) StreamIn(dst string, src io.Reader) error {
 		TarStream: src,
 	})
 }
+
+// taskOutputSource is one of the task's outputs, streamed from its directory
+// under the task's artifacts root.
+type taskOutputSource struct {
+	step *taskStep
+	path string
+}
+
+func (source taskOutputSource) StreamTo(destination ArtifactDestination) error {
+	return source.step.streamDirTo(path.Join(source.step.artifactsRoot, source.path), destination)
+}
+
+func (source taskOutputSource) StreamFile(filePath string) (io.ReadCloser, error) {
+	return source.step.streamFile(path.Join(source.step.artifactsRoot, source.path), filePath)
+}
+
+func outputPath(output atc.TaskOutputConfig) string {
+	if len(output.Path) == 0 {
+		return output.Name
+	}
+
+	return output.Path
+}
@@ -7,18 +7,24 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	cfakes "github.com/concourse/atc/creds/fakes"
 	. "github.com/concourse/atc/exec"
 	"github.com/concourse/atc/exec/fakes"
+	"github.com/concourse/atc/resource"
 	rfakes "github.com/concourse/atc/resource/fakes"
 	"github.com/concourse/atc/worker"
 	wfakes "github.com/concourse/atc/worker/fakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/pivotal-golang/clock/fakeclock"
 	"github.com/tedsuo/ifrit"
 )
 
@@ -26,6 +32,8 @@ var _ = Describe("GardenFactory", func() {
 	var (
 		fakeTracker      *rfakes.FakeTracker
 		fakeWorkerClient *wfakes.FakeClient
+		fakeClock        *fakeclock.FakeClock
+		fakeCreds        *cfakes.FakeManager
 
 		factory Factory
 
@@ -42,10 +50,12 @@ var _ = Describe("GardenFactory", func() {
 	BeforeEach(func() {
 		fakeTracker = new(rfakes.FakeTracker)
 		fakeWorkerClient = new(wfakes.FakeClient)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
+		fakeCreds = new(cfakes.FakeManager)
 
 		factory = NewGardenFactory(fakeWorkerClient, fakeTracker, func() string {
 			return "a-random-guid"
-		})
+		}, fakeClock, fakeCreds)
 
 		stdoutBuf = gbytes.NewBuffer()
 		stderrBuf = gbytes.NewBuffer()
@@ -58,6 +68,9 @@ var _ = Describe("GardenFactory", func() {
 			tags         []string
 			configSource *fakes.FakeTaskConfigSource
 
+			inputMapping  InputMapping
+			outputMapping OutputMapping
+
 			inStep *fakes.FakeStep
 			repo   *SourceRepository
 
@@ -74,12 +87,15 @@ var _ = Describe("GardenFactory", func() {
 			tags = []string{"step", "tags"}
 			configSource = new(fakes.FakeTaskConfigSource)
 
+			inputMapping = nil
+			outputMapping = nil
+
 			inStep = new(fakes.FakeStep)
 			repo = NewSourceRepository()
 		})
 
 		JustBeforeEach(func() {
-			step = factory.Task(sourceName, identifier, taskDelegate, privileged, tags, configSource).Using(inStep, repo)
+			step = factory.Task(sourceName, identifier, taskDelegate, privileged, tags, configSource, inputMapping, outputMapping).Using(inStep, repo)
 			process = ifrit.Invoke(step)
 		})
 
@@ -157,7 +173,7 @@ var _ = Describe("GardenFactory", func() {
 						Ω(taskSpec.Tags).Should(ConsistOf("config", "step", "tags"))
 						Ω(taskSpec.Image).Should(Equal("some-image"))
 						Ω(taskSpec.Privileged).Should(BeFalse())
-
+						Ω(taskSpec.ArtifactWorkers).Should(BeEmpty())
 					})
 
 					It("ensures artifacts root exists by streaming in an empty payload", func() {
@@ -194,9 +210,9 @@ var _ = Describe("GardenFactory", func() {
 					})
 
 					It("saves the process ID as a property", func() {
-						Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(1))
+						Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(2))
 
-						name, value := fakeContainer.SetPropertyArgsForCall(0)
+						name, value := fakeContainer.SetPropertyArgsForCall(1)
 						Ω(name).Should(Equal("concourse:task-process"))
 						Ω(value).Should(Equal("42"))
 					})
@@ -205,6 +221,308 @@ var _ = Describe("GardenFactory", func() {
 						Ω(taskDelegate.StartedCallCount()).Should(Equal(1))
 					})
 
+					Context("when the config's params refer to credentials", func() {
+						BeforeEach(func() {
+							fetchedConfig.Params = map[string]string{"SOME": "((some-password))"}
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							fakeCreds.GetStub = func(name string) (string, bool, error) {
+								return "resolved-" + strings.TrimPrefix(name, "main/"), true, nil
+							}
+						})
+
+						It("looks them up among the team's credentials", func() {
+							Ω(fakeCreds.GetCallCount()).Should(Equal(1))
+
+							names := []string{}
+							for i := 0; i < fakeCreds.GetCallCount(); i++ {
+								names = append(names, fakeCreds.GetArgsForCall(i))
+							}
+
+							Ω(names).Should(ConsistOf("main/some-password"))
+						})
+
+						It("runs the process with them resolved", func() {
+							Ω(fakeContainer.RunCallCount()).Should(Equal(1))
+
+							spec, _ := fakeContainer.RunArgsForCall(0)
+							Ω(spec.Env).Should(Equal([]string{"SOME=resolved-some-password"}))
+						})
+
+						It("tells the delegate which credentials were resolved", func() {
+							Ω(taskDelegate.CredentialsResolvedCallCount()).Should(Equal(1))
+							Ω(taskDelegate.CredentialsResolvedArgsForCall(0)).Should(ConsistOf("resolved-some-password"))
+						})
+
+						It("initializes via the delegate without resolving them", func() {
+							Ω(taskDelegate.InitializingCallCount()).Should(Equal(1))
+							Ω(taskDelegate.InitializingArgsForCall(0).Params).Should(Equal(map[string]string{"SOME": "((some-password))"}))
+						})
+
+						Context("when a credential is undefined", func() {
+							BeforeEach(func() {
+								fakeCreds.GetStub = nil
+								fakeCreds.GetReturns("", false, nil)
+							})
+
+							It("exits with an error without creating the container", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(creds.UndefinedCredentialsError{
+									Names: []string{"some-password"},
+								})))
+
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(BeZero())
+							})
+						})
+					})
+
+					Context("when the config names an image resource", func() {
+						var (
+							fakeCheckingResource *rfakes.FakeResource
+							fakeImageResource    *rfakes.FakeResource
+							fakeImage            *rfakes.FakeVersionedSource
+						)
+
+						BeforeEach(func() {
+							fetchedConfig.Image = ""
+							fetchedConfig.ImageResource = &atc.TaskImageConfig{
+								Type:   "docker-image",
+								Source: atc.Source{"repository": "some/image"},
+							}
+
+							configSource.FetchConfigReturns(fetchedConfig, nil)
+
+							fakeCheckingResource = new(rfakes.FakeResource)
+							fakeCheckingResource.CheckReturns([]atc.Version{{"v": "1"}, {"v": "2"}}, nil)
+
+							fakeImage = new(rfakes.FakeVersionedSource)
+							fakeImage.VersionReturns(atc.Version{"v": "2"})
+							fakeImage.MetadataReturns([]atc.MetadataField{{"some", "metadata"}})
+							fakeImage.StreamOutStub = func(path string) (io.ReadCloser, error) {
+								switch path {
+								case "rootfs.tar":
+									return tarFile("rootfs.tar", tarString("etc/hostname", "some-image")), nil
+								case "metadata.json":
+									return tarFile("metadata.json", `{"env":["PATH=/usr/bin","SOME=image-value"],"user":"some-user"}`), nil
+								default:
+									return nil, errors.New("unexpected path: " + path)
+								}
+							}
+							fakeImage.HostPathStub = func(path string) (string, error) {
+								return "/depot/some-handle/mnt/tmp/build/get/" + path, nil
+							}
+
+							fakeImageResource = new(rfakes.FakeResource)
+							fakeImageResource.GetReturns(fakeImage)
+							fakeImageResource.WorkerNameReturns("some-image-worker")
+							fakeImageResource.PropertyReturns("", errors.New("property does not exist"))
+
+							fakeTracker.InitStub = func(session resource.Session, typ resource.ResourceType, tags atc.Tags) (resource.Resource, error) {
+								if session.ID.Type == worker.ContainerTypeCheck {
+									return fakeCheckingResource, nil
+								}
+
+								return fakeImageResource, nil
+							}
+						})
+
+						It("checks for the latest version of the image in an ephemeral container", func() {
+							Ω(fakeTracker.InitCallCount()).Should(Equal(2))
+
+							session, typ, tags := fakeTracker.InitArgsForCall(0)
+							Ω(session.ID).Should(Equal(worker.Identifier{
+								Name:      "some-session-id",
+								Type:      worker.ContainerTypeCheck,
+								CheckType: "docker-image",
+							}))
+							Ω(session.Ephemeral).Should(BeTrue())
+							Ω(typ).Should(Equal(resource.ResourceType("docker-image")))
+							Ω(tags).Should(ConsistOf("config", "step", "tags"))
+
+							Ω(fakeCheckingResource.CheckCallCount()).Should(Equal(1))
+							source, version := fakeCheckingResource.CheckArgsForCall(0)
+							Ω(source).Should(Equal(atc.Source{"repository": "some/image"}))
+							Ω(version).Should(BeNil())
+
+							Ω(fakeCheckingResource.ReleaseCallCount()).Should(Equal(1))
+						})
+
+						It("fetches the latest version of the image, reusing a cached fetch", func() {
+							session, typ, _ := fakeTracker.InitArgsForCall(1)
+							Ω(session.ID).Should(Equal(worker.Identifier{
+								Name: "some-session-id",
+								Type: worker.ContainerTypeGet,
+							}))
+							Ω(session.CacheKey).Should(Equal(resource.CacheKey("docker-image", atc.Source{"repository": "some/image"}, atc.Params{"rootfs": true}, atc.Version{"v": "2"})))
+							Ω(typ).Should(Equal(resource.ResourceType("docker-image")))
+
+							Ω(fakeImageResource.GetCallCount()).Should(Equal(1))
+							ioConfig, source, params, version := fakeImageResource.GetArgsForCall(0)
+							Ω(ioConfig.Stdout).Should(Equal(stdoutBuf))
+							Ω(ioConfig.Stderr).Should(Equal(stderrBuf))
+							Ω(source).Should(Equal(atc.Source{"repository": "some/image"}))
+							Ω(params).Should(Equal(atc.Params{"rootfs": true}))
+							Ω(version).Should(Equal(atc.Version{"v": "2"}))
+
+							Ω(fakeImage.RunCallCount()).Should(Equal(1))
+						})
+
+						It("holds on to the fetched image until the task has finished", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+							Ω(fakeImageResource.ReleaseCallCount()).Should(Equal(1))
+						})
+
+						It("reports the version of the image", func() {
+							Ω(taskDelegate.ImageVersionDeterminedCallCount()).Should(Equal(1))
+							Ω(taskDelegate.ImageVersionDeterminedArgsForCall(0)).Should(Equal(VersionInfo{
+								Version:  atc.Version{"v": "2"},
+								Metadata: []atc.MetadataField{{"some", "metadata"}},
+							}))
+						})
+
+						It("unpacks the fetched rootfs next to it, in a directory of its own", func() {
+							Ω(fakeImageResource.PropertyCallCount()).Should(Equal(1))
+							Ω(fakeImageResource.PropertyArgsForCall(0)).Should(Equal("concourse:image-rootfs"))
+
+							Ω(fakeImage.StreamInCallCount()).Should(Equal(1))
+
+							dst, src := fakeImage.StreamInArgsForCall(0)
+							Ω(dst).Should(Equal("rootfs/a-random-guid"))
+
+							tarReader := tar.NewReader(src)
+
+							header, err := tarReader.Next()
+							Ω(err).ShouldNot(HaveOccurred())
+							Ω(header.Name).Should(Equal("etc/hostname"))
+						})
+
+						It("records where it was unpacked on the image's container", func() {
+							Ω(fakeImageResource.SetPropertyCallCount()).Should(Equal(1))
+
+							name, value := fakeImageResource.SetPropertyArgsForCall(0)
+							Ω(name).Should(Equal("concourse:image-rootfs"))
+							Ω(value).Should(Equal("rootfs/a-random-guid"))
+						})
+
+						It("creates the container with the unpacked rootfs, on the worker it was fetched onto", func() {
+							Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+							_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+							taskSpec := spec.(worker.TaskContainerSpec)
+							Ω(taskSpec.Image).Should(Equal("raw:///depot/some-handle/mnt/tmp/build/get/rootfs/a-random-guid"))
+							Ω(taskSpec.ImageWorker).Should(Equal("some-image-worker"))
+						})
+
+						Context("when the rootfs was already unpacked in the image's container", func() {
+							BeforeEach(func() {
+								fakeImageResource.PropertyStub = func(name string) (string, error) {
+									if name == "concourse:image-rootfs" {
+										return "rootfs/some-other-guid", nil
+									}
+
+									return "", errors.New("property does not exist")
+								}
+							})
+
+							It("does not unpack it again over what other tasks may be running on", func() {
+								Ω(fakeImage.StreamInCallCount()).Should(BeZero())
+								Ω(fakeImageResource.SetPropertyCallCount()).Should(BeZero())
+							})
+
+							It("creates the container with the rootfs already unpacked", func() {
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(Equal(1))
+								_, spec := fakeWorkerClient.CreateContainerArgsForCall(0)
+
+								taskSpec := spec.(worker.TaskContainerSpec)
+								Ω(taskSpec.Image).Should(Equal("raw:///depot/some-handle/mnt/tmp/build/get/rootfs/some-other-guid"))
+							})
+						})
+
+						It("runs the process with the image's environment and user, overridden by the params", func() {
+							Ω(fakeContainer.RunCallCount()).Should(Equal(1))
+
+							spec, _ := fakeContainer.RunArgsForCall(0)
+							Ω(spec.Env).Should(Equal([]string{"PATH=/usr/bin", "SOME=params"}))
+							Ω(spec.User).Should(Equal("some-user"))
+						})
+
+						Context("when the image resource did not fetch metadata", func() {
+							BeforeEach(func() {
+								fakeImage.StreamOutStub = func(path string) (io.ReadCloser, error) {
+									switch path {
+									case "rootfs.tar":
+										return tarFile("rootfs.tar", tarString("etc/hostname", "some-image")), nil
+									default:
+										return ioutil.NopCloser(new(bytes.Buffer)), nil
+									}
+								}
+							})
+
+							It("runs the process as by default", func() {
+								Ω(fakeContainer.RunCallCount()).Should(Equal(1))
+
+								spec, _ := fakeContainer.RunArgsForCall(0)
+								Ω(spec.Env).Should(Equal([]string{"SOME=params"}))
+								Ω(spec.User).Should(Equal("root"))
+							})
+						})
+
+						Context("when a version of the image is already pinned for the build", func() {
+							BeforeEach(func() {
+								key := resource.CacheKey("docker-image", atc.Source{"repository": "some/image"}, atc.Params{"rootfs": true}, nil)
+								repo.PinImageVersion(key, atc.Version{"v": "1"})
+							})
+
+							It("fetches the pinned version without checking", func() {
+								Ω(fakeCheckingResource.CheckCallCount()).Should(BeZero())
+
+								Ω(fakeImageResource.GetCallCount()).Should(Equal(1))
+								_, _, _, version := fakeImageResource.GetArgsForCall(0)
+								Ω(version).Should(Equal(atc.Version{"v": "1"}))
+							})
+						})
+
+						Context("when the image resource has no versions", func() {
+							BeforeEach(func() {
+								fakeCheckingResource.CheckReturns([]atc.Version{}, nil)
+							})
+
+							It("exits with an error without creating the container", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(ErrNoImageVersions)))
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(BeZero())
+							})
+						})
+
+						Context("when fetching the image fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								fakeImage.RunReturns(disaster)
+							})
+
+							It("exits with the error without creating the container", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(BeZero())
+							})
+						})
+
+						Context("when the image resource did not fetch a rootfs", func() {
+							BeforeEach(func() {
+								fakeImage.StreamOutStub = nil
+								fakeImage.StreamOutReturns(ioutil.NopCloser(new(bytes.Buffer)), nil)
+							})
+
+							It("exits with an error without creating the container", func() {
+								Eventually(process.Wait()).Should(Receive(Equal(ErrNoImageRootFS)))
+								Ω(fakeWorkerClient.CreateContainerCallCount()).Should(BeZero())
+							})
+
+							It("releases the image resource", func() {
+								Eventually(process.Wait()).Should(Receive())
+								Ω(fakeImageResource.ReleaseCallCount()).Should(Equal(1))
+							})
+						})
+					})
+
 					Context("when privileged", func() {
 						BeforeEach(func() {
 							privileged = true
@@ -349,6 +667,160 @@ var _ = Describe("GardenFactory", func() {
 								Ω(err.(MissingInputsError).Inputs).Should(ConsistOf("some-other-input"))
 							})
 						})
+
+						Context("when the inputs are mapped to other names", func() {
+							BeforeEach(func() {
+								inputMapping = InputMapping{"some-input": "some-mapped-input"}
+
+								repo.RegisterSource("some-mapped-input", inputSource)
+								repo.RegisterSource("some-other-input", otherInputSource)
+							})
+
+							It("streams the mapped sources to the inputs' destinations", func() {
+								Ω(inputSource.StreamToCallCount()).Should(Equal(1))
+
+								destination := inputSource.StreamToArgsForCall(0)
+
+								initial := fakeContainer.StreamInCallCount()
+
+								err := destination.StreamIn("foo", new(bytes.Buffer))
+								Ω(err).ShouldNot(HaveOccurred())
+
+								spec := fakeContainer.StreamInArgsForCall(initial)
+								Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-input-configured-path/foo"))
+							})
+
+							Context("when a mapped source is missing", func() {
+								BeforeEach(func() {
+									inputMapping = InputMapping{"some-input": "some-bogus-input"}
+								})
+
+								It("exits with failure naming both the input and what it was mapped to", func() {
+									var err error
+									Eventually(process.Wait()).Should(Receive(&err))
+									Ω(err).Should(Equal(MissingInputsError{
+										Inputs:       []string{"some-input"},
+										MappedInputs: map[string]string{"some-input": "some-bogus-input"},
+									}))
+									Ω(err.Error()).Should(Equal("missing inputs: some-input (mapped to some-bogus-input)"))
+								})
+							})
+						})
+					})
+
+					Context("when the configuration specifies outputs", func() {
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Image: "some-image",
+								Run: atc.TaskRunConfig{
+									Path: "ls",
+								},
+								Outputs: []atc.TaskOutputConfig{
+									{Name: "some-output", Path: "some-output-configured-path"},
+									{Name: "some-other-output"},
+								},
+							}, nil)
+
+							fakeProcess.WaitReturns(0, nil)
+						})
+
+						It("ensures each output directory exists", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Ω(fakeContainer.StreamInCallCount()).Should(Equal(3))
+							Ω(fakeContainer.StreamInArgsForCall(1).Path).Should(Equal("/tmp/build/a-random-guid/some-output-configured-path"))
+							Ω(fakeContainer.StreamInArgsForCall(2).Path).Should(Equal("/tmp/build/a-random-guid/some-other-output"))
+						})
+
+						It("saves the outputs as a property before running the process", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							Ω(fakeContainer.SetPropertyCallCount()).Should(BeNumerically(">", 0))
+
+							name, value := fakeContainer.SetPropertyArgsForCall(0)
+							Ω(name).Should(Equal("concourse:task-outputs"))
+							Ω(value).Should(MatchJSON(`{
+								"artifacts_root": "/tmp/build/a-random-guid",
+								"outputs": [
+									{"name": "some-output", "path": "some-output-configured-path"},
+									{"name": "some-other-output"}
+								]
+							}`))
+						})
+
+						It("registers each output as a source", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							_, found := repo.SourceFor(sourceName)
+							Ω(found).Should(BeTrue())
+
+							_, found = repo.SourceFor("some-output")
+							Ω(found).Should(BeTrue())
+
+							_, found = repo.SourceFor("some-other-output")
+							Ω(found).Should(BeTrue())
+						})
+
+						Context("when the outputs are mapped to other names", func() {
+							BeforeEach(func() {
+								outputMapping = OutputMapping{"some-output": "some-mapped-output"}
+							})
+
+							It("registers them under the mapped names", func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								_, found := repo.SourceFor("some-mapped-output")
+								Ω(found).Should(BeTrue())
+
+								_, found = repo.SourceFor("some-output")
+								Ω(found).Should(BeFalse())
+
+								_, found = repo.SourceFor("some-other-output")
+								Ω(found).Should(BeTrue())
+							})
+						})
+
+						Describe("a registered output", func() {
+							var outputSource ArtifactSource
+
+							JustBeforeEach(func() {
+								Eventually(process.Wait()).Should(Receive(BeNil()))
+
+								var found bool
+								outputSource, found = repo.SourceFor("some-output")
+								Ω(found).Should(BeTrue())
+							})
+
+							It("streams only its directory to a destination", func() {
+								streamedOut := gbytes.NewBuffer()
+								fakeContainer.StreamOutReturns(streamedOut, nil)
+
+								fakeDestination := new(fakes.FakeArtifactDestination)
+
+								err := outputSource.StreamTo(fakeDestination)
+								Ω(err).ShouldNot(HaveOccurred())
+
+								Ω(fakeContainer.StreamOutCallCount()).Should(Equal(1))
+								spec := fakeContainer.StreamOutArgsForCall(0)
+								Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-output-configured-path/"))
+
+								Ω(fakeDestination.StreamInCallCount()).Should(Equal(1))
+								dest, src := fakeDestination.StreamInArgsForCall(0)
+								Ω(dest).Should(Equal("."))
+								Ω(src).Should(Equal(streamedOut))
+							})
+
+							It("streams files out relative to its directory", func() {
+								fakeContainer.StreamOutReturns(nil, errors.New("nope"))
+
+								_, err := outputSource.StreamFile("some-file")
+								Ω(err).Should(HaveOccurred())
+
+								Ω(fakeContainer.StreamOutCallCount()).Should(Equal(1))
+								spec := fakeContainer.StreamOutArgsForCall(0)
+								Ω(spec.Path).Should(Equal("/tmp/build/a-random-guid/some-output-configured-path/some-file"))
+							})
+						})
 					})
 
 					Context("when the process exits 0", func() {
@@ -359,9 +831,9 @@ var _ = Describe("GardenFactory", func() {
 						It("saves the exit status property", func() {
 							Eventually(process.Wait()).Should(Receive(BeNil()))
 
-							Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(2))
+							Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(3))
 
-							name, value := fakeContainer.SetPropertyArgsForCall(1)
+							name, value := fakeContainer.SetPropertyArgsForCall(2)
 							Ω(name).Should(Equal("concourse:exit-status"))
 							Ω(value).Should(Equal("0"))
 						})
@@ -591,9 +1063,9 @@ var _ = Describe("GardenFactory", func() {
 						It("saves the exit status property", func() {
 							Eventually(process.Wait()).Should(Receive(BeNil()))
 
-							Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(2))
+							Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(3))
 
-							name, value := fakeContainer.SetPropertyArgsForCall(1)
+							name, value := fakeContainer.SetPropertyArgsForCall(2)
 							Ω(name).Should(Equal("concourse:exit-status"))
 							Ω(value).Should(Equal("1"))
 						})
@@ -750,6 +1222,58 @@ var _ = Describe("GardenFactory", func() {
 					})
 				})
 
+				Context("when all workers are at capacity", func() {
+					var fakeContainer *wfakes.FakeContainer
+
+					BeforeEach(func() {
+						fakeContainer = new(wfakes.FakeContainer)
+						fakeContainer.RunReturns(new(gfakes.FakeProcess), nil)
+
+						fakeWorkerClient.CreateContainerStub = func(worker.Identifier, worker.ContainerSpec) (worker.Container, error) {
+							if fakeWorkerClient.CreateContainerCallCount() < 3 {
+								return nil, worker.NoCapacityError{}
+							}
+
+							return fakeContainer, nil
+						}
+					})
+
+					It("retries until a worker has capacity", func() {
+						Eventually(func() int {
+							fakeClock.Increment(10 * time.Second)
+							return fakeWorkerClient.CreateContainerCallCount()
+						}).Should(Equal(3))
+
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+						Ω(fakeContainer.RunCallCount()).Should(Equal(1))
+					})
+
+					It("tells the delegate it is waiting only once", func() {
+						Eventually(func() int {
+							fakeClock.Increment(10 * time.Second)
+							return fakeWorkerClient.CreateContainerCallCount()
+						}).Should(Equal(3))
+
+						Ω(taskDelegate.WaitingForWorkerCallCount()).Should(Equal(1))
+					})
+
+					Context("when interrupted while waiting", func() {
+						BeforeEach(func() {
+							fakeWorkerClient.CreateContainerStub = nil
+							fakeWorkerClient.CreateContainerReturns(nil, worker.NoCapacityError{})
+						})
+
+						JustBeforeEach(func() {
+							Eventually(taskDelegate.WaitingForWorkerCallCount).Should(Equal(1))
+						})
+
+						It("exits with ErrInterrupted", func() {
+							process.Signal(os.Interrupt)
+							Eventually(process.Wait()).Should(Receive(Equal(ErrInterrupted)))
+						})
+					})
+				})
+
 				Context("when creating the container fails", func() {
 					disaster := errors.New("nope")
 
@@ -852,6 +1376,49 @@ var _ = Describe("GardenFactory", func() {
 					Eventually(process.Wait()).Should(Receive(BeNil()))
 					Ω(taskDelegate.FinishedCallCount()).Should(BeZero())
 				})
+
+				Context("when the task's outputs were saved", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertyStub = func(name string) (string, error) {
+							switch name {
+							case "concourse:exit-status":
+								return "0", nil
+							case "concourse:task-outputs":
+								return `{
+									"artifacts_root": "/tmp/build/some-previous-guid",
+									"outputs": [{"name": "some-output", "path": "some-output-configured-path"}]
+								}`, nil
+							default:
+								return "", errors.New("unstubbed property: " + name)
+							}
+						}
+					})
+
+					It("registers the task and each output as a source", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						_, found := repo.SourceFor(sourceName)
+						Ω(found).Should(BeTrue())
+
+						_, found = repo.SourceFor("some-output")
+						Ω(found).Should(BeTrue())
+					})
+
+					It("streams the outputs from where the task put them", func() {
+						Eventually(process.Wait()).Should(Receive(BeNil()))
+
+						outputSource, found := repo.SourceFor("some-output")
+						Ω(found).Should(BeTrue())
+
+						fakeContainer.StreamOutReturns(ioutil.NopCloser(new(bytes.Buffer)), nil)
+
+						err := outputSource.StreamTo(new(fakes.FakeArtifactDestination))
+						Ω(err).ShouldNot(HaveOccurred())
+
+						spec := fakeContainer.StreamOutArgsForCall(0)
+						Ω(spec.Path).Should(Equal("/tmp/build/some-previous-guid/some-output-configured-path/"))
+					})
+				})
 			})
 
 			Context("when the process id can be found", func() {
@@ -892,6 +1459,125 @@ var _ = Describe("GardenFactory", func() {
 					It("does not invoke the delegate's Started callback", func() {
 						Ω(taskDelegate.StartedCallCount()).Should(BeZero())
 					})
+
+					It("does not initialize via the delegate again", func() {
+						Ω(taskDelegate.InitializingCallCount()).Should(BeZero())
+					})
+
+					Context("when the task's outputs were saved", func() {
+						BeforeEach(func() {
+							fakeContainer.PropertyStub = func(name string) (string, error) {
+								switch name {
+								case "concourse:task-process":
+									return "42", nil
+								case "concourse:task-outputs":
+									return `{
+										"artifacts_root": "/tmp/build/some-previous-guid",
+										"outputs": [{"name": "some-output"}]
+									}`, nil
+								default:
+									return "", errors.New("unstubbed property: " + name)
+								}
+							}
+
+							fakeProcess.WaitReturns(0, nil)
+						})
+
+						It("registers each output as a source once the process exits", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+
+							_, found := repo.SourceFor("some-output")
+							Ω(found).Should(BeTrue())
+						})
+					})
+
+					Context("when the task runs on an image resource whose version was pinned", func() {
+						var fakeImageResource *rfakes.FakeResource
+
+						imageSource := atc.Source{"some": "image-source"}
+
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								ImageResource: &atc.TaskImageConfig{
+									Type:   "docker-image",
+									Source: imageSource,
+								},
+							}, nil)
+
+							repo.PinImageVersion(
+								resource.CacheKey("docker-image", imageSource, atc.Params{"rootfs": true}, nil),
+								atc.Version{"v": "2"},
+							)
+
+							fakeImageResource = new(rfakes.FakeResource)
+							fakeTracker.InitReturns(fakeImageResource, nil)
+
+							fakeProcess.WaitReturns(0, nil)
+						})
+
+						It("holds on to the image's container so its rootfs is not evicted", func() {
+							Ω(fakeTracker.InitCallCount()).Should(Equal(1))
+
+							session, typ, _ := fakeTracker.InitArgsForCall(0)
+							Ω(typ).Should(Equal(resource.ResourceType("docker-image")))
+							Ω(session.ID.Type).Should(Equal(worker.ContainerTypeGet))
+							Ω(session.CacheKey).Should(Equal(resource.CacheKey(
+								"docker-image",
+								imageSource,
+								atc.Params{"rootfs": true},
+								atc.Version{"v": "2"},
+							)))
+						})
+
+						It("releases the image once the process exits", func() {
+							Eventually(process.Wait()).Should(Receive(BeNil()))
+							Ω(fakeImageResource.ReleaseCallCount()).Should(Equal(1))
+						})
+					})
+
+					Context("when the config has params referring to credentials", func() {
+						var resolvedBeforeAttaching int
+
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{
+								Params: map[string]string{
+									"SOME":  "((some-password))",
+									"OTHER": "some-literal",
+								},
+							}, nil)
+
+							fakeCreds.GetStub = func(name string) (string, bool, error) {
+								return "resolved-" + strings.TrimPrefix(name, "main/"), true, nil
+							}
+
+							fakeContainer.AttachStub = func(uint32, garden.ProcessIO) (garden.Process, error) {
+								resolvedBeforeAttaching = taskDelegate.CredentialsResolvedCallCount()
+								return fakeProcess, nil
+							}
+						})
+
+						It("tells the delegate the values to redact before attaching", func() {
+							Ω(resolvedBeforeAttaching).Should(Equal(1))
+							Ω(taskDelegate.CredentialsResolvedArgsForCall(0)).Should(ConsistOf(
+								"resolved-some-password",
+								"resolved-some-password",
+								"some-literal",
+							))
+						})
+					})
+
+					Context("when fetching the config fails", func() {
+						disaster := errors.New("nope")
+
+						BeforeEach(func() {
+							configSource.FetchConfigReturns(atc.TaskConfig{}, disaster)
+						})
+
+						It("exits with the error without attaching", func() {
+							Eventually(process.Wait()).Should(Receive(Equal(disaster)))
+							Ω(fakeContainer.AttachCallCount()).Should(BeZero())
+						})
+					})
 				})
 
 				Context("when attaching to the process fails", func() {
@@ -933,3 +1619,27 @@ var _ = Describe("GardenFactory", func() {
 		})
 	})
 })
+
+func tarFile(name string, content string) io.ReadCloser {
+	return ioutil.NopCloser(bytes.NewBufferString(tarString(name, content)))
+}
+
+func tarString(name string, content string) string {
+	tarBuffer := new(bytes.Buffer)
+	tarWriter := tar.NewWriter(tarBuffer)
+
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(content)),
+	})
+	Ω(err).ShouldNot(HaveOccurred())
+
+	_, err = tarWriter.Write([]byte(content))
+	Ω(err).ShouldNot(HaveOccurred())
+
+	err = tarWriter.Close()
+	Ω(err).ShouldNot(HaveOccurred())
+
+	return tarBuffer.String()
+}
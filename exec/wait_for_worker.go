@@ -0,0 +1,40 @@
+package exec
+
+import (
+	"os"
+	"time"
+
+	"github.com/concourse/atc/worker"
+	"github.com/pivotal-golang/clock"
+)
+
+// workerRetryInterval is how long a step waits before trying again when all
+// of the workers it could run on are at capacity.
+const workerRetryInterval = 10 * time.Second
+
+// waitForWorker calls create until it returns something other than a
+// worker.NoCapacityError, telling the delegate the first time it has to wait.
+func waitForWorker(signals <-chan os.Signal, clock clock.Clock, waiting func(), create func() error) error {
+	notified := false
+
+	for {
+		err := create()
+		if _, atCapacity := err.(worker.NoCapacityError); !atCapacity {
+			return err
+		}
+
+		if !notified {
+			waiting()
+			notified = true
+		}
+
+		timer := clock.NewTimer(workerRetryInterval)
+
+		select {
+		case <-timer.C():
+		case <-signals:
+			timer.Stop()
+			return ErrInterrupted
+		}
+	}
+}
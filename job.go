@@ -14,10 +14,11 @@ type Job struct {
 }
 
 type JobInput struct {
-	Name     string   `json:"name"`
-	Resource string   `json:"resource"`
-	Passed   []string `json:"passed,omitempty"`
-	Trigger  bool     `json:"trigger"`
+	Name     string         `json:"name"`
+	Resource string         `json:"resource"`
+	Passed   []string       `json:"passed,omitempty"`
+	Trigger  bool           `json:"trigger"`
+	Version  *VersionConfig `json:"version,omitempty"`
 }
 
 type JobOutput struct {
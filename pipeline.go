@@ -1,7 +1,8 @@
 package atc
 
 type Pipeline struct {
-	Name   string `json:"name"`
-	URL    string `json:"url"`
-	Paused bool   `json:"paused"`
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Paused   bool   `json:"paused"`
+	TeamName string `json:"team_name"`
 }
@@ -19,6 +19,14 @@ type FakeRadarSchedulerFactory struct {
 	buildRadarReturns struct {
 		result1 *radar.Radar
 	}
+	BuildScannerStub        func(pipelineDB db.PipelineDB) radar.Scanner
+	buildScannerMutex       sync.RWMutex
+	buildScannerArgsForCall []struct {
+		pipelineDB db.PipelineDB
+	}
+	buildScannerReturns struct {
+		result1 radar.Scanner
+	}
 	BuildSchedulerStub        func(pipelineDB db.PipelineDB) *scheduler.Scheduler
 	buildSchedulerMutex       sync.RWMutex
 	buildSchedulerArgsForCall []struct {
@@ -61,6 +69,38 @@ func (fake *FakeRadarSchedulerFactory) BuildRadarReturns(result1 *radar.Radar) {
 	}{result1}
 }
 
+func (fake *FakeRadarSchedulerFactory) BuildScanner(pipelineDB db.PipelineDB) radar.Scanner {
+	fake.buildScannerMutex.Lock()
+	fake.buildScannerArgsForCall = append(fake.buildScannerArgsForCall, struct {
+		pipelineDB db.PipelineDB
+	}{pipelineDB})
+	fake.buildScannerMutex.Unlock()
+	if fake.BuildScannerStub != nil {
+		return fake.BuildScannerStub(pipelineDB)
+	} else {
+		return fake.buildScannerReturns.result1
+	}
+}
+
+func (fake *FakeRadarSchedulerFactory) BuildScannerCallCount() int {
+	fake.buildScannerMutex.RLock()
+	defer fake.buildScannerMutex.RUnlock()
+	return len(fake.buildScannerArgsForCall)
+}
+
+func (fake *FakeRadarSchedulerFactory) BuildScannerArgsForCall(i int) db.PipelineDB {
+	fake.buildScannerMutex.RLock()
+	defer fake.buildScannerMutex.RUnlock()
+	return fake.buildScannerArgsForCall[i].pipelineDB
+}
+
+func (fake *FakeRadarSchedulerFactory) BuildScannerReturns(result1 radar.Scanner) {
+	fake.BuildScannerStub = nil
+	fake.buildScannerReturns = struct {
+		result1 radar.Scanner
+	}{result1}
+}
+
 func (fake *FakeRadarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB) *scheduler.Scheduler {
 	fake.buildSchedulerMutex.Lock()
 	fake.buildSchedulerArgsForCall = append(fake.buildSchedulerArgsForCall, struct {
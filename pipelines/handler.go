@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"net/http"
 
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 )
 
@@ -13,8 +14,9 @@ type PipelineHandlerFactory struct {
 
 func (pdbh *PipelineHandlerFactory) HandlerFor(handlerFunc func(db.PipelineDB) http.Handler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		teamName := auth.RequestedTeamName(r)
 		pipelineName := r.FormValue(":pipeline_name")
-		pipelineDB, err := pdbh.pipelineDBFactory.BuildWithName(pipelineName)
+		pipelineDB, err := pdbh.pipelineDBFactory.BuildWithTeamNameAndName(teamName, pipelineName)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				w.WriteHeader(http.StatusNotFound)
@@ -3,6 +3,7 @@ package pipelines
 import (
 	"time"
 
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/engine"
 	"github.com/concourse/atc/radar"
@@ -24,6 +25,7 @@ type Locker interface {
 
 type RadarSchedulerFactory interface {
 	BuildRadar(pipelineDB db.PipelineDB) *radar.Radar
+	BuildScanner(pipelineDB db.PipelineDB) radar.Scanner
 	BuildScheduler(pipelineDB db.PipelineDB) *scheduler.Scheduler
 }
 
@@ -33,6 +35,7 @@ type radarSchedulerFactory struct {
 	locker   Locker
 	engine   engine.Engine
 	db       db.DB
+	creds    creds.Manager
 }
 
 func NewRadarSchedulerFactory(
@@ -41,6 +44,7 @@ func NewRadarSchedulerFactory(
 	locker Locker,
 	engine engine.Engine,
 	db db.DB,
+	creds creds.Manager,
 ) RadarSchedulerFactory {
 	return &radarSchedulerFactory{
 		tracker:  tracker,
@@ -48,11 +52,16 @@ func NewRadarSchedulerFactory(
 		locker:   locker,
 		engine:   engine,
 		db:       db,
+		creds:    creds,
 	}
 }
 
 func (rsf *radarSchedulerFactory) BuildRadar(pipelineDB db.PipelineDB) *radar.Radar {
-	return radar.NewRadar(rsf.tracker, rsf.interval, rsf.locker, pipelineDB)
+	return radar.NewRadar(rsf.tracker, rsf.interval, rsf.locker, pipelineDB, rsf.creds)
+}
+
+func (rsf *radarSchedulerFactory) BuildScanner(pipelineDB db.PipelineDB) radar.Scanner {
+	return rsf.BuildRadar(pipelineDB)
 }
 
 func (rsf *radarSchedulerFactory) BuildScheduler(pipelineDB db.PipelineDB) *scheduler.Scheduler {
@@ -54,10 +54,10 @@ func (syncer *Syncer) Sync() {
 		return
 	}
 
-	for name, runningPipeline := range syncer.runningPipelines {
+	for key, runningPipeline := range syncer.runningPipelines {
 		select {
 		case <-runningPipeline.Exited:
-			syncer.removePipeline(name)
+			syncer.removePipeline(key)
 		default:
 		}
 
@@ -67,7 +67,7 @@ func (syncer *Syncer) Sync() {
 				continue
 			}
 
-			if pipeline.Name == name {
+			if pipelineKey(pipeline) == key {
 				found = true
 			}
 		}
@@ -78,7 +78,7 @@ func (syncer *Syncer) Sync() {
 	}
 
 	for _, pipeline := range pipelines {
-		if pipeline.Paused || syncer.isPipelineRunning(pipeline.Name) {
+		if pipeline.Paused || syncer.isPipelineRunning(pipelineKey(pipeline)) {
 			continue
 		}
 
@@ -87,18 +87,23 @@ func (syncer *Syncer) Sync() {
 
 		process := ifrit.Invoke(runner)
 
-		syncer.runningPipelines[pipeline.Name] = runningProcess{
+		syncer.runningPipelines[pipelineKey(pipeline)] = runningProcess{
 			Process: process,
 			Exited:  process.Wait(),
 		}
 	}
 }
 
-func (syncer *Syncer) removePipeline(pipelineName string) {
-	delete(syncer.runningPipelines, pipelineName)
+func (syncer *Syncer) removePipeline(key string) {
+	delete(syncer.runningPipelines, key)
 }
 
-func (syncer *Syncer) isPipelineRunning(pipelineName string) bool {
-	_, found := syncer.runningPipelines[pipelineName]
+func (syncer *Syncer) isPipelineRunning(key string) bool {
+	_, found := syncer.runningPipelines[key]
 	return found
 }
+
+// pipeline names are only unique within a team
+func pipelineKey(pipeline db.SavedPipeline) string {
+	return pipeline.TeamName + "/" + pipeline.Name
+}
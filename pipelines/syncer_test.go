@@ -133,6 +133,40 @@ var _ = Describe("Pipelines Syncer", func() {
 		})
 	})
 
+	Context("when pipelines in different teams share a name", func() {
+		BeforeEach(func() {
+			pipelineDBFactory.BuildStub = func(pipeline db.SavedPipeline) db.PipelineDB {
+				if pipeline.TeamName == "some-team" {
+					return otherPipelineDB
+				}
+
+				return pipelineDB
+			}
+
+			pipelinesDB.GetAllActivePipelinesReturns([]db.SavedPipeline{
+				{
+					ID:       1,
+					TeamName: "main",
+					Pipeline: db.Pipeline{
+						Name: "pipeline",
+					},
+				},
+				{
+					ID:       2,
+					TeamName: "some-team",
+					Pipeline: db.Pipeline{
+						Name: "pipeline",
+					},
+				},
+			}, nil)
+		})
+
+		It("spawns a process for each of them", func() {
+			Ω(fakeRunner.RunCallCount()).Should(Equal(1))
+			Ω(otherFakeRunner.RunCallCount()).Should(Equal(1))
+		})
+	})
+
 	Context("when we sync again", func() {
 		It("does not spawn any processes again", func() {
 			syncer.Sync()
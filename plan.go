@@ -52,6 +52,8 @@ type GetPlan struct {
 	Version  Version  `json:"version,omitempty"`
 	Tags     Tags     `json:"tags,omitempty"`
 	Timeout  Duration `json:"timeout,omitempty"`
+
+	Sensitive []string `json:"sensitive,omitempty"`
 }
 
 type PutPlan struct {
@@ -64,6 +66,8 @@ type PutPlan struct {
 	GetParams Params   `json:"get_params,omitempty"`
 	Tags      Tags     `json:"tags,omitempty"`
 	Timeout   Duration `json:"timeout,omitempty"`
+
+	Sensitive []string `json:"sensitive,omitempty"`
 }
 
 func (plan PutPlan) GetPlan() GetPlan {
@@ -76,6 +80,8 @@ func (plan PutPlan) GetPlan() GetPlan {
 		Params:   plan.GetParams,
 		Tags:     plan.Tags,
 		Timeout:  plan.Timeout,
+
+		Sensitive: plan.Sensitive,
 	}
 }
 
@@ -88,6 +94,9 @@ type TaskPlan struct {
 
 	ConfigPath string      `json:"config_path,omitempty"`
 	Config     *TaskConfig `json:"config,omitempty"`
+
+	InputMapping  map[string]string `json:"input_mapping,omitempty"`
+	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 }
 
 type ConditionalPlan struct {
@@ -16,6 +16,12 @@ type FakeRadarDB struct {
 	getPipelineNameReturns struct {
 		result1 string
 	}
+	GetPipelineTeamNameStub        func() string
+	getPipelineTeamNameMutex       sync.RWMutex
+	getPipelineTeamNameArgsForCall []struct{}
+	getPipelineTeamNameReturns struct {
+		result1 string
+	}
 	ScopedNameStub        func(string) string
 	scopedNameMutex       sync.RWMutex
 	scopedNameArgsForCall []struct {
@@ -117,6 +123,30 @@ func (fake *FakeRadarDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRadarDB) GetPipelineTeamName() string {
+	fake.getPipelineTeamNameMutex.Lock()
+	fake.getPipelineTeamNameArgsForCall = append(fake.getPipelineTeamNameArgsForCall, struct{}{})
+	fake.getPipelineTeamNameMutex.Unlock()
+	if fake.GetPipelineTeamNameStub != nil {
+		return fake.GetPipelineTeamNameStub()
+	} else {
+		return fake.getPipelineTeamNameReturns.result1
+	}
+}
+
+func (fake *FakeRadarDB) GetPipelineTeamNameCallCount() int {
+	fake.getPipelineTeamNameMutex.RLock()
+	defer fake.getPipelineTeamNameMutex.RUnlock()
+	return len(fake.getPipelineTeamNameArgsForCall)
+}
+
+func (fake *FakeRadarDB) GetPipelineTeamNameReturns(result1 string) {
+	fake.GetPipelineTeamNameStub = nil
+	fake.getPipelineTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRadarDB) ScopedName(arg1 string) string {
 	fake.scopedNameMutex.Lock()
 	fake.scopedNameArgsForCall = append(fake.scopedNameArgsForCall, struct {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/resource"
 	"github.com/concourse/atc/worker"
@@ -22,10 +23,24 @@ func (err resourceNotConfiguredError) Error() string {
 	return fmt.Sprintf("resource '%s' was not found in config", err.ResourceName)
 }
 
+type checkTimedOutError struct {
+	Timeout time.Duration
+}
+
+func (err checkTimedOutError) Error() string {
+	return fmt.Sprintf("check timed out after %s", err.Timeout)
+}
+
+// DefaultCheckTimeout bounds checks of resources that do not configure a
+// check_timeout, so that a hung check does not hold the resource's checking
+// lock forever.
+const DefaultCheckTimeout = time.Hour
+
 //go:generate counterfeiter . RadarDB
 
 type RadarDB interface {
 	GetPipelineName() string
+	GetPipelineTeamName() string
 	ScopedName(string) string
 
 	IsPaused() (bool, error)
@@ -41,6 +56,15 @@ type RadarDB interface {
 	SetResourceCheckError(resource db.SavedResource, err error) error
 }
 
+//go:generate counterfeiter . Scanner
+
+// Scanner checks a resource for new versions on demand, waiting for any
+// check already in progress.
+type Scanner interface {
+	Scan(lager.Logger, string) error
+	ScanFromVersion(lager.Logger, string, atc.Version) ([]atc.Version, error)
+}
+
 type Radar struct {
 	logger lager.Logger
 
@@ -50,6 +74,7 @@ type Radar struct {
 
 	locker Locker
 	db     RadarDB
+	creds  creds.Manager
 }
 
 func NewRadar(
@@ -57,24 +82,28 @@ func NewRadar(
 	interval time.Duration,
 	locker Locker,
 	db RadarDB,
+	creds creds.Manager,
 ) *Radar {
 	return &Radar{
 		tracker:  tracker,
 		interval: interval,
 		locker:   locker,
 		db:       db,
+		creds:    creds,
 	}
 }
 
 func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runner {
 	return ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
-		ticker := time.NewTicker(radar.interval)
+		interval := radar.initialInterval(logger, resourceName)
+		ticker := time.NewTicker(interval)
 
 		close(ready)
 
 		for {
 			select {
 			case <-signals:
+				ticker.Stop()
 				return nil
 
 			case <-ticker.C:
@@ -85,84 +114,112 @@ func (radar *Radar) Scanner(logger lager.Logger, resourceName string) ifrit.Runn
 					continue
 				}
 
-				err = radar.scan(logger.Session("tick"), resourceName)
+				resourceConfig, _, err := radar.scan(logger.Session("tick"), resourceName, nil)
 
 				resourceCheckingLock.Release()
 
 				if err != nil {
+					ticker.Stop()
 					return err
 				}
+
+				if resourceConfig.Name == "" {
+					// nothing was scanned, e.g. because the pipeline is paused, so
+					// there's no check_every to go by
+					continue
+				}
+
+				// the resource's check_every may have been configured or changed
+				// since the last tick
+				newInterval := radar.checkInterval(logger, resourceConfig)
+				if newInterval != interval {
+					ticker.Stop()
+
+					interval = newInterval
+					ticker = time.NewTicker(interval)
+				}
 			}
 		}
 	})
 }
 
 func (radar *Radar) Scan(logger lager.Logger, resourceName string) error {
+	_, err := radar.ScanFromVersion(logger, resourceName, nil)
+	return err
+}
+
+// ScanFromVersion checks for versions of the resource from the given version
+// rather than the latest saved one, unless it is nil, and returns the
+// versions that were found.
+func (radar *Radar) ScanFromVersion(logger lager.Logger, resourceName string, fromVersion atc.Version) ([]atc.Version, error) {
 	lock, err := radar.locker.AcquireWriteLock(radar.checkLock(radar.db.ScopedName(resourceName)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer lock.Release()
 
-	return radar.scan(logger, resourceName)
+	_, versions, err := radar.scan(logger, resourceName, fromVersion)
+	return versions, err
 }
 
-func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
+func (radar *Radar) scan(logger lager.Logger, resourceName string, fromVersion atc.Version) (atc.ResourceConfig, []atc.Version, error) {
 	pipelinePaused, err := radar.db.IsPaused()
 	if err != nil {
 		logger.Error("failed-to-check-if-pipeline-paused", err)
-		return err
+		return atc.ResourceConfig{}, nil, err
 	}
 
 	if pipelinePaused {
 		logger.Debug("pipeline-paused")
-		return nil
+		return atc.ResourceConfig{}, nil, nil
 	}
 
 	config, _, err := radar.db.GetConfig()
 	if err != nil {
 		logger.Error("failed-to-get-config", err)
 		// don't propagate error; we can just retry next tick
-		return nil
+		return atc.ResourceConfig{}, nil, nil
 	}
 
 	resourceConfig, found := config.Resources.Lookup(resourceName)
 	if !found {
 		logger.Info("resource-removed-from-configuration")
 		// return an error so that we exit
-		return resourceNotConfiguredError{ResourceName: resourceName}
+		return atc.ResourceConfig{}, nil, resourceNotConfiguredError{ResourceName: resourceName}
 	}
 
 	savedResource, err := radar.db.GetResource(resourceName)
 	if err != nil {
-		return err
+		return resourceConfig, nil, err
 	}
 
 	if savedResource.Paused {
-		return nil
+		return resourceConfig, nil, nil
 	}
 
 	typ := resource.ResourceType(resourceConfig.Type)
 
-	res, err := radar.tracker.Init(checkIdentifier(radar.db.GetPipelineName(), resourceConfig), typ, []string{})
+	res, err := radar.tracker.Init(checkIdentifier(radar.db.GetPipelineTeamName(), radar.db.GetPipelineName(), resourceConfig), typ, []string{})
 	if err != nil {
 		logger.Error("failed-to-initialize-new-resource", err)
-		return err
+		return resourceConfig, nil, err
 	}
 
 	defer res.Release()
 
-	var from db.Version
-	if vr, err := radar.db.GetLatestVersionedResource(savedResource); err == nil {
-		from = vr.Version
+	from := fromVersion
+	if from == nil {
+		if vr, err := radar.db.GetLatestVersionedResource(savedResource); err == nil {
+			from = atc.Version(vr.Version)
+		}
 	}
 
 	logger.Debug("checking", lager.Data{
 		"from": from,
 	})
 
-	newVersions, err := res.Check(resourceConfig.Source, atc.Version(from))
+	newVersions, err := radar.check(logger, res, resourceConfig, from)
 	setErr := radar.db.SetResourceCheckError(savedResource, err)
 	if setErr != nil {
 		logger.Error("failed-to-set-check-error", err)
@@ -171,12 +228,12 @@ func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
 	if err != nil {
 		logger.Error("failed-to-check", err)
 
-		return err
+		return resourceConfig, nil, err
 	}
 
 	if len(newVersions) == 0 {
 		logger.Debug("no-new-versions")
-		return nil
+		return resourceConfig, newVersions, nil
 	}
 
 	logger.Info("versions-found", lager.Data{
@@ -191,16 +248,94 @@ func (radar *Radar) scan(logger lager.Logger, resourceName string) error {
 		})
 	}
 
-	return nil
+	return resourceConfig, newVersions, nil
+}
+
+func (radar *Radar) check(logger lager.Logger, res resource.Resource, resourceConfig atc.ResourceConfig, from atc.Version) ([]atc.Version, error) {
+	timeout := configuredDuration(logger, "check-timeout", resourceConfig.CheckTimeout, DefaultCheckTimeout)
+
+	scoped := creds.Scoped(radar.creds, radar.db.GetPipelineTeamName(), radar.db.GetPipelineName())
+
+	source, err := creds.ResolveSource(scoped, resourceConfig.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	type checkResult struct {
+		versions []atc.Version
+		err      error
+	}
+
+	// buffered so that a check finishing after the timeout does not leak
+	results := make(chan checkResult, 1)
+
+	go func() {
+		versions, err := res.Check(source, from)
+		results <- checkResult{versions, err}
+	}()
+
+	select {
+	case result := <-results:
+		return result.versions, result.err
+
+	case <-time.After(timeout):
+		// the next check would find this same container and run alongside the
+		// hung one, so kill it rather than leave it to pile up
+		err := res.Destroy()
+		if err != nil {
+			logger.Error("failed-to-destroy-timed-out-check", err)
+		}
+
+		return nil, checkTimedOutError{Timeout: timeout}
+	}
+}
+
+// initialInterval is the resource's check_every as configured before the
+// first tick, or the default interval if the config can't be loaded.
+func (radar *Radar) initialInterval(logger lager.Logger, resourceName string) time.Duration {
+	config, _, err := radar.db.GetConfig()
+	if err != nil {
+		logger.Error("failed-to-get-config", err)
+		return radar.interval
+	}
+
+	resourceConfig, found := config.Resources.Lookup(resourceName)
+	if !found {
+		return radar.interval
+	}
+
+	return radar.checkInterval(logger, resourceConfig)
+}
+
+func (radar *Radar) checkInterval(logger lager.Logger, resourceConfig atc.ResourceConfig) time.Duration {
+	return configuredDuration(logger, "check-every", resourceConfig.CheckEvery, radar.interval)
+}
+
+func configuredDuration(logger lager.Logger, field string, value string, fallback time.Duration) time.Duration {
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logger.Info("invalid-"+field, lager.Data{
+			field: value,
+		})
+
+		return fallback
+	}
+
+	return duration
 }
 
 func (radar *Radar) checkLock(resourceName string) []db.NamedLock {
 	return []db.NamedLock{db.ResourceCheckingLock(resourceName)}
 }
 
-func checkIdentifier(pipelineName string, res atc.ResourceConfig) resource.Session {
+func checkIdentifier(teamName string, pipelineName string, res atc.ResourceConfig) resource.Session {
 	return resource.Session{
 		ID: worker.Identifier{
+			TeamName:     teamName,
 			PipelineName: pipelineName,
 
 			Name: res.Name,
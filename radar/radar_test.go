@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/creds"
+	cfakes "github.com/concourse/atc/creds/fakes"
 	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
 	"github.com/concourse/atc/worker"
@@ -24,6 +26,7 @@ var _ = Describe("Radar", func() {
 	var (
 		fakeTracker *rfakes.FakeTracker
 		fakeRadarDB *fakes.FakeRadarDB
+		fakeCreds   *cfakes.FakeManager
 		interval    time.Duration
 
 		radar *Radar
@@ -46,7 +49,9 @@ var _ = Describe("Radar", func() {
 		interval = 100 * time.Millisecond
 
 		fakeRadarDB.GetPipelineNameReturns("some-pipeline-name")
-		radar = NewRadar(fakeTracker, interval, locker, fakeRadarDB)
+		fakeRadarDB.GetPipelineTeamNameReturns("some-team")
+		fakeCreds = new(cfakes.FakeManager)
+		radar = NewRadar(fakeTracker, interval, locker, fakeRadarDB, fakeCreds)
 
 		resourceConfig = atc.ResourceConfig{
 			Name:   "some-resource",
@@ -117,6 +122,7 @@ var _ = Describe("Radar", func() {
 			sessionID, typ, tags := fakeTracker.InitArgsForCall(0)
 			Ω(sessionID).Should(Equal(resource.Session{
 				ID: worker.Identifier{
+					TeamName:     "some-team",
 					PipelineName: "some-pipeline-name",
 
 					Name: "some-resource",
@@ -312,9 +318,13 @@ var _ = Describe("Radar", func() {
 			var newConfig atc.Config
 
 			BeforeEach(func() {
-				configs := make(chan atc.Config, 1)
-				configs <- atc.Config{
-					Resources: atc.ResourceConfigs{resourceConfig},
+				configs := make(chan atc.Config, 2)
+
+				// once for the initial interval, once for the first check
+				for i := 0; i < 2; i++ {
+					configs <- atc.Config{
+						Resources: atc.ResourceConfigs{resourceConfig},
+					}
 				}
 
 				fakeRadarDB.GetConfigStub = func() (atc.Config, db.ConfigVersion, error) {
@@ -373,6 +383,116 @@ var _ = Describe("Radar", func() {
 			})
 		})
 
+		Context("when the resource configures its own interval", func() {
+			var started time.Time
+
+			BeforeEach(func() {
+				resourceConfig.CheckEvery = "250ms"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+
+				started = time.Now()
+			})
+
+			It("checks on that interval", func() {
+				var time1 time.Time
+				var time2 time.Time
+
+				Eventually(times).Should(Receive(&time1))
+				Eventually(times).Should(Receive(&time2))
+
+				Ω(time1.Sub(started)).Should(BeNumerically("~", 250*time.Millisecond, interval/2))
+				Ω(time2.Sub(time1)).Should(BeNumerically("~", 250*time.Millisecond, interval/2))
+			})
+
+			Context("when the pipeline is paused", func() {
+				var ticks chan time.Time
+
+				BeforeEach(func() {
+					ticks = make(chan time.Time, 100)
+
+					fakeRadarDB.IsPausedStub = func() (bool, error) {
+						ticks <- time.Now()
+						return true, nil
+					}
+				})
+
+				It("keeps ticking on that interval", func() {
+					var time1 time.Time
+					var time2 time.Time
+
+					Eventually(ticks).Should(Receive(&time1))
+					Eventually(ticks).Should(Receive(&time2))
+
+					Ω(time1.Sub(started)).Should(BeNumerically("~", 250*time.Millisecond, interval/2))
+					Ω(time2.Sub(time1)).Should(BeNumerically("~", 250*time.Millisecond, interval/2))
+				})
+
+				It("does not check", func() {
+					Eventually(ticks).Should(Receive())
+					Eventually(ticks).Should(Receive())
+
+					Ω(times).ShouldNot(Receive())
+				})
+			})
+		})
+
+		Context("when a check exceeds the resource's check timeout", func() {
+			BeforeEach(func() {
+				resourceConfig.CheckTimeout = "50ms"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+
+				fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+					times <- time.Now()
+					time.Sleep(time.Second)
+					return nil, nil
+				}
+			})
+
+			It("records the timeout and exits with it", func() {
+				Eventually(times).Should(Receive())
+
+				var err error
+				Eventually(process.Wait()).Should(Receive(&err))
+				Ω(err).Should(MatchError("check timed out after 50ms"))
+
+				Ω(fakeRadarDB.SetResourceCheckErrorCallCount()).Should(Equal(1))
+				_, checkErr := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+				Ω(checkErr).Should(MatchError("check timed out after 50ms"))
+			})
+		})
+
+		Context("when the resource configures an invalid interval", func() {
+			BeforeEach(func() {
+				resourceConfig.CheckEvery = "bogus"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+			})
+
+			It("checks on the default interval", func() {
+				var time1 time.Time
+				var time2 time.Time
+
+				Eventually(times).Should(Receive(&time1))
+				Eventually(times).Should(Receive(&time2))
+
+				Ω(time2.Sub(time1)).Should(BeNumerically("~", interval, interval/4))
+			})
+		})
+
 		Context("and checking takes a while", func() {
 			BeforeEach(func() {
 				checked := false
@@ -426,6 +546,7 @@ var _ = Describe("Radar", func() {
 			sessionID, typ, tags := fakeTracker.InitArgsForCall(0)
 			Ω(sessionID).Should(Equal(resource.Session{
 				ID: worker.Identifier{
+					TeamName:     "some-team",
 					PipelineName: "some-pipeline-name",
 					Name:         "some-resource",
 					Type:         "check",
@@ -554,5 +675,194 @@ var _ = Describe("Radar", func() {
 				Ω(err).Should(Equal(disaster))
 			})
 		})
+
+		Context("when the resource's source refers to credentials", func() {
+			BeforeEach(func() {
+				resourceConfig.Source = atc.Source{"uri": "https://((some-user))@example.com"}
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+
+				fakeCreds.GetReturns("resolved-user", true, nil)
+			})
+
+			It("checks with them resolved", func() {
+				source, _ := fakeResource.CheckArgsForCall(0)
+				Ω(source).Should(Equal(atc.Source{"uri": "https://resolved-user@example.com"}))
+			})
+
+			It("looks them up among the pipeline's credentials", func() {
+				Ω(fakeCreds.GetArgsForCall(0)).Should(Equal("some-team/some-pipeline-name/some-user"))
+			})
+
+			It("identifies the check container by the unresolved source", func() {
+				sessionID, _, _ := fakeTracker.InitArgsForCall(0)
+				Ω(sessionID.ID.CheckSource).Should(Equal(atc.Source{"uri": "https://((some-user))@example.com"}))
+			})
+
+			Context("when a credential is undefined", func() {
+				BeforeEach(func() {
+					fakeCreds.GetReturns("", false, nil)
+				})
+
+				It("records the error as the resource's check error", func() {
+					Ω(scanErr).Should(Equal(creds.UndefinedCredentialsError{Names: []string{"some-user"}}))
+
+					Ω(fakeRadarDB.SetResourceCheckErrorCallCount()).Should(Equal(1))
+					_, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+					Ω(err).Should(Equal(creds.UndefinedCredentialsError{Names: []string{"some-user"}}))
+
+					Ω(fakeResource.CheckCallCount()).Should(BeZero())
+				})
+			})
+		})
+
+		Context("when checking takes longer than the resource's check timeout", func() {
+			BeforeEach(func() {
+				resourceConfig.CheckTimeout = "50ms"
+
+				fakeRadarDB.GetConfigReturns(atc.Config{
+					Resources: atc.ResourceConfigs{
+						resourceConfig,
+					},
+				}, 1, nil)
+
+				fakeResource.CheckStub = func(atc.Source, atc.Version) ([]atc.Version, error) {
+					time.Sleep(time.Second)
+					return []atc.Version{{"version": "too-late"}}, nil
+				}
+			})
+
+			It("returns a timeout error", func() {
+				Ω(scanErr).Should(MatchError("check timed out after 50ms"))
+			})
+
+			It("records the timeout as the resource's check error", func() {
+				Ω(fakeRadarDB.SetResourceCheckErrorCallCount()).Should(Equal(1))
+
+				savedResourceArg, err := fakeRadarDB.SetResourceCheckErrorArgsForCall(0)
+				Ω(savedResourceArg).Should(Equal(savedResource))
+				Ω(err).Should(MatchError("check timed out after 50ms"))
+			})
+
+			It("destroys the container the check is hung in", func() {
+				Ω(fakeResource.DestroyCallCount()).Should(Equal(1))
+			})
+
+			It("does not save any versions", func() {
+				Ω(fakeRadarDB.SaveResourceVersionsCallCount()).Should(BeZero())
+			})
+
+			It("releases the resource checking lock", func() {
+				Ω(writeLock.ReleaseCallCount()).Should(Equal(1))
+			})
+		})
+	})
+
+	Describe("ScanFromVersion", func() {
+		var (
+			fakeResource *rfakes.FakeResource
+
+			fromVersion atc.Version
+
+			scannedVersions []atc.Version
+			scanErr         error
+		)
+
+		BeforeEach(func() {
+			fakeResource = new(rfakes.FakeResource)
+			fakeTracker.InitReturns(fakeResource, nil)
+
+			fromVersion = atc.Version{"version": "given"}
+
+			fakeRadarDB.GetLatestVersionedResourceReturns(
+				db.SavedVersionedResource{
+					ID: 1,
+					VersionedResource: db.VersionedResource{
+						Version: db.Version{
+							"version": "latest",
+						},
+					},
+				}, nil)
+		})
+
+		JustBeforeEach(func() {
+			scannedVersions, scanErr = radar.ScanFromVersion(lagertest.NewTestLogger("test"), "some-resource", fromVersion)
+		})
+
+		It("grabs a resource checking lock before checking, releases after done", func() {
+			Ω(locker.AcquireWriteLockCallCount()).Should(Equal(1))
+
+			lockedInputs := locker.AcquireWriteLockArgsForCall(0)
+			Ω(lockedInputs).Should(Equal([]db.NamedLock{db.ResourceCheckingLock("pipeline:some-resource")}))
+
+			Ω(writeLock.ReleaseCallCount()).Should(Equal(1))
+		})
+
+		It("checks from the given version", func() {
+			_, version := fakeResource.CheckArgsForCall(0)
+			Ω(version).Should(Equal(atc.Version{"version": "given"}))
+		})
+
+		Context("when no version is given", func() {
+			BeforeEach(func() {
+				fromVersion = nil
+			})
+
+			It("checks from the current version", func() {
+				_, version := fakeResource.CheckArgsForCall(0)
+				Ω(version).Should(Equal(atc.Version{"version": "latest"}))
+			})
+		})
+
+		Context("when the check returns versions", func() {
+			BeforeEach(func() {
+				fakeResource.CheckReturns([]atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+				}, nil)
+			})
+
+			It("saves and returns them", func() {
+				Ω(scanErr).ShouldNot(HaveOccurred())
+
+				Ω(fakeRadarDB.SaveResourceVersionsCallCount()).Should(Equal(1))
+
+				_, versions := fakeRadarDB.SaveResourceVersionsArgsForCall(0)
+				Ω(versions).Should(Equal([]atc.Version{
+					{"version": "1"},
+					{"version": "2"},
+				}))
+
+				Ω(scannedVersions).Should(Equal(versions))
+			})
+		})
+
+		Context("when checking fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeResource.CheckReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(scanErr).Should(Equal(disaster))
+				Ω(scannedVersions).Should(BeEmpty())
+			})
+		})
+
+		Context("when the resource is not in the config", func() {
+			BeforeEach(func() {
+				fakeRadarDB.GetConfigReturns(atc.Config{}, 1, nil)
+			})
+
+			It("returns an error without checking", func() {
+				Ω(scanErr).Should(HaveOccurred())
+				Ω(fakeResource.CheckCallCount()).Should(BeZero())
+			})
+		})
 	})
 })
@@ -8,6 +8,17 @@ type Resource struct {
 
 	Paused bool `json:"paused,omitempty"`
 
+	PinnedVersionID int `json:"pinned_version_id,omitempty"`
+
 	FailingToCheck bool   `json:"failing_to_check,omitempty"`
 	CheckError     string `json:"check_error,omitempty"`
 }
+
+type CheckRequestBody struct {
+	From Version `json:"from"`
+}
+
+type CheckResponseBody struct {
+	ExitStatus int    `json:"exit_status"`
+	Stderr     string `json:"stderr"`
+}
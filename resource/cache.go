@@ -0,0 +1,28 @@
+package resource
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/concourse/atc"
+)
+
+// CacheKey identifies the bits fetched by getting the given version of a
+// resource, so that they can be reused by later gets on the same worker.
+func CacheKey(typ ResourceType, source atc.Source, params atc.Params, version atc.Version) string {
+	// json.Marshal sorts map keys, so equal inputs give equal keys
+	payload, err := json.Marshal(struct {
+		Type    ResourceType `json:"type"`
+		Source  atc.Source   `json:"source"`
+		Params  atc.Params   `json:"params"`
+		Version atc.Version  `json:"version"`
+	}{typ, source, params, version})
+	if err != nil {
+		panic("failed to marshal cache key: " + err.Error())
+	}
+
+	sum := sha256.Sum256(payload)
+
+	return hex.EncodeToString(sum[:])
+}
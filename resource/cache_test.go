@@ -0,0 +1,48 @@
+package resource_test
+
+import (
+	"github.com/concourse/atc"
+	. "github.com/concourse/atc/resource"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CacheKey", func() {
+	var (
+		source  atc.Source
+		params  atc.Params
+		version atc.Version
+	)
+
+	BeforeEach(func() {
+		source = atc.Source{"uri": "some-uri", "branch": "master"}
+		params = atc.Params{"submodules": "none"}
+		version = atc.Version{"ref": "abc"}
+	})
+
+	It("is the same for the same fetch", func() {
+		Ω(CacheKey("git", source, params, version)).Should(Equal(CacheKey(
+			"git",
+			atc.Source{"branch": "master", "uri": "some-uri"},
+			atc.Params{"submodules": "none"},
+			atc.Version{"ref": "abc"},
+		)))
+	})
+
+	It("differs by type", func() {
+		Ω(CacheKey("git", source, params, version)).ShouldNot(Equal(CacheKey("hg", source, params, version)))
+	})
+
+	It("differs by source", func() {
+		Ω(CacheKey("git", source, params, version)).ShouldNot(Equal(CacheKey("git", atc.Source{"uri": "other-uri"}, params, version)))
+	})
+
+	It("differs by params", func() {
+		Ω(CacheKey("git", source, params, version)).ShouldNot(Equal(CacheKey("git", source, atc.Params{"submodules": "all"}, version)))
+	})
+
+	It("differs by version", func() {
+		Ω(CacheKey("git", source, params, version)).ShouldNot(Equal(CacheKey("git", source, params, atc.Version{"ref": "def"})))
+	})
+})
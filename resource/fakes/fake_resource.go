@@ -47,6 +47,30 @@ type FakeResource struct {
 		result1 []atc.Version
 		result2 error
 	}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns struct {
+		result1 string
+	}
+	PropertyStub        func(string) (string, error)
+	propertyMutex       sync.RWMutex
+	propertyArgsForCall []struct {
+		arg1 string
+	}
+	propertyReturns struct {
+		result1 string
+		result2 error
+	}
+	SetPropertyStub        func(string, string) error
+	setPropertyMutex       sync.RWMutex
+	setPropertyArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	setPropertyReturns struct {
+		result1 error
+	}
 	ReleaseStub        func()
 	releaseMutex       sync.RWMutex
 	releaseArgsForCall []struct{}
@@ -186,6 +210,96 @@ func (fake *FakeResource) CheckReturns(result1 []atc.Version, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeResource) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	} else {
+		return fake.workerNameReturns.result1
+	}
+}
+
+func (fake *FakeResource) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeResource) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResource) Property(arg1 string) (string, error) {
+	fake.propertyMutex.Lock()
+	fake.propertyArgsForCall = append(fake.propertyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.propertyMutex.Unlock()
+	if fake.PropertyStub != nil {
+		return fake.PropertyStub(arg1)
+	} else {
+		return fake.propertyReturns.result1, fake.propertyReturns.result2
+	}
+}
+
+func (fake *FakeResource) PropertyCallCount() int {
+	fake.propertyMutex.RLock()
+	defer fake.propertyMutex.RUnlock()
+	return len(fake.propertyArgsForCall)
+}
+
+func (fake *FakeResource) PropertyArgsForCall(i int) string {
+	fake.propertyMutex.RLock()
+	defer fake.propertyMutex.RUnlock()
+	return fake.propertyArgsForCall[i].arg1
+}
+
+func (fake *FakeResource) PropertyReturns(result1 string, result2 error) {
+	fake.PropertyStub = nil
+	fake.propertyReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResource) SetProperty(arg1 string, arg2 string) error {
+	fake.setPropertyMutex.Lock()
+	fake.setPropertyArgsForCall = append(fake.setPropertyArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.setPropertyMutex.Unlock()
+	if fake.SetPropertyStub != nil {
+		return fake.SetPropertyStub(arg1, arg2)
+	} else {
+		return fake.setPropertyReturns.result1
+	}
+}
+
+func (fake *FakeResource) SetPropertyCallCount() int {
+	fake.setPropertyMutex.RLock()
+	defer fake.setPropertyMutex.RUnlock()
+	return len(fake.setPropertyArgsForCall)
+}
+
+func (fake *FakeResource) SetPropertyArgsForCall(i int) (string, string) {
+	fake.setPropertyMutex.RLock()
+	defer fake.setPropertyMutex.RUnlock()
+	return fake.setPropertyArgsForCall[i].arg1, fake.setPropertyArgsForCall[i].arg2
+}
+
+func (fake *FakeResource) SetPropertyReturns(result1 error) {
+	fake.SetPropertyStub = nil
+	fake.setPropertyReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) Release() {
 	fake.releaseMutex.Lock()
 	fake.releaseArgsForCall = append(fake.releaseArgsForCall, struct{}{})
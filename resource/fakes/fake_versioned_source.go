@@ -50,6 +50,15 @@ type FakeVersionedSource struct {
 	streamInReturns struct {
 		result1 error
 	}
+	HostPathStub        func(string) (string, error)
+	hostPathMutex       sync.RWMutex
+	hostPathArgsForCall []struct {
+		arg1 string
+	}
+	hostPathReturns struct {
+		result1 string
+		result2 error
+	}
 }
 
 func (fake *FakeVersionedSource) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
@@ -199,4 +208,37 @@ func (fake *FakeVersionedSource) StreamInReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeVersionedSource) HostPath(arg1 string) (string, error) {
+	fake.hostPathMutex.Lock()
+	fake.hostPathArgsForCall = append(fake.hostPathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.hostPathMutex.Unlock()
+	if fake.HostPathStub != nil {
+		return fake.HostPathStub(arg1)
+	} else {
+		return fake.hostPathReturns.result1, fake.hostPathReturns.result2
+	}
+}
+
+func (fake *FakeVersionedSource) HostPathCallCount() int {
+	fake.hostPathMutex.RLock()
+	defer fake.hostPathMutex.RUnlock()
+	return len(fake.hostPathArgsForCall)
+}
+
+func (fake *FakeVersionedSource) HostPathArgsForCall(i int) string {
+	fake.hostPathMutex.RLock()
+	defer fake.hostPathMutex.RUnlock()
+	return fake.hostPathArgsForCall[i].arg1
+}
+
+func (fake *FakeVersionedSource) HostPathReturns(result1 string, result2 error) {
+	fake.HostPathStub = nil
+	fake.hostPathReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 var _ resource.VersionedSource = new(FakeVersionedSource)
@@ -20,6 +20,15 @@ type Resource interface {
 
 	Check(atc.Source, atc.Version) ([]atc.Version, error)
 
+	// WorkerName returns the name of the worker the resource's container
+	// lives on.
+	WorkerName() string
+
+	// Property and SetProperty read and record state on the resource's
+	// container, e.g. what has been done with the bits it fetched.
+	Property(string) (string, error)
+	SetProperty(string, string) error
+
 	Release()
 	Destroy() error
 }
@@ -51,6 +60,10 @@ type VersionedSource interface {
 
 	StreamOut(string) (io.ReadCloser, error)
 	StreamIn(string, io.Reader) error
+
+	// HostPath returns where the given path lives on the worker's host, e.g.
+	// so that fetched bits can be used as another container's rootfs.
+	HostPath(string) (string, error)
 }
 
 func ResourcesDir(suffix string) string {
@@ -60,6 +73,7 @@ func ResourcesDir(suffix string) string {
 type resource struct {
 	container worker.Container
 	typ       ResourceType
+	cacheKey  string
 
 	releaseOnce sync.Once
 
@@ -69,10 +83,12 @@ type resource struct {
 func NewResource(
 	container worker.Container,
 	typ ResourceType,
+	cacheKey string,
 ) Resource {
 	return &resource{
 		container: container,
 		typ:       typ,
+		cacheKey:  cacheKey,
 	}
 }
 
@@ -80,6 +96,18 @@ func (resource *resource) Type() ResourceType {
 	return resource.typ
 }
 
+func (resource *resource) WorkerName() string {
+	return resource.container.WorkerName()
+}
+
+func (resource *resource) Property(name string) (string, error) {
+	return resource.container.Property(name)
+}
+
+func (resource *resource) SetProperty(name string, value string) error {
+	return resource.container.SetProperty(name, value)
+}
+
 func (resource *resource) Release() {
 	resource.container.Release()
 }
@@ -1,6 +1,12 @@
 package resource
 
-import "github.com/concourse/atc"
+import (
+	"os"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/worker"
+	"github.com/tedsuo/ifrit"
+)
 
 type inRequest struct {
 	Source  atc.Source  `json:"source"`
@@ -16,7 +22,7 @@ func (resource *resource) Get(ioConfig IOConfig, source atc.Source, params atc.P
 		resourceDir: resourceDir,
 	}
 
-	vs.Runner = resource.runScript(
+	fetch := resource.runScript(
 		"/opt/resource/in",
 		[]string{resourceDir},
 		inRequest{source, params, version},
@@ -27,5 +33,20 @@ func (resource *resource) Get(ioConfig IOConfig, source atc.Source, params atc.P
 		true,
 	)
 
+	if resource.cacheKey == "" {
+		vs.Runner = fetch
+		return vs
+	}
+
+	vs.Runner = ifrit.RunFunc(func(signals <-chan os.Signal, ready chan<- struct{}) error {
+		err := fetch.Run(signals, ready)
+		if err != nil {
+			return err
+		}
+
+		// only now that the fetch succeeded may later gets reuse it
+		return resource.container.SetProperty(worker.ResourceCachePropertyName, resource.cacheKey)
+	})
+
 	return vs
 }
@@ -17,6 +17,7 @@ import (
 
 	"github.com/concourse/atc"
 	. "github.com/concourse/atc/resource"
+	"github.com/concourse/atc/worker"
 )
 
 var _ = Describe("Resource In", func() {
@@ -357,6 +358,35 @@ var _ = Describe("Resource In", func() {
 			Ω(streamSpec.Path).Should(Equal(streamOutSpec.Path))
 		})
 
+		Describe("finding bits on the worker's host", func() {
+			Context("when the container's info can be fetched", func() {
+				BeforeEach(func() {
+					fakeContainer.InfoReturns(garden.ContainerInfo{
+						ContainerPath: "/depot/some-handle",
+					}, nil)
+				})
+
+				It("returns the path within the container's filesystem", func() {
+					hostPath, err := versionedSource.HostPath("some/path")
+					Ω(err).ShouldNot(HaveOccurred())
+					Ω(hostPath).Should(Equal("/depot/some-handle/mnt/tmp/build/get/some/path"))
+				})
+			})
+
+			Context("when the container's info cannot be fetched", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeContainer.InfoReturns(garden.ContainerInfo{}, disaster)
+				})
+
+				It("returns the error", func() {
+					_, err := versionedSource.HostPath("some/path")
+					Ω(err).Should(Equal(disaster))
+				})
+			})
+		})
+
 		It("runs /opt/resource/in <destination> with the request on stdin", func() {
 			Eventually(inProcess.Wait()).Should(Receive(BeNil()))
 
@@ -454,6 +484,37 @@ var _ = Describe("Resource In", func() {
 			})
 		})
 
+		Context("when the fetch may be reused", func() {
+			BeforeEach(func() {
+				resource = NewResource(fakeContainer, "some-type", "some-cache-key")
+			})
+
+			It("marks the container as holding it once it succeeds", func() {
+				Eventually(inProcess.Wait()).Should(Receive(BeNil()))
+
+				Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(3))
+
+				name, value := fakeContainer.SetPropertyArgsForCall(2)
+				Ω(name).Should(Equal(worker.ResourceCachePropertyName))
+				Ω(value).Should(Equal("some-cache-key"))
+			})
+
+			Context("when /opt/resource/in exits nonzero", func() {
+				BeforeEach(func() {
+					inScriptExitStatus = 9
+				})
+
+				It("does not mark the container", func() {
+					Eventually(inProcess.Wait()).Should(Receive(HaveOccurred()))
+
+					for i := 0; i < fakeContainer.SetPropertyCallCount(); i++ {
+						name, _ := fakeContainer.SetPropertyArgsForCall(i)
+						Ω(name).ShouldNot(Equal(worker.ResourceCachePropertyName))
+					}
+				})
+			})
+		})
+
 		itCanStreamOut()
 		itStopsOnSignal()
 	})
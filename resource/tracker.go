@@ -13,6 +13,11 @@ type ContainerImage string
 type Session struct {
 	ID        worker.Identifier
 	Ephemeral bool
+
+	// if set, a container that already fetched what the key identifies is
+	// reused, and the session's container is kept around for reuse; see
+	// CacheKey
+	CacheKey string
 }
 
 //go:generate counterfeiter . Tracker
@@ -43,6 +48,7 @@ func (tracker *tracker) Init(session Session, typ ResourceType, tags atc.Tags) (
 			Type:      string(typ),
 			Ephemeral: session.Ephemeral,
 			Tags:      tags,
+			CacheKey:  session.CacheKey,
 		})
 	}
 
@@ -50,5 +56,5 @@ func (tracker *tracker) Init(session Session, typ ResourceType, tags atc.Tags) (
 		return nil, err
 	}
 
-	return NewResource(container, typ), nil
+	return NewResource(container, typ, session.CacheKey), nil
 }
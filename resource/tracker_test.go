@@ -64,6 +64,22 @@ var _ = Describe("Tracker", func() {
 				Ω(resourceSpec.Type).Should(Equal(string(initType)))
 				Ω(resourceSpec.Ephemeral).Should(Equal(true))
 				Ω(resourceSpec.Tags).Should(ConsistOf("resource", "tags"))
+				Ω(resourceSpec.CacheKey).Should(BeEmpty())
+			})
+
+			Context("when the session has a cache key", func() {
+				BeforeEach(func() {
+					session.CacheKey = "some-cache-key"
+				})
+
+				AfterEach(func() {
+					session.CacheKey = ""
+				})
+
+				It("creates the container with the cache key, so that an earlier fetch can be reused", func() {
+					_, spec := workerClient.CreateContainerArgsForCall(0)
+					Ω(spec.(worker.ResourceTypeContainerSpec).CacheKey).Should(Equal("some-cache-key"))
+				})
 			})
 
 			Context("when creating the container fails", func() {
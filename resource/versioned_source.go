@@ -9,6 +9,10 @@ import (
 	"github.com/tedsuo/ifrit"
 )
 
+// containerRootFSDir is where Garden mounts a container's filesystem, relative
+// to the container's directory on the host.
+const containerRootFSDir = "mnt"
+
 type versionResult struct {
 	Version atc.Version `json:"version"`
 
@@ -46,3 +50,12 @@ func (vs *versionedSource) StreamIn(dst string, src io.Reader) error {
 		TarStream: src,
 	})
 }
+
+func (vs *versionedSource) HostPath(src string) (string, error) {
+	info, err := vs.container.Info()
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(info.ContainerPath, containerRootFSDir, vs.resourceDir, src), nil
+}
@@ -6,7 +6,8 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
-	Hijack = "Hijack"
+	Hijack         = "Hijack"
+	ListContainers = "ListContainers"
 
 	CreateBuild = "CreateBuild"
 	ListBuilds  = "ListBuilds"
@@ -25,6 +26,10 @@ const (
 	DisableResourceVersion = "DisableResourceVersion"
 	PauseResource          = "PauseResource"
 	UnpauseResource        = "UnpauseResource"
+	PinResourceVersion     = "PinResourceVersion"
+	UnpinResource          = "UnpinResource"
+	CheckResource          = "CheckResource"
+	CheckResourceWebhook   = "CheckResourceWebhook"
 
 	ListPipelines   = "ListPipelines"
 	DeletePipeline  = "DeletePipeline"
@@ -43,6 +48,12 @@ const (
 	GetLogLevel = "GetLogLevel"
 
 	DownloadCLI = "DownloadCLI"
+
+	ListAuthMethods = "ListAuthMethods"
+	GetAuthToken    = "GetAuthToken"
+
+	ListTeams = "ListTeams"
+	SaveTeam  = "SaveTeam"
 )
 
 var Routes = rata.Routes{
@@ -54,6 +65,7 @@ var Routes = rata.Routes{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "POST", Name: AbortBuild},
 	{Path: "/api/v1/hijack", Method: "POST", Name: Hijack},
+	{Path: "/api/v1/containers", Method: "GET", Name: ListContainers},
 
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
 	{Path: "/api/v1/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
@@ -73,6 +85,10 @@ var Routes = rata.Routes{
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
 	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebhook},
 
 	{Path: "/api/v1/pipes", Method: "POST", Name: CreatePipe},
 	{Path: "/api/v1/pipes/:pipe_id", Method: "PUT", Name: WritePipe},
@@ -85,4 +101,44 @@ var Routes = rata.Routes{
 	{Path: "/api/v1/log-level", Method: "PUT", Name: SetLogLevel},
 
 	{Path: "/api/v1/cli", Method: "GET", Name: DownloadCLI},
+
+	{Path: "/api/v1/auth/methods", Method: "GET", Name: ListAuthMethods},
+	{Path: "/api/v1/auth/token", Method: "GET", Name: GetAuthToken},
+
+	{Path: "/api/v1/teams", Method: "GET", Name: ListTeams},
+	{Path: "/api/v1/teams/:team_name", Method: "PUT", Name: SaveTeam},
+
+	// team-scoped variants of the above; routes without a :team_name act on
+	// the DefaultTeamName
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+
+	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
+	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListBuilds},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
+
+	{Path: "/api/v1/teams/:team_name/pipelines", Method: "GET", Name: ListPipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name", Method: "DELETE", Name: DeletePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/ordering", Method: "PUT", Name: OrderPipelines},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/pause", Method: "PUT", Name: PausePipeline},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/unpause", Method: "PUT", Name: UnpausePipeline},
+
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources", Method: "GET", Name: ListResources},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/enable", Method: "PUT", Name: EnableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/disable", Method: "PUT", Name: DisableResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/pause", Method: "PUT", Name: PauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpause", Method: "PUT", Name: UnpauseResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions/:resource_version_id/pin", Method: "PUT", Name: PinResourceVersion},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/unpin", Method: "PUT", Name: UnpinResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebhook},
+
+	{Path: "/api/v1/teams/:team_name/workers", Method: "GET", Name: ListWorkers},
+	{Path: "/api/v1/teams/:team_name/workers", Method: "POST", Name: RegisterWorker},
 }
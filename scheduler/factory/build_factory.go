@@ -251,6 +251,7 @@ func (factory *BuildFactory) constructPlanFromConfig(
 			Params:    planConfig.Params,
 			GetParams: planConfig.GetParams,
 			Tags:      planConfig.Tags,
+			Sensitive: resource.Sensitive,
 		}
 
 		plan = atc.Plan{
@@ -289,6 +290,8 @@ func (factory *BuildFactory) constructPlanFromConfig(
 				Params:   planConfig.Params,
 				Version:  atc.Version(version),
 				Tags:     planConfig.Tags,
+
+				Sensitive: resource.Sensitive,
 			},
 		}
 
@@ -300,6 +303,9 @@ func (factory *BuildFactory) constructPlanFromConfig(
 				Config:     planConfig.TaskConfig,
 				ConfigPath: planConfig.TaskConfigPath,
 				Tags:       planConfig.Tags,
+
+				InputMapping:  planConfig.InputMapping,
+				OutputMapping: planConfig.OutputMapping,
 			},
 		}
 
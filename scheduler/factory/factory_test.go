@@ -251,6 +251,49 @@ var _ = Describe("Factory", func() {
 			Ω(plan).Should(Equal(expectedPlan))
 		})
 
+		Context("when a resource marks fields of its source as sensitive", func() {
+			BeforeEach(func() {
+				resources = append(resources, atc.ResourceConfig{
+					Name:      "some-secret-resource",
+					Type:      "git",
+					Source:    atc.Source{"uri": "git://some-secret-resource", "private_key": "some-key"},
+					Sensitive: []string{"private_key"},
+				})
+			})
+
+			It("marks them as sensitive in its get and put plans", func() {
+				plan, err := factory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{Get: "some-secret-resource"},
+						{Put: "some-secret-resource"},
+					},
+				}, resources, nil)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(plan.HookedCompose.Step.Get.Sensitive).Should(Equal([]string{"private_key"}))
+				Ω(plan.HookedCompose.Next.PutGet.Head.Put.Sensitive).Should(Equal([]string{"private_key"}))
+			})
+		})
+
+		Context("when a task maps its inputs and outputs", func() {
+			It("passes the mappings along in its plan", func() {
+				plan, err := factory.Create(atc.JobConfig{
+					Plan: atc.PlanSequence{
+						{
+							Task:           "build",
+							TaskConfigPath: "some-input/build.yml",
+							InputMapping:   map[string]string{"source": "some-input"},
+							OutputMapping:  map[string]string{"binary": "some-binary"},
+						},
+					},
+				}, resources, nil)
+				Ω(err).ShouldNot(HaveOccurred())
+
+				Ω(plan.Task.InputMapping).Should(Equal(map[string]string{"source": "some-input"}))
+				Ω(plan.Task.OutputMapping).Should(Equal(map[string]string{"binary": "some-binary"}))
+			})
+		})
+
 		Describe("chains of conditional plans", func() {
 			It("breaks the chain at each condition", func() {
 				Ω(factory.Create(atc.JobConfig{
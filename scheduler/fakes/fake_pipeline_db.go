@@ -20,11 +20,11 @@ type FakePipelineDB struct {
 		result2 error
 	}
 	CreateJobBuildForCandidateInputsStub        func(job string) (db.Build, bool, error)
-	createJobBuildIfNoBuildsPendingMutex       sync.RWMutex
-	createJobBuildIfNoBuildsPendingArgsForCall []struct {
+	createJobBuildForCandidateInputsMutex       sync.RWMutex
+	createJobBuildForCandidateInputsArgsForCall []struct {
 		job string
 	}
-	createJobBuildIfNoBuildsPendingReturns struct {
+	createJobBuildForCandidateInputsReturns struct {
 		result1 db.Build
 		result2 bool
 		result3 error
@@ -58,10 +58,20 @@ type FakePipelineDB struct {
 		result1 db.Build
 		result2 error
 	}
-	GetLatestInputVersionsStub        func([]atc.JobInput) ([]db.BuildInput, error)
+	GetResourceStub        func(resourceName string) (db.SavedResource, error)
+	getResourceMutex       sync.RWMutex
+	getResourceArgsForCall []struct {
+		resourceName string
+	}
+	getResourceReturns struct {
+		result1 db.SavedResource
+		result2 error
+	}
+	GetLatestInputVersionsStub        func(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error)
 	getLatestInputVersionsMutex       sync.RWMutex
 	getLatestInputVersionsArgsForCall []struct {
-		arg1 []atc.JobInput
+		jobName string
+		inputs  []atc.JobInput
 	}
 	getLatestInputVersionsReturns struct {
 		result1 []db.BuildInput
@@ -121,33 +131,33 @@ func (fake *FakePipelineDB) CreateJobBuildReturns(result1 db.Build, result2 erro
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputs(job string) (db.Build, bool, error) {
-	fake.createJobBuildIfNoBuildsPendingMutex.Lock()
-	fake.createJobBuildIfNoBuildsPendingArgsForCall = append(fake.createJobBuildIfNoBuildsPendingArgsForCall, struct {
+	fake.createJobBuildForCandidateInputsMutex.Lock()
+	fake.createJobBuildForCandidateInputsArgsForCall = append(fake.createJobBuildForCandidateInputsArgsForCall, struct {
 		job string
 	}{job})
-	fake.createJobBuildIfNoBuildsPendingMutex.Unlock()
+	fake.createJobBuildForCandidateInputsMutex.Unlock()
 	if fake.CreateJobBuildForCandidateInputsStub != nil {
 		return fake.CreateJobBuildForCandidateInputsStub(job)
 	} else {
-		return fake.createJobBuildIfNoBuildsPendingReturns.result1, fake.createJobBuildIfNoBuildsPendingReturns.result2, fake.createJobBuildIfNoBuildsPendingReturns.result3
+		return fake.createJobBuildForCandidateInputsReturns.result1, fake.createJobBuildForCandidateInputsReturns.result2, fake.createJobBuildForCandidateInputsReturns.result3
 	}
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsCallCount() int {
-	fake.createJobBuildIfNoBuildsPendingMutex.RLock()
-	defer fake.createJobBuildIfNoBuildsPendingMutex.RUnlock()
-	return len(fake.createJobBuildIfNoBuildsPendingArgsForCall)
+	fake.createJobBuildForCandidateInputsMutex.RLock()
+	defer fake.createJobBuildForCandidateInputsMutex.RUnlock()
+	return len(fake.createJobBuildForCandidateInputsArgsForCall)
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsArgsForCall(i int) string {
-	fake.createJobBuildIfNoBuildsPendingMutex.RLock()
-	defer fake.createJobBuildIfNoBuildsPendingMutex.RUnlock()
-	return fake.createJobBuildIfNoBuildsPendingArgsForCall[i].job
+	fake.createJobBuildForCandidateInputsMutex.RLock()
+	defer fake.createJobBuildForCandidateInputsMutex.RUnlock()
+	return fake.createJobBuildForCandidateInputsArgsForCall[i].job
 }
 
 func (fake *FakePipelineDB) CreateJobBuildForCandidateInputsReturns(result1 db.Build, result2 bool, result3 error) {
 	fake.CreateJobBuildForCandidateInputsStub = nil
-	fake.createJobBuildIfNoBuildsPendingReturns = struct {
+	fake.createJobBuildForCandidateInputsReturns = struct {
 		result1 db.Build
 		result2 bool
 		result3 error
@@ -255,14 +265,48 @@ func (fake *FakePipelineDB) GetNextPendingBuildReturns(result1 db.Build, result2
 	}{result1, result2}
 }
 
-func (fake *FakePipelineDB) GetLatestInputVersions(arg1 []atc.JobInput) ([]db.BuildInput, error) {
+func (fake *FakePipelineDB) GetResource(resourceName string) (db.SavedResource, error) {
+	fake.getResourceMutex.Lock()
+	fake.getResourceArgsForCall = append(fake.getResourceArgsForCall, struct {
+		resourceName string
+	}{resourceName})
+	fake.getResourceMutex.Unlock()
+	if fake.GetResourceStub != nil {
+		return fake.GetResourceStub(resourceName)
+	} else {
+		return fake.getResourceReturns.result1, fake.getResourceReturns.result2
+	}
+}
+
+func (fake *FakePipelineDB) GetResourceCallCount() int {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return len(fake.getResourceArgsForCall)
+}
+
+func (fake *FakePipelineDB) GetResourceArgsForCall(i int) string {
+	fake.getResourceMutex.RLock()
+	defer fake.getResourceMutex.RUnlock()
+	return fake.getResourceArgsForCall[i].resourceName
+}
+
+func (fake *FakePipelineDB) GetResourceReturns(result1 db.SavedResource, result2 error) {
+	fake.GetResourceStub = nil
+	fake.getResourceReturns = struct {
+		result1 db.SavedResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipelineDB) GetLatestInputVersions(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error) {
 	fake.getLatestInputVersionsMutex.Lock()
 	fake.getLatestInputVersionsArgsForCall = append(fake.getLatestInputVersionsArgsForCall, struct {
-		arg1 []atc.JobInput
-	}{arg1})
+		jobName string
+		inputs  []atc.JobInput
+	}{jobName, inputs})
 	fake.getLatestInputVersionsMutex.Unlock()
 	if fake.GetLatestInputVersionsStub != nil {
-		return fake.GetLatestInputVersionsStub(arg1)
+		return fake.GetLatestInputVersionsStub(jobName, inputs)
 	} else {
 		return fake.getLatestInputVersionsReturns.result1, fake.getLatestInputVersionsReturns.result2
 	}
@@ -274,10 +318,10 @@ func (fake *FakePipelineDB) GetLatestInputVersionsCallCount() int {
 	return len(fake.getLatestInputVersionsArgsForCall)
 }
 
-func (fake *FakePipelineDB) GetLatestInputVersionsArgsForCall(i int) []atc.JobInput {
+func (fake *FakePipelineDB) GetLatestInputVersionsArgsForCall(i int) (string, []atc.JobInput) {
 	fake.getLatestInputVersionsMutex.RLock()
 	defer fake.getLatestInputVersionsMutex.RUnlock()
-	return fake.getLatestInputVersionsArgsForCall[i].arg1
+	return fake.getLatestInputVersionsArgsForCall[i].jobName, fake.getLatestInputVersionsArgsForCall[i].inputs
 }
 
 func (fake *FakePipelineDB) GetLatestInputVersionsReturns(result1 []db.BuildInput, result2 error) {
@@ -20,7 +20,8 @@ type PipelineDB interface {
 	GetJobBuildForInputs(job string, inputs []db.BuildInput) (db.Build, error)
 	GetNextPendingBuild(job string) (db.Build, error)
 
-	GetLatestInputVersions([]atc.JobInput) ([]db.BuildInput, error)
+	GetResource(resourceName string) (db.SavedResource, error)
+	GetLatestInputVersions(jobName string, inputs []atc.JobInput) ([]db.BuildInput, error)
 	SaveResourceVersions(atc.ResourceConfig, []atc.Version) error
 	UseInputsForBuild(buildID int, inputs []db.BuildInput) error
 }
@@ -66,7 +67,9 @@ func (s *Scheduler) BuildLatestInputs(logger lager.Logger, job atc.JobConfig, re
 		return nil
 	}
 
-	latestInputs, err := s.PipelineDB.GetLatestInputVersions(inputs)
+	// inputs with `version: every` resolve to the oldest version the job has
+	// not built yet, so every new version ends up with a build of its own
+	latestInputs, err := s.PipelineDB.GetLatestInputVersions(job.Name, inputs)
 	if err != nil {
 		if err == db.ErrNoVersions {
 			logger.Debug("no-input-versions-available")
@@ -206,7 +209,22 @@ func (s *Scheduler) scheduleAndResumePendingBuild(logger lager.Logger, build db.
 			"resource": input.Resource,
 		})
 
-		err := s.Scanner.Scan(scanLog, input.Resource)
+		resource, err := s.PipelineDB.GetResource(input.Resource)
+		if err != nil {
+			scanLog.Error("failed-to-get-resource", err)
+			return nil
+		}
+
+		if resource.Pinned() {
+			// the pinned version will be used regardless of what a check finds
+			scanLog.Debug("skipping-pinned-resource", lager.Data{
+				"pinned-version": resource.PinnedVersionID,
+			})
+
+			continue
+		}
+
+		err = s.Scanner.Scan(scanLog, input.Resource)
 		if err != nil {
 			scanLog.Error("failed-to-scan", err)
 
@@ -221,7 +239,7 @@ func (s *Scheduler) scheduleAndResumePendingBuild(logger lager.Logger, build db.
 		scanLog.Info("done")
 	}
 
-	inputs, err := s.PipelineDB.GetLatestInputVersions(buildInputs)
+	inputs, err := s.PipelineDB.GetLatestInputVersions(job.Name, buildInputs)
 	if err != nil {
 		logger.Error("failed-to-get-latest-input-versions", err)
 		return nil
@@ -182,7 +182,9 @@ var _ = Describe("Scheduler", func() {
 				Ω(err).ShouldNot(HaveOccurred())
 
 				Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
-				Ω(fakePipelineDB.GetLatestInputVersionsArgsForCall(0)).Should(Equal([]atc.JobInput{
+				jobName, inputConfigs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+				Ω(jobName).Should(Equal("some-job"))
+				Ω(inputConfigs).Should(Equal([]atc.JobInput{
 					{
 						Name:     "some-input",
 						Resource: "some-resource",
@@ -473,7 +475,8 @@ var _ = Describe("Scheduler", func() {
 						Ω(resourceName).Should(Equal("some-other-resource"))
 
 						Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
-						inputConfigs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+						jobName, inputConfigs := fakePipelineDB.GetLatestInputVersionsArgsForCall(0)
+						Ω(jobName).Should(Equal("some-job"))
 						Ω(inputConfigs).Should(Equal([]atc.JobInput{
 							{
 								Name:     "some-input",
@@ -519,6 +522,38 @@ var _ = Describe("Scheduler", func() {
 							Ω(err).Should(Equal(disaster))
 						})
 					})
+
+					Context("when an input's resource is pinned", func() {
+						BeforeEach(func() {
+							fakePipelineDB.GetResourceStub = func(resourceName string) (db.SavedResource, error) {
+								if resourceName == "some-resource" {
+									return db.SavedResource{PinnedVersionID: 42}, nil
+								}
+
+								return db.SavedResource{}, nil
+							}
+						})
+
+						It("only scans the unpinned resources", func() {
+							Ω(fakeScanner.ScanCallCount()).Should(Equal(1))
+
+							_, resourceName := fakeScanner.ScanArgsForCall(0)
+							Ω(resourceName).Should(Equal("some-other-resource"))
+
+							Ω(fakePipelineDB.GetLatestInputVersionsCallCount()).Should(Equal(1))
+						})
+					})
+
+					Context("when getting a resource fails", func() {
+						BeforeEach(func() {
+							fakePipelineDB.GetResourceReturns(db.SavedResource{}, errors.New("nope"))
+						})
+
+						It("does not create the build", func() {
+							Ω(fakeScanner.ScanCallCount()).Should(Equal(0))
+							Ω(fakeEngine.CreateBuildCallCount()).Should(Equal(0))
+						})
+					})
 				})
 			})
 
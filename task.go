@@ -6,11 +6,12 @@ import (
 )
 
 type Build struct {
-	ID      int    `json:"id"`
-	Name    string `json:"name"`
-	Status  string `json:"status"`
-	JobName string `json:"job_name"`
-	URL     string `json:"url"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	JobName  string `json:"job_name"`
+	URL      string `json:"url"`
+	TeamName string `json:"team_name,omitempty"`
 }
 
 type BuildStatus string
@@ -34,6 +35,11 @@ type TaskConfig struct {
 	// platform, this may or may not be required (e.g. Windows/OS X vs. Linux).
 	Image string `json:"image,omitempty"   yaml:"image,omitempty"`
 
+	// Optional resource to fetch the image from instead, e.g. a docker-image
+	// resource. Its latest version is used, and stays the same for the rest
+	// of the build.
+	ImageResource *TaskImageConfig `json:"image_resource,omitempty" yaml:"image_resource,omitempty"`
+
 	// Parameters to pass to the task via environment variables.
 	Params map[string]string `json:"params,omitempty"  yaml:"params,omitempty"`
 
@@ -42,6 +48,10 @@ type TaskConfig struct {
 
 	// The set of (logical, name-only) inputs required by the task.
 	Inputs []TaskInputConfig `json:"inputs,omitempty"  yaml:"inputs,omitempty"`
+
+	// The set of directories the task produces, each available to later
+	// steps under its own name.
+	Outputs []TaskOutputConfig `json:"outputs,omitempty" yaml:"outputs,omitempty"`
 }
 
 func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
@@ -51,6 +61,12 @@ func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
 
 	if b.Image != "" {
 		a.Image = b.Image
+		a.ImageResource = nil
+	}
+
+	if b.ImageResource != nil {
+		a.Image = ""
+		a.ImageResource = b.ImageResource
 	}
 
 	if len(a.Params) > 0 {
@@ -94,6 +110,10 @@ func (a TaskConfig) Merge(b TaskConfig) TaskConfig {
 		a.Inputs = b.Inputs
 	}
 
+	if len(b.Outputs) != 0 {
+		a.Outputs = b.Outputs
+	}
+
 	if b.Run.Path != "" {
 		a.Run = b.Run
 	}
@@ -115,6 +135,39 @@ func (config TaskConfig) Validate() error {
 		invalid = true
 	}
 
+	if config.ImageResource != nil {
+		if config.Image != "" {
+			messages = append(messages, "  specify either 'image' or 'image_resource', not both")
+			invalid = true
+		}
+
+		if config.ImageResource.Type == "" {
+			messages = append(messages, "  missing 'type' of image resource")
+			invalid = true
+		}
+	}
+
+	inputNames := map[string]bool{}
+	for _, input := range config.Inputs {
+		inputNames[input.Name] = true
+	}
+
+	outputNames := map[string]bool{}
+	for _, output := range config.Outputs {
+		if output.Name == "" {
+			messages = append(messages, "  output has no name")
+			invalid = true
+		} else if outputNames[output.Name] {
+			messages = append(messages, fmt.Sprintf("  output '%s' is declared more than once", output.Name))
+			invalid = true
+		} else if inputNames[output.Name] {
+			messages = append(messages, fmt.Sprintf("  output '%s' has the same name as an input", output.Name))
+			invalid = true
+		}
+
+		outputNames[output.Name] = true
+	}
+
 	if invalid {
 		return fmt.Errorf(strings.Join(messages, "\n"))
 	}
@@ -122,6 +175,11 @@ func (config TaskConfig) Validate() error {
 	return nil
 }
 
+type TaskImageConfig struct {
+	Type   string `json:"type"   yaml:"type"`
+	Source Source `json:"source" yaml:"source"`
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path" yaml:"path"`
 	Args []string `json:"args,omitempty" yaml:"args"`
@@ -132,6 +190,11 @@ type TaskInputConfig struct {
 	Path string `json:"path,omitempty" yaml:"path"`
 }
 
+type TaskOutputConfig struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path,omitempty" yaml:"path"`
+}
+
 type MetadataField struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
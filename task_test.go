@@ -41,6 +41,83 @@ var _ = Describe("TaskConfig", func() {
 				Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("missing path to executable to run")))
 			})
 		})
+
+		Context("when an image resource is given", func() {
+			BeforeEach(func() {
+				invalidConfig.ImageResource = &TaskImageConfig{
+					Type:   "docker-image",
+					Source: Source{"repository": "some/image"},
+				}
+			})
+
+			It("is valid", func() {
+				Ω(invalidConfig.Validate()).Should(Succeed())
+			})
+
+			Context("along with an image", func() {
+				BeforeEach(func() {
+					invalidConfig.Image = "some-image"
+				})
+
+				It("returns an error", func() {
+					Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("specify either 'image' or 'image_resource', not both")))
+				})
+			})
+
+			Context("without a type", func() {
+				BeforeEach(func() {
+					invalidConfig.ImageResource.Type = ""
+				})
+
+				It("returns an error", func() {
+					Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("missing 'type' of image resource")))
+				})
+			})
+		})
+
+		Context("when outputs are given", func() {
+			BeforeEach(func() {
+				invalidConfig.Inputs = []TaskInputConfig{{Name: "some-input"}}
+				invalidConfig.Outputs = []TaskOutputConfig{
+					{Name: "some-output"},
+					{Name: "some-other-output", Path: "some/path"},
+				}
+			})
+
+			It("is valid", func() {
+				Ω(invalidConfig.Validate()).Should(Succeed())
+			})
+
+			Context("with the same name twice", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "some-output"})
+				})
+
+				It("returns an error", func() {
+					Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output 'some-output' is declared more than once")))
+				})
+			})
+
+			Context("with the same name as an input", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Name: "some-input"})
+				})
+
+				It("returns an error", func() {
+					Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output 'some-input' has the same name as an input")))
+				})
+			})
+
+			Context("without a name", func() {
+				BeforeEach(func() {
+					invalidConfig.Outputs = append(invalidConfig.Outputs, TaskOutputConfig{Path: "some/path"})
+				})
+
+				It("returns an error", func() {
+					Ω(invalidConfig.Validate()).Should(MatchError(ContainSubstring("output has no name")))
+				})
+			})
+		})
 	})
 
 	Describe("merging", func() {
@@ -94,6 +171,26 @@ var _ = Describe("TaskConfig", func() {
 			}))
 		})
 
+		It("overrides the image with an image resource", func() {
+			Ω(TaskConfig{
+				Image: "some-image",
+			}.Merge(TaskConfig{
+				ImageResource: &TaskImageConfig{Type: "docker-image"},
+			})).Should(Equal(TaskConfig{
+				ImageResource: &TaskImageConfig{Type: "docker-image"},
+			}))
+		})
+
+		It("overrides the image resource with an image", func() {
+			Ω(TaskConfig{
+				ImageResource: &TaskImageConfig{Type: "docker-image"},
+			}.Merge(TaskConfig{
+				Image: "better-image",
+			})).Should(Equal(TaskConfig{
+				Image: "better-image",
+			}))
+		})
+
 		It("overrides the run config", func() {
 			Ω(TaskConfig{
 				Run: TaskRunConfig{
@@ -149,5 +246,21 @@ var _ = Describe("TaskConfig", func() {
 				},
 			}))
 		})
+
+		It("overrides output configuration", func() {
+			Ω(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "some-output", Path: "some-path"},
+				},
+			}.Merge(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "another-output"},
+				},
+			})).Should(Equal(TaskConfig{
+				Outputs: []TaskOutputConfig{
+					{Name: "another-output"},
+				},
+			}))
+		})
 	})
 })
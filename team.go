@@ -0,0 +1,7 @@
+package atc
+
+const DefaultTeamName = "main"
+
+type Team struct {
+	Name string `json:"name"`
+}
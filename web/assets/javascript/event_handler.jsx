@@ -128,6 +128,15 @@ var eventHandlers = {
         flux.actions.setStepRunning(origin, false);
       },
 
+      "waiting-for-worker": function(data) {
+        flux.actions.setStepRunning(data.origin, true);
+        flux.actions.addLog(data.origin, "waiting for a worker with capacity...\n");
+      },
+
+      "image-version": function(data) {
+        flux.actions.addLog(data.origin, "using image version " + JSON.stringify(data.version) + "\n");
+      },
+
       "initialize-task": function(data) {
         flux.actions.setStepRunning(data.origin, true);
       },
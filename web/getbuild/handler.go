@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/pagination"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web/group"
 	"github.com/pivotal-golang/lager"
@@ -34,6 +35,7 @@ type TemplateData struct {
 	Inputs       []db.BuildInput
 	Outputs      []db.BuildOutput
 	PipelineName string
+	TeamName     string
 }
 
 func (server *server) GetBuild(pipelineDB db.PipelineDB) http.Handler {
@@ -82,9 +84,11 @@ func (server *server) GetBuild(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		bs, err := pipelineDB.GetAllJobBuilds(jobName)
+		// only the most recent builds are listed; older ones are paged through
+		// on the job's page
+		bs, _, err := pipelineDB.GetJobBuilds(jobName, db.Page{Limit: pagination.DefaultLimit})
 		if err != nil {
-			log.Error("get-all-builds-failed", err)
+			log.Error("get-job-builds-failed", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -107,6 +111,7 @@ func (server *server) GetBuild(pipelineDB db.PipelineDB) http.Handler {
 			Inputs:       inputs,
 			Outputs:      outputs,
 			PipelineName: pipelineDB.GetPipelineName(),
+			TeamName:     pipelineDB.GetPipelineTeamName(),
 		}
 
 		err = server.template.Execute(w, templateData)
@@ -9,38 +9,52 @@ import (
 )
 
 type FakeBuildsDB struct {
-	GetAllBuildsStub        func() ([]db.Build, error)
-	getAllBuildsMutex       sync.RWMutex
-	getAllBuildsArgsForCall []struct{}
-	getAllBuildsReturns struct {
+	GetBuildsStub        func(teamName string, page db.Page) ([]db.Build, db.Pagination, error)
+	getBuildsMutex       sync.RWMutex
+	getBuildsArgsForCall []struct {
+		teamName string
+		page     db.Page
+	}
+	getBuildsReturns struct {
 		result1 []db.Build
-		result2 error
+		result2 db.Pagination
+		result3 error
 	}
 }
 
-func (fake *FakeBuildsDB) GetAllBuilds() ([]db.Build, error) {
-	fake.getAllBuildsMutex.Lock()
-	fake.getAllBuildsArgsForCall = append(fake.getAllBuildsArgsForCall, struct{}{})
-	fake.getAllBuildsMutex.Unlock()
-	if fake.GetAllBuildsStub != nil {
-		return fake.GetAllBuildsStub()
+func (fake *FakeBuildsDB) GetBuilds(teamName string, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getBuildsMutex.Lock()
+	fake.getBuildsArgsForCall = append(fake.getBuildsArgsForCall, struct {
+		teamName string
+		page     db.Page
+	}{teamName, page})
+	fake.getBuildsMutex.Unlock()
+	if fake.GetBuildsStub != nil {
+		return fake.GetBuildsStub(teamName, page)
 	} else {
-		return fake.getAllBuildsReturns.result1, fake.getAllBuildsReturns.result2
+		return fake.getBuildsReturns.result1, fake.getBuildsReturns.result2, fake.getBuildsReturns.result3
 	}
 }
 
-func (fake *FakeBuildsDB) GetAllBuildsCallCount() int {
-	fake.getAllBuildsMutex.RLock()
-	defer fake.getAllBuildsMutex.RUnlock()
-	return len(fake.getAllBuildsArgsForCall)
+func (fake *FakeBuildsDB) GetBuildsCallCount() int {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return len(fake.getBuildsArgsForCall)
+}
+
+func (fake *FakeBuildsDB) GetBuildsArgsForCall(i int) (string, db.Page) {
+	fake.getBuildsMutex.RLock()
+	defer fake.getBuildsMutex.RUnlock()
+	return fake.getBuildsArgsForCall[i].teamName, fake.getBuildsArgsForCall[i].page
 }
 
-func (fake *FakeBuildsDB) GetAllBuildsReturns(result1 []db.Build, result2 error) {
-	fake.GetAllBuildsStub = nil
-	fake.getAllBuildsReturns = struct {
+func (fake *FakeBuildsDB) GetBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetBuildsStub = nil
+	fake.getBuildsReturns = struct {
 		result1 []db.Build
-		result2 error
-	}{result1, result2}
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
 }
 
 var _ getbuilds.BuildsDB = new(FakeBuildsDB)
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/concourse/atc/api/pagination"
 	"github.com/concourse/atc/db"
 	"github.com/pivotal-golang/lager"
 )
@@ -21,7 +22,7 @@ type handler struct {
 //go:generate counterfeiter . BuildsDB
 
 type BuildsDB interface {
-	GetAllBuilds() ([]db.Build, error)
+	GetBuilds(teamName string, page db.Page) ([]db.Build, db.Pagination, error)
 }
 
 func NewHandler(logger lager.Logger, db BuildsDB, configDB db.ConfigDB, template *template.Template) http.Handler {
@@ -37,22 +38,30 @@ func NewHandler(logger lager.Logger, db BuildsDB, configDB db.ConfigDB, template
 }
 
 type TemplateData struct {
-	Builds []PresentedBuild
+	Builds     []PresentedBuild
+	Pagination db.Pagination
 }
 
-func FetchTemplateData(buildDB BuildsDB, configDB db.ConfigDB) (TemplateData, error) {
-	builds, err := buildDB.GetAllBuilds()
+func FetchTemplateData(buildDB BuildsDB, configDB db.ConfigDB, page db.Page) (TemplateData, error) {
+	builds, pages, err := buildDB.GetBuilds("", page)
 	if err != nil {
 		return TemplateData{}, err
 	}
 
 	return TemplateData{
-		Builds: PresentBuilds(builds),
+		Builds:     PresentBuilds(builds),
+		Pagination: pages,
 	}, nil
 }
 
 func (handler *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	templateData, err := FetchTemplateData(handler.db, handler.configDB)
+	page, err := pagination.ParsePage(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	templateData, err := FetchTemplateData(handler.db, handler.configDB, page)
 	if err != nil {
 		handler.logger.Error("failed-to-build-template-data", err)
 		http.Error(w, "failed to fetch builds", http.StatusInternalServerError)
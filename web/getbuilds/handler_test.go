@@ -29,19 +29,29 @@ var _ = Describe("FetchTemplateData", func() {
 			},
 		}
 
-		fakeDB.GetAllBuildsReturns(builds, nil)
+		pagination := db.Pagination{
+			Next: &db.Page{Since: 6, Limit: 1},
+		}
+
+		fakeDB.GetBuildsReturns(builds, pagination, nil)
 
-		templateData, err := FetchTemplateData(fakeDB, fakeConfigDB)
+		templateData, err := FetchTemplateData(fakeDB, fakeConfigDB, db.Page{Limit: 1})
 		Ω(err).ShouldNot(HaveOccurred())
 
+		Ω(fakeDB.GetBuildsCallCount()).Should(Equal(1))
+		teamName, page := fakeDB.GetBuildsArgsForCall(0)
+		Ω(teamName).Should(BeEmpty())
+		Ω(page).Should(Equal(db.Page{Limit: 1}))
+
 		Ω(templateData.Builds[0].ID).Should(Equal(6))
 		Ω(templateData.Builds).Should(BeAssignableToTypeOf([]PresentedBuild{}))
+		Ω(templateData.Pagination).Should(Equal(pagination))
 	})
 
 	It("returns an error if fetching from the database fails", func() {
-		fakeDB.GetAllBuildsReturns(nil, errors.New("disaster"))
+		fakeDB.GetBuildsReturns(nil, db.Pagination{}, errors.New("disaster"))
 
-		_, err := FetchTemplateData(fakeDB, fakeConfigDB)
+		_, err := FetchTemplateData(fakeDB, fakeConfigDB, db.Page{})
 		Ω(err).Should(HaveOccurred())
 	})
 })
@@ -27,14 +27,16 @@ type FakeJobDB struct {
 		result1 db.SavedJob
 		result2 error
 	}
-	GetAllJobBuildsStub        func(job string) ([]db.Build, error)
-	getAllJobBuildsMutex       sync.RWMutex
-	getAllJobBuildsArgsForCall []struct {
-		job string
+	GetJobBuildsStub        func(job string, page db.Page) ([]db.Build, db.Pagination, error)
+	getJobBuildsMutex       sync.RWMutex
+	getJobBuildsArgsForCall []struct {
+		job  string
+		page db.Page
 	}
-	getAllJobBuildsReturns struct {
+	getJobBuildsReturns struct {
 		result1 []db.Build
-		result2 error
+		result2 db.Pagination
+		result3 error
 	}
 	GetCurrentBuildStub        func(job string) (db.Build, error)
 	getCurrentBuildMutex       sync.RWMutex
@@ -51,6 +53,12 @@ type FakeJobDB struct {
 	getPipelineNameReturns struct {
 		result1 string
 	}
+	GetPipelineTeamNameStub        func() string
+	getPipelineTeamNameMutex       sync.RWMutex
+	getPipelineTeamNameArgsForCall []struct{}
+	getPipelineTeamNameReturns struct {
+		result1 string
+	}
 }
 
 func (fake *FakeJobDB) GetConfig() (atc.Config, db.ConfigVersion, error) {
@@ -112,37 +120,39 @@ func (fake *FakeJobDB) GetJobReturns(result1 db.SavedJob, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeJobDB) GetAllJobBuilds(job string) ([]db.Build, error) {
-	fake.getAllJobBuildsMutex.Lock()
-	fake.getAllJobBuildsArgsForCall = append(fake.getAllJobBuildsArgsForCall, struct {
-		job string
-	}{job})
-	fake.getAllJobBuildsMutex.Unlock()
-	if fake.GetAllJobBuildsStub != nil {
-		return fake.GetAllJobBuildsStub(job)
+func (fake *FakeJobDB) GetJobBuilds(job string, page db.Page) ([]db.Build, db.Pagination, error) {
+	fake.getJobBuildsMutex.Lock()
+	fake.getJobBuildsArgsForCall = append(fake.getJobBuildsArgsForCall, struct {
+		job  string
+		page db.Page
+	}{job, page})
+	fake.getJobBuildsMutex.Unlock()
+	if fake.GetJobBuildsStub != nil {
+		return fake.GetJobBuildsStub(job, page)
 	} else {
-		return fake.getAllJobBuildsReturns.result1, fake.getAllJobBuildsReturns.result2
+		return fake.getJobBuildsReturns.result1, fake.getJobBuildsReturns.result2, fake.getJobBuildsReturns.result3
 	}
 }
 
-func (fake *FakeJobDB) GetAllJobBuildsCallCount() int {
-	fake.getAllJobBuildsMutex.RLock()
-	defer fake.getAllJobBuildsMutex.RUnlock()
-	return len(fake.getAllJobBuildsArgsForCall)
+func (fake *FakeJobDB) GetJobBuildsCallCount() int {
+	fake.getJobBuildsMutex.RLock()
+	defer fake.getJobBuildsMutex.RUnlock()
+	return len(fake.getJobBuildsArgsForCall)
 }
 
-func (fake *FakeJobDB) GetAllJobBuildsArgsForCall(i int) string {
-	fake.getAllJobBuildsMutex.RLock()
-	defer fake.getAllJobBuildsMutex.RUnlock()
-	return fake.getAllJobBuildsArgsForCall[i].job
+func (fake *FakeJobDB) GetJobBuildsArgsForCall(i int) (string, db.Page) {
+	fake.getJobBuildsMutex.RLock()
+	defer fake.getJobBuildsMutex.RUnlock()
+	return fake.getJobBuildsArgsForCall[i].job, fake.getJobBuildsArgsForCall[i].page
 }
 
-func (fake *FakeJobDB) GetAllJobBuildsReturns(result1 []db.Build, result2 error) {
-	fake.GetAllJobBuildsStub = nil
-	fake.getAllJobBuildsReturns = struct {
+func (fake *FakeJobDB) GetJobBuildsReturns(result1 []db.Build, result2 db.Pagination, result3 error) {
+	fake.GetJobBuildsStub = nil
+	fake.getJobBuildsReturns = struct {
 		result1 []db.Build
-		result2 error
-	}{result1, result2}
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
 }
 
 func (fake *FakeJobDB) GetCurrentBuild(job string) (db.Build, error) {
@@ -202,4 +212,28 @@ func (fake *FakeJobDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJobDB) GetPipelineTeamName() string {
+	fake.getPipelineTeamNameMutex.Lock()
+	fake.getPipelineTeamNameArgsForCall = append(fake.getPipelineTeamNameArgsForCall, struct{}{})
+	fake.getPipelineTeamNameMutex.Unlock()
+	if fake.GetPipelineTeamNameStub != nil {
+		return fake.GetPipelineTeamNameStub()
+	} else {
+		return fake.getPipelineTeamNameReturns.result1
+	}
+}
+
+func (fake *FakeJobDB) GetPipelineTeamNameCallCount() int {
+	fake.getPipelineTeamNameMutex.RLock()
+	defer fake.getPipelineTeamNameMutex.RUnlock()
+	return len(fake.getPipelineTeamNameArgsForCall)
+}
+
+func (fake *FakeJobDB) GetPipelineTeamNameReturns(result1 string) {
+	fake.GetPipelineTeamNameStub = nil
+	fake.getPipelineTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 var _ getjob.JobDB = new(FakeJobDB)
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/concourse/atc"
+	"github.com/concourse/atc/api/pagination"
 	"github.com/concourse/atc/db"
 	"github.com/concourse/atc/web/group"
 	"github.com/pivotal-golang/lager"
@@ -34,10 +35,13 @@ type TemplateData struct {
 	DBJob  db.SavedJob
 	Builds []db.Build
 
+	Pagination db.Pagination
+
 	GroupStates []group.State
 
 	CurrentBuild db.Build
 	PipelineName string
+	TeamName     string
 }
 
 //go:generate counterfeiter . JobDB
@@ -45,15 +49,16 @@ type TemplateData struct {
 type JobDB interface {
 	GetConfig() (atc.Config, db.ConfigVersion, error)
 	GetJob(string) (db.SavedJob, error)
-	GetAllJobBuilds(job string) ([]db.Build, error)
+	GetJobBuilds(job string, page db.Page) ([]db.Build, db.Pagination, error)
 	GetCurrentBuild(job string) (db.Build, error)
 	GetPipelineName() string
+	GetPipelineTeamName() string
 }
 
 var ErrJobConfigNotFound = errors.New("could not find job")
 var Err = errors.New("could not find job")
 
-func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
+func FetchTemplateData(jobDB JobDB, jobName string, page db.Page) (TemplateData, error) {
 	config, _, err := jobDB.GetConfig()
 	if err != nil {
 		return TemplateData{}, err
@@ -64,7 +69,7 @@ func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
 		return TemplateData{}, ErrJobConfigNotFound
 	}
 
-	bs, err := jobDB.GetAllJobBuilds(job.Name)
+	bs, pages, err := jobDB.GetJobBuilds(job.Name, page)
 	if err != nil {
 		return TemplateData{}, err
 	}
@@ -84,6 +89,8 @@ func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
 		DBJob:  dbJob,
 		Builds: bs,
 
+		Pagination: pages,
+
 		GroupStates: group.States(config.Groups, func(g atc.GroupConfig) bool {
 			for _, groupJob := range g.Jobs {
 				if groupJob == job.Name {
@@ -96,6 +103,7 @@ func FetchTemplateData(jobDB JobDB, jobName string) (TemplateData, error) {
 
 		CurrentBuild: currentBuild,
 		PipelineName: jobDB.GetPipelineName(),
+		TeamName:     jobDB.GetPipelineTeamName(),
 	}, nil
 }
 
@@ -107,7 +115,13 @@ func (server *server) GetJob(pipelineDB db.PipelineDB) http.Handler {
 			return
 		}
 
-		templateData, err := FetchTemplateData(pipelineDB, jobName)
+		page, err := pagination.ParsePage(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		templateData, err := FetchTemplateData(pipelineDB, jobName, page)
 		switch err {
 		case ErrJobConfigNotFound:
 			server.logger.Error("could-not-find-job-in-config", ErrJobConfigNotFound, lager.Data{
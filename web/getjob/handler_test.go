@@ -27,7 +27,7 @@ var _ = Describe("FetchTemplateData", func() {
 		})
 
 		It("returns an error if the config could not be loaded", func() {
-			_, err := FetchTemplateData(fakeDB, "job-name")
+			_, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 			Ω(err).Should(HaveOccurred())
 		})
 	})
@@ -58,7 +58,7 @@ var _ = Describe("FetchTemplateData", func() {
 		})
 
 		It("returns not found if the job cannot be found in the config", func() {
-			_, err := FetchTemplateData(fakeDB, "not-a-job-name")
+			_, err := FetchTemplateData(fakeDB, "not-a-job-name", db.Page{Limit: 100})
 			Ω(err).Should(HaveOccurred())
 			Ω(err).Should(MatchError(ErrJobConfigNotFound))
 		})
@@ -67,14 +67,15 @@ var _ = Describe("FetchTemplateData", func() {
 			Context("when the job builds lookup returns an error", func() {
 
 				It("returns an error if the jobs's builds could not be retreived", func() {
-					fakeDB.GetAllJobBuildsReturns([]db.Build{}, errors.New("disaster"))
-					_, err := FetchTemplateData(fakeDB, "job-name")
+					fakeDB.GetJobBuildsReturns([]db.Build{}, db.Pagination{}, errors.New("disaster"))
+					_, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 					Ω(err).Should(HaveOccurred())
 				})
 			})
 
 			Context("when the job builds lookup returns a build", func() {
 				var builds []db.Build
+				var pagination db.Pagination
 
 				BeforeEach(func() {
 					builds = []db.Build{
@@ -86,13 +87,17 @@ var _ = Describe("FetchTemplateData", func() {
 						},
 					}
 
-					fakeDB.GetAllJobBuildsReturns(builds, nil)
+					pagination = db.Pagination{
+						Previous: &db.Page{Until: 1, Limit: 100},
+					}
+
+					fakeDB.GetJobBuildsReturns(builds, pagination, nil)
 				})
 
 				Context("when the get job lookup returns an error", func() {
 					It("returns an ", func() {
 						fakeDB.GetJobReturns(db.SavedJob{}, errors.New("disaster"))
-						_, err := FetchTemplateData(fakeDB, "job-name")
+						_, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 						Ω(err).Should(HaveOccurred())
 					})
 
@@ -128,7 +133,7 @@ var _ = Describe("FetchTemplateData", func() {
 							It("has the correct template data and sets the current build status to pending", func() {
 								fakeDB.GetCurrentBuildReturns(db.Build{}, errors.New("No current build"))
 
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 								Ω(err).ShouldNot(HaveOccurred())
 
 								Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
@@ -158,13 +163,19 @@ var _ = Describe("FetchTemplateData", func() {
 							})
 
 							It("has the correct template data", func() {
-								templateData, err := FetchTemplateData(fakeDB, "job-name")
+								templateData, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 								Ω(err).ShouldNot(HaveOccurred())
 
+								Ω(fakeDB.GetJobBuildsCallCount()).Should(Equal(1))
+								jobName, page := fakeDB.GetJobBuildsArgsForCall(0)
+								Ω(jobName).Should(Equal("job-name"))
+								Ω(page).Should(Equal(db.Page{Limit: 100}))
+
 								Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
 								Ω(templateData.Job).Should(Equal(job))
 								Ω(templateData.DBJob).Should(Equal(dbJob))
 								Ω(templateData.Builds).Should(Equal(builds))
+								Ω(templateData.Pagination).Should(Equal(pagination))
 								Ω(templateData.CurrentBuild).Should(Equal(currentBuild))
 							})
 
@@ -180,7 +191,7 @@ var _ = Describe("FetchTemplateData", func() {
 								})
 
 								It("has the correct template data and sets the current build status to paused", func() {
-									templateData, err := FetchTemplateData(fakeDB, "job-name")
+									templateData, err := FetchTemplateData(fakeDB, "job-name", db.Page{Limit: 100})
 									Ω(err).ShouldNot(HaveOccurred())
 
 									Ω(templateData.GroupStates).Should(ConsistOf(groupStates))
@@ -16,6 +16,12 @@ type FakeResourcesDB struct {
 	getPipelineNameReturns struct {
 		result1 string
 	}
+	GetPipelineTeamNameStub        func() string
+	getPipelineTeamNameMutex       sync.RWMutex
+	getPipelineTeamNameArgsForCall []struct{}
+	getPipelineTeamNameReturns struct {
+		result1 string
+	}
 	GetConfigStub        func() (atc.Config, db.ConfigVersion, error)
 	getConfigMutex       sync.RWMutex
 	getConfigArgsForCall []struct{}
@@ -68,6 +74,30 @@ func (fake *FakeResourcesDB) GetPipelineNameReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResourcesDB) GetPipelineTeamName() string {
+	fake.getPipelineTeamNameMutex.Lock()
+	fake.getPipelineTeamNameArgsForCall = append(fake.getPipelineTeamNameArgsForCall, struct{}{})
+	fake.getPipelineTeamNameMutex.Unlock()
+	if fake.GetPipelineTeamNameStub != nil {
+		return fake.GetPipelineTeamNameStub()
+	} else {
+		return fake.getPipelineTeamNameReturns.result1
+	}
+}
+
+func (fake *FakeResourcesDB) GetPipelineTeamNameCallCount() int {
+	fake.getPipelineTeamNameMutex.RLock()
+	defer fake.getPipelineTeamNameMutex.RUnlock()
+	return len(fake.getPipelineTeamNameArgsForCall)
+}
+
+func (fake *FakeResourcesDB) GetPipelineTeamNameReturns(result1 string) {
+	fake.GetPipelineTeamNameStub = nil
+	fake.getPipelineTeamNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeResourcesDB) GetConfig() (atc.Config, db.ConfigVersion, error) {
 	fake.getConfigMutex.Lock()
 	fake.getConfigArgsForCall = append(fake.getConfigArgsForCall, struct{}{})
@@ -41,12 +41,14 @@ type TemplateData struct {
 
 	GroupStates  []group.State
 	PipelineName string
+	TeamName     string
 }
 
 //go:generate counterfeiter . ResourcesDB
 
 type ResourcesDB interface {
 	GetPipelineName() string
+	GetPipelineTeamName() string
 	GetConfig() (atc.Config, db.ConfigVersion, error)
 	GetResource(string) (db.SavedResource, error)
 	GetResourceHistory(string) ([]*db.VersionHistory, error)
@@ -80,6 +82,7 @@ func FetchTemplateData(resourceDB ResourcesDB, resourceName string) (TemplateDat
 		DBResource:   resource,
 		History:      history,
 		PipelineName: resourceDB.GetPipelineName(),
+		TeamName:     resourceDB.GetPipelineTeamName(),
 		GroupStates: group.States(config.Groups, func(g atc.GroupConfig) bool {
 			for _, groupResource := range g.Resources {
 				if groupResource == configResource.Name {
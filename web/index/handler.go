@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 
+	"github.com/concourse/atc/auth"
 	"github.com/concourse/atc/db"
 	"github.com/pivotal-golang/lager"
 )
@@ -18,7 +19,7 @@ func NewHandler(
 	template *template.Template,
 ) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		pipelineDB, err := pipelineDBFactory.BuildDefault()
+		pipelineDB, err := pipelineDBFactory.BuildDefaultForTeam(auth.RequestedTeamName(r))
 		if err != nil {
 			if err == db.ErrNoPipelines {
 				err = template.Execute(w, TemplateData{})
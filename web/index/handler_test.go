@@ -10,6 +10,7 @@ import (
 
 	"github.com/pivotal-golang/lager/lagertest"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	dbfakes "github.com/concourse/atc/db/fakes"
 	enginefakes "github.com/concourse/atc/engine/fakes"
@@ -36,6 +37,8 @@ var _ = Describe("Handler", func() {
 		handler, err = web.NewHandler(
 			logger,
 			auth.NoopValidator{},
+			auth.Providers{},
+			false,
 			radarSchedulerFactory,
 			db,
 			pipelineDBFactory,
@@ -47,11 +50,16 @@ var _ = Describe("Handler", func() {
 		Ω(err).ShouldNot(HaveOccurred())
 	})
 
+	var path string
 	var recorder *httptest.ResponseRecorder
 
+	BeforeEach(func() {
+		path = "/"
+	})
+
 	JustBeforeEach(func() {
 		recorder = httptest.NewRecorder()
-		req, err := http.NewRequest("GET", "http://concourse.example.com/", nil)
+		req, err := http.NewRequest("GET", "http://concourse.example.com"+path, nil)
 		Ω(err).ShouldNot(HaveOccurred())
 
 		handler.ServeHTTP(recorder, req)
@@ -60,7 +68,7 @@ var _ = Describe("Handler", func() {
 	Context("when the pipeline lookup fails", func() {
 		Context("when there is an unexpected error", func() {
 			BeforeEach(func() {
-				pipelineDBFactory.BuildDefaultReturns(nil, errors.New("nope"))
+				pipelineDBFactory.BuildDefaultForTeamReturns(nil, errors.New("nope"))
 			})
 
 			It("returns an internal server error", func() {
@@ -70,7 +78,7 @@ var _ = Describe("Handler", func() {
 
 		Context("because there are no pipelines", func() {
 			BeforeEach(func() {
-				pipelineDBFactory.BuildDefaultReturns(nil, db.ErrNoPipelines)
+				pipelineDBFactory.BuildDefaultForTeamReturns(nil, db.ErrNoPipelines)
 			})
 
 			It("is successful", func() {
@@ -86,7 +94,7 @@ var _ = Describe("Handler", func() {
 	Context("when there is a pipeline", func() {
 		BeforeEach(func() {
 			pipelineDB := new(dbfakes.FakePipelineDB)
-			pipelineDBFactory.BuildDefaultReturns(pipelineDB, nil)
+			pipelineDBFactory.BuildDefaultForTeamReturns(pipelineDB, nil)
 		})
 
 		It("is successful", func() {
@@ -96,5 +104,25 @@ var _ = Describe("Handler", func() {
 		It("renders the pipeline template", func() {
 			Ω(recorder.Body).Should(ContainSubstring("pipeline"))
 		})
+
+		It("looks up the main team's pipeline", func() {
+			Ω(pipelineDBFactory.BuildDefaultForTeamCallCount()).Should(Equal(1))
+			Ω(pipelineDBFactory.BuildDefaultForTeamArgsForCall(0)).Should(Equal(atc.DefaultTeamName))
+		})
+
+		Context("when requested for a team", func() {
+			BeforeEach(func() {
+				path = "/teams/some-team"
+			})
+
+			It("renders the pipeline template", func() {
+				Ω(recorder.Body).Should(ContainSubstring("pipeline"))
+			})
+
+			It("looks up the team's pipeline", func() {
+				Ω(pipelineDBFactory.BuildDefaultForTeamCallCount()).Should(Equal(1))
+				Ω(pipelineDBFactory.BuildDefaultForTeamArgsForCall(0)).Should(Equal("some-team"))
+			})
+		})
 	})
 })
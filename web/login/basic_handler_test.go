@@ -0,0 +1,71 @@
+package login_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/pivotal-golang/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/concourse/atc/web/login"
+)
+
+var _ = Describe("BasicHandler", func() {
+	var redirectTo string
+	var response *httptest.ResponseRecorder
+
+	JustBeforeEach(func() {
+		request, err := http.NewRequest("GET", "/login/basic?"+url.Values{
+			"redirect-to": {redirectTo},
+		}.Encode(), nil)
+		Ω(err).ShouldNot(HaveOccurred())
+
+		response = httptest.NewRecorder()
+
+		NewBasicHandler(lagertest.NewTestLogger("test")).ServeHTTP(response, request)
+	})
+
+	Context("when told to redirect within the ATC", func() {
+		BeforeEach(func() {
+			redirectTo = "/pipelines/some-pipeline"
+		})
+
+		It("redirects there", func() {
+			Ω(response.Code).Should(Equal(http.StatusFound))
+			Ω(response.Header().Get("Location")).Should(Equal("/pipelines/some-pipeline"))
+		})
+	})
+
+	Context("when not told where to redirect", func() {
+		BeforeEach(func() {
+			redirectTo = ""
+		})
+
+		It("redirects to the index", func() {
+			Ω(response.Code).Should(Equal(http.StatusFound))
+			Ω(response.Header().Get("Location")).Should(Equal("/"))
+		})
+	})
+
+	for _, offsite := range []string{
+		"http://evil.example.com",
+		"//evil.example.com",
+		"/\\evil.example.com",
+	} {
+		offsite := offsite
+
+		Context("when told to redirect to "+offsite, func() {
+			BeforeEach(func() {
+				redirectTo = offsite
+			})
+
+			It("redirects to the index instead", func() {
+				Ω(response.Code).Should(Equal(http.StatusFound))
+				Ω(response.Header().Get("Location")).Should(Equal("/"))
+			})
+		})
+	}
+})
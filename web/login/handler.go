@@ -1,26 +1,75 @@
 package login
 
 import (
+	"html/template"
 	"net/http"
+	"sort"
 
+	"github.com/concourse/atc/auth"
 	"github.com/pivotal-golang/lager"
 )
 
 type handler struct {
-	logger lager.Logger
+	logger           lager.Logger
+	providers        auth.Providers
+	basicAuthEnabled bool
+	template         *template.Template
 }
 
-func NewHandler(logger lager.Logger) http.Handler {
+func NewHandler(
+	logger lager.Logger,
+	providers auth.Providers,
+	basicAuthEnabled bool,
+	template *template.Template,
+) http.Handler {
 	return &handler{
-		logger: logger,
+		logger:           logger,
+		providers:        providers,
+		basicAuthEnabled: basicAuthEnabled,
+		template:         template,
 	}
 }
 
+type TemplateData struct {
+	Redirect         string
+	Providers        []ProviderData
+	BasicAuthEnabled bool
+}
+
+type ProviderData struct {
+	Name        string
+	DisplayName string
+}
+
 func (handler *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	redirectPath := r.FormValue("redirect-to")
 	if redirectPath == "" {
 		redirectPath = "/"
 	}
 
-	http.Redirect(w, r, redirectPath, http.StatusFound)
+	names := []string{}
+	for name := range handler.providers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	providers := []ProviderData{}
+	for _, name := range names {
+		providers = append(providers, ProviderData{
+			Name:        name,
+			DisplayName: handler.providers[name].DisplayName(),
+		})
+	}
+
+	err := handler.template.Execute(w, TemplateData{
+		Redirect:         redirectPath,
+		Providers:        providers,
+		BasicAuthEnabled: handler.basicAuthEnabled,
+	})
+	if err != nil {
+		handler.logger.Info("failed-to-generate-login-template", lager.Data{
+			"error": err.Error(),
+		})
+	}
 }
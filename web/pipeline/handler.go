@@ -29,6 +29,7 @@ type TemplateData struct {
 	GroupStates  []group.State
 	Groups       map[string]bool
 	PipelineName string
+	TeamName     string
 }
 
 func (server *server) GetPipeline(pipelineDB db.PipelineDB) http.Handler {
@@ -60,6 +61,7 @@ func (server *server) GetPipeline(pipelineDB db.PipelineDB) http.Handler {
 				return groups[g.Name]
 			}),
 			PipelineName: pipelineDB.GetPipelineName(),
+			TeamName:     pipelineDB.GetPipelineTeamName(),
 		}
 
 		log := server.logger.Session("index")
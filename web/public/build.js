@@ -1,4 +1,4 @@
-!function t(e,n,r){function i(a,s){if(!n[a]){if(!e[a]){var u="function"==typeof require&&require;if(!s&&u)return u(a,!0);if(o)return o(a,!0);var c=new Error("Cannot find module '"+a+"'");throw c.code="MODULE_NOT_FOUND",c}var l=n[a]={exports:{}};e[a][0].call(l.exports,function(t){var n=e[a][1][t];return i(n?n:t)},l,l.exports,t,e,n,r)}return n[a].exports}for(var o="function"==typeof require&&require,a=0;a<r.length;a++)i(r[a]);return i}({1:[function(t,e,n){function r(){var t=f.render(g,document.getElementById("build-logs")),e=d("#page-header");(e.hasClass("pending")||e.hasClass("started"))&&t.setState({autoscroll:!0}),d(window).scroll(function(){var e=d(window).scrollTop()+d(window).height();t.setState(e>=d(document).height()-16?{autoscroll:!0}:{autoscroll:!1})})}function i(t,e){var n=new EventSource(t),i="pending"==e||"started"==e;i&&r();var o=!1;n.addEventListener("event",function(t){var e=JSON.parse(t.data),n=e.version.split("."),r=y[n[0]];if(!r)return void console.log("unknown major version: "+e.version);var i=r[n[1]]||r["*"];if(!i)return void console.log("unknown minor version: "+e.version);var o=i[e.event];o&&o(e.data)}),n.addEventListener("end",function(t){n.close(),i||r()}),n.onopen=function(){o=!0},n.onerror=function(t){o||d("#build-requires-auth").show()}}function o(t){var e=d("#page-header").attr("class"),n=d(".build-times"),r=t.status,i=m.unix(t.time),o=d("<time>");if(o.text(i.fromNow()),o.attr("datetime",i.format()),o.attr("title",i.format("lll Z")),"started"==r)o.addClass("js-startTime"),d("<dt/>").text(r).appendTo(n),d("<dd/>").append(o).appendTo(n);else{d("<dt/>").text(r).appendTo(n),d("<dd/>").append(o).appendTo(n);var a=d(".js-startTime").attr("datetime"),s=!!a;if(s){var u=m.duration(i.diff(m(a))),c=d("<span>");c.addClass("duration"),c.text(u.format("h[h]m[m]s[s]")),d("<dt/>").text("duration").appendTo(n),d("<dd/>").append(c).appendTo(n)}}("pending"==e||"started"==e)&&(d("#page-header").attr("class",r),d("#builds .current").attr("class",r+" current"),"started"!=r&&d(".abort-build, .js-abortBuild").remove())}function a(t){var e;switch(t.origin.type){case"run":e=l();break;case"input":e=c(t.origin.name);break;case"output":e=p(t.origin.name);break;case"get":case"put":case"task":e=t.origin}e&&t.payload&&(v.actions.setStepRunning(e,!0),v.actions.addLog(e,t.payload))}function s(t){var e;if(t.origin&&t.origin.type)switch(t.origin.type){case"input":e=p(t.origin.name);break;case"output":e=p(t.origin.name);break;case"get":case"put":case"task":e=t.origin}else e=l();e&&(v.actions.setStepRunning(e,!1),v.actions.setStepErrored(e,!0),v.actions.addError(e,t.message))}function u(){var t=d("#builds .current"),e=t.width(),n=t.offset().left;n+e>window.innerWidth&&d("#builds").scrollLeft(n-e)}function c(t){var e=_.indexOf(t);-1==e&&(e=_.length,_.push(t));var n=[0,e];return{name:t,type:"get",location:n}}function l(){return{name:"build",type:"task",location:[1]}}function p(t){var e=b.indexOf(t);-1==e&&(e=b.length,b.push(t));var n=[2,e];return{name:t,type:"put",location:n}}var f=t("react/addons"),h=t("./build.jsx"),d=t("jquery"),m=t("moment");t("moment-duration-format");var v=t("./flux"),g=f.createElement(h,{flux:v}),y={1:{"*":{log:function(t){a(t)},error:function(t){s(t)},status:function(t){o(t)},input:function(t){var e=event.input,n=c(e.name);v.actions.setStepVersionInfo(n,e.version,e.metadata),v.actions.setStepRunning(n,!1)},output:function(t){var e=event.output,n=p(e.name);v.actions.setStepVersionInfo(n,e.version,e.metadata),v.actions.setStepRunning(n,!1)},finish:function(t){var e=l();v.actions.setStepRunning(e,!1)},"initialize-task":function(t){v.actions.setStepRunning(t.origin,!0)},"finish-task":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepRunning(t.origin,!1)},"finish-get":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)},"finish-put":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)}}},2:{"*":{input:function(t){var e=c(t.plan.name);v.actions.setStepVersionInfo(e,t.version,t.metadata),v.actions.setStepRunning(e,!1)},output:function(t){var e=p(t.plan.name);v.actions.setStepVersionInfo(e,t.version,t.metadata),v.actions.setStepRunning(e,!1)},log:function(t){a(t)},error:function(t){s(t)},"initialize-task":function(t){v.actions.setStepRunning(t.origin,!0)},"finish-task":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepRunning(t.origin,!1)},"finish-get":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)},"finish-put":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)}}},3:{"*":{log:function(t){a(t)}}}};d(document).ready(function(){d("#builds").bind("mousewheel",function(t){return d(this).scrollLeft(0!=t.originalEvent.deltaX?d(this).scrollLeft()+t.originalEvent.deltaX:d(this).scrollLeft()-t.originalEvent.deltaY),!1}),u()});var _=[],b=[];window.streamLog=i,window.preloadInput=v.actions.preloadInput},{"./build.jsx":2,"./flux":3,jquery:108,moment:110,"moment-duration-format":109,"react/addons":114}],2:[function(t,e,n){var r=t("react/addons"),i=(t("immutable"),t("react-immutable-render-mixin")),o=t("./step.jsx"),a=t("fluxxor"),s=a.FluxMixin(r),u=a.StoreWatchMixin,c=(t("./flux"),t("./tree"),r.createClass({displayName:"Build",mixins:[i,s,u("StepStore")],getStateFromFlux:function(){var t=this.getFlux();return{steps:t.store("StepStore").getState()}},getInitialState:function(){return{autoscroll:!1}},render:function(){function t(e){for(var i=["seq"],a=[],s=0;s<=e.children.length;s++)childStep=e.children[s],void 0!=childStep&&a.push(t(childStep));if(e.step.isHook()&&(i.push("hook"),i.push(e.step.hookClassName())),e.step.isDependentGet()&&i.push("seq-dependent-get"),e.group){for(var u=[],c=["aggregate"],s=0;s<=e.groupSteps.length;s++)groupStep=e.groupSteps[s],void 0!=groupStep&&u.push(t(groupStep));return r.createElement("div",{className:i.join(" "),key:e.key},r.createElement("div",{className:c.join(" ")},u)," ",r.createElement("div",{className:"children"},a))}return r.createElement("div",{className:i.join(" "),key:e.key},r.createElement(o,{key:e.key,model:e.step,logs:e.logLines,autoscroll:n}),r.createElement("div",{className:"children"},a))}for(var e=this.state.steps.getRenderableData(),n=(this.state.steps,this.state.autoscroll),i=[],a=0;a<e.length;a++)currentStep=e[a],void 0!=currentStep&&0===currentStep.location.parent_id&&i.push(t(currentStep));return r.createElement("div",{className:"steps"},i)}}));e.exports=c},{"./flux":3,"./step.jsx":6,"./tree":8,fluxxor:9,immutable:107,"react-immutable-render-mixin":112,"react/addons":114}],3:[function(t,e,n){var r=t("fluxxor"),i=t("./step_store"),o={preloadInput:function(t,e,n,r){this.dispatch(i.PRELOAD_INPUT,{name:t,firstOccurrence:e,version:n,metadata:r})},addLog:function(t,e){this.dispatch(i.ADD_LOG,{origin:t,line:e})},addError:function(t,e){this.dispatch(i.ADD_ERROR,{origin:t,line:e})},setStepVersionInfo:function(t,e,n){this.dispatch(i.SET_STEP_VERSION_INFO,{origin:t,version:e,metadata:n})},setStepSuccessful:function(t,e){this.dispatch(i.SET_STEP_SUCCESSFUL,{origin:t,successful:e})},setStepRunning:function(t,e){this.dispatch(i.SET_STEP_RUNNING,{origin:t,running:e})},setStepErrored:function(t,e){this.dispatch(i.SET_STEP_ERRORED,{origin:t,errored:e})},toggleStepLogs:function(t){this.dispatch(i.TOGGLE_STEP_LOGS,{origin:t})}},a={StepStore:new i.Store};e.exports=new r.Flux(a,o)},{"./step_store":7,fluxxor:9}],4:[function(t,e,n){var r=t("react/addons"),i=t("react-immutable-render-mixin"),o=r.createClass({displayName:"LogLine",mixins:[i],componentDidMount:function(){this.props.autoscroll&&window.scrollTo(0,document.body.scrollHeight)},renderSequence:function(t,e){var n="";return t.linebreak&&(n+="linebreak "),t.foreground&&(n+="ansi-"+t.foreground+"-fg "),t.background&&(n+="ansi-"+t.background+"-bg "),t.bold&&(n+="ansi-bold "),t.error&&(n+="error "),r.createElement("span",{key:e,className:n},t.text)},render:function(){return r.createElement("div",null,this.props.line.toJS().map(this.renderSequence))}}),a=r.createClass({displayName:"LogsLineBatch",mixins:[i],render:function(){var t=[];return this.props.lines&&this.props.lines.forEach(function(e,n){t.push(r.createElement(o,{key:n,line:e,autoscroll:this.props.autoscroll}))},this),r.createElement("div",null,t)}}),s=r.createClass({displayName:"Logs",mixins:[i],render:function(){var t=[];return this.props.batches.forEach(function(e,n){t.push(r.createElement(a,{key:n,lines:e,autoscroll:this.props.autoscroll}))},this),r.createElement("pre",null,t)}});e.exports=s},{"react-immutable-render-mixin":112,"react/addons":114}],5:[function(t,e,n){function r(){this.lines=i.fromJS([[[]]]),this.batchCursor=o.from(this.lines,function(t){this.lines=t}.bind(this)),this.linesCursor=this.batchCursor.last(),this.seqsSinceCR=0,this.state={},this.inst_p=function(t){var e=t.length,n=this.seqsSinceCR,r=this.cursor.update(function(t){return t.slice(n)});this.cursor=this.cursor.update(function(t){return t.slice(0,n)}),this.pushSequence({text:t,foreground:this.state.foreground,background:this.state.background,bold:this.state.bold,italic:this.state.italic,underline:this.state.underline}),this.seqsSinceCR++,r.forEach(function(t,n){return t.text.length>=e?(t.text=t.text.substr(e),e-=t.text.length,this.pushSequence(t),!1):void 0},this),this.refreshLineCursor(),this.changed=!0},this.inst_x=function(t){switch(t.charCodeAt(0)){case 10:this.pushLine(i.List.of({text:"\n",linebreak:!0})),this.refreshCursor(),this.changed=!0,this.seqsSinceCR=0;break;case 13:this.seqsSinceCR=0;break;default:this.inst_p(t)}},this.inst_c=function(t,e,n){for(var r in e){var i=e[r];u[i]?this.state.foreground=u[i]:c[i]?this.state.foreground=c[i]:l[i]?this.state.background=l[i]:39==i?delete this.state.foreground:49==i?delete this.state.background:p[i]?this.state[p[i]]=!0:22==i?this.state.bold=!1:23==i?this.state.italic=!1:24==i?this.state.underline=!1:0==i&&(this.state={})}};var t=new a(this);this.addLog=function(e){t.parse(e)},this.addError=function(t){this.pushLine(i.List()),this.refreshCursor(),this.pushSequence({text:t,error:!0}),this.refreshLineCursor(),this.changed=!0},this.refreshLineCursor=function(){this.linesCursor=this.linesCursor.set(this.linesCursor.count()-1,this.cursor)},this.refreshCursor=function(){this.cursor=this.linesCursor.last()},this.pushLine=function(t){this.linesCursor.count()>=s?(this.batchCursor=this.batchCursor.set(this.batchCursor.count()-1,this.linesCursor),this.batchCursor=this.batchCursor.update(function(e){return e.push(i.List.of(t))}),this.linesCursor=this.batchCursor.last()):this.linesCursor=this.linesCursor.update(function(e){return e.push(t)})},this.pushSequence=function(t){this.cursor=this.cursor.update(function(e){return e.push(t)})},this.refreshCursor(),this.changed=!1}var i=t("immutable"),o=t("immutable/contrib/cursor"),a=t("node-ansiparser"),s=300,u={30:"black",31:"red",32:"green",33:"yellow",34:"blue",35:"magenta",36:"cyan",37:"white"},c={90:"bright-black",91:"bright-red",92:"bright-green",93:"bright-yellow",94:"bright-blue",95:"bright-magenta",96:"bright-cyan",97:"bright-white"},l={40:"black",41:"red",42:"green",43:"yellow",44:"blue",45:"magenta",46:"cyan",47:"white"},p={1:"bold",3:"italic",4:"underline"};e.exports=r},{immutable:107,"immutable/contrib/cursor":106,"node-ansiparser":111}],6:[function(t,e,n){var r=t("react/addons"),i=(t("immutable"),t("react-immutable-render-mixin")),o=t("fluxxor").FluxMixin(r),a=t("./logs.jsx"),s=r.createClass({displayName:"Step",mixins:[o,i],toggleLogs:function(){var t=this.props.model;this.getFlux().actions.toggleStepLogs(t.origin())},render:function(){var t=this.props.model,e=[],n=t.version();if(void 0!==n)for(var i in n){var o=n[i];e.push(r.createElement("dt",{key:"version-dt-"+i},i)),e.push(r.createElement("dd",{key:"version-dd-"+i},o))}var s=[],u=t.metadata();void 0!==u&&u.forEach(function(t,e){s.push(r.createElement("dt",{key:"metadata-dt-"+e},t.name)),s.push(r.createElement("dd",{key:"metadata-dd-"+e},t.value))});var c=r.addons.classSet,l=(t.hookClassName(),{"build-step":!0,running:t.isRunning(),"first-occurrence":t.isFirstOccurrence()&&!t.isDependentGet(),"dependent-get":t.isDependentGet(),hook:t.isHook()}),p=c(l),f=t.isShowingLogs()?"block":"none",h=["left","fa","fa-fw"];switch(t.origin().type){case"get":h.push("fa-arrow-down");break;case"put":h.push("fa-arrow-up");break;case"task":h.push("fa-terminal")}var d="";return t.isRunning()?d=r.createElement("i",{className:"right fa fa-fw fa-circle-o-notch fa-spin"}):t.isErrored()?d=r.createElement("i",{className:"right errored fa fa-fw fa-exclamation-triangle"}):t.isSuccessful()===!0?d=r.createElement("i",{className:"right succeeded fa fa-fw fa-check"}):t.isSuccessful()===!1?d=r.createElement("i",{className:"right failed fa fa-fw fa-times"}):void 0!==t.version()&&(d=r.createElement("i",{className:"right fa fa-fw fa-cube"})),r.createElement("div",{className:p},r.createElement("div",{className:"header",onClick:this.toggleLogs},d,r.createElement("i",{className:h.join(" ")}),r.createElement("dl",{className:"version"},e),r.createElement("h3",null,t.origin().name),r.createElement("div",{style:{clear:"both"}})),r.createElement("div",{className:"step-body",style:{display:f}},r.createElement("dl",{className:"build-metadata fr"},s),r.createElement(a,{batches:this.props.logs,autoscroll:this.props.autoscroll}),r.createElement("div",{style:{clear:"both"}})))}});e.exports=s},{"./logs.jsx":4,fluxxor:9,immutable:107,"react-immutable-render-mixin":112,"react/addons":114}],7:[function(t,e,n){function r(t){this._map=new o.Map({origin:t,logs:new a,showLogs:!0,userToggled:!1,running:!1,errored:!1,version:void 0,metadata:void 0,successful:void 0,firstOccurrence:!1}),this.merge=function(t){var e=this._map.merge(t);if(e==this._map)return this;var n=new r(this.origin());return n._map=e,n},this.copy=function(){var t=new r(this.origin());return t._map=this._map,t},this.origin=function(){return this._map.get("origin")},this.logs=function(){return this._map.get("logs")},this.isShowingLogs=function(){var t=this._map.get("showLogs");return this.wasToggled()?t:t&&(this.isRunning()||this.isErrored()||this.isSuccessful()===!1)},this.isRunning=function(){return this._map.get("running")},this.isErrored=function(){return this._map.get("errored")},this.isDependentGet=function(){return Array.isArray(this.origin().location)?!!this.origin().substep:!this.isHook()&&0!=this.origin().location.parent_id},this.isHook=function(){return""!=this.origin().hook&&!Array.isArray(this.origin().location)},this.hookName=function(){return this.origin().hook},this.hookClassName=function(){return"hook-"+this.origin().hook},this.isSuccessful=function(){return this._map.get("successful")},this.isFirstOccurrence=function(){return this._map.get("firstOccurrence")},this.wasToggled=function(){return this._map.get("userToggled")},this.version=function(){var t=this._map.get("version");return t?t.toJS():void 0},this.metadata=function(){var t=this._map.get("metadata");return void 0===t?void 0:t.toJS()}}var i=t("fluxxor"),o=t("immutable"),a=t("./logs_model"),s=t("./tree"),u=300,c={ADD_LOG:"ADD_LOG",ADD_ERROR:"ADD_ERROR",SET_STEP_RUNNING:"SET_STEP_RUNNING",SET_STEP_ERRORED:"SET_STEP_ERRORED",SET_STEP_VERSION_INFO:"SET_STEP_VERSION_INFO",SET_STEP_SUCCESSFUL:"SET_STEP_SUCCESSFUL",TOGGLE_STEP_LOGS:"TOGGLE_STEP_LOGS",PRELOAD_INPUT:"PRELOAD_INPUT"},l=i.createStore({initialize:function(){this.steps=new concourse.StepData,this.preloadedInputs=o.Map(),this.bindActions(c.ADD_LOG,this.onAddLog,c.ADD_ERROR,this.onAddError,c.SET_STEP_RUNNING,this.onSetStepRunning,c.SET_STEP_ERRORED,this.onSetStepErrored,c.SET_STEP_VERSION_INFO,this.onSetStepVersionInfo,c.SET_STEP_SUCCESSFUL,this.onSetStepSuccessful,c.TOGGLE_STEP_LOGS,this.onToggleStepLogs,c.PRELOAD_INPUT,this.onPreloadInput),setInterval(this.emitChangedLogs.bind(this),u)},setStep:function(t,e){var n={};"get"==t.type&&this.preloadedInputs.has(t.name)&&(n=this.preloadedInputs.get(t.name)),this.steps=this.steps.updateIn(t.location,function(i){return void 0===i?new r(t).merge(n).merge(e):i.merge(e)}),this.emit("change")},setPreloadedInput:function(t,e){this.preloadedInputs=this.preloadedInputs.set(t,e)},onPreloadInput:function(t){this.setPreloadedInput(t.name,t)},onSetStepVersionInfo:function(t){this.setStep(t.origin,{version:t.version,metadata:t.metadata})},onSetStepSuccessful:function(t){this.setStep(t.origin,{successful:t.successful})},onSetStepRunning:function(t){this.setStep(t.origin,{running:t.running})},onSetStepErrored:function(t){this.setStep(t.origin,{errored:t.errored})},onToggleStepLogs:function(t){var e=this.steps.getIn(t.origin.location);this.setStep(t.origin,{showLogs:!e.isShowingLogs(),userToggled:!0})},onAddLog:function(t){var e=this.steps.getIn(t.origin.location);e&&e.logs().addLog(t.line)},onAddError:function(t){var e=this.steps.getIn(t.origin.location);e&&e.logs().addError(t.line)},emitChangedLogs:function(){var t=[],e=!1;s.walk(this.steps,function(n){var r=n.logs();r.changed&&(t.push(n),e=!0,r.changed=!1)});for(var n in t){var r=t[n];this.steps=this.steps.setIn(r.origin().location,r.copy())}e&&this.emit("change")},getState:function(){return this.steps}});e.exports={Store:l};for(var p in c)e.exports[p]=c[p]},{"./logs_model":5,"./tree":8,fluxxor:9,immutable:107}],8:[function(t,e,n){function r(){this.tree=o.List(),this.add=function(t,e){for(var n=0;n<t.length;n++)this.tree=this.tree.updateIn(t.slice(0,n+1),function(t){return t||o.List()});this.tree=this.tree.setIn(t,e)},this.walk=function(t){i(this.tree,function(e){return void 0!==e?t(e):void 0})}}function i(t,e){t.forEach(function(t){return o.Iterable.isIterable(t)?void i(t,e):e(t)})}var o=t("immutable");e.exports.OrderedTree=r,e.exports.walk=i},{immutable:107}],9:[function(t,e,n){var r=t("./lib/dispatcher"),i=t("./lib/flux"),o=t("./lib/flux_mixin"),a=t("./lib/flux_child_mixin"),s=t("./lib/store_watch_mixin"),u=t("./lib/create_store"),c={Dispatcher:r,Flux:i,FluxMixin:o,FluxChildMixin:a,StoreWatchMixin:s,createStore:u,version:t("./version")};e.exports=c},{"./lib/create_store":10,"./lib/dispatcher":11,"./lib/flux":12,"./lib/flux_child_mixin":13,"./lib/flux_mixin":14,"./lib/store_watch_mixin":16,"./version":105}],10:[function(t,e,n){var r=t("lodash/collection/forEach"),i=t("lodash/lang/isFunction"),o=t("./store"),a=t("./util/inherits"),s=["flux","waitFor"],u=function(t){r(s,function(e){if(t[e])throw new Error("Reserved key '"+e+"' found in store definition")});var e=function(e){e=e||{},o.call(this);for(var n in t)"actions"===n?this.bindActions(t[n]):"initialize"===n||(this[n]=i(t[n])?t[n].bind(this):t[n]);t.initialize&&t.initialize.call(this,e)};return a(e,o),e};e.exports=u},{"./store":15,"./util/inherits":17,"lodash/collection/forEach":22,"lodash/lang/isFunction":89}],11:[function(t,e,n){var r=t("lodash/lang/clone"),i=t("lodash/object/mapValues"),o=t("lodash/object/forOwn"),a=t("lodash/array/intersection"),s=t("lodash/object/keys"),u=t("lodash/collection/map"),c=t("lodash/collection/forEach"),l=t("lodash/collection/size"),p=t("lodash/object/findKey"),f=t("lodash/array/uniq"),h=function(t,e){e(t)},d=function(t){this.stores={},this.currentDispatch=null,this.currentActionType=null,this.waitingToDispatch=[],this.dispatchInterceptor=h,this._boundDispatch=this._dispatch.bind(this);for(var e in t)t.hasOwnProperty(e)&&this.addStore(e,t[e])};d.prototype.addStore=function(t,e){e.dispatcher=this,this.stores[t]=e},d.prototype.dispatch=function(t){this.dispatchInterceptor(t,this._boundDispatch)},d.prototype._dispatch=function(t){if(!t||!t.type)throw new Error("Can only dispatch actions with a 'type' property");if(this.currentDispatch){var e="Cannot dispatch an action ('"+t.type+"') while another action ('"+this.currentActionType+"') is being dispatched";throw new Error(e)}this.waitingToDispatch=r(this.stores),this.currentActionType=t.type,this.currentDispatch=i(this.stores,function(){return{resolved:!1,waitingOn:[],waitCallback:null}});try{this.doDispatchLoop(t)}finally{this.currentActionType=null,this.currentDispatch=null}},d.prototype.doDispatchLoop=function(t){var e,n,r=!1,i=[],p=[];if(o(this.waitingToDispatch,function(o,c){if(e=this.currentDispatch[c],n=!e.waitingOn.length||!a(e.waitingOn,s(this.waitingToDispatch)).length){if(e.waitCallback){var l=u(e.waitingOn,function(t){return this.stores[t]},this),f=e.waitCallback;e.waitCallback=null,e.waitingOn=[],e.resolved=!0,f.apply(null,l),r=!0}else{e.resolved=!0;var h=this.stores[c].__handleAction__(t);h&&(r=!0)}p.push(c),this.currentDispatch[c].resolved&&i.push(c)}},this),s(this.waitingToDispatch).length&&!p.length){var f=s(this.waitingToDispatch).join(", ");throw new Error("Indirect circular wait detected among: "+f)}c(i,function(t){delete this.waitingToDispatch[t]},this),l(this.waitingToDispatch)&&this.doDispatchLoop(t),!r&&console&&console.warn&&console.warn("An action of type "+t.type+" was dispatched, but no store handled it")},d.prototype.waitForStores=function(t,e,n){if(!this.currentDispatch)throw new Error("Cannot wait unless an action is being dispatched");var r=p(this.stores,function(e){return e===t});if(e.indexOf(r)>-1)throw new Error("A store cannot wait on itself");var i=this.currentDispatch[r];if(i.waitingOn.length)throw new Error(r+" already waiting on stores");c(e,function(t){var e=this.currentDispatch[t];if(!this.stores[t])throw new Error("Cannot wait for non-existent store "+t);if(e.waitingOn.indexOf(r)>-1)throw new Error("Circular wait detected between "+r+" and "+t)},this),i.resolved=!1,i.waitingOn=f(i.waitingOn.concat(e)),i.waitCallback=n},d.prototype.setDispatchInterceptor=function(t){this.dispatchInterceptor=t?t:h},e.exports=d},{"lodash/array/intersection":19,"lodash/array/uniq":21,"lodash/collection/forEach":22,"lodash/collection/map":23,"lodash/collection/size":25,"lodash/lang/clone":86,"lodash/object/findKey":94,"lodash/object/forOwn":95,"lodash/object/keys":96,"lodash/object/mapValues":98}],12:[function(t,e,n){var r=t("eventemitter3"),i=t("./util/inherits"),o=t("object-path"),a=t("lodash/collection/forEach"),s=t("lodash/collection/reduce"),u=t("lodash/lang/isFunction"),c=t("lodash/lang/isString"),l=t("./dispatcher"),p=function(t,e,n){e=e||[];for(var r in t)t.hasOwnProperty(r)&&(u(t[r])?n(e.concat(r),t[r]):p(t[r],e.concat(r),n))},f=function(t,e){r.call(this),this.dispatcher=new l(t),this.actions={},this.stores={};var n=this.dispatcher,i=this;this.dispatchBinder={flux:i,dispatch:function(t,e){try{i.emit("dispatch",t,e)}finally{n.dispatch({type:t,payload:e})}}},this.addActions(e),this.addStores(t)};i(f,r),f.prototype.addActions=function(t){p(t,[],this.addAction.bind(this))},f.prototype.addAction=function(){if(arguments.length<2)throw new Error("addAction requires at least two arguments, a string (or array of strings) and a function");var t=Array.prototype.slice.call(arguments);if(!u(t[t.length-1]))throw new Error("The last argument to addAction must be a function");var e=t.pop().bind(this.dispatchBinder);c(t[0])||(t=t[0]);var n=s(t,function(t,e){if(t){var n=t[t.length-1].concat([e]);return t.concat([n])}return[[e]]},null);if(a(n,function(e){if(u(o.get(this.actions,e)))throw new Error("An action named "+t.join(".")+" already exists")},this),o.get(this.actions,t))throw new Error("A namespace named "+t.join(".")+" already exists");o.set(this.actions,t,e,!0)},f.prototype.store=function(t){return this.stores[t]},f.prototype.addStore=function(t,e){if(t in this.stores)throw new Error("A store named '"+t+"' already exists");e.flux=this,this.stores[t]=e,this.dispatcher.addStore(t,e)},f.prototype.addStores=function(t){for(var e in t)t.hasOwnProperty(e)&&this.addStore(e,t[e])},f.prototype.setDispatchInterceptor=function(t){this.dispatcher.setDispatchInterceptor(t)},e.exports=f},{"./dispatcher":11,"./util/inherits":17,eventemitter3:18,"lodash/collection/forEach":22,"lodash/collection/reduce":24,"lodash/lang/isFunction":89,"lodash/lang/isString":92,"object-path":104}],13:[function(t,e,n){var r=function(t){return{componentWillMount:function(){if(console&&console.warn){var t=this.constructor.displayName?" in "+this.constructor.displayName:"",e="Fluxxor.FluxChildMixin was found in use"+t+", but has been deprecated. Use Fluxxor.FluxMixin instead.";console.warn(e)}},contextTypes:{flux:t.PropTypes.object},getFlux:function(){return this.context.flux}}};r.componentWillMount=function(){throw new Error("Fluxxor.FluxChildMixin is a function that takes React as a parameter and returns the mixin, e.g.: mixins[Fluxxor.FluxChildMixin(React)]")},e.exports=r},{}],14:[function(t,e,n){var r=function(t){return{componentWillMount:function(){if(!(this.props.flux||this.context&&this.context.flux)){var t=this.constructor.displayName?" of "+this.constructor.displayName:"";throw new Error("Could not find flux on this.props or this.context"+t)}},childContextTypes:{flux:t.PropTypes.object},contextTypes:{flux:t.PropTypes.object},getChildContext:function(){return{flux:this.getFlux()}},getFlux:function(){return this.props.flux||this.context&&this.context.flux}}};r.componentWillMount=function(){throw new Error("Fluxxor.FluxMixin is a function that takes React as a parameter and returns the mixin, e.g.: mixins: [Fluxxor.FluxMixin(React)]")},e.exports=r},{}],15:[function(t,e,n){function r(t){this.dispatcher=t,this.__actions__={},i.call(this)}var i=t("eventemitter3"),o=t("./util/inherits"),a=t("lodash/lang/isFunction"),s=t("lodash/lang/isObject");o(r,i),r.prototype.__handleAction__=function(t){var e;if(e=this.__actions__[t.type]){if(a(e))e.call(this,t.payload,t.type);else{if(!e||!a(this[e]))throw new Error("The handler for action type "+t.type+" is not a function");this[e].call(this,t.payload,t.type)}return!0}return!1},r.prototype.bindActions=function(){var t=Array.prototype.slice.call(arguments);if(t.length>1&&t.length%2!==0)throw new Error("bindActions must take an even number of arguments.");var e=function(t,e){if(!e)throw new Error("The handler for action type "+t+" is falsy");this.__actions__[t]=e}.bind(this);if(1===t.length&&s(t[0])){t=t[0];for(var n in t)t.hasOwnProperty(n)&&e(n,t[n])}else for(var r=0;r<t.length;r+=2){var i=t[r],o=t[r+1];if(!i)throw new Error("Argument "+(r+1)+" to bindActions is a falsy value");e(i,o)}},r.prototype.waitFor=function(t,e){this.dispatcher.waitForStores(this,t,e.bind(this))},e.exports=r},{"./util/inherits":17,eventemitter3:18,"lodash/lang/isFunction":89,"lodash/lang/isObject":91}],16:[function(t,e,n){var r=t("lodash/collection/forEach"),i=function(){var t=Array.prototype.slice.call(arguments);return{componentDidMount:function(){var e=this.props.flux||this.context.flux;r(t,function(t){e.store(t).on("change",this._setStateFromFlux)},this)},componentWillUnmount:function(){var e=this.props.flux||this.context.flux;r(t,function(t){e.store(t).removeListener("change",this._setStateFromFlux)},this)},_setStateFromFlux:function(){this.isMounted()&&this.setState(this.getStateFromFlux())},getInitialState:function(){return this.getStateFromFlux()}}};i.componentWillMount=function(){throw new Error('Fluxxor.StoreWatchMixin is a function that takes one or more store names as parameters and returns the mixin, e.g.: mixins: [Fluxxor.StoreWatchMixin("Store1", "Store2")]')},e.exports=i},{"lodash/collection/forEach":22}],17:[function(t,e,n){e.exports="function"==typeof Object.create?function(t,e){t.super_=e,t.prototype=Object.create(e.prototype,{constructor:{value:t,enumerable:!1,writable:!0,configurable:!0}})}:function(t,e){t.super_=e;var n=function(){};n.prototype=e.prototype,t.prototype=new n,t.prototype.constructor=t}},{}],18:[function(t,e,n){"use strict";function r(t,e,n){this.fn=t,this.context=e,this.once=n||!1}function i(){}i.prototype._events=void 0,i.prototype.listeners=function(t){if(!this._events||!this._events[t])return[];if(this._events[t].fn)return[this._events[t].fn];for(var e=0,n=this._events[t].length,r=new Array(n);n>e;e++)r[e]=this._events[t][e].fn;return r},i.prototype.emit=function(t,e,n,r,i,o){if(!this._events||!this._events[t])return!1;var a,s,u=this._events[t],c=arguments.length;if("function"==typeof u.fn){switch(u.once&&this.removeListener(t,u.fn,!0),c){case 1:return u.fn.call(u.context),!0;case 2:return u.fn.call(u.context,e),!0;case 3:return u.fn.call(u.context,e,n),!0;case 4:return u.fn.call(u.context,e,n,r),!0;case 5:return u.fn.call(u.context,e,n,r,i),!0;case 6:return u.fn.call(u.context,e,n,r,i,o),!0}for(s=1,a=new Array(c-1);c>s;s++)a[s-1]=arguments[s];u.fn.apply(u.context,a)}else{var l,p=u.length;for(s=0;p>s;s++)switch(u[s].once&&this.removeListener(t,u[s].fn,!0),c){case 1:u[s].fn.call(u[s].context);break;case 2:u[s].fn.call(u[s].context,e);break;case 3:u[s].fn.call(u[s].context,e,n);break;default:if(!a)for(l=1,a=new Array(c-1);c>l;l++)a[l-1]=arguments[l];u[s].fn.apply(u[s].context,a)}}return!0},i.prototype.on=function(t,e,n){var i=new r(e,n||this);return this._events||(this._events={}),this._events[t]?this._events[t].fn?this._events[t]=[this._events[t],i]:this._events[t].push(i):this._events[t]=i,this},i.prototype.once=function(t,e,n){var i=new r(e,n||this,!0);return this._events||(this._events={}),this._events[t]?this._events[t].fn?this._events[t]=[this._events[t],i]:this._events[t].push(i):this._events[t]=i,this},i.prototype.removeListener=function(t,e,n){if(!this._events||!this._events[t])return this;var r=this._events[t],i=[];if(e&&(r.fn&&(r.fn!==e||n&&!r.once)&&i.push(r),!r.fn))for(var o=0,a=r.length;a>o;o++)(r[o].fn!==e||n&&!r[o].once)&&i.push(r[o]);return i.length?this._events[t]=1===i.length?i[0]:i:delete this._events[t],this},i.prototype.removeAllListeners=function(t){return this._events?(t?delete this._events[t]:this._events={},this):this},i.prototype.off=i.prototype.removeListener,i.prototype.addListener=i.prototype.on,i.prototype.setMaxListeners=function(){return this},i.EventEmitter=i,i.EventEmitter2=i,i.EventEmitter3=i,e.exports=i},{}],19:[function(t,e,n){function r(){for(var t=[],e=-1,n=arguments.length,r=[],u=i,c=!0,l=[];++e<n;){var p=arguments[e];s(p)&&(t.push(p),r.push(c&&p.length>=120?a(e&&p):null))}if(n=t.length,2>n)return l;var f=t[0],h=-1,d=f?f.length:0,m=r[0];t:for(;++h<d;)if(p=f[h],(m?o(m,p):u(l,p,0))<0){for(e=n;--e;){var v=r[e];if((v?o(v,p):u(t[e],p,0))<0)continue t}m&&m.push(p),l.push(p)}return l}var i=t("../internal/baseIndexOf"),o=t("../internal/cacheIndexOf"),a=t("../internal/createCache"),s=t("../internal/isArrayLike");e.exports=r},{"../internal/baseIndexOf":40,"../internal/cacheIndexOf":56,"../internal/createCache":60,"../internal/isArrayLike":75}],20:[function(t,e,n){function r(t){var e=t?t.length:0;return e?t[e-1]:void 0}e.exports=r},{}],21:[function(t,e,n){function r(t,e,n,r){var u=t?t.length:0;return u?(null!=e&&"boolean"!=typeof e&&(r=n,n=a(t,e,r)?null:e,e=!1),n=null==n?n:i(n,r,3),e?s(t,n):o(t,n)):[]}var i=t("../internal/baseCallback"),o=t("../internal/baseUniq"),a=t("../internal/isIterateeCall"),s=t("../internal/sortedUniq");e.exports=r},{"../internal/baseCallback":32,"../internal/baseUniq":53,"../internal/isIterateeCall":77,"../internal/sortedUniq":83}],22:[function(t,e,n){var r=t("../internal/arrayEach"),i=t("../internal/baseEach"),o=t("../internal/createForEach"),a=o(r,i);e.exports=a},{"../internal/arrayEach":28,"../internal/baseEach":35,"../internal/createForEach":62}],23:[function(t,e,n){function r(t,e,n){var r=s(t)?i:a;return e=o(e,n,3),r(t,e)}var i=t("../internal/arrayMap"),o=t("../internal/baseCallback"),a=t("../internal/baseMap"),s=t("../lang/isArray");e.exports=r},{"../internal/arrayMap":29,"../internal/baseCallback":32,"../internal/baseMap":45,"../lang/isArray":88}],24:[function(t,e,n){var r=t("../internal/arrayReduce"),i=t("../internal/baseEach"),o=t("../internal/createReduce"),a=o(r,i);e.exports=a;
+!function t(e,n,r){function i(a,s){if(!n[a]){if(!e[a]){var u="function"==typeof require&&require;if(!s&&u)return u(a,!0);if(o)return o(a,!0);var c=new Error("Cannot find module '"+a+"'");throw c.code="MODULE_NOT_FOUND",c}var l=n[a]={exports:{}};e[a][0].call(l.exports,function(t){var n=e[a][1][t];return i(n?n:t)},l,l.exports,t,e,n,r)}return n[a].exports}for(var o="function"==typeof require&&require,a=0;a<r.length;a++)i(r[a]);return i}({1:[function(t,e,n){function r(){var t=f.render(g,document.getElementById("build-logs")),e=d("#page-header");(e.hasClass("pending")||e.hasClass("started"))&&t.setState({autoscroll:!0}),d(window).scroll(function(){var e=d(window).scrollTop()+d(window).height();t.setState(e>=d(document).height()-16?{autoscroll:!0}:{autoscroll:!1})})}function i(t,e){var n=new EventSource(t),i="pending"==e||"started"==e;i&&r();var o=!1;n.addEventListener("event",function(t){var e=JSON.parse(t.data),n=e.version.split("."),r=y[n[0]];if(!r)return void console.log("unknown major version: "+e.version);var i=r[n[1]]||r["*"];if(!i)return void console.log("unknown minor version: "+e.version);var o=i[e.event];o&&o(e.data)}),n.addEventListener("end",function(t){n.close(),i||r()}),n.onopen=function(){o=!0},n.onerror=function(t){o||d("#build-requires-auth").show()}}function o(t){var e=d("#page-header").attr("class"),n=d(".build-times"),r=t.status,i=m.unix(t.time),o=d("<time>");if(o.text(i.fromNow()),o.attr("datetime",i.format()),o.attr("title",i.format("lll Z")),"started"==r)o.addClass("js-startTime"),d("<dt/>").text(r).appendTo(n),d("<dd/>").append(o).appendTo(n);else{d("<dt/>").text(r).appendTo(n),d("<dd/>").append(o).appendTo(n);var a=d(".js-startTime").attr("datetime"),s=!!a;if(s){var u=m.duration(i.diff(m(a))),c=d("<span>");c.addClass("duration"),c.text(u.format("h[h]m[m]s[s]")),d("<dt/>").text("duration").appendTo(n),d("<dd/>").append(c).appendTo(n)}}("pending"==e||"started"==e)&&(d("#page-header").attr("class",r),d("#builds .current").attr("class",r+" current"),"started"!=r&&d(".abort-build, .js-abortBuild").remove())}function a(t){var e;switch(t.origin.type){case"run":e=l();break;case"input":e=c(t.origin.name);break;case"output":e=p(t.origin.name);break;case"get":case"put":case"task":e=t.origin}e&&t.payload&&(v.actions.setStepRunning(e,!0),v.actions.addLog(e,t.payload))}function s(t){var e;if(t.origin&&t.origin.type)switch(t.origin.type){case"input":e=p(t.origin.name);break;case"output":e=p(t.origin.name);break;case"get":case"put":case"task":e=t.origin}else e=l();e&&(v.actions.setStepRunning(e,!1),v.actions.setStepErrored(e,!0),v.actions.addError(e,t.message))}function u(){var t=d("#builds .current"),e=t.width(),n=t.offset().left;n+e>window.innerWidth&&d("#builds").scrollLeft(n-e)}function c(t){var e=_.indexOf(t);-1==e&&(e=_.length,_.push(t));var n=[0,e];return{name:t,type:"get",location:n}}function l(){return{name:"build",type:"task",location:[1]}}function p(t){var e=b.indexOf(t);-1==e&&(e=b.length,b.push(t));var n=[2,e];return{name:t,type:"put",location:n}}var f=t("react/addons"),h=t("./build.jsx"),d=t("jquery"),m=t("moment");t("moment-duration-format");var v=t("./flux"),g=f.createElement(h,{flux:v}),y={1:{"*":{log:function(t){a(t)},error:function(t){s(t)},status:function(t){o(t)},input:function(t){var e=event.input,n=c(e.name);v.actions.setStepVersionInfo(n,e.version,e.metadata),v.actions.setStepRunning(n,!1)},output:function(t){var e=event.output,n=p(e.name);v.actions.setStepVersionInfo(n,e.version,e.metadata),v.actions.setStepRunning(n,!1)},finish:function(t){var e=l();v.actions.setStepRunning(e,!1)},"waiting-for-worker":function(t){v.actions.setStepRunning(t.origin,!0),v.actions.addLog(t.origin,"waiting for a worker with capacity...\n")},"image-version":function(t){v.actions.addLog(t.origin,"using image version "+JSON.stringify(t.version)+"\n")},"initialize-task":function(t){v.actions.setStepRunning(t.origin,!0)},"finish-task":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepRunning(t.origin,!1)},"finish-get":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)},"finish-put":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)}}},2:{"*":{input:function(t){var e=c(t.plan.name);v.actions.setStepVersionInfo(e,t.version,t.metadata),v.actions.setStepRunning(e,!1)},output:function(t){var e=p(t.plan.name);v.actions.setStepVersionInfo(e,t.version,t.metadata),v.actions.setStepRunning(e,!1)},log:function(t){a(t)},error:function(t){s(t)},"initialize-task":function(t){v.actions.setStepRunning(t.origin,!0)},"finish-task":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepRunning(t.origin,!1)},"finish-get":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)},"finish-put":function(t){v.actions.setStepSuccessful(t.origin,0==t.exit_status),v.actions.setStepVersionInfo(t.origin,t.version,t.metadata),v.actions.setStepRunning(t.origin,!1)}}},3:{"*":{log:function(t){a(t)}}}};d(document).ready(function(){d("#builds").bind("mousewheel",function(t){return d(this).scrollLeft(0!=t.originalEvent.deltaX?d(this).scrollLeft()+t.originalEvent.deltaX:d(this).scrollLeft()-t.originalEvent.deltaY),!1}),u()});var _=[],b=[];window.streamLog=i,window.preloadInput=v.actions.preloadInput},{"./build.jsx":2,"./flux":3,jquery:108,moment:110,"moment-duration-format":109,"react/addons":114}],2:[function(t,e,n){var r=t("react/addons"),i=(t("immutable"),t("react-immutable-render-mixin")),o=t("./step.jsx"),a=t("fluxxor"),s=a.FluxMixin(r),u=a.StoreWatchMixin,c=(t("./flux"),t("./tree"),r.createClass({displayName:"Build",mixins:[i,s,u("StepStore")],getStateFromFlux:function(){var t=this.getFlux();return{steps:t.store("StepStore").getState()}},getInitialState:function(){return{autoscroll:!1}},render:function(){function t(e){for(var i=["seq"],a=[],s=0;s<=e.children.length;s++)childStep=e.children[s],void 0!=childStep&&a.push(t(childStep));if(e.step.isHook()&&(i.push("hook"),i.push(e.step.hookClassName())),e.step.isDependentGet()&&i.push("seq-dependent-get"),e.group){for(var u=[],c=["aggregate"],s=0;s<=e.groupSteps.length;s++)groupStep=e.groupSteps[s],void 0!=groupStep&&u.push(t(groupStep));return r.createElement("div",{className:i.join(" "),key:e.key},r.createElement("div",{className:c.join(" ")},u)," ",r.createElement("div",{className:"children"},a))}return r.createElement("div",{className:i.join(" "),key:e.key},r.createElement(o,{key:e.key,model:e.step,logs:e.logLines,autoscroll:n}),r.createElement("div",{className:"children"},a))}for(var e=this.state.steps.getRenderableData(),n=(this.state.steps,this.state.autoscroll),i=[],a=0;a<e.length;a++)currentStep=e[a],void 0!=currentStep&&0===currentStep.location.parent_id&&i.push(t(currentStep));return r.createElement("div",{className:"steps"},i)}}));e.exports=c},{"./flux":3,"./step.jsx":6,"./tree":8,fluxxor:9,immutable:107,"react-immutable-render-mixin":112,"react/addons":114}],3:[function(t,e,n){var r=t("fluxxor"),i=t("./step_store"),o={preloadInput:function(t,e,n,r){this.dispatch(i.PRELOAD_INPUT,{name:t,firstOccurrence:e,version:n,metadata:r})},addLog:function(t,e){this.dispatch(i.ADD_LOG,{origin:t,line:e})},addError:function(t,e){this.dispatch(i.ADD_ERROR,{origin:t,line:e})},setStepVersionInfo:function(t,e,n){this.dispatch(i.SET_STEP_VERSION_INFO,{origin:t,version:e,metadata:n})},setStepSuccessful:function(t,e){this.dispatch(i.SET_STEP_SUCCESSFUL,{origin:t,successful:e})},setStepRunning:function(t,e){this.dispatch(i.SET_STEP_RUNNING,{origin:t,running:e})},setStepErrored:function(t,e){this.dispatch(i.SET_STEP_ERRORED,{origin:t,errored:e})},toggleStepLogs:function(t){this.dispatch(i.TOGGLE_STEP_LOGS,{origin:t})}},a={StepStore:new i.Store};e.exports=new r.Flux(a,o)},{"./step_store":7,fluxxor:9}],4:[function(t,e,n){var r=t("react/addons"),i=t("react-immutable-render-mixin"),o=r.createClass({displayName:"LogLine",mixins:[i],componentDidMount:function(){this.props.autoscroll&&window.scrollTo(0,document.body.scrollHeight)},renderSequence:function(t,e){var n="";return t.linebreak&&(n+="linebreak "),t.foreground&&(n+="ansi-"+t.foreground+"-fg "),t.background&&(n+="ansi-"+t.background+"-bg "),t.bold&&(n+="ansi-bold "),t.error&&(n+="error "),r.createElement("span",{key:e,className:n},t.text)},render:function(){return r.createElement("div",null,this.props.line.toJS().map(this.renderSequence))}}),a=r.createClass({displayName:"LogsLineBatch",mixins:[i],render:function(){var t=[];return this.props.lines&&this.props.lines.forEach(function(e,n){t.push(r.createElement(o,{key:n,line:e,autoscroll:this.props.autoscroll}))},this),r.createElement("div",null,t)}}),s=r.createClass({displayName:"Logs",mixins:[i],render:function(){var t=[];return this.props.batches.forEach(function(e,n){t.push(r.createElement(a,{key:n,lines:e,autoscroll:this.props.autoscroll}))},this),r.createElement("pre",null,t)}});e.exports=s},{"react-immutable-render-mixin":112,"react/addons":114}],5:[function(t,e,n){function r(){this.lines=i.fromJS([[[]]]),this.batchCursor=o.from(this.lines,function(t){this.lines=t}.bind(this)),this.linesCursor=this.batchCursor.last(),this.seqsSinceCR=0,this.state={},this.inst_p=function(t){var e=t.length,n=this.seqsSinceCR,r=this.cursor.update(function(t){return t.slice(n)});this.cursor=this.cursor.update(function(t){return t.slice(0,n)}),this.pushSequence({text:t,foreground:this.state.foreground,background:this.state.background,bold:this.state.bold,italic:this.state.italic,underline:this.state.underline}),this.seqsSinceCR++,r.forEach(function(t,n){return t.text.length>=e?(t.text=t.text.substr(e),e-=t.text.length,this.pushSequence(t),!1):void 0},this),this.refreshLineCursor(),this.changed=!0},this.inst_x=function(t){switch(t.charCodeAt(0)){case 10:this.pushLine(i.List.of({text:"\n",linebreak:!0})),this.refreshCursor(),this.changed=!0,this.seqsSinceCR=0;break;case 13:this.seqsSinceCR=0;break;default:this.inst_p(t)}},this.inst_c=function(t,e,n){for(var r in e){var i=e[r];u[i]?this.state.foreground=u[i]:c[i]?this.state.foreground=c[i]:l[i]?this.state.background=l[i]:39==i?delete this.state.foreground:49==i?delete this.state.background:p[i]?this.state[p[i]]=!0:22==i?this.state.bold=!1:23==i?this.state.italic=!1:24==i?this.state.underline=!1:0==i&&(this.state={})}};var t=new a(this);this.addLog=function(e){t.parse(e)},this.addError=function(t){this.pushLine(i.List()),this.refreshCursor(),this.pushSequence({text:t,error:!0}),this.refreshLineCursor(),this.changed=!0},this.refreshLineCursor=function(){this.linesCursor=this.linesCursor.set(this.linesCursor.count()-1,this.cursor)},this.refreshCursor=function(){this.cursor=this.linesCursor.last()},this.pushLine=function(t){this.linesCursor.count()>=s?(this.batchCursor=this.batchCursor.set(this.batchCursor.count()-1,this.linesCursor),this.batchCursor=this.batchCursor.update(function(e){return e.push(i.List.of(t))}),this.linesCursor=this.batchCursor.last()):this.linesCursor=this.linesCursor.update(function(e){return e.push(t)})},this.pushSequence=function(t){this.cursor=this.cursor.update(function(e){return e.push(t)})},this.refreshCursor(),this.changed=!1}var i=t("immutable"),o=t("immutable/contrib/cursor"),a=t("node-ansiparser"),s=300,u={30:"black",31:"red",32:"green",33:"yellow",34:"blue",35:"magenta",36:"cyan",37:"white"},c={90:"bright-black",91:"bright-red",92:"bright-green",93:"bright-yellow",94:"bright-blue",95:"bright-magenta",96:"bright-cyan",97:"bright-white"},l={40:"black",41:"red",42:"green",43:"yellow",44:"blue",45:"magenta",46:"cyan",47:"white"},p={1:"bold",3:"italic",4:"underline"};e.exports=r},{immutable:107,"immutable/contrib/cursor":106,"node-ansiparser":111}],6:[function(t,e,n){var r=t("react/addons"),i=(t("immutable"),t("react-immutable-render-mixin")),o=t("fluxxor").FluxMixin(r),a=t("./logs.jsx"),s=r.createClass({displayName:"Step",mixins:[o,i],toggleLogs:function(){var t=this.props.model;this.getFlux().actions.toggleStepLogs(t.origin())},render:function(){var t=this.props.model,e=[],n=t.version();if(void 0!==n)for(var i in n){var o=n[i];e.push(r.createElement("dt",{key:"version-dt-"+i},i)),e.push(r.createElement("dd",{key:"version-dd-"+i},o))}var s=[],u=t.metadata();void 0!==u&&u.forEach(function(t,e){s.push(r.createElement("dt",{key:"metadata-dt-"+e},t.name)),s.push(r.createElement("dd",{key:"metadata-dd-"+e},t.value))});var c=r.addons.classSet,l=(t.hookClassName(),{"build-step":!0,running:t.isRunning(),"first-occurrence":t.isFirstOccurrence()&&!t.isDependentGet(),"dependent-get":t.isDependentGet(),hook:t.isHook()}),p=c(l),f=t.isShowingLogs()?"block":"none",h=["left","fa","fa-fw"];switch(t.origin().type){case"get":h.push("fa-arrow-down");break;case"put":h.push("fa-arrow-up");break;case"task":h.push("fa-terminal")}var d="";return t.isRunning()?d=r.createElement("i",{className:"right fa fa-fw fa-circle-o-notch fa-spin"}):t.isErrored()?d=r.createElement("i",{className:"right errored fa fa-fw fa-exclamation-triangle"}):t.isSuccessful()===!0?d=r.createElement("i",{className:"right succeeded fa fa-fw fa-check"}):t.isSuccessful()===!1?d=r.createElement("i",{className:"right failed fa fa-fw fa-times"}):void 0!==t.version()&&(d=r.createElement("i",{className:"right fa fa-fw fa-cube"})),r.createElement("div",{className:p},r.createElement("div",{className:"header",onClick:this.toggleLogs},d,r.createElement("i",{className:h.join(" ")}),r.createElement("dl",{className:"version"},e),r.createElement("h3",null,t.origin().name),r.createElement("div",{style:{clear:"both"}})),r.createElement("div",{className:"step-body",style:{display:f}},r.createElement("dl",{className:"build-metadata fr"},s),r.createElement(a,{batches:this.props.logs,autoscroll:this.props.autoscroll}),r.createElement("div",{style:{clear:"both"}})))}});e.exports=s},{"./logs.jsx":4,fluxxor:9,immutable:107,"react-immutable-render-mixin":112,"react/addons":114}],7:[function(t,e,n){function r(t){this._map=new o.Map({origin:t,logs:new a,showLogs:!0,userToggled:!1,running:!1,errored:!1,version:void 0,metadata:void 0,successful:void 0,firstOccurrence:!1}),this.merge=function(t){var e=this._map.merge(t);if(e==this._map)return this;var n=new r(this.origin());return n._map=e,n},this.copy=function(){var t=new r(this.origin());return t._map=this._map,t},this.origin=function(){return this._map.get("origin")},this.logs=function(){return this._map.get("logs")},this.isShowingLogs=function(){var t=this._map.get("showLogs");return this.wasToggled()?t:t&&(this.isRunning()||this.isErrored()||this.isSuccessful()===!1)},this.isRunning=function(){return this._map.get("running")},this.isErrored=function(){return this._map.get("errored")},this.isDependentGet=function(){return Array.isArray(this.origin().location)?!!this.origin().substep:!this.isHook()&&0!=this.origin().location.parent_id},this.isHook=function(){return""!=this.origin().hook&&!Array.isArray(this.origin().location)},this.hookName=function(){return this.origin().hook},this.hookClassName=function(){return"hook-"+this.origin().hook},this.isSuccessful=function(){return this._map.get("successful")},this.isFirstOccurrence=function(){return this._map.get("firstOccurrence")},this.wasToggled=function(){return this._map.get("userToggled")},this.version=function(){var t=this._map.get("version");return t?t.toJS():void 0},this.metadata=function(){var t=this._map.get("metadata");return void 0===t?void 0:t.toJS()}}var i=t("fluxxor"),o=t("immutable"),a=t("./logs_model"),s=t("./tree"),u=300,c={ADD_LOG:"ADD_LOG",ADD_ERROR:"ADD_ERROR",SET_STEP_RUNNING:"SET_STEP_RUNNING",SET_STEP_ERRORED:"SET_STEP_ERRORED",SET_STEP_VERSION_INFO:"SET_STEP_VERSION_INFO",SET_STEP_SUCCESSFUL:"SET_STEP_SUCCESSFUL",TOGGLE_STEP_LOGS:"TOGGLE_STEP_LOGS",PRELOAD_INPUT:"PRELOAD_INPUT"},l=i.createStore({initialize:function(){this.steps=new concourse.StepData,this.preloadedInputs=o.Map(),this.bindActions(c.ADD_LOG,this.onAddLog,c.ADD_ERROR,this.onAddError,c.SET_STEP_RUNNING,this.onSetStepRunning,c.SET_STEP_ERRORED,this.onSetStepErrored,c.SET_STEP_VERSION_INFO,this.onSetStepVersionInfo,c.SET_STEP_SUCCESSFUL,this.onSetStepSuccessful,c.TOGGLE_STEP_LOGS,this.onToggleStepLogs,c.PRELOAD_INPUT,this.onPreloadInput),setInterval(this.emitChangedLogs.bind(this),u)},setStep:function(t,e){var n={};"get"==t.type&&this.preloadedInputs.has(t.name)&&(n=this.preloadedInputs.get(t.name)),this.steps=this.steps.updateIn(t.location,function(i){return void 0===i?new r(t).merge(n).merge(e):i.merge(e)}),this.emit("change")},setPreloadedInput:function(t,e){this.preloadedInputs=this.preloadedInputs.set(t,e)},onPreloadInput:function(t){this.setPreloadedInput(t.name,t)},onSetStepVersionInfo:function(t){this.setStep(t.origin,{version:t.version,metadata:t.metadata})},onSetStepSuccessful:function(t){this.setStep(t.origin,{successful:t.successful})},onSetStepRunning:function(t){this.setStep(t.origin,{running:t.running})},onSetStepErrored:function(t){this.setStep(t.origin,{errored:t.errored})},onToggleStepLogs:function(t){var e=this.steps.getIn(t.origin.location);this.setStep(t.origin,{showLogs:!e.isShowingLogs(),userToggled:!0})},onAddLog:function(t){var e=this.steps.getIn(t.origin.location);e&&e.logs().addLog(t.line)},onAddError:function(t){var e=this.steps.getIn(t.origin.location);e&&e.logs().addError(t.line)},emitChangedLogs:function(){var t=[],e=!1;s.walk(this.steps,function(n){var r=n.logs();r.changed&&(t.push(n),e=!0,r.changed=!1)});for(var n in t){var r=t[n];this.steps=this.steps.setIn(r.origin().location,r.copy())}e&&this.emit("change")},getState:function(){return this.steps}});e.exports={Store:l};for(var p in c)e.exports[p]=c[p]},{"./logs_model":5,"./tree":8,fluxxor:9,immutable:107}],8:[function(t,e,n){function r(){this.tree=o.List(),this.add=function(t,e){for(var n=0;n<t.length;n++)this.tree=this.tree.updateIn(t.slice(0,n+1),function(t){return t||o.List()});this.tree=this.tree.setIn(t,e)},this.walk=function(t){i(this.tree,function(e){return void 0!==e?t(e):void 0})}}function i(t,e){t.forEach(function(t){return o.Iterable.isIterable(t)?void i(t,e):e(t)})}var o=t("immutable");e.exports.OrderedTree=r,e.exports.walk=i},{immutable:107}],9:[function(t,e,n){var r=t("./lib/dispatcher"),i=t("./lib/flux"),o=t("./lib/flux_mixin"),a=t("./lib/flux_child_mixin"),s=t("./lib/store_watch_mixin"),u=t("./lib/create_store"),c={Dispatcher:r,Flux:i,FluxMixin:o,FluxChildMixin:a,StoreWatchMixin:s,createStore:u,version:t("./version")};e.exports=c},{"./lib/create_store":10,"./lib/dispatcher":11,"./lib/flux":12,"./lib/flux_child_mixin":13,"./lib/flux_mixin":14,"./lib/store_watch_mixin":16,"./version":105}],10:[function(t,e,n){var r=t("lodash/collection/forEach"),i=t("lodash/lang/isFunction"),o=t("./store"),a=t("./util/inherits"),s=["flux","waitFor"],u=function(t){r(s,function(e){if(t[e])throw new Error("Reserved key '"+e+"' found in store definition")});var e=function(e){e=e||{},o.call(this);for(var n in t)"actions"===n?this.bindActions(t[n]):"initialize"===n||(this[n]=i(t[n])?t[n].bind(this):t[n]);t.initialize&&t.initialize.call(this,e)};return a(e,o),e};e.exports=u},{"./store":15,"./util/inherits":17,"lodash/collection/forEach":22,"lodash/lang/isFunction":89}],11:[function(t,e,n){var r=t("lodash/lang/clone"),i=t("lodash/object/mapValues"),o=t("lodash/object/forOwn"),a=t("lodash/array/intersection"),s=t("lodash/object/keys"),u=t("lodash/collection/map"),c=t("lodash/collection/forEach"),l=t("lodash/collection/size"),p=t("lodash/object/findKey"),f=t("lodash/array/uniq"),h=function(t,e){e(t)},d=function(t){this.stores={},this.currentDispatch=null,this.currentActionType=null,this.waitingToDispatch=[],this.dispatchInterceptor=h,this._boundDispatch=this._dispatch.bind(this);for(var e in t)t.hasOwnProperty(e)&&this.addStore(e,t[e])};d.prototype.addStore=function(t,e){e.dispatcher=this,this.stores[t]=e},d.prototype.dispatch=function(t){this.dispatchInterceptor(t,this._boundDispatch)},d.prototype._dispatch=function(t){if(!t||!t.type)throw new Error("Can only dispatch actions with a 'type' property");if(this.currentDispatch){var e="Cannot dispatch an action ('"+t.type+"') while another action ('"+this.currentActionType+"') is being dispatched";throw new Error(e)}this.waitingToDispatch=r(this.stores),this.currentActionType=t.type,this.currentDispatch=i(this.stores,function(){return{resolved:!1,waitingOn:[],waitCallback:null}});try{this.doDispatchLoop(t)}finally{this.currentActionType=null,this.currentDispatch=null}},d.prototype.doDispatchLoop=function(t){var e,n,r=!1,i=[],p=[];if(o(this.waitingToDispatch,function(o,c){if(e=this.currentDispatch[c],n=!e.waitingOn.length||!a(e.waitingOn,s(this.waitingToDispatch)).length){if(e.waitCallback){var l=u(e.waitingOn,function(t){return this.stores[t]},this),f=e.waitCallback;e.waitCallback=null,e.waitingOn=[],e.resolved=!0,f.apply(null,l),r=!0}else{e.resolved=!0;var h=this.stores[c].__handleAction__(t);h&&(r=!0)}p.push(c),this.currentDispatch[c].resolved&&i.push(c)}},this),s(this.waitingToDispatch).length&&!p.length){var f=s(this.waitingToDispatch).join(", ");throw new Error("Indirect circular wait detected among: "+f)}c(i,function(t){delete this.waitingToDispatch[t]},this),l(this.waitingToDispatch)&&this.doDispatchLoop(t),!r&&console&&console.warn&&console.warn("An action of type "+t.type+" was dispatched, but no store handled it")},d.prototype.waitForStores=function(t,e,n){if(!this.currentDispatch)throw new Error("Cannot wait unless an action is being dispatched");var r=p(this.stores,function(e){return e===t});if(e.indexOf(r)>-1)throw new Error("A store cannot wait on itself");var i=this.currentDispatch[r];if(i.waitingOn.length)throw new Error(r+" already waiting on stores");c(e,function(t){var e=this.currentDispatch[t];if(!this.stores[t])throw new Error("Cannot wait for non-existent store "+t);if(e.waitingOn.indexOf(r)>-1)throw new Error("Circular wait detected between "+r+" and "+t)},this),i.resolved=!1,i.waitingOn=f(i.waitingOn.concat(e)),i.waitCallback=n},d.prototype.setDispatchInterceptor=function(t){this.dispatchInterceptor=t?t:h},e.exports=d},{"lodash/array/intersection":19,"lodash/array/uniq":21,"lodash/collection/forEach":22,"lodash/collection/map":23,"lodash/collection/size":25,"lodash/lang/clone":86,"lodash/object/findKey":94,"lodash/object/forOwn":95,"lodash/object/keys":96,"lodash/object/mapValues":98}],12:[function(t,e,n){var r=t("eventemitter3"),i=t("./util/inherits"),o=t("object-path"),a=t("lodash/collection/forEach"),s=t("lodash/collection/reduce"),u=t("lodash/lang/isFunction"),c=t("lodash/lang/isString"),l=t("./dispatcher"),p=function(t,e,n){e=e||[];for(var r in t)t.hasOwnProperty(r)&&(u(t[r])?n(e.concat(r),t[r]):p(t[r],e.concat(r),n))},f=function(t,e){r.call(this),this.dispatcher=new l(t),this.actions={},this.stores={};var n=this.dispatcher,i=this;this.dispatchBinder={flux:i,dispatch:function(t,e){try{i.emit("dispatch",t,e)}finally{n.dispatch({type:t,payload:e})}}},this.addActions(e),this.addStores(t)};i(f,r),f.prototype.addActions=function(t){p(t,[],this.addAction.bind(this))},f.prototype.addAction=function(){if(arguments.length<2)throw new Error("addAction requires at least two arguments, a string (or array of strings) and a function");var t=Array.prototype.slice.call(arguments);if(!u(t[t.length-1]))throw new Error("The last argument to addAction must be a function");var e=t.pop().bind(this.dispatchBinder);c(t[0])||(t=t[0]);var n=s(t,function(t,e){if(t){var n=t[t.length-1].concat([e]);return t.concat([n])}return[[e]]},null);if(a(n,function(e){if(u(o.get(this.actions,e)))throw new Error("An action named "+t.join(".")+" already exists")},this),o.get(this.actions,t))throw new Error("A namespace named "+t.join(".")+" already exists");o.set(this.actions,t,e,!0)},f.prototype.store=function(t){return this.stores[t]},f.prototype.addStore=function(t,e){if(t in this.stores)throw new Error("A store named '"+t+"' already exists");e.flux=this,this.stores[t]=e,this.dispatcher.addStore(t,e)},f.prototype.addStores=function(t){for(var e in t)t.hasOwnProperty(e)&&this.addStore(e,t[e])},f.prototype.setDispatchInterceptor=function(t){this.dispatcher.setDispatchInterceptor(t)},e.exports=f},{"./dispatcher":11,"./util/inherits":17,eventemitter3:18,"lodash/collection/forEach":22,"lodash/collection/reduce":24,"lodash/lang/isFunction":89,"lodash/lang/isString":92,"object-path":104}],13:[function(t,e,n){var r=function(t){return{componentWillMount:function(){if(console&&console.warn){var t=this.constructor.displayName?" in "+this.constructor.displayName:"",e="Fluxxor.FluxChildMixin was found in use"+t+", but has been deprecated. Use Fluxxor.FluxMixin instead.";console.warn(e)}},contextTypes:{flux:t.PropTypes.object},getFlux:function(){return this.context.flux}}};r.componentWillMount=function(){throw new Error("Fluxxor.FluxChildMixin is a function that takes React as a parameter and returns the mixin, e.g.: mixins[Fluxxor.FluxChildMixin(React)]")},e.exports=r},{}],14:[function(t,e,n){var r=function(t){return{componentWillMount:function(){if(!(this.props.flux||this.context&&this.context.flux)){var t=this.constructor.displayName?" of "+this.constructor.displayName:"";throw new Error("Could not find flux on this.props or this.context"+t)}},childContextTypes:{flux:t.PropTypes.object},contextTypes:{flux:t.PropTypes.object},getChildContext:function(){return{flux:this.getFlux()}},getFlux:function(){return this.props.flux||this.context&&this.context.flux}}};r.componentWillMount=function(){throw new Error("Fluxxor.FluxMixin is a function that takes React as a parameter and returns the mixin, e.g.: mixins: [Fluxxor.FluxMixin(React)]")},e.exports=r},{}],15:[function(t,e,n){function r(t){this.dispatcher=t,this.__actions__={},i.call(this)}var i=t("eventemitter3"),o=t("./util/inherits"),a=t("lodash/lang/isFunction"),s=t("lodash/lang/isObject");o(r,i),r.prototype.__handleAction__=function(t){var e;if(e=this.__actions__[t.type]){if(a(e))e.call(this,t.payload,t.type);else{if(!e||!a(this[e]))throw new Error("The handler for action type "+t.type+" is not a function");this[e].call(this,t.payload,t.type)}return!0}return!1},r.prototype.bindActions=function(){var t=Array.prototype.slice.call(arguments);if(t.length>1&&t.length%2!==0)throw new Error("bindActions must take an even number of arguments.");var e=function(t,e){if(!e)throw new Error("The handler for action type "+t+" is falsy");this.__actions__[t]=e}.bind(this);if(1===t.length&&s(t[0])){t=t[0];for(var n in t)t.hasOwnProperty(n)&&e(n,t[n])}else for(var r=0;r<t.length;r+=2){var i=t[r],o=t[r+1];if(!i)throw new Error("Argument "+(r+1)+" to bindActions is a falsy value");e(i,o)}},r.prototype.waitFor=function(t,e){this.dispatcher.waitForStores(this,t,e.bind(this))},e.exports=r},{"./util/inherits":17,eventemitter3:18,"lodash/lang/isFunction":89,"lodash/lang/isObject":91}],16:[function(t,e,n){var r=t("lodash/collection/forEach"),i=function(){var t=Array.prototype.slice.call(arguments);return{componentDidMount:function(){var e=this.props.flux||this.context.flux;r(t,function(t){e.store(t).on("change",this._setStateFromFlux)},this)},componentWillUnmount:function(){var e=this.props.flux||this.context.flux;r(t,function(t){e.store(t).removeListener("change",this._setStateFromFlux)},this)},_setStateFromFlux:function(){this.isMounted()&&this.setState(this.getStateFromFlux())},getInitialState:function(){return this.getStateFromFlux()}}};i.componentWillMount=function(){throw new Error('Fluxxor.StoreWatchMixin is a function that takes one or more store names as parameters and returns the mixin, e.g.: mixins: [Fluxxor.StoreWatchMixin("Store1", "Store2")]')},e.exports=i},{"lodash/collection/forEach":22}],17:[function(t,e,n){e.exports="function"==typeof Object.create?function(t,e){t.super_=e,t.prototype=Object.create(e.prototype,{constructor:{value:t,enumerable:!1,writable:!0,configurable:!0}})}:function(t,e){t.super_=e;var n=function(){};n.prototype=e.prototype,t.prototype=new n,t.prototype.constructor=t}},{}],18:[function(t,e,n){"use strict";function r(t,e,n){this.fn=t,this.context=e,this.once=n||!1}function i(){}i.prototype._events=void 0,i.prototype.listeners=function(t){if(!this._events||!this._events[t])return[];if(this._events[t].fn)return[this._events[t].fn];for(var e=0,n=this._events[t].length,r=new Array(n);n>e;e++)r[e]=this._events[t][e].fn;return r},i.prototype.emit=function(t,e,n,r,i,o){if(!this._events||!this._events[t])return!1;var a,s,u=this._events[t],c=arguments.length;if("function"==typeof u.fn){switch(u.once&&this.removeListener(t,u.fn,!0),c){case 1:return u.fn.call(u.context),!0;case 2:return u.fn.call(u.context,e),!0;case 3:return u.fn.call(u.context,e,n),!0;case 4:return u.fn.call(u.context,e,n,r),!0;case 5:return u.fn.call(u.context,e,n,r,i),!0;case 6:return u.fn.call(u.context,e,n,r,i,o),!0}for(s=1,a=new Array(c-1);c>s;s++)a[s-1]=arguments[s];u.fn.apply(u.context,a)}else{var l,p=u.length;for(s=0;p>s;s++)switch(u[s].once&&this.removeListener(t,u[s].fn,!0),c){case 1:u[s].fn.call(u[s].context);break;case 2:u[s].fn.call(u[s].context,e);break;case 3:u[s].fn.call(u[s].context,e,n);break;default:if(!a)for(l=1,a=new Array(c-1);c>l;l++)a[l-1]=arguments[l];u[s].fn.apply(u[s].context,a)}}return!0},i.prototype.on=function(t,e,n){var i=new r(e,n||this);return this._events||(this._events={}),this._events[t]?this._events[t].fn?this._events[t]=[this._events[t],i]:this._events[t].push(i):this._events[t]=i,this},i.prototype.once=function(t,e,n){var i=new r(e,n||this,!0);return this._events||(this._events={}),this._events[t]?this._events[t].fn?this._events[t]=[this._events[t],i]:this._events[t].push(i):this._events[t]=i,this},i.prototype.removeListener=function(t,e,n){if(!this._events||!this._events[t])return this;var r=this._events[t],i=[];if(e&&(r.fn&&(r.fn!==e||n&&!r.once)&&i.push(r),!r.fn))for(var o=0,a=r.length;a>o;o++)(r[o].fn!==e||n&&!r[o].once)&&i.push(r[o]);return i.length?this._events[t]=1===i.length?i[0]:i:delete this._events[t],this},i.prototype.removeAllListeners=function(t){return this._events?(t?delete this._events[t]:this._events={},this):this},i.prototype.off=i.prototype.removeListener,i.prototype.addListener=i.prototype.on,i.prototype.setMaxListeners=function(){return this},i.EventEmitter=i,i.EventEmitter2=i,i.EventEmitter3=i,e.exports=i},{}],19:[function(t,e,n){function r(){for(var t=[],e=-1,n=arguments.length,r=[],u=i,c=!0,l=[];++e<n;){var p=arguments[e];s(p)&&(t.push(p),r.push(c&&p.length>=120?a(e&&p):null))}if(n=t.length,2>n)return l;var f=t[0],h=-1,d=f?f.length:0,m=r[0];t:for(;++h<d;)if(p=f[h],(m?o(m,p):u(l,p,0))<0){for(e=n;--e;){var v=r[e];if((v?o(v,p):u(t[e],p,0))<0)continue t}m&&m.push(p),l.push(p)}return l}var i=t("../internal/baseIndexOf"),o=t("../internal/cacheIndexOf"),a=t("../internal/createCache"),s=t("../internal/isArrayLike");e.exports=r},{"../internal/baseIndexOf":40,"../internal/cacheIndexOf":56,"../internal/createCache":60,"../internal/isArrayLike":75}],20:[function(t,e,n){function r(t){var e=t?t.length:0;return e?t[e-1]:void 0}e.exports=r},{}],21:[function(t,e,n){function r(t,e,n,r){var u=t?t.length:0;return u?(null!=e&&"boolean"!=typeof e&&(r=n,n=a(t,e,r)?null:e,e=!1),n=null==n?n:i(n,r,3),e?s(t,n):o(t,n)):[]}var i=t("../internal/baseCallback"),o=t("../internal/baseUniq"),a=t("../internal/isIterateeCall"),s=t("../internal/sortedUniq");e.exports=r},{"../internal/baseCallback":32,"../internal/baseUniq":53,"../internal/isIterateeCall":77,"../internal/sortedUniq":83}],22:[function(t,e,n){var r=t("../internal/arrayEach"),i=t("../internal/baseEach"),o=t("../internal/createForEach"),a=o(r,i);e.exports=a},{"../internal/arrayEach":28,"../internal/baseEach":35,"../internal/createForEach":62}],23:[function(t,e,n){function r(t,e,n){var r=s(t)?i:a;return e=o(e,n,3),r(t,e)}var i=t("../internal/arrayMap"),o=t("../internal/baseCallback"),a=t("../internal/baseMap"),s=t("../lang/isArray");e.exports=r},{"../internal/arrayMap":29,"../internal/baseCallback":32,"../internal/baseMap":45,"../lang/isArray":88}],24:[function(t,e,n){var r=t("../internal/arrayReduce"),i=t("../internal/baseEach"),o=t("../internal/createReduce"),a=o(r,i);e.exports=a;
 
 },{"../internal/arrayReduce":30,"../internal/baseEach":35,"../internal/createReduce":65}],25:[function(t,e,n){function r(t){var e=t?i(t):0;return o(e)?e:a(t).length}var i=t("../internal/getLength"),o=t("../internal/isLength"),a=t("../object/keys");e.exports=r},{"../internal/getLength":69,"../internal/isLength":79,"../object/keys":96}],26:[function(t,e,n){(function(n){function r(t){var e=t?t.length:0;for(this.data={hash:s(null),set:new a};e--;)this.push(t[e])}var i=t("./cachePush"),o=t("../lang/isNative"),a=o(a=n.Set)&&a,s=o(s=Object.create)&&s;r.prototype.push=i,e.exports=r}).call(this,"undefined"!=typeof global?global:"undefined"!=typeof self?self:"undefined"!=typeof window?window:{})},{"../lang/isNative":90,"./cachePush":57}],27:[function(t,e,n){function r(t,e){var n=-1,r=t.length;for(e||(e=Array(r));++n<r;)e[n]=t[n];return e}e.exports=r},{}],28:[function(t,e,n){function r(t,e){for(var n=-1,r=t.length;++n<r&&e(t[n],n,t)!==!1;);return t}e.exports=r},{}],29:[function(t,e,n){function r(t,e){for(var n=-1,r=t.length,i=Array(r);++n<r;)i[n]=e(t[n],n,t);return i}e.exports=r},{}],30:[function(t,e,n){function r(t,e,n,r){var i=-1,o=t.length;for(r&&o&&(n=t[++i]);++i<o;)n=e(n,t[i],i,t);return n}e.exports=r},{}],31:[function(t,e,n){var r=t("./baseCopy"),i=t("./getSymbols"),o=t("../lang/isNative"),a=t("../object/keys"),s=o(s=Object.preventExtensions)&&s,u=function(){var t=s&&o(t=Object.assign)&&t;try{if(t){var e=s({1:0});e[0]=1}}catch(n){try{t(e,"xo")}catch(n){}return!e[1]&&t}return!1}(),c=u||function(t,e){return null==e?t:r(e,i(e),r(e,a(e),t))};e.exports=c},{"../lang/isNative":90,"../object/keys":96,"./baseCopy":34,"./getSymbols":70}],32:[function(t,e,n){function r(t,e,n){var r=typeof t;return"function"==r?void 0===e?t:a(t,e,n):null==t?s:"object"==r?i(t):void 0===e?u(t):o(t,e)}var i=t("./baseMatches"),o=t("./baseMatchesProperty"),a=t("./bindCallback"),s=t("../utility/identity"),u=t("../utility/property");e.exports=r},{"../utility/identity":102,"../utility/property":103,"./baseMatches":46,"./baseMatchesProperty":47,"./bindCallback":54}],33:[function(t,e,n){function r(t,e,n,d,m,v,g){var _;if(n&&(_=m?n(t,d,m):n(t)),void 0!==_)return _;if(!f(t))return t;var b=p(t);if(b){if(_=u(t),!e)return i(t,_)}else{var w=U.call(t),x=w==y;if(w!=E&&w!=h&&(!x||m))return L[w]?c(t,w,e):m?t:{};if(_=l(x?{}:t),!e)return a(_,t)}v||(v=[]),g||(g=[]);for(var C=v.length;C--;)if(v[C]==t)return g[C];return v.push(t),g.push(_),(b?o:s)(t,function(i,o){_[o]=r(i,e,n,o,t,v,g)}),_}var i=t("./arrayCopy"),o=t("./arrayEach"),a=t("./baseAssign"),s=t("./baseForOwn"),u=t("./initCloneArray"),c=t("./initCloneByTag"),l=t("./initCloneObject"),p=t("../lang/isArray"),f=t("../lang/isObject"),h="[object Arguments]",d="[object Array]",m="[object Boolean]",v="[object Date]",g="[object Error]",y="[object Function]",_="[object Map]",b="[object Number]",E="[object Object]",w="[object RegExp]",x="[object Set]",C="[object String]",S="[object WeakMap]",M="[object ArrayBuffer]",D="[object Float32Array]",O="[object Float64Array]",R="[object Int8Array]",T="[object Int16Array]",k="[object Int32Array]",I="[object Uint8Array]",N="[object Uint8ClampedArray]",P="[object Uint16Array]",A="[object Uint32Array]",L={};L[h]=L[d]=L[M]=L[m]=L[v]=L[D]=L[O]=L[R]=L[T]=L[k]=L[b]=L[E]=L[w]=L[C]=L[I]=L[N]=L[P]=L[A]=!0,L[g]=L[y]=L[_]=L[x]=L[S]=!1;var j=Object.prototype,U=j.toString;e.exports=r},{"../lang/isArray":88,"../lang/isObject":91,"./arrayCopy":27,"./arrayEach":28,"./baseAssign":31,"./baseForOwn":38,"./initCloneArray":72,"./initCloneByTag":73,"./initCloneObject":74}],34:[function(t,e,n){function r(t,e,n){n||(n={});for(var r=-1,i=e.length;++r<i;){var o=e[r];n[o]=t[o]}return n}e.exports=r},{}],35:[function(t,e,n){var r=t("./baseForOwn"),i=t("./createBaseEach"),o=i(r);e.exports=o},{"./baseForOwn":38,"./createBaseEach":58}],36:[function(t,e,n){function r(t,e,n,r){var i;return n(t,function(t,n,o){return e(t,n,o)?(i=r?n:t,!1):void 0}),i}e.exports=r},{}],37:[function(t,e,n){var r=t("./createBaseFor"),i=r();e.exports=i},{"./createBaseFor":59}],38:[function(t,e,n){function r(t,e){return i(t,e,o)}var i=t("./baseFor"),o=t("../object/keys");e.exports=r},{"../object/keys":96,"./baseFor":37}],39:[function(t,e,n){function r(t,e,n){if(null!=t){void 0!==n&&n in i(t)&&(e=[n]);for(var r=-1,o=e.length;null!=t&&++r<o;)t=t[e[r]];return r&&r==o?t:void 0}}var i=t("./toObject");e.exports=r},{"./toObject":84}],40:[function(t,e,n){function r(t,e,n){if(e!==e)return i(t,n);for(var r=n-1,o=t.length;++r<o;)if(t[r]===e)return r;return-1}var i=t("./indexOfNaN");e.exports=r},{"./indexOfNaN":71}],41:[function(t,e,n){function r(t,e,n,o,a,s){if(t===e)return!0;var u=typeof t,c=typeof e;return"function"!=u&&"object"!=u&&"function"!=c&&"object"!=c||null==t||null==e?t!==t&&e!==e:i(t,e,r,n,o,a,s)}var i=t("./baseIsEqualDeep");e.exports=r},{"./baseIsEqualDeep":42}],42:[function(t,e,n){function r(t,e,n,r,f,m,v){var g=s(t),y=s(e),_=l,b=l;g||(_=d.call(t),_==c?_=p:_!=p&&(g=u(t))),y||(b=d.call(e),b==c?b=p:b!=p&&(y=u(e)));var E=_==p,w=b==p,x=_==b;if(x&&!g&&!E)return o(t,e,_);if(!f){var C=E&&h.call(t,"__wrapped__"),S=w&&h.call(e,"__wrapped__");if(C||S)return n(C?t.value():t,S?e.value():e,r,f,m,v)}if(!x)return!1;m||(m=[]),v||(v=[]);for(var M=m.length;M--;)if(m[M]==t)return v[M]==e;m.push(t),v.push(e);var D=(g?i:a)(t,e,n,r,f,m,v);return m.pop(),v.pop(),D}var i=t("./equalArrays"),o=t("./equalByTag"),a=t("./equalObjects"),s=t("../lang/isArray"),u=t("../lang/isTypedArray"),c="[object Arguments]",l="[object Array]",p="[object Object]",f=Object.prototype,h=f.hasOwnProperty,d=f.toString;e.exports=r},{"../lang/isArray":88,"../lang/isTypedArray":93,"./equalArrays":66,"./equalByTag":67,"./equalObjects":68}],43:[function(t,e,n){function r(t){return"function"==typeof t||!1}e.exports=r},{}],44:[function(t,e,n){function r(t,e,n,r,o){for(var a=-1,s=e.length,u=!o;++a<s;)if(u&&r[a]?n[a]!==t[e[a]]:!(e[a]in t))return!1;for(a=-1;++a<s;){var c=e[a],l=t[c],p=n[a];if(u&&r[a])var f=void 0!==l||c in t;else f=o?o(l,p,c):void 0,void 0===f&&(f=i(p,l,o,!0));if(!f)return!1}return!0}var i=t("./baseIsEqual");e.exports=r},{"./baseIsEqual":41}],45:[function(t,e,n){function r(t,e){var n=-1,r=o(t)?Array(t.length):[];return i(t,function(t,i,o){r[++n]=e(t,i,o)}),r}var i=t("./baseEach"),o=t("./isArrayLike");e.exports=r},{"./baseEach":35,"./isArrayLike":75}],46:[function(t,e,n){function r(t){var e=s(t),n=e.length;if(!n)return o(!0);if(1==n){var r=e[0],c=t[r];if(a(c))return function(t){return null==t?!1:t[r]===c&&(void 0!==c||r in u(t))}}for(var l=Array(n),p=Array(n);n--;)c=t[e[n]],l[n]=c,p[n]=a(c);return function(t){return null!=t&&i(u(t),e,l,p)}}var i=t("./baseIsMatch"),o=t("../utility/constant"),a=t("./isStrictComparable"),s=t("../object/keys"),u=t("./toObject");e.exports=r},{"../object/keys":96,"../utility/constant":101,"./baseIsMatch":44,"./isStrictComparable":81,"./toObject":84}],47:[function(t,e,n){function r(t,e){var n=s(t),r=u(t)&&c(e),h=t+"";return t=f(t),function(s){if(null==s)return!1;var u=h;if(s=p(s),!(!n&&r||u in s)){if(s=1==t.length?s:i(s,a(t,0,-1)),null==s)return!1;u=l(t),s=p(s)}return s[u]===e?void 0!==e||u in s:o(e,s[u],null,!0)}}var i=t("./baseGet"),o=t("./baseIsEqual"),a=t("./baseSlice"),s=t("../lang/isArray"),u=t("./isKey"),c=t("./isStrictComparable"),l=t("../array/last"),p=t("./toObject"),f=t("./toPath");e.exports=r},{"../array/last":20,"../lang/isArray":88,"./baseGet":39,"./baseIsEqual":41,"./baseSlice":51,"./isKey":78,"./isStrictComparable":81,"./toObject":84,"./toPath":85}],48:[function(t,e,n){function r(t){return function(e){return null==e?void 0:e[t]}}e.exports=r},{}],49:[function(t,e,n){function r(t){var e=t+"";return t=o(t),function(n){return i(n,t,e)}}var i=t("./baseGet"),o=t("./toPath");e.exports=r},{"./baseGet":39,"./toPath":85}],50:[function(t,e,n){function r(t,e,n,r,i){return i(t,function(t,i,o){n=r?(r=!1,t):e(n,t,i,o)}),n}e.exports=r},{}],51:[function(t,e,n){function r(t,e,n){var r=-1,i=t.length;e=null==e?0:+e||0,0>e&&(e=-e>i?0:i+e),n=void 0===n||n>i?i:+n||0,0>n&&(n+=i),i=e>n?0:n-e>>>0,e>>>=0;for(var o=Array(i);++r<i;)o[r]=t[r+e];return o}e.exports=r},{}],52:[function(t,e,n){function r(t){return"string"==typeof t?t:null==t?"":t+""}e.exports=r},{}],53:[function(t,e,n){function r(t,e){var n=-1,r=i,s=t.length,u=!0,c=u&&s>=200,l=c?a():null,p=[];l?(r=o,u=!1):(c=!1,l=e?[]:p);t:for(;++n<s;){var f=t[n],h=e?e(f,n,t):f;if(u&&f===f){for(var d=l.length;d--;)if(l[d]===h)continue t;e&&l.push(h),p.push(f)}else r(l,h,0)<0&&((e||c)&&l.push(h),p.push(f))}return p}var i=t("./baseIndexOf"),o=t("./cacheIndexOf"),a=t("./createCache");e.exports=r},{"./baseIndexOf":40,"./cacheIndexOf":56,"./createCache":60}],54:[function(t,e,n){function r(t,e,n){if("function"!=typeof t)return i;if(void 0===e)return t;switch(n){case 1:return function(n){return t.call(e,n)};case 3:return function(n,r,i){return t.call(e,n,r,i)};case 4:return function(n,r,i,o){return t.call(e,n,r,i,o)};case 5:return function(n,r,i,o,a){return t.call(e,n,r,i,o,a)}}return function(){return t.apply(e,arguments)}}var i=t("../utility/identity");e.exports=r},{"../utility/identity":102}],55:[function(t,e,n){(function(n){function r(t){return s.call(t,0)}var i=t("../utility/constant"),o=t("../lang/isNative"),a=o(a=n.ArrayBuffer)&&a,s=o(s=a&&new a(0).slice)&&s,u=Math.floor,c=o(c=n.Uint8Array)&&c,l=function(){try{var t=o(t=n.Float64Array)&&t,e=new t(new a(10),0,1)&&t}catch(r){}return e}(),p=l?l.BYTES_PER_ELEMENT:0;s||(r=a&&c?function(t){var e=t.byteLength,n=l?u(e/p):0,r=n*p,i=new a(e);if(n){var o=new l(i,0,n);o.set(new l(t,0,n))}return e!=r&&(o=new c(i,r),o.set(new c(t,r))),i}:i(null)),e.exports=r}).call(this,"undefined"!=typeof global?global:"undefined"!=typeof self?self:"undefined"!=typeof window?window:{})},{"../lang/isNative":90,"../utility/constant":101}],56:[function(t,e,n){function r(t,e){var n=t.data,r="string"==typeof e||i(e)?n.set.has(e):n.hash[e];return r?0:-1}var i=t("../lang/isObject");e.exports=r},{"../lang/isObject":91}],57:[function(t,e,n){function r(t){var e=this.data;"string"==typeof t||i(t)?e.set.add(t):e.hash[t]=!0}var i=t("../lang/isObject");e.exports=r},{"../lang/isObject":91}],58:[function(t,e,n){function r(t,e){return function(n,r){var s=n?i(n):0;if(!o(s))return t(n,r);for(var u=e?s:-1,c=a(n);(e?u--:++u<s)&&r(c[u],u,c)!==!1;);return n}}var i=t("./getLength"),o=t("./isLength"),a=t("./toObject");e.exports=r},{"./getLength":69,"./isLength":79,"./toObject":84}],59:[function(t,e,n){function r(t){return function(e,n,r){for(var o=i(e),a=r(e),s=a.length,u=t?s:-1;t?u--:++u<s;){var c=a[u];if(n(o[c],c,o)===!1)break}return e}}var i=t("./toObject");e.exports=r},{"./toObject":84}],60:[function(t,e,n){(function(n){var r=t("./SetCache"),i=t("../utility/constant"),o=t("../lang/isNative"),a=o(a=n.Set)&&a,s=o(s=Object.create)&&s,u=s&&a?function(t){return new r(t)}:i(null);e.exports=u}).call(this,"undefined"!=typeof global?global:"undefined"!=typeof self?self:"undefined"!=typeof window?window:{})},{"../lang/isNative":90,"../utility/constant":101,"./SetCache":26}],61:[function(t,e,n){function r(t){return function(e,n,r){return n=i(n,r,3),o(e,n,t,!0)}}var i=t("./baseCallback"),o=t("./baseFind");e.exports=r},{"./baseCallback":32,"./baseFind":36}],62:[function(t,e,n){function r(t,e){return function(n,r,a){return"function"==typeof r&&void 0===a&&o(n)?t(n,r):e(n,i(r,a,3))}}var i=t("./bindCallback"),o=t("../lang/isArray");e.exports=r},{"../lang/isArray":88,"./bindCallback":54}],63:[function(t,e,n){function r(t){return function(e,n,r){return("function"!=typeof n||void 0!==r)&&(n=i(n,r,3)),t(e,n)}}var i=t("./bindCallback");e.exports=r},{"./bindCallback":54}],64:[function(t,e,n){function r(t){return function(e,n,r){var a={};return n=i(n,r,3),o(e,function(e,r,i){var o=n(e,r,i);r=t?o:r,e=t?e:o,a[r]=e}),a}}var i=t("./baseCallback"),o=t("./baseForOwn");e.exports=r},{"./baseCallback":32,"./baseForOwn":38}],65:[function(t,e,n){function r(t,e){return function(n,r,s,u){var c=arguments.length<3;return"function"==typeof r&&void 0===u&&a(n)?t(n,r,s,c):o(n,i(r,u,4),s,c,e)}}var i=t("./baseCallback"),o=t("./baseReduce"),a=t("../lang/isArray");e.exports=r},{"../lang/isArray":88,"./baseCallback":32,"./baseReduce":50}],66:[function(t,e,n){function r(t,e,n,r,i,o,a){var s=-1,u=t.length,c=e.length,l=!0;if(u!=c&&!(i&&c>u))return!1;for(;l&&++s<u;){var p=t[s],f=e[s];if(l=void 0,r&&(l=i?r(f,p,s):r(p,f,s)),void 0===l)if(i)for(var h=c;h--&&(f=e[h],!(l=p&&p===f||n(p,f,r,i,o,a))););else l=p&&p===f||n(p,f,r,i,o,a)}return!!l}e.exports=r},{}],67:[function(t,e,n){function r(t,e,n){switch(n){case i:case o:return+t==+e;case a:return t.name==e.name&&t.message==e.message;case s:return t!=+t?e!=+e:t==+e;case u:case c:return t==e+""}return!1}var i="[object Boolean]",o="[object Date]",a="[object Error]",s="[object Number]",u="[object RegExp]",c="[object String]";e.exports=r},{}],68:[function(t,e,n){function r(t,e,n,r,o,s,u){var c=i(t),l=c.length,p=i(e),f=p.length;if(l!=f&&!o)return!1;for(var h=o,d=-1;++d<l;){var m=c[d],v=o?m in e:a.call(e,m);if(v){var g=t[m],y=e[m];v=void 0,r&&(v=o?r(y,g,m):r(g,y,m)),void 0===v&&(v=g&&g===y||n(g,y,r,o,s,u))}if(!v)return!1;h||(h="constructor"==m)}if(!h){var _=t.constructor,b=e.constructor;if(_!=b&&"constructor"in t&&"constructor"in e&&!("function"==typeof _&&_ instanceof _&&"function"==typeof b&&b instanceof b))return!1}return!0}var i=t("../object/keys"),o=Object.prototype,a=o.hasOwnProperty;e.exports=r},{"../object/keys":96}],69:[function(t,e,n){var r=t("./baseProperty"),i=r("length");e.exports=i},{"./baseProperty":48}],70:[function(t,e,n){var r=t("../utility/constant"),i=t("../lang/isNative"),o=t("./toObject"),a=i(a=Object.getOwnPropertySymbols)&&a,s=a?function(t){return a(o(t))}:r([]);e.exports=s},{"../lang/isNative":90,"../utility/constant":101,"./toObject":84}],71:[function(t,e,n){function r(t,e,n){for(var r=t.length,i=e+(n?0:-1);n?i--:++i<r;){var o=t[i];if(o!==o)return i}return-1}e.exports=r},{}],72:[function(t,e,n){function r(t){var e=t.length,n=new t.constructor(e);return e&&"string"==typeof t[0]&&o.call(t,"index")&&(n.index=t.index,n.input=t.input),n}var i=Object.prototype,o=i.hasOwnProperty;e.exports=r},{}],73:[function(t,e,n){function r(t,e,n){var r=t.constructor;switch(e){case l:return i(t);case o:case a:return new r(+t);case p:case f:case h:case d:case m:case v:case g:case y:case _:var E=t.buffer;return new r(n?i(E):E,t.byteOffset,t.length);case s:case c:return new r(t);case u:var w=new r(t.source,b.exec(t));w.lastIndex=t.lastIndex}return w}var i=t("./bufferClone"),o="[object Boolean]",a="[object Date]",s="[object Number]",u="[object RegExp]",c="[object String]",l="[object ArrayBuffer]",p="[object Float32Array]",f="[object Float64Array]",h="[object Int8Array]",d="[object Int16Array]",m="[object Int32Array]",v="[object Uint8Array]",g="[object Uint8ClampedArray]",y="[object Uint16Array]",_="[object Uint32Array]",b=/\w*$/;e.exports=r},{"./bufferClone":55}],74:[function(t,e,n){function r(t){var e=t.constructor;return"function"==typeof e&&e instanceof e||(e=Object),new e}e.exports=r},{}],75:[function(t,e,n){function r(t){return null!=t&&o(i(t))}var i=t("./getLength"),o=t("./isLength");e.exports=r},{"./getLength":69,"./isLength":79}],76:[function(t,e,n){function r(t,e){return t=+t,e=null==e?i:e,t>-1&&t%1==0&&e>t}var i=Math.pow(2,53)-1;e.exports=r},{}],77:[function(t,e,n){function r(t,e,n){if(!a(n))return!1;var r=typeof e;if("number"==r?i(n)&&o(e,n.length):"string"==r&&e in n){var s=n[e];return t===t?t===s:s!==s}return!1}var i=t("./isArrayLike"),o=t("./isIndex"),a=t("../lang/isObject");e.exports=r},{"../lang/isObject":91,"./isArrayLike":75,"./isIndex":76}],78:[function(t,e,n){function r(t,e){var n=typeof t;if("string"==n&&s.test(t)||"number"==n)return!0;if(i(t))return!1;var r=!a.test(t);return r||null!=e&&t in o(e)}var i=t("../lang/isArray"),o=t("./toObject"),a=/\.|\[(?:[^[\]]*|(["'])(?:(?!\1)[^\n\\]|\\.)*?\1)\]/,s=/^\w*$/;e.exports=r},{"../lang/isArray":88,"./toObject":84}],79:[function(t,e,n){function r(t){return"number"==typeof t&&t>-1&&t%1==0&&i>=t}var i=Math.pow(2,53)-1;e.exports=r},{}],80:[function(t,e,n){function r(t){return!!t&&"object"==typeof t}e.exports=r},{}],81:[function(t,e,n){function r(t){return t===t&&!i(t)}var i=t("../lang/isObject");e.exports=r},{"../lang/isObject":91}],82:[function(t,e,n){function r(t){for(var e=u(t),n=e.length,r=n&&t.length,l=r&&s(r)&&(o(t)||c.nonEnumArgs&&i(t)),f=-1,h=[];++f<n;){var d=e[f];(l&&a(d,r)||p.call(t,d))&&h.push(d)}return h}var i=t("../lang/isArguments"),o=t("../lang/isArray"),a=t("./isIndex"),s=t("./isLength"),u=t("../object/keysIn"),c=t("../support"),l=Object.prototype,p=l.hasOwnProperty;e.exports=r},{"../lang/isArguments":87,"../lang/isArray":88,"../object/keysIn":97,"../support":100,"./isIndex":76,"./isLength":79}],83:[function(t,e,n){function r(t,e){for(var n,r=-1,i=t.length,o=-1,a=[];++r<i;){var s=t[r],u=e?e(s,r,t):s;r&&n===u||(n=u,a[++o]=s)}return a}e.exports=r},{}],84:[function(t,e,n){function r(t){return i(t)?t:Object(t)}var i=t("../lang/isObject");e.exports=r},{"../lang/isObject":91}],85:[function(t,e,n){function r(t){if(o(t))return t;var e=[];return i(t).replace(a,function(t,n,r,i){e.push(r?i.replace(s,"$1"):n||t)}),e}var i=t("./baseToString"),o=t("../lang/isArray"),a=/[^.[\]]+|\[(?:(-?\d+(?:\.\d+)?)|(["'])((?:(?!\2)[^\n\\]|\\.)*?)\2)\]/g,s=/\\(\\)?/g;e.exports=r},{"../lang/isArray":88,"./baseToString":52}],86:[function(t,e,n){function r(t,e,n,r){return e&&"boolean"!=typeof e&&a(t,e,n)?e=!1:"function"==typeof e&&(r=n,n=e,e=!1),n="function"==typeof n&&o(n,r,1),i(t,e,n)}var i=t("../internal/baseClone"),o=t("../internal/bindCallback"),a=t("../internal/isIterateeCall");e.exports=r},{"../internal/baseClone":33,"../internal/bindCallback":54,"../internal/isIterateeCall":77}],87:[function(t,e,n){function r(t){return o(t)&&i(t)&&u.call(t)==a}var i=t("../internal/isArrayLike"),o=t("../internal/isObjectLike"),a="[object Arguments]",s=Object.prototype,u=s.toString;e.exports=r},{"../internal/isArrayLike":75,"../internal/isObjectLike":80}],88:[function(t,e,n){var r=t("../internal/isLength"),i=t("./isNative"),o=t("../internal/isObjectLike"),a="[object Array]",s=Object.prototype,u=s.toString,c=i(c=Array.isArray)&&c,l=c||function(t){return o(t)&&r(t.length)&&u.call(t)==a};e.exports=l},{"../internal/isLength":79,"../internal/isObjectLike":80,"./isNative":90}],89:[function(t,e,n){(function(n){var r=t("../internal/baseIsFunction"),i=t("./isNative"),o="[object Function]",a=Object.prototype,s=a.toString,u=i(u=n.Uint8Array)&&u,c=r(/x/)||u&&!r(u)?function(t){return s.call(t)==o}:r;e.exports=c}).call(this,"undefined"!=typeof global?global:"undefined"!=typeof self?self:"undefined"!=typeof window?window:{})},{"../internal/baseIsFunction":43,"./isNative":90}],90:[function(t,e,n){function r(t){return null==t?!1:l.call(t)==a?p.test(c.call(t)):o(t)&&s.test(t)}var i=t("../string/escapeRegExp"),o=t("../internal/isObjectLike"),a="[object Function]",s=/^\[object .+?Constructor\]$/,u=Object.prototype,c=Function.prototype.toString,l=u.toString,p=RegExp("^"+i(l).replace(/toString|(function).*?(?=\\\()| for .+?(?=\\\])/g,"$1.*?")+"$");e.exports=r},{"../internal/isObjectLike":80,"../string/escapeRegExp":99}],91:[function(t,e,n){function r(t){var e=typeof t;return"function"==e||!!t&&"object"==e}e.exports=r},{}],92:[function(t,e,n){function r(t){return"string"==typeof t||i(t)&&s.call(t)==o}var i=t("../internal/isObjectLike"),o="[object String]",a=Object.prototype,s=a.toString;e.exports=r},{"../internal/isObjectLike":80}],93:[function(t,e,n){function r(t){return o(t)&&i(t.length)&&!!R[k.call(t)]}var i=t("../internal/isLength"),o=t("../internal/isObjectLike"),a="[object Arguments]",s="[object Array]",u="[object Boolean]",c="[object Date]",l="[object Error]",p="[object Function]",f="[object Map]",h="[object Number]",d="[object Object]",m="[object RegExp]",v="[object Set]",g="[object String]",y="[object WeakMap]",_="[object ArrayBuffer]",b="[object Float32Array]",E="[object Float64Array]",w="[object Int8Array]",x="[object Int16Array]",C="[object Int32Array]",S="[object Uint8Array]",M="[object Uint8ClampedArray]",D="[object Uint16Array]",O="[object Uint32Array]",R={};R[b]=R[E]=R[w]=R[x]=R[C]=R[S]=R[M]=R[D]=R[O]=!0,R[a]=R[s]=R[_]=R[u]=R[c]=R[l]=R[p]=R[f]=R[h]=R[d]=R[m]=R[v]=R[g]=R[y]=!1;var T=Object.prototype,k=T.toString;e.exports=r},{"../internal/isLength":79,"../internal/isObjectLike":80}],94:[function(t,e,n){var r=t("../internal/baseForOwn"),i=t("../internal/createFindKey"),o=i(r);e.exports=o},{"../internal/baseForOwn":38,"../internal/createFindKey":61}],95:[function(t,e,n){var r=t("../internal/baseForOwn"),i=t("../internal/createForOwn"),o=i(r);e.exports=o},{"../internal/baseForOwn":38,"../internal/createForOwn":63}],96:[function(t,e,n){var r=t("../internal/isArrayLike"),i=t("../lang/isNative"),o=t("../lang/isObject"),a=t("../internal/shimKeys"),s=i(s=Object.keys)&&s,u=s?function(t){var e=null!=t&&t.constructor;return"function"==typeof e&&e.prototype===t||"function"!=typeof t&&r(t)?a(t):o(t)?s(t):[]}:a;e.exports=u},{"../internal/isArrayLike":75,"../internal/shimKeys":82,"../lang/isNative":90,"../lang/isObject":91}],97:[function(t,e,n){function r(t){if(null==t)return[];u(t)||(t=Object(t));var e=t.length;e=e&&s(e)&&(o(t)||c.nonEnumArgs&&i(t))&&e||0;for(var n=t.constructor,r=-1,l="function"==typeof n&&n.prototype===t,f=Array(e),h=e>0;++r<e;)f[r]=r+"";for(var d in t)h&&a(d,e)||"constructor"==d&&(l||!p.call(t,d))||f.push(d);return f}var i=t("../lang/isArguments"),o=t("../lang/isArray"),a=t("../internal/isIndex"),s=t("../internal/isLength"),u=t("../lang/isObject"),c=t("../support"),l=Object.prototype,p=l.hasOwnProperty;e.exports=r},{"../internal/isIndex":76,"../internal/isLength":79,"../lang/isArguments":87,"../lang/isArray":88,"../lang/isObject":91,"../support":100}],98:[function(t,e,n){var r=t("../internal/createObjectMapper"),i=r();e.exports=i},{"../internal/createObjectMapper":64}],99:[function(t,e,n){function r(t){return t=i(t),t&&a.test(t)?t.replace(o,"\\$&"):t}var i=t("../internal/baseToString"),o=/[.*+?^${}()|[\]\/\\]/g,a=RegExp(o.source);e.exports=r},{"../internal/baseToString":52}],100:[function(t,e,n){(function(t){var n=Object.prototype,r=(r=t.window)&&r.document,i=n.propertyIsEnumerable,o={};!function(t){var e=function(){this.x=t},n=arguments,a=[];e.prototype={valueOf:t,y:t};for(var s in new e)a.push(s);o.funcDecomp=/\bthis\b/.test(function(){return this}),o.funcNames="string"==typeof Function.name;try{o.dom=11===r.createDocumentFragment().nodeType}catch(u){o.dom=!1}try{o.nonEnumArgs=!i.call(n,1)}catch(u){o.nonEnumArgs=!0}}(1,0),e.exports=o}).call(this,"undefined"!=typeof global?global:"undefined"!=typeof self?self:"undefined"!=typeof window?window:{})},{}],101:[function(t,e,n){function r(t){return function(){return t}}e.exports=r},{}],102:[function(t,e,n){function r(t){return t}e.exports=r},{}],103:[function(t,e,n){function r(t){return a(t)?i(t):o(t)}var i=t("../internal/baseProperty"),o=t("../internal/basePropertyDeep"),a=t("../internal/isKey");e.exports=r},{"../internal/baseProperty":48,"../internal/basePropertyDeep":49,"../internal/isKey":78}],104:[function(t,e,n){!function(t,n){"use strict";"object"==typeof e&&"object"==typeof e.exports?e.exports=n():"function"==typeof define&&define.amd?define([],n):t.objectPath=n()}(this,function(){"use strict";function t(t){if(!t)return!0;if(o(t)&&0===t.length)return!0;for(var e in t)if(p.call(t,e))return!1;return!0}function e(t){return l.call(t)}function n(t){return"number"==typeof t||"[object Number]"===e(t)}function r(t){return"string"==typeof t||"[object String]"===e(t)}function i(t){return"object"==typeof t&&"[object Object]"===e(t)}function o(t){return"object"==typeof t&&"number"==typeof t.length&&"[object Array]"===e(t)}function a(t){return"boolean"==typeof t||"[object Boolean]"===e(t)}function s(t){var e=parseInt(t);return e.toString()===t?e:t}function u(e,i,o,a){if(n(i)&&(i=[i]),t(i))return e;if(r(i))return u(e,i.split("."),o,a);var c=s(i[0]);if(1===i.length){var l=e[c];return void 0!==l&&a||(e[c]=o),l}return void 0===e[c]&&(e[c]=n(c)?[]:{}),u(e[c],i.slice(1),o,a)}function c(e,i){if(n(i)&&(i=[i]),t(e))return void 0;if(t(i))return e;if(r(i))return c(e,i.split("."));var a=s(i[0]),u=e[a];if(1===i.length)void 0!==u&&(o(e)?e.splice(a,1):delete e[a]);else if(void 0!==e[a])return c(e[a],i.slice(1));return e}var l=Object.prototype.toString,p=Object.prototype.hasOwnProperty,f={};return f.ensureExists=function(t,e,n){return u(t,e,n,!0)},f.set=function(t,e,n,r){return u(t,e,n,r)},f.insert=function(t,e,n,r){var i=f.get(t,e);r=~~r,o(i)||(i=[],f.set(t,e,i)),i.splice(r,0,n)},f.empty=function(e,s){if(t(s))return e;if(t(e))return void 0;var u,c;if(!(u=f.get(e,s)))return e;if(r(u))return f.set(e,s,"");if(a(u))return f.set(e,s,!1);if(n(u))return f.set(e,s,0);if(o(u))u.length=0;else{if(!i(u))return f.set(e,s,null);for(c in u)p.call(u,c)&&delete u[c]}},f.push=function(t,e){var n=f.get(t,e);o(n)||(n=[],f.set(t,e,n)),n.push.apply(n,Array.prototype.slice.call(arguments,2))},f.coalesce=function(t,e,n){for(var r,i=0,o=e.length;o>i;i++)if(void 0!==(r=f.get(t,e[i])))return r;return n},f.get=function(e,i,o){if(n(i)&&(i=[i]),t(i))return e;if(t(e))return o;if(r(i))return f.get(e,i.split("."),o);var a=s(i[0]);return 1===i.length?void 0===e[a]?o:e[a]:f.get(e[a],i.slice(1),o)},f.del=function(t,e){return c(t,e)},f})},{}],105:[function(t,e,n){e.exports="1.6.0"},{}],106:[function(t,e,n){function r(t,e,n){return 1===arguments.length?e=[]:"function"==typeof e?(n=e,e=[]):e=d(e),a(t,e,n)}function i(t,e,n,r){this.size=r,this._rootData=t,this._keyPath=e,this._onChange=n}function o(t,e,n,r){this.size=r,this._rootData=t,this._keyPath=e,this._onChange=n}function a(t,e,n,r){arguments.length<4&&(r=t.getIn(e));var a=r&&r.size,u=v.isIndexed(r)?o:i,c=new u(t,e,n,a);return r instanceof b&&s(c,r),c}function s(t,e){try{e._keys.forEach(u.bind(void 0,t))}catch(n){}}function u(t,e){Object.defineProperty(t,e,{get:function(){return this.get(e)},set:function(t){if(!this.__ownerID)throw new Error("Cannot set on an immutable record.")}})}function c(t,e,n){return v.isIterable(n)?l(t,e,n):n}function l(t,e,n){return arguments.length<3?a(t._rootData,f(t._keyPath,e),t._onChange):a(t._rootData,f(t._keyPath,e),t._onChange,n)}function p(t,e,n){var r=arguments.length>2,i=t._rootData.updateIn(t._keyPath,r?_():void 0,e),o=t._keyPath||[],s=t._onChange&&t._onChange.call(void 0,i,t._rootData,r?f(o,n):o);return void 0!==s&&(i=s),a(i,t._keyPath,t._onChange)}function f(t,e){return t.concat(h(e))}function h(t){return Array.isArray(t)?t:m.Iterable(t).toArray()}function d(t){return Array.isArray(t)?t:v.isIterable(t)?t.toArray():[t]}var m=t("immutable"),v=m.Iterable,g=v.Iterator,y=m.Seq,_=m.Map,b=m.Record,E=Object.create(y.Keyed.prototype),w=Object.create(y.Indexed.prototype);E.constructor=i,w.constructor=o,E.toString=function(){return this.__toString("Cursor {","}")},w.toString=function(){return this.__toString("Cursor [","]")},E.deref=E.valueOf=w.deref=w.valueOf=function(t){return this._rootData.getIn(this._keyPath,t)},E.get=w.get=function(t,e){return this.getIn([t],e)},E.getIn=w.getIn=function(t,e){if(t=h(t),0===t.length)return this;var n=this._rootData.getIn(f(this._keyPath,t),x);return n===x?e:c(this,t,n)},w.set=E.set=function(t,e){return p(this,function(n){return n.set(t,e)},[t])},w.push=function(){var t=arguments;return p(this,function(e){return e.push.apply(e,t)})},w.pop=function(){return p(this,function(t){return t.pop()})},w.unshift=function(){var t=arguments;return p(this,function(e){return e.unshift.apply(e,t)})},w.shift=function(){return p(this,function(t){return t.shift()})},w.setIn=E.setIn=_.prototype.setIn,E.remove=E["delete"]=w.remove=w["delete"]=function(t){return p(this,function(e){return e.remove(t)},[t])},w.removeIn=w.deleteIn=E.removeIn=E.deleteIn=_.prototype.deleteIn,E.clear=w.clear=function(){return p(this,function(t){return t.clear()})},w.update=E.update=function(t,e,n){return 1===arguments.length?p(this,t):this.updateIn([t],e,n)},w.updateIn=E.updateIn=function(t,e,n){return p(this,function(r){return r.updateIn(t,e,n)},t)},w.merge=E.merge=function(){var t=arguments;return p(this,function(e){return e.merge.apply(e,t)})},w.mergeWith=E.mergeWith=function(t){var e=arguments;return p(this,function(t){return t.mergeWith.apply(t,e)})},w.mergeIn=E.mergeIn=_.prototype.mergeIn,w.mergeDeep=E.mergeDeep=function(){var t=arguments;return p(this,function(e){return e.mergeDeep.apply(e,t)})},w.mergeDeepWith=E.mergeDeepWith=function(t){var e=arguments;return p(this,function(t){return t.mergeDeepWith.apply(t,e)})},w.mergeDeepIn=E.mergeDeepIn=_.prototype.mergeDeepIn,E.withMutations=w.withMutations=function(t){return p(this,function(e){return(e||_()).withMutations(t)})},E.cursor=w.cursor=function(t){return t=d(t),0===t.length?this:l(this,t)},E.__iterate=w.__iterate=function(t,e){var n=this,r=n.deref();return r&&r.__iterate?r.__iterate(function(e,r){return t(c(n,[r],e),r,n)},e):0},E.__iterator=w.__iterator=function(t,e){var n=this.deref(),r=this,i=n&&n.__iterator&&n.__iterator(g.ENTRIES,e);return new g(function(){if(!i)return{value:void 0,done:!0};var e=i.next();if(e.done)return e;var n=e.value,o=n[0],a=c(r,[o],n[1]);return{value:t===g.KEYS?o:t===g.VALUES?a:[o,a],done:!1}})},i.prototype=E,o.prototype=w;var x={};n.from=r},{immutable:107}],107:[function(t,e,n){!function(t,r){"object"==typeof n&&"undefined"!=typeof e?e.exports=r():"function"==typeof define&&define.amd?define(r):t.Immutable=r()}(this,function(){"use strict";function t(t,e){e&&(t.prototype=Object.create(e.prototype)),t.prototype.constructor=t}function e(t){return t.value=!1,t}function n(t){t&&(t.value=!0)}function r(){}function i(t,e){e=e||0;for(var n=Math.max(0,t.length-e),r=new Array(n),i=0;n>i;i++)r[i]=t[i+e];return r}function o(t){return void 0===t.size&&(t.size=t.__iterate(s)),t.size}function a(t,e){return e>=0?+e:o(t)+ +e}function s(){return!0}function u(t,e,n){return(0===t||void 0!==n&&-n>=t)&&(void 0===e||void 0!==n&&e>=n)}function c(t,e){return p(t,e,0)}function l(t,e){return p(t,e,e)}function p(t,e,n){return void 0===t?n:0>t?Math.max(0,e+t):void 0===e?t:Math.min(e,t)}function f(t){return v(t)?t:R(t)}function h(t){return g(t)?t:T(t)}function d(t){return y(t)?t:k(t)}function m(t){return v(t)&&!_(t)?t:I(t)}function v(t){return!(!t||!t[mn])}function g(t){return!(!t||!t[vn])}function y(t){return!(!t||!t[gn])}function _(t){return g(t)||y(t)}function b(t){return!(!t||!t[yn])}function E(t){this.next=t}function w(t,e,n,r){var i=0===t?e:1===t?n:[e,n];return r?r.value=i:r={value:i,done:!1},r}function x(){return{value:void 0,done:!0}}function C(t){return!!D(t)}function S(t){return t&&"function"==typeof t.next}function M(t){var e=D(t);return e&&e.call(t)}function D(t){var e=t&&(wn&&t[wn]||t[xn]);return"function"==typeof e?e:void 0}function O(t){return t&&"number"==typeof t.length}function R(t){return null===t||void 0===t?U():v(t)?t.toSeq():z(t)}function T(t){return null===t||void 0===t?U().toKeyedSeq():v(t)?g(t)?t.toSeq():t.fromEntrySeq():F(t)}function k(t){return null===t||void 0===t?U():v(t)?g(t)?t.entrySeq():t.toIndexedSeq():B(t)}function I(t){return(null===t||void 0===t?U():v(t)?g(t)?t.entrySeq():t:B(t)).toSetSeq()}function N(t){this._array=t,this.size=t.length}function P(t){var e=Object.keys(t);this._object=t,this._keys=e,this.size=e.length}function A(t){this._iterable=t,this.size=t.length||t.size}function L(t){this._iterator=t,this._iteratorCache=[]}function j(t){return!(!t||!t[Sn])}function U(){return Mn||(Mn=new N([]))}function F(t){var e=Array.isArray(t)?new N(t).fromEntrySeq():S(t)?new L(t).fromEntrySeq():C(t)?new A(t).fromEntrySeq():"object"==typeof t?new P(t):void 0;if(!e)throw new TypeError("Expected Array or iterable object of [k, v] entries, or keyed object: "+t);return e}function B(t){var e=q(t);if(!e)throw new TypeError("Expected Array or iterable object of values: "+t);return e}function z(t){var e=q(t)||"object"==typeof t&&new P(t);if(!e)throw new TypeError("Expected Array or iterable object of values, or keyed object: "+t);return e}function q(t){return O(t)?new N(t):S(t)?new L(t):C(t)?new A(t):void 0}function W(t,e,n,r){var i=t._cache;
 
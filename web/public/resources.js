@@ -25,4 +25,26 @@ $(document).ready(function() {
 
     return false;
   });
+
+  $(".js-pinResource").on("click", function() {
+    $.ajax({
+      method: "PUT",
+      url: $(this).data("pin-url")
+    }).done(function() {
+      window.location.reload();
+    });
+
+    return false;
+  });
+
+  $(".js-unpinResource").on("click", function() {
+    $.ajax({
+      method: "PUT",
+      url: $(this).data("unpin-url")
+    }).done(function() {
+      window.location.reload();
+    });
+
+    return false;
+  });
 });
@@ -2,7 +2,9 @@ package routes
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 	"github.com/tedsuo/rata"
 )
@@ -18,6 +20,7 @@ const (
 	GetResource     = "GetResource"
 	GetJob          = "GetJob"
 	LogIn           = "LogIn"
+	BasicLogIn      = "BasicLogIn"
 )
 
 var Routes = rata.Routes{
@@ -29,15 +32,24 @@ var Routes = rata.Routes{
 	{Path: "/public/:filename", Method: "GET", Name: Public},
 	{Path: "/public/fonts/:filename", Method: "GET", Name: Public},
 	{Path: "/public/favicons/:filename", Method: "GET", Name: Public},
+	{Path: "/login", Method: "GET", Name: LogIn},
 
 	// public jobs only
 	{Path: "/pipelines/:pipeline_name/jobs/:job/builds/:build", Method: "GET", Name: GetBuild},
 
 	// private
-	{Path: "/login", Method: "GET", Name: LogIn},
+	{Path: "/login/basic", Method: "GET", Name: BasicLogIn},
 	{Path: "/pipelines/:pipeline_name/jobs/:job/builds", Method: "POST", Name: TriggerBuild},
 	{Path: "/builds", Method: "GET", Name: GetBuilds},
 	{Path: "/builds/:build_id", Method: "GET", Name: GetJoblessBuild},
+
+	// team-scoped
+	{Path: "/teams/:team_name", Method: "GET", Name: Index},
+	{Path: "/teams/:team_name/pipelines/:pipeline_name", Method: "GET", Name: Pipeline},
+	{Path: "/teams/:team_name/pipelines/:pipeline_name/jobs/:job", Method: "GET", Name: GetJob},
+	{Path: "/teams/:team_name/pipelines/:pipeline_name/resources/:resource", Method: "GET", Name: GetResource},
+	{Path: "/teams/:team_name/pipelines/:pipeline_name/jobs/:job/builds/:build", Method: "GET", Name: GetBuild},
+	{Path: "/teams/:team_name/pipelines/:pipeline_name/jobs/:job/builds", Method: "POST", Name: TriggerBuild},
 }
 
 func PathForBuild(build db.Build) string {
@@ -47,7 +59,7 @@ func PathForBuild(build db.Build) string {
 			"build_id": fmt.Sprintf("%d", build.ID),
 		})
 	} else {
-		path, _ = Routes.CreatePathForRoute(GetBuild, rata.Params{
+		path, _ = PathForTeam(Routes, build.TeamName, GetBuild, rata.Params{
 			"pipeline_name": build.PipelineName,
 			"job":           build.JobName,
 			"build":         build.Name,
@@ -56,3 +68,24 @@ func PathForBuild(build db.Build) string {
 
 	return path
 }
+
+// PathForTeam generates the path for the named route scoped to the team.
+// Routes of the main team keep their unscoped paths.
+func PathForTeam(routes rata.Routes, teamName string, name string, params rata.Params) (string, error) {
+	if teamName == "" || teamName == atc.DefaultTeamName {
+		return routes.CreatePathForRoute(name, params)
+	}
+
+	teamParams := rata.Params{"team_name": teamName}
+	for key, value := range params {
+		teamParams[key] = value
+	}
+
+	for _, route := range routes {
+		if route.Name == name && strings.Contains(route.Path, ":team_name") {
+			return route.CreatePath(teamParams)
+		}
+	}
+
+	return "", fmt.Errorf("no team-scoped route: %s", name)
+}
@@ -28,12 +28,14 @@ import (
 
 type WebDB interface {
 	GetBuild(buildID int) (db.Build, error)
-	GetAllBuilds() ([]db.Build, error)
+	GetBuilds(teamName string, page db.Page) ([]db.Build, db.Pagination, error)
 }
 
 func NewHandler(
 	logger lager.Logger,
 	validator auth.Validator,
+	providers auth.Providers,
+	basicAuthEnabled bool,
 	radarSchedulerFactory pipelines.RadarSchedulerFactory,
 	db WebDB,
 	pipelineDBFactory db.PipelineDBFactory,
@@ -88,6 +90,11 @@ func NewHandler(
 		return nil, err
 	}
 
+	logInTemplate, err := loadTemplateWithoutPipeline(templatesDir, "login.html", funcs)
+	if err != nil {
+		return nil, err
+	}
+
 	absPublicDir, err := filepath.Abs(publicDir)
 	if err != nil {
 		return nil, err
@@ -111,15 +118,20 @@ func NewHandler(
 		routes.GetBuild:        pipelineHandlerFactory.HandlerFor(buildServer.GetBuild),
 		routes.GetBuilds:       getbuilds.NewHandler(logger, db, configDB, buildsTemplate),
 		routes.GetJoblessBuild: getjoblessbuild.NewHandler(logger, db, configDB, joblessBuildTemplate),
+		routes.LogIn:           login.NewHandler(logger, providers, basicAuthEnabled, logInTemplate),
 
 		// private
-		routes.LogIn: auth.Handler{
-			Handler:   login.NewHandler(logger),
+		routes.BasicLogIn: auth.Handler{
+			Handler:   login.NewBasicHandler(logger),
 			Validator: validator,
 		},
 
-		routes.TriggerBuild: auth.Handler{
-			Handler:   pipelineHandlerFactory.HandlerFor(triggerBuildServer.TriggerBuild),
+		routes.TriggerBuild: auth.RoleHandler{
+			Handler: auth.TeamHandler{
+				Handler:   pipelineHandlerFactory.HandlerFor(triggerBuildServer.TriggerBuild),
+				Validator: validator,
+			},
+			Role:      auth.RoleOperator,
 			Validator: validator,
 		},
 	}
@@ -130,6 +142,7 @@ func NewHandler(
 func loadTemplateWithPipeline(templatesDir, name string, funcs template.FuncMap) (*template.Template, error) {
 	return template.New("with_pipeline.html").Funcs(funcs).ParseFiles(
 		filepath.Join(templatesDir, "layouts", "with_pipeline.html"),
+		filepath.Join(templatesDir, "pagination.html"),
 		filepath.Join(templatesDir, name),
 	)
 }
@@ -137,6 +150,7 @@ func loadTemplateWithPipeline(templatesDir, name string, funcs template.FuncMap)
 func loadTemplateWithoutPipeline(templatesDir, name string, funcs template.FuncMap) (*template.Template, error) {
 	return template.New("without_pipeline.html").Funcs(funcs).ParseFiles(
 		filepath.Join(templatesDir, "layouts", "without_pipeline.html"),
+		filepath.Join(templatesDir, "pagination.html"),
 		filepath.Join(templatesDir, name),
 	)
 }
@@ -60,10 +60,15 @@ func jobName(x interface{}) string {
 
 func PathFor(route string, args ...interface{}) (string, error) {
 	switch route {
+	case routes.Pipeline:
+		return routes.PathForTeam(routes.Routes, args[0].(string), route, rata.Params{
+			"pipeline_name": args[1].(string),
+		})
+
 	case routes.TriggerBuild:
-		return routes.Routes.CreatePathForRoute(route, rata.Params{
-			"pipeline_name": args[0].(string),
-			"job":           jobName(args[1]),
+		return routes.PathForTeam(routes.Routes, args[0].(string), route, rata.Params{
+			"pipeline_name": args[1].(string),
+			"job":           jobName(args[2]),
 		})
 
 	case routes.GetBuild:
@@ -80,9 +85,9 @@ func PathFor(route string, args ...interface{}) (string, error) {
 		})
 
 	case routes.GetJob:
-		return routes.Routes.CreatePathForRoute(route, rata.Params{
-			"pipeline_name": args[0].(string),
-			"job":           args[1].(atc.JobConfig).Name,
+		return routes.PathForTeam(routes.Routes, args[0].(string), route, rata.Params{
+			"pipeline_name": args[1].(string),
+			"job":           args[2].(atc.JobConfig).Name,
 		})
 
 	case atc.BuildEvents:
@@ -90,15 +95,21 @@ func PathFor(route string, args ...interface{}) (string, error) {
 			"build_id": fmt.Sprintf("%d", args[0].(db.Build).ID),
 		})
 
-	case atc.EnableResourceVersion, atc.DisableResourceVersion:
-		versionedResource := args[1].(db.SavedVersionedResource)
+	case atc.EnableResourceVersion, atc.DisableResourceVersion, atc.PinResourceVersion:
+		versionedResource := args[2].(db.SavedVersionedResource)
 
-		return atc.Routes.CreatePathForRoute(route, rata.Params{
-			"pipeline_name":       args[0].(string),
+		return routes.PathForTeam(atc.Routes, args[0].(string), route, rata.Params{
+			"pipeline_name":       args[1].(string),
 			"resource_name":       fmt.Sprintf("%s", versionedResource.Resource),
 			"resource_version_id": fmt.Sprintf("%d", versionedResource.ID),
 		})
 
+	case atc.UnpinResource:
+		return routes.PathForTeam(atc.Routes, args[0].(string), route, rata.Params{
+			"pipeline_name": args[1].(string),
+			"resource_name": args[2].(string),
+		})
+
 	case routes.LogIn:
 		return routes.Routes.CreatePathForRoute(route, rata.Params{})
 
@@ -27,7 +27,7 @@ var _ = Describe("URLs", func() {
 				},
 			}
 
-			path, err := web.PathFor(atc.EnableResourceVersion, "some-pipeline", versionedResource)
+			path, err := web.PathFor(atc.EnableResourceVersion, atc.DefaultTeamName, "some-pipeline", versionedResource)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/versions/123/enable"))
@@ -43,23 +43,104 @@ var _ = Describe("URLs", func() {
 				},
 			}
 
-			path, err := web.PathFor(atc.DisableResourceVersion, "some-pipeline", versionedResource)
+			path, err := web.PathFor(atc.DisableResourceVersion, atc.DefaultTeamName, "some-pipeline", versionedResource)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/versions/123/disable"))
 		})
 	})
 
+	Describe("PinResourceVersion", func() {
+		It("returns the correct URL", func() {
+			versionedResource := db.SavedVersionedResource{
+				ID: 123,
+				VersionedResource: db.VersionedResource{
+					Resource: "resource-name",
+				},
+			}
+
+			path, err := web.PathFor(atc.PinResourceVersion, atc.DefaultTeamName, "some-pipeline", versionedResource)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/versions/123/pin"))
+		})
+
+		It("returns the team's URL for pipelines of other teams", func() {
+			versionedResource := db.SavedVersionedResource{
+				ID: 123,
+				VersionedResource: db.VersionedResource{
+					Resource: "resource-name",
+				},
+			}
+
+			path, err := web.PathFor(atc.PinResourceVersion, "some-team", "some-pipeline", versionedResource)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/api/v1/teams/some-team/pipelines/some-pipeline/resources/resource-name/versions/123/pin"))
+		})
+	})
+
+	Describe("UnpinResource", func() {
+		It("returns the correct URL", func() {
+			path, err := web.PathFor(atc.UnpinResource, atc.DefaultTeamName, "some-pipeline", "resource-name")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/api/v1/pipelines/some-pipeline/resources/resource-name/unpin"))
+		})
+	})
+
 	Describe("Jobs Patch", func() {
 		It("returns the correct URL", func() {
 			job := atc.JobConfig{
 				Name: "some-job",
 			}
 
-			path, err := web.PathFor(routes.GetJob, "another-pipeline", job)
+			path, err := web.PathFor(routes.GetJob, atc.DefaultTeamName, "another-pipeline", job)
 			Ω(err).ShouldNot(HaveOccurred())
 
 			Ω(path).Should(Equal("/pipelines/another-pipeline/jobs/some-job"))
 		})
+
+		It("returns the team's URL for pipelines of other teams", func() {
+			job := atc.JobConfig{
+				Name: "some-job",
+			}
+
+			path, err := web.PathFor(routes.GetJob, "some-team", "another-pipeline", job)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/teams/some-team/pipelines/another-pipeline/jobs/some-job"))
+		})
+	})
+
+	Describe("Pipeline", func() {
+		It("returns the correct URL", func() {
+			path, err := web.PathFor(routes.Pipeline, atc.DefaultTeamName, "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/pipelines/some-pipeline"))
+		})
+
+		It("returns the team's URL for pipelines of other teams", func() {
+			path, err := web.PathFor(routes.Pipeline, "some-team", "some-pipeline")
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/teams/some-team/pipelines/some-pipeline"))
+		})
+	})
+
+	Describe("GetBuild", func() {
+		It("returns the team's URL for builds of other teams", func() {
+			build := db.Build{
+				Name:         "42",
+				PipelineName: "some-pipeline",
+				TeamName:     "some-team",
+			}
+
+			path, err := web.PathFor(routes.GetBuild, "some-job", build)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Ω(path).Should(Equal("/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/42"))
+		})
 	})
 })
@@ -1,16 +1,54 @@
 package atc
 
 type Worker struct {
+	// defaults to the address when registering
+	Name string `json:"name,omitempty"`
 	Addr string `json:"addr"`
 
 	ActiveContainers int `json:"active_containers"`
 
+	// zero for no limit
+	MaxContainers int `json:"max_containers,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform string   `json:"platform"`
 	Tags     []string `json:"tags"`
+
+	// empty if the worker is shared by all teams
+	Team string `json:"team,omitempty"`
+
+	// when registering, empty keeps the worker's current state
+	State WorkerState `json:"state,omitempty"`
+
+	// false while the worker's garden server is failing health checks;
+	// ignored when registering
+	Healthy bool `json:"healthy"`
+
+	// unix timestamps; ignored when registering
+	RegisteredAt    int64 `json:"registered_at,omitempty"`
+	LastHeartbeatAt int64 `json:"last_heartbeat_at,omitempty"`
 }
 
+type WorkerState string
+
+const (
+	// WorkerStateRunning workers are given new containers.
+	WorkerStateRunning WorkerState = "running"
+
+	// WorkerStateLanding workers are given no new containers, and go away
+	// quietly once they stop heartbeating.
+	WorkerStateLanding WorkerState = "landing"
+
+	// WorkerStateRetiring workers are given no new containers, and are removed
+	// once they have no containers left.
+	WorkerStateRetiring WorkerState = "retiring"
+
+	// WorkerStateStalled workers stopped heartbeating without landing or
+	// retiring. They become running again once they heartbeat.
+	WorkerStateStalled WorkerState = "stalled"
+)
+
 type WorkerResourceType struct {
 	Type  string `json:"type"`
 	Image string `json:"image"`
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/concourse/atc"
@@ -15,6 +16,10 @@ import (
 type Client interface {
 	CreateContainer(Identifier, ContainerSpec) (Container, error)
 	LookupContainer(Identifier) (Container, error)
+
+	// FindContainersForIdentifier returns all containers whose properties
+	// match the non-zero fields of the given identifier.
+	FindContainersForIdentifier(Identifier) ([]Container, error)
 }
 
 //go:generate counterfeiter . Container
@@ -25,12 +30,32 @@ type Container interface {
 	Destroy() error
 
 	Release()
+
+	// WorkerName returns the name of the worker the container lives on.
+	WorkerName() string
+
+	// WorkerAddr returns the address of the worker the container lives on.
+	WorkerAddr() string
+
+	// IdentifierFromProperties decodes the identifier the container was
+	// created with from its properties.
+	IdentifierFromProperties() (Identifier, error)
+
+	// TTL returns how long the container will live for if it stops being
+	// kept alive.
+	TTL() (time.Duration, error)
+
+	// KeptAlive returns whether the container has been kept alive at all;
+	// until it is, its TTL is the full TTL.
+	KeptAlive() (bool, error)
 }
 
 type Identifier struct {
 	Name string
 
+	TeamName     string
 	PipelineName string
+	JobName      string
 
 	BuildID int
 
@@ -51,10 +76,18 @@ func (id Identifier) gardenProperties() garden.Properties {
 		props[propertyPrefix+"name"] = id.Name
 	}
 
+	if id.TeamName != "" {
+		props[propertyPrefix+"team-name"] = id.TeamName
+	}
+
 	if id.PipelineName != "" {
 		props[propertyPrefix+"pipeline-name"] = id.PipelineName
 	}
 
+	if id.JobName != "" {
+		props[propertyPrefix+"job-name"] = id.JobName
+	}
+
 	if id.BuildID != 0 {
 		props[propertyPrefix+"build-id"] = strconv.Itoa(id.BuildID)
 	}
@@ -79,6 +112,37 @@ func (id Identifier) gardenProperties() garden.Properties {
 	return props
 }
 
+func identifierFromProperties(props garden.Properties) Identifier {
+	id := Identifier{
+		Name:         props[propertyPrefix+"name"],
+		TeamName:     props[propertyPrefix+"team-name"],
+		PipelineName: props[propertyPrefix+"pipeline-name"],
+		JobName:      props[propertyPrefix+"job-name"],
+		Type:         ContainerType(props[propertyPrefix+"type"]),
+		CheckType:    props[propertyPrefix+"check-type"],
+	}
+
+	// values that fail to parse are left as their zero value, just as they
+	// would have been omitted when creating the container
+
+	if buildID, err := strconv.Atoi(props[propertyPrefix+"build-id"]); err == nil {
+		id.BuildID = buildID
+	}
+
+	if location, err := strconv.ParseUint(props[propertyPrefix+"location"], 10, 0); err == nil {
+		id.StepLocation = uint(location)
+	}
+
+	if payload, found := props[propertyPrefix+"check-source"]; found {
+		var source atc.Source
+		if err := json.Unmarshal([]byte(payload), &source); err == nil {
+			id.CheckSource = source
+		}
+	}
+
+	return id
+}
+
 type ContainerType string
 
 const (
@@ -13,6 +13,11 @@ type ResourceTypeContainerSpec struct {
 	Type      string
 	Ephemeral bool
 	Tags      []string
+
+	// CacheKey identifies what the container will fetch, if it can be reused
+	// by later fetches. A container that already fetched it is returned
+	// instead of creating a new one, and workers holding one are preferred.
+	CacheKey string
 }
 
 func (spec ResourceTypeContainerSpec) Description() string {
@@ -25,6 +30,14 @@ type TaskContainerSpec struct {
 
 	Image      string
 	Privileged bool
+
+	// ImageWorker is the worker the image's rootfs was fetched onto, if the
+	// image lives on a worker; the container can only be created there.
+	ImageWorker string
+
+	// the workers holding the artifacts the task will consume, one per
+	// artifact
+	ArtifactWorkers []string
 }
 
 func (spec TaskContainerSpec) Description() string {
@@ -36,5 +49,9 @@ func (spec TaskContainerSpec) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.ImageWorker != "" {
+		messages = append(messages, fmt.Sprintf("worker '%s'", spec.ImageWorker))
+	}
+
 	return strings.Join(messages, ", ")
 }
@@ -0,0 +1,74 @@
+package worker
+
+import (
+	"sync"
+	"time"
+)
+
+// createdContainers counts the containers created on each worker since it
+// last heartbeated, which the count it heartbeated does not include yet.
+type createdContainers struct {
+	lock   sync.Mutex
+	counts map[string]createdSince
+}
+
+type createdSince struct {
+	heartbeat time.Time
+	count     int
+}
+
+func newCreatedContainers() *createdContainers {
+	return &createdContainers{
+		counts: map[string]createdSince{},
+	}
+}
+
+// Count returns how many containers were created on the worker since the
+// given heartbeat.
+func (created *createdContainers) Count(workerName string, heartbeat time.Time) int {
+	created.lock.Lock()
+	defer created.lock.Unlock()
+
+	return created.since(workerName, heartbeat).count
+}
+
+// Reserve counts a container about to be created on the worker, unless that
+// would take it past its limit.
+func (created *createdContainers) Reserve(workerName string, heartbeat time.Time, active int, max int) bool {
+	created.lock.Lock()
+	defer created.lock.Unlock()
+
+	since := created.since(workerName, heartbeat)
+	if active+since.count >= max {
+		return false
+	}
+
+	since.count++
+	created.counts[workerName] = since
+
+	return true
+}
+
+// Unreserve stops counting a container that failed to be created.
+func (created *createdContainers) Unreserve(workerName string, heartbeat time.Time) {
+	created.lock.Lock()
+	defer created.lock.Unlock()
+
+	since := created.since(workerName, heartbeat)
+	if since.count == 0 {
+		return
+	}
+
+	since.count--
+	created.counts[workerName] = since
+}
+
+func (created *createdContainers) since(workerName string, heartbeat time.Time) createdSince {
+	since, found := created.counts[workerName]
+	if !found || heartbeat.After(since.heartbeat) {
+		// a newer heartbeat counted whatever was created before it
+		return createdSince{heartbeat: heartbeat}
+	}
+
+	return since
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pivotal-golang/clock"
 	"github.com/pivotal-golang/lager"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/db"
 )
 
@@ -19,11 +20,14 @@ type WorkerDB interface {
 
 type dbProvider struct {
 	db     WorkerDB
+	health WorkerHealth
 	logger lager.Logger
+
+	created *createdContainers
 }
 
-func NewDBWorkerProvider(db WorkerDB, logger lager.Logger) WorkerProvider {
-	return &dbProvider{db, logger}
+func NewDBWorkerProvider(db WorkerDB, health WorkerHealth, logger lager.Logger) WorkerProvider {
+	return &dbProvider{db, health, logger, newCreatedContainers()}
 }
 
 func (provider *dbProvider) Workers() ([]Worker, error) {
@@ -34,9 +38,20 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 
 	tikTok := clock.NewClock()
 
-	workers := make([]Worker, len(workerInfos))
-	for i, info := range workerInfos {
+	workers := []Worker{}
+	for _, info := range workerInfos {
+		if info.State == atc.WorkerStateStalled {
+			// unreachable; talking to it would only time out
+			continue
+		}
+
+		if !provider.health.Healthy(info.Name) {
+			// its garden is failing health checks; leave it be until it recovers
+			continue
+		}
+
 		workerLog := provider.logger.Session("worker-connection", lager.Data{
+			"name": info.Name,
 			"addr": info.Addr,
 		})
 
@@ -49,14 +64,21 @@ func (provider *dbProvider) Workers() ([]Worker, error) {
 			},
 		}
 
-		workers[i] = NewGardenWorker(
+		workers = append(workers, newGardenWorker(
 			gclient.New(gardenConn),
 			tikTok,
+			info.Name,
+			info.Addr,
 			info.ActiveContainers,
+			info.MaxContainers,
 			info.ResourceTypes,
 			info.Platform,
 			info.Tags,
-		)
+			info.Team,
+			info.State,
+			provider.created,
+			info.LastHeartbeatAt,
+		))
 	}
 
 	return workers, nil
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
@@ -20,7 +21,8 @@ import (
 
 var _ = Describe("DBProvider", func() {
 	var (
-		fakeDB *fakes.FakeWorkerDB
+		fakeDB     *fakes.FakeWorkerDB
+		fakeHealth *fakes.FakeWorkerHealth
 
 		logger *lagertest.TestLogger
 
@@ -42,6 +44,9 @@ var _ = Describe("DBProvider", func() {
 	BeforeEach(func() {
 		fakeDB = new(fakes.FakeWorkerDB)
 
+		fakeHealth = new(fakes.FakeWorkerHealth)
+		fakeHealth.HealthyReturns(true)
+
 		logger = lagertest.NewTestLogger("test")
 
 		workerA = new(gfakes.FakeBackend)
@@ -59,7 +64,7 @@ var _ = Describe("DBProvider", func() {
 		err = workerBServer.Start()
 		Ω(err).ShouldNot(HaveOccurred())
 
-		provider = NewDBWorkerProvider(fakeDB, logger)
+		provider = NewDBWorkerProvider(fakeDB, fakeHealth, logger)
 	})
 
 	JustBeforeEach(func() {
@@ -93,18 +98,31 @@ var _ = Describe("DBProvider", func() {
 		BeforeEach(func() {
 			fakeDB.WorkersReturns([]db.WorkerInfo{
 				{
+					Name:             "worker-a",
 					Addr:             workerAAddr,
 					ActiveContainers: 2,
 					ResourceTypes: []atc.WorkerResourceType{
 						{Type: "some-resource-a", Image: "some-image-a"},
 					},
+					State: atc.WorkerStateRunning,
 				},
 				{
+					Name:             "worker-b",
 					Addr:             workerBAddr,
 					ActiveContainers: 2,
 					ResourceTypes: []atc.WorkerResourceType{
 						{Type: "some-resource-b", Image: "some-image-b"},
 					},
+					State: atc.WorkerStateLanding,
+				},
+				{
+					Name:             "worker-c",
+					Addr:             "1.2.3.4:7777",
+					ActiveContainers: 2,
+					ResourceTypes: []atc.WorkerResourceType{
+						{Type: "some-resource-c", Image: "some-image-c"},
+					},
+					State: atc.WorkerStateStalled,
 				},
 			}, nil)
 		})
@@ -113,8 +131,28 @@ var _ = Describe("DBProvider", func() {
 			Ω(workersErr).ShouldNot(HaveOccurred())
 		})
 
-		It("returns a worker for each one", func() {
+		It("returns a worker named after each one that is not stalled", func() {
 			Ω(workers).Should(HaveLen(2))
+			Ω(workers[0].Name()).Should(Equal("worker-a"))
+			Ω(workers[1].Name()).Should(Equal("worker-b"))
+		})
+
+		It("carries over their states", func() {
+			Ω(workers[0].State()).Should(Equal(atc.WorkerStateRunning))
+			Ω(workers[1].State()).Should(Equal(atc.WorkerStateLanding))
+		})
+
+		Context("when a worker is unhealthy", func() {
+			BeforeEach(func() {
+				fakeHealth.HealthyStub = func(name string) bool {
+					return name != "worker-a"
+				}
+			})
+
+			It("leaves it out", func() {
+				Ω(workers).Should(HaveLen(1))
+				Ω(workers[0].Name()).Should(Equal("worker-b"))
+			})
 		})
 
 		Describe("a created container", func() {
@@ -134,6 +172,7 @@ var _ = Describe("DBProvider", func() {
 				Ω(err).ShouldNot(HaveOccurred())
 
 				Ω(container.Handle()).Should(Equal("created-handle"))
+				Ω(container.WorkerAddr()).Should(Equal(workerAAddr))
 
 				Ω(workerA.CreateCallCount()).Should(Equal(1))
 				Ω(workerA.CreateArgsForCall(0).Properties).Should(Equal(garden.Properties{
@@ -148,6 +187,66 @@ var _ = Describe("DBProvider", func() {
 			})
 		})
 
+		Describe("a burst of containers", func() {
+			var pool Client
+
+			BeforeEach(func() {
+				fakeDB.WorkersReturns([]db.WorkerInfo{
+					{
+						Name:             "worker-a",
+						Addr:             workerAAddr,
+						ActiveContainers: 1,
+						MaxContainers:    3,
+						ResourceTypes: []atc.WorkerResourceType{
+							{Type: "some-resource-a", Image: "some-image-a"},
+						},
+						State:           atc.WorkerStateRunning,
+						LastHeartbeatAt: time.Unix(123, 0),
+					},
+				}, nil)
+
+				fakeContainer := new(gfakes.FakeContainer)
+				fakeContainer.HandleReturns("created-handle")
+
+				workerA.CreateReturns(fakeContainer, nil)
+
+				pool = NewPool(logger, provider, NewRandomPlacementStrategy())
+			})
+
+			createContainer := func() error {
+				_, err := pool.CreateContainer(Identifier{Name: "some-name"}, ResourceTypeContainerSpec{
+					Type: "some-resource-a",
+				})
+
+				return err
+			}
+
+			It("is not placed past the worker's limit before it heartbeats again", func() {
+				Ω(createContainer()).Should(Succeed())
+				Ω(createContainer()).Should(Succeed())
+				Ω(createContainer()).Should(BeAssignableToTypeOf(NoCapacityError{}))
+
+				Ω(workerA.CreateCallCount()).Should(Equal(2))
+			})
+
+			Context("when the worker heartbeats again", func() {
+				It("goes by its new count", func() {
+					Ω(createContainer()).Should(Succeed())
+					Ω(createContainer()).Should(Succeed())
+
+					infos, err := fakeDB.Workers()
+					Ω(err).ShouldNot(HaveOccurred())
+
+					infos[0].ActiveContainers = 2
+					infos[0].LastHeartbeatAt = time.Unix(153, 0)
+					fakeDB.WorkersReturns(infos, nil)
+
+					Ω(createContainer()).Should(Succeed())
+					Ω(createContainer()).Should(BeAssignableToTypeOf(NoCapacityError{}))
+				})
+			})
+		})
+
 		Describe("a looked-up container", func() {
 			It("calls through to garden", func() {
 				fakeContainer := new(gfakes.FakeContainer)
@@ -27,6 +27,15 @@ type FakeClient struct {
 		result1 worker.Container
 		result2 error
 	}
+	FindContainersForIdentifierStub        func(worker.Identifier) ([]worker.Container, error)
+	findContainersForIdentifierMutex       sync.RWMutex
+	findContainersForIdentifierArgsForCall []struct {
+		arg1 worker.Identifier
+	}
+	findContainersForIdentifierReturns struct {
+		result1 []worker.Container
+		result2 error
+	}
 }
 
 func (fake *FakeClient) CreateContainer(arg1 worker.Identifier, arg2 worker.ContainerSpec) (worker.Container, error) {
@@ -96,4 +105,37 @@ func (fake *FakeClient) LookupContainerReturns(result1 worker.Container, result2
 	}{result1, result2}
 }
 
+func (fake *FakeClient) FindContainersForIdentifier(arg1 worker.Identifier) ([]worker.Container, error) {
+	fake.findContainersForIdentifierMutex.Lock()
+	fake.findContainersForIdentifierArgsForCall = append(fake.findContainersForIdentifierArgsForCall, struct {
+		arg1 worker.Identifier
+	}{arg1})
+	fake.findContainersForIdentifierMutex.Unlock()
+	if fake.FindContainersForIdentifierStub != nil {
+		return fake.FindContainersForIdentifierStub(arg1)
+	} else {
+		return fake.findContainersForIdentifierReturns.result1, fake.findContainersForIdentifierReturns.result2
+	}
+}
+
+func (fake *FakeClient) FindContainersForIdentifierCallCount() int {
+	fake.findContainersForIdentifierMutex.RLock()
+	defer fake.findContainersForIdentifierMutex.RUnlock()
+	return len(fake.findContainersForIdentifierArgsForCall)
+}
+
+func (fake *FakeClient) FindContainersForIdentifierArgsForCall(i int) worker.Identifier {
+	fake.findContainersForIdentifierMutex.RLock()
+	defer fake.findContainersForIdentifierMutex.RUnlock()
+	return fake.findContainersForIdentifierArgsForCall[i].arg1
+}
+
+func (fake *FakeClient) FindContainersForIdentifierReturns(result1 []worker.Container, result2 error) {
+	fake.FindContainersForIdentifierStub = nil
+	fake.findContainersForIdentifierReturns = struct {
+		result1 []worker.Container
+		result2 error
+	}{result1, result2}
+}
+
 var _ worker.Client = new(FakeClient)
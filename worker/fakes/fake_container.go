@@ -4,6 +4,7 @@ package fakes
 import (
 	"io"
 	"sync"
+	"time"
 
 	"github.com/cloudfoundry-incubator/garden"
 	"github.com/concourse/atc/worker"
@@ -196,6 +197,39 @@ type FakeContainer struct {
 	ReleaseStub        func()
 	releaseMutex       sync.RWMutex
 	releaseArgsForCall []struct{}
+	WorkerNameStub        func() string
+	workerNameMutex       sync.RWMutex
+	workerNameArgsForCall []struct{}
+	workerNameReturns struct {
+		result1 string
+	}
+	WorkerAddrStub        func() string
+	workerAddrMutex       sync.RWMutex
+	workerAddrArgsForCall []struct{}
+	workerAddrReturns struct {
+		result1 string
+	}
+	IdentifierFromPropertiesStub        func() (worker.Identifier, error)
+	identifierFromPropertiesMutex       sync.RWMutex
+	identifierFromPropertiesArgsForCall []struct{}
+	identifierFromPropertiesReturns struct {
+		result1 worker.Identifier
+		result2 error
+	}
+	TTLStub        func() (time.Duration, error)
+	tTLMutex       sync.RWMutex
+	tTLArgsForCall []struct{}
+	tTLReturns struct {
+		result1 time.Duration
+		result2 error
+	}
+	KeptAliveStub        func() (bool, error)
+	keptAliveMutex       sync.RWMutex
+	keptAliveArgsForCall []struct{}
+	keptAliveReturns     struct {
+		result1 bool
+		result2 error
+	}
 }
 
 func (fake *FakeContainer) Handle() string {
@@ -894,4 +928,127 @@ func (fake *FakeContainer) ReleaseCallCount() int {
 	return len(fake.releaseArgsForCall)
 }
 
+func (fake *FakeContainer) WorkerName() string {
+	fake.workerNameMutex.Lock()
+	fake.workerNameArgsForCall = append(fake.workerNameArgsForCall, struct{}{})
+	fake.workerNameMutex.Unlock()
+	if fake.WorkerNameStub != nil {
+		return fake.WorkerNameStub()
+	} else {
+		return fake.workerNameReturns.result1
+	}
+}
+
+func (fake *FakeContainer) WorkerNameCallCount() int {
+	fake.workerNameMutex.RLock()
+	defer fake.workerNameMutex.RUnlock()
+	return len(fake.workerNameArgsForCall)
+}
+
+func (fake *FakeContainer) WorkerNameReturns(result1 string) {
+	fake.WorkerNameStub = nil
+	fake.workerNameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeContainer) WorkerAddr() string {
+	fake.workerAddrMutex.Lock()
+	fake.workerAddrArgsForCall = append(fake.workerAddrArgsForCall, struct{}{})
+	fake.workerAddrMutex.Unlock()
+	if fake.WorkerAddrStub != nil {
+		return fake.WorkerAddrStub()
+	} else {
+		return fake.workerAddrReturns.result1
+	}
+}
+
+func (fake *FakeContainer) WorkerAddrCallCount() int {
+	fake.workerAddrMutex.RLock()
+	defer fake.workerAddrMutex.RUnlock()
+	return len(fake.workerAddrArgsForCall)
+}
+
+func (fake *FakeContainer) WorkerAddrReturns(result1 string) {
+	fake.WorkerAddrStub = nil
+	fake.workerAddrReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeContainer) IdentifierFromProperties() (worker.Identifier, error) {
+	fake.identifierFromPropertiesMutex.Lock()
+	fake.identifierFromPropertiesArgsForCall = append(fake.identifierFromPropertiesArgsForCall, struct{}{})
+	fake.identifierFromPropertiesMutex.Unlock()
+	if fake.IdentifierFromPropertiesStub != nil {
+		return fake.IdentifierFromPropertiesStub()
+	} else {
+		return fake.identifierFromPropertiesReturns.result1, fake.identifierFromPropertiesReturns.result2
+	}
+}
+
+func (fake *FakeContainer) IdentifierFromPropertiesCallCount() int {
+	fake.identifierFromPropertiesMutex.RLock()
+	defer fake.identifierFromPropertiesMutex.RUnlock()
+	return len(fake.identifierFromPropertiesArgsForCall)
+}
+
+func (fake *FakeContainer) IdentifierFromPropertiesReturns(result1 worker.Identifier, result2 error) {
+	fake.IdentifierFromPropertiesStub = nil
+	fake.identifierFromPropertiesReturns = struct {
+		result1 worker.Identifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainer) TTL() (time.Duration, error) {
+	fake.tTLMutex.Lock()
+	fake.tTLArgsForCall = append(fake.tTLArgsForCall, struct{}{})
+	fake.tTLMutex.Unlock()
+	if fake.TTLStub != nil {
+		return fake.TTLStub()
+	} else {
+		return fake.tTLReturns.result1, fake.tTLReturns.result2
+	}
+}
+
+func (fake *FakeContainer) TTLCallCount() int {
+	fake.tTLMutex.RLock()
+	defer fake.tTLMutex.RUnlock()
+	return len(fake.tTLArgsForCall)
+}
+
+func (fake *FakeContainer) TTLReturns(result1 time.Duration, result2 error) {
+	fake.TTLStub = nil
+	fake.tTLReturns = struct {
+		result1 time.Duration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainer) KeptAlive() (bool, error) {
+	fake.keptAliveMutex.Lock()
+	fake.keptAliveArgsForCall = append(fake.keptAliveArgsForCall, struct{}{})
+	fake.keptAliveMutex.Unlock()
+	if fake.KeptAliveStub != nil {
+		return fake.KeptAliveStub()
+	} else {
+		return fake.keptAliveReturns.result1, fake.keptAliveReturns.result2
+	}
+}
+
+func (fake *FakeContainer) KeptAliveCallCount() int {
+	fake.keptAliveMutex.RLock()
+	defer fake.keptAliveMutex.RUnlock()
+	return len(fake.keptAliveArgsForCall)
+}
+
+func (fake *FakeContainer) KeptAliveReturns(result1 bool, result2 error) {
+	fake.KeptAliveStub = nil
+	fake.keptAliveReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 var _ worker.Container = new(FakeContainer)
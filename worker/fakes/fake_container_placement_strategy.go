@@ -0,0 +1,57 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeContainerPlacementStrategy struct {
+	ChooseStub        func([]worker.Worker, worker.ContainerSpec) (worker.Worker, error)
+	chooseMutex       sync.RWMutex
+	chooseArgsForCall []struct {
+		arg1 []worker.Worker
+		arg2 worker.ContainerSpec
+	}
+	chooseReturns struct {
+		result1 worker.Worker
+		result2 error
+	}
+}
+
+func (fake *FakeContainerPlacementStrategy) Choose(arg1 []worker.Worker, arg2 worker.ContainerSpec) (worker.Worker, error) {
+	fake.chooseMutex.Lock()
+	fake.chooseArgsForCall = append(fake.chooseArgsForCall, struct {
+		arg1 []worker.Worker
+		arg2 worker.ContainerSpec
+	}{arg1, arg2})
+	fake.chooseMutex.Unlock()
+	if fake.ChooseStub != nil {
+		return fake.ChooseStub(arg1, arg2)
+	} else {
+		return fake.chooseReturns.result1, fake.chooseReturns.result2
+	}
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseCallCount() int {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return len(fake.chooseArgsForCall)
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseArgsForCall(i int) ([]worker.Worker, worker.ContainerSpec) {
+	fake.chooseMutex.RLock()
+	defer fake.chooseMutex.RUnlock()
+	return fake.chooseArgsForCall[i].arg1, fake.chooseArgsForCall[i].arg2
+}
+
+func (fake *FakeContainerPlacementStrategy) ChooseReturns(result1 worker.Worker, result2 error) {
+	fake.ChooseStub = nil
+	fake.chooseReturns = struct {
+		result1 worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+var _ worker.ContainerPlacementStrategy = new(FakeContainerPlacementStrategy)
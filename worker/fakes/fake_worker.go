@@ -4,6 +4,7 @@ package fakes
 import (
 	"sync"
 
+	"github.com/concourse/atc"
 	"github.com/concourse/atc/worker"
 )
 
@@ -27,6 +28,21 @@ type FakeWorker struct {
 		result1 worker.Container
 		result2 error
 	}
+	FindContainersForIdentifierStub        func(worker.Identifier) ([]worker.Container, error)
+	findContainersForIdentifierMutex       sync.RWMutex
+	findContainersForIdentifierArgsForCall []struct {
+		arg1 worker.Identifier
+	}
+	findContainersForIdentifierReturns struct {
+		result1 []worker.Container
+		result2 error
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct{}
+	nameReturns struct {
+		result1 string
+	}
 	ActiveContainersStub        func() int
 	activeContainersMutex       sync.RWMutex
 	activeContainersArgsForCall []struct{}
@@ -41,6 +57,40 @@ type FakeWorker struct {
 	satisfiesReturns struct {
 		result1 bool
 	}
+	HasCapacityStub        func() bool
+	hasCapacityMutex       sync.RWMutex
+	hasCapacityArgsForCall []struct{}
+	hasCapacityReturns struct {
+		result1 bool
+	}
+	LookupResourceCacheStub        func(cacheKey string) (worker.Container, error)
+	lookupResourceCacheMutex       sync.RWMutex
+	lookupResourceCacheArgsForCall []struct {
+		cacheKey string
+	}
+	lookupResourceCacheReturns struct {
+		result1 worker.Container
+		result2 error
+	}
+	EvictResourceCacheStub        func() (bool, error)
+	evictResourceCacheMutex       sync.RWMutex
+	evictResourceCacheArgsForCall []struct{}
+	evictResourceCacheReturns struct {
+		result1 bool
+		result2 error
+	}
+	TeamStub        func() string
+	teamMutex       sync.RWMutex
+	teamArgsForCall []struct{}
+	teamReturns struct {
+		result1 string
+	}
+	StateStub        func() atc.WorkerState
+	stateMutex       sync.RWMutex
+	stateArgsForCall []struct{}
+	stateReturns struct {
+		result1 atc.WorkerState
+	}
 	DescriptionStub        func() string
 	descriptionMutex       sync.RWMutex
 	descriptionArgsForCall []struct{}
@@ -116,6 +166,63 @@ func (fake *FakeWorker) LookupContainerReturns(result1 worker.Container, result2
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) FindContainersForIdentifier(arg1 worker.Identifier) ([]worker.Container, error) {
+	fake.findContainersForIdentifierMutex.Lock()
+	fake.findContainersForIdentifierArgsForCall = append(fake.findContainersForIdentifierArgsForCall, struct {
+		arg1 worker.Identifier
+	}{arg1})
+	fake.findContainersForIdentifierMutex.Unlock()
+	if fake.FindContainersForIdentifierStub != nil {
+		return fake.FindContainersForIdentifierStub(arg1)
+	} else {
+		return fake.findContainersForIdentifierReturns.result1, fake.findContainersForIdentifierReturns.result2
+	}
+}
+
+func (fake *FakeWorker) FindContainersForIdentifierCallCount() int {
+	fake.findContainersForIdentifierMutex.RLock()
+	defer fake.findContainersForIdentifierMutex.RUnlock()
+	return len(fake.findContainersForIdentifierArgsForCall)
+}
+
+func (fake *FakeWorker) FindContainersForIdentifierArgsForCall(i int) worker.Identifier {
+	fake.findContainersForIdentifierMutex.RLock()
+	defer fake.findContainersForIdentifierMutex.RUnlock()
+	return fake.findContainersForIdentifierArgsForCall[i].arg1
+}
+
+func (fake *FakeWorker) FindContainersForIdentifierReturns(result1 []worker.Container, result2 error) {
+	fake.FindContainersForIdentifierStub = nil
+	fake.findContainersForIdentifierReturns = struct {
+		result1 []worker.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Name() string {
+	fake.nameMutex.Lock()
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct{}{})
+	fake.nameMutex.Unlock()
+	if fake.NameStub != nil {
+		return fake.NameStub()
+	} else {
+		return fake.nameReturns.result1
+	}
+}
+
+func (fake *FakeWorker) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeWorker) NameReturns(result1 string) {
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeWorker) ActiveContainers() int {
 	fake.activeContainersMutex.Lock()
 	fake.activeContainersArgsForCall = append(fake.activeContainersArgsForCall, struct{}{})
@@ -172,6 +279,136 @@ func (fake *FakeWorker) SatisfiesReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeWorker) HasCapacity() bool {
+	fake.hasCapacityMutex.Lock()
+	fake.hasCapacityArgsForCall = append(fake.hasCapacityArgsForCall, struct{}{})
+	fake.hasCapacityMutex.Unlock()
+	if fake.HasCapacityStub != nil {
+		return fake.HasCapacityStub()
+	} else {
+		return fake.hasCapacityReturns.result1
+	}
+}
+
+func (fake *FakeWorker) HasCapacityCallCount() int {
+	fake.hasCapacityMutex.RLock()
+	defer fake.hasCapacityMutex.RUnlock()
+	return len(fake.hasCapacityArgsForCall)
+}
+
+func (fake *FakeWorker) HasCapacityReturns(result1 bool) {
+	fake.HasCapacityStub = nil
+	fake.hasCapacityReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) LookupResourceCache(cacheKey string) (worker.Container, error) {
+	fake.lookupResourceCacheMutex.Lock()
+	fake.lookupResourceCacheArgsForCall = append(fake.lookupResourceCacheArgsForCall, struct {
+		cacheKey string
+	}{cacheKey})
+	fake.lookupResourceCacheMutex.Unlock()
+	if fake.LookupResourceCacheStub != nil {
+		return fake.LookupResourceCacheStub(cacheKey)
+	} else {
+		return fake.lookupResourceCacheReturns.result1, fake.lookupResourceCacheReturns.result2
+	}
+}
+
+func (fake *FakeWorker) LookupResourceCacheCallCount() int {
+	fake.lookupResourceCacheMutex.RLock()
+	defer fake.lookupResourceCacheMutex.RUnlock()
+	return len(fake.lookupResourceCacheArgsForCall)
+}
+
+func (fake *FakeWorker) LookupResourceCacheArgsForCall(i int) string {
+	fake.lookupResourceCacheMutex.RLock()
+	defer fake.lookupResourceCacheMutex.RUnlock()
+	return fake.lookupResourceCacheArgsForCall[i].cacheKey
+}
+
+func (fake *FakeWorker) LookupResourceCacheReturns(result1 worker.Container, result2 error) {
+	fake.LookupResourceCacheStub = nil
+	fake.lookupResourceCacheReturns = struct {
+		result1 worker.Container
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) EvictResourceCache() (bool, error) {
+	fake.evictResourceCacheMutex.Lock()
+	fake.evictResourceCacheArgsForCall = append(fake.evictResourceCacheArgsForCall, struct{}{})
+	fake.evictResourceCacheMutex.Unlock()
+	if fake.EvictResourceCacheStub != nil {
+		return fake.EvictResourceCacheStub()
+	} else {
+		return fake.evictResourceCacheReturns.result1, fake.evictResourceCacheReturns.result2
+	}
+}
+
+func (fake *FakeWorker) EvictResourceCacheCallCount() int {
+	fake.evictResourceCacheMutex.RLock()
+	defer fake.evictResourceCacheMutex.RUnlock()
+	return len(fake.evictResourceCacheArgsForCall)
+}
+
+func (fake *FakeWorker) EvictResourceCacheReturns(result1 bool, result2 error) {
+	fake.EvictResourceCacheStub = nil
+	fake.evictResourceCacheReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) Team() string {
+	fake.teamMutex.Lock()
+	fake.teamArgsForCall = append(fake.teamArgsForCall, struct{}{})
+	fake.teamMutex.Unlock()
+	if fake.TeamStub != nil {
+		return fake.TeamStub()
+	} else {
+		return fake.teamReturns.result1
+	}
+}
+
+func (fake *FakeWorker) TeamCallCount() int {
+	fake.teamMutex.RLock()
+	defer fake.teamMutex.RUnlock()
+	return len(fake.teamArgsForCall)
+}
+
+func (fake *FakeWorker) TeamReturns(result1 string) {
+	fake.TeamStub = nil
+	fake.teamReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeWorker) State() atc.WorkerState {
+	fake.stateMutex.Lock()
+	fake.stateArgsForCall = append(fake.stateArgsForCall, struct{}{})
+	fake.stateMutex.Unlock()
+	if fake.StateStub != nil {
+		return fake.StateStub()
+	} else {
+		return fake.stateReturns.result1
+	}
+}
+
+func (fake *FakeWorker) StateCallCount() int {
+	fake.stateMutex.RLock()
+	defer fake.stateMutex.RUnlock()
+	return len(fake.stateArgsForCall)
+}
+
+func (fake *FakeWorker) StateReturns(result1 atc.WorkerState) {
+	fake.StateStub = nil
+	fake.stateReturns = struct {
+		result1 atc.WorkerState
+	}{result1}
+}
+
 func (fake *FakeWorker) Description() string {
 	fake.descriptionMutex.Lock()
 	fake.descriptionArgsForCall = append(fake.descriptionArgsForCall, struct{}{})
@@ -0,0 +1,53 @@
+// This file was generated by counterfeiter
+package fakes
+
+import (
+	"sync"
+
+	"github.com/concourse/atc/worker"
+)
+
+type FakeWorkerHealth struct {
+	HealthyStub        func(name string) bool
+	healthyMutex       sync.RWMutex
+	healthyArgsForCall []struct {
+		name string
+	}
+	healthyReturns struct {
+		result1 bool
+	}
+}
+
+func (fake *FakeWorkerHealth) Healthy(name string) bool {
+	fake.healthyMutex.Lock()
+	fake.healthyArgsForCall = append(fake.healthyArgsForCall, struct {
+		name string
+	}{name})
+	fake.healthyMutex.Unlock()
+	if fake.HealthyStub != nil {
+		return fake.HealthyStub(name)
+	} else {
+		return fake.healthyReturns.result1
+	}
+}
+
+func (fake *FakeWorkerHealth) HealthyCallCount() int {
+	fake.healthyMutex.RLock()
+	defer fake.healthyMutex.RUnlock()
+	return len(fake.healthyArgsForCall)
+}
+
+func (fake *FakeWorkerHealth) HealthyArgsForCall(i int) string {
+	fake.healthyMutex.RLock()
+	defer fake.healthyMutex.RUnlock()
+	return fake.healthyArgsForCall[i].name
+}
+
+func (fake *FakeWorkerHealth) HealthyReturns(result1 bool) {
+	fake.HealthyStub = nil
+	fake.healthyReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+var _ worker.WorkerHealth = new(FakeWorkerHealth)
@@ -0,0 +1,192 @@
+package worker
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	gclient "github.com/cloudfoundry-incubator/garden/client"
+	gconn "github.com/cloudfoundry-incubator/garden/client/connection"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/lager"
+
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+)
+
+var ErrHealthCheckTimedOut = errors.New("health check timed out")
+
+//go:generate counterfeiter . WorkerHealth
+
+type WorkerHealth interface {
+	// Healthy returns false if the named worker failed enough consecutive
+	// health checks; workers that have not been checked yet are healthy.
+	Healthy(name string) bool
+}
+
+// HealthChecker pings the Garden server of every worker that is not stalled.
+// Once a worker fails a number of checks in a row it is reported unhealthy,
+// taking it out of the pool, until one of its checks succeeds again.
+type HealthChecker struct {
+	logger lager.Logger
+
+	db WorkerDB
+
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+	clock            clock.Clock
+
+	failures  map[string]int
+	failuresL sync.RWMutex
+}
+
+func NewHealthChecker(
+	logger lager.Logger,
+	db WorkerDB,
+	interval time.Duration,
+	timeout time.Duration,
+	failureThreshold int,
+	clock clock.Clock,
+) *HealthChecker {
+	return &HealthChecker{
+		logger: logger,
+
+		db: db,
+
+		interval:         interval,
+		timeout:          timeout,
+		failureThreshold: failureThreshold,
+		clock:            clock,
+
+		failures: map[string]int{},
+	}
+}
+
+func (checker *HealthChecker) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	close(ready)
+
+	ticker := checker.clock.NewTicker(checker.interval)
+	defer ticker.Stop()
+
+	checker.Check()
+
+	for {
+		select {
+		case <-ticker.C():
+			checker.Check()
+		case <-signals:
+			return nil
+		}
+	}
+
+	panic("unreachable")
+}
+
+func (checker *HealthChecker) Healthy(name string) bool {
+	checker.failuresL.RLock()
+	defer checker.failuresL.RUnlock()
+
+	return checker.failures[name] < checker.failureThreshold
+}
+
+func (checker *HealthChecker) Check() {
+	workerInfos, err := checker.db.Workers()
+	if err != nil {
+		checker.logger.Error("failed-to-get-workers", err)
+		return
+	}
+
+	results := map[string]error{}
+	resultsL := new(sync.Mutex)
+
+	wg := new(sync.WaitGroup)
+	for _, info := range workerInfos {
+		if info.State == atc.WorkerStateStalled {
+			continue
+		}
+
+		wg.Add(1)
+		go func(info db.WorkerInfo) {
+			defer wg.Done()
+
+			err := checker.ping(info)
+
+			resultsL.Lock()
+			results[info.Name] = err
+			resultsL.Unlock()
+		}(info)
+	}
+
+	wg.Wait()
+
+	checker.failuresL.Lock()
+	defer checker.failuresL.Unlock()
+
+	failures := map[string]int{}
+	for name, err := range results {
+		wasHealthy := checker.failures[name] < checker.failureThreshold
+
+		if err == nil {
+			if !wasHealthy {
+				checker.logger.Info("worker-recovered", lager.Data{"name": name})
+			}
+
+			continue
+		}
+
+		failures[name] = checker.failures[name] + 1
+
+		if wasHealthy && failures[name] >= checker.failureThreshold {
+			checker.logger.Error("worker-unhealthy", err, lager.Data{
+				"name":     name,
+				"failures": failures[name],
+			})
+		}
+	}
+
+	checker.failures = failures
+}
+
+func (checker *HealthChecker) ping(info db.WorkerInfo) error {
+	logger := checker.logger.Session("ping", lager.Data{
+		"name": info.Name,
+		"addr": info.Addr,
+	})
+
+	// the deadline bounds the whole ping, so that a worker that accepts the
+	// connection but never responds does not hold anything up
+	deadline := time.Now().Add(checker.timeout)
+
+	dialer := func(string, string) (net.Conn, error) {
+		conn, err := net.DialTimeout("tcp", info.Addr, checker.timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		return conn, nil
+	}
+
+	client := gclient.New(gconn.NewWithDialerAndLogger(dialer, logger.Session("garden-connection")))
+
+	err := client.Ping()
+	if err != nil {
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			logger.Info("timed-out")
+			return ErrHealthCheckTimedOut
+		}
+
+		logger.Info("failed", lager.Data{"error": err.Error()})
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package worker_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	gfakes "github.com/cloudfoundry-incubator/garden/fakes"
+	"github.com/cloudfoundry-incubator/garden/server"
+	"github.com/concourse/atc"
+	"github.com/concourse/atc/db"
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/fakes"
+	"github.com/pivotal-golang/clock/fakeclock"
+	"github.com/pivotal-golang/lager/lagertest"
+	"github.com/tedsuo/ifrit"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HealthChecker", func() {
+	var (
+		fakeDB    *fakes.FakeWorkerDB
+		fakeClock *fakeclock.FakeClock
+
+		logger *lagertest.TestLogger
+
+		gardenBackend *gfakes.FakeBackend
+		gardenAddr    string
+		gardenServer  *server.GardenServer
+
+		interval = 10 * time.Second
+
+		checker *HealthChecker
+	)
+
+	BeforeEach(func() {
+		fakeDB = new(fakes.FakeWorkerDB)
+		fakeClock = fakeclock.NewFakeClock(time.Unix(0, 123))
+
+		logger = lagertest.NewTestLogger("test")
+
+		gardenBackend = new(gfakes.FakeBackend)
+		gardenAddr = fmt.Sprintf("0.0.0.0:%d", 7777+GinkgoParallelNode())
+		gardenServer = server.New("tcp", gardenAddr, 0, gardenBackend, logger)
+
+		err := gardenServer.Start()
+		Ω(err).ShouldNot(HaveOccurred())
+
+		fakeDB.WorkersReturns([]db.WorkerInfo{
+			{
+				Name:  "some-worker",
+				Addr:  gardenAddr,
+				State: atc.WorkerStateRunning,
+			},
+		}, nil)
+
+		checker = NewHealthChecker(logger, fakeDB, interval, time.Minute, 2, fakeClock)
+	})
+
+	AfterEach(func() {
+		gardenServer.Stop()
+
+		Eventually(func() error {
+			conn, err := net.Dial("tcp", gardenAddr)
+			if err == nil {
+				conn.Close()
+			}
+
+			return err
+		}).Should(HaveOccurred())
+	})
+
+	It("reports workers it has not checked as healthy", func() {
+		Ω(checker.Healthy("some-worker")).Should(BeTrue())
+		Ω(checker.Healthy("some-other-worker")).Should(BeTrue())
+	})
+
+	Describe("checking", func() {
+		It("pings each worker's garden server", func() {
+			checker.Check()
+			Ω(gardenBackend.PingCallCount()).Should(Equal(1))
+		})
+
+		Context("when the ping succeeds", func() {
+			It("reports the worker as healthy", func() {
+				checker.Check()
+				checker.Check()
+				Ω(checker.Healthy("some-worker")).Should(BeTrue())
+			})
+		})
+
+		Context("when the ping fails", func() {
+			BeforeEach(func() {
+				gardenBackend.PingReturns(errors.New("garden is sad"))
+			})
+
+			It("reports the worker as healthy until it fails enough checks in a row", func() {
+				checker.Check()
+				Ω(checker.Healthy("some-worker")).Should(BeTrue())
+
+				checker.Check()
+				Ω(checker.Healthy("some-worker")).Should(BeFalse())
+			})
+
+			Context("and then succeeds again", func() {
+				It("reports the worker as healthy again", func() {
+					checker.Check()
+					checker.Check()
+					Ω(checker.Healthy("some-worker")).Should(BeFalse())
+
+					gardenBackend.PingReturns(nil)
+
+					checker.Check()
+					Ω(checker.Healthy("some-worker")).Should(BeTrue())
+				})
+			})
+
+			Context("but not in a row", func() {
+				It("reports the worker as healthy", func() {
+					checker.Check()
+
+					gardenBackend.PingReturns(nil)
+					checker.Check()
+
+					gardenBackend.PingReturns(errors.New("garden is sad"))
+					checker.Check()
+
+					Ω(checker.Healthy("some-worker")).Should(BeTrue())
+				})
+			})
+		})
+
+		Context("when the garden server is unreachable", func() {
+			BeforeEach(func() {
+				fakeDB.WorkersReturns([]db.WorkerInfo{
+					{
+						Name:  "some-worker",
+						Addr:  fmt.Sprintf("127.0.0.1:%d", 6666+GinkgoParallelNode()),
+						State: atc.WorkerStateRunning,
+					},
+				}, nil)
+			})
+
+			It("reports the worker as unhealthy after enough checks", func() {
+				checker.Check()
+				checker.Check()
+				Ω(checker.Healthy("some-worker")).Should(BeFalse())
+			})
+		})
+
+		Context("when the ping does not return before the timeout", func() {
+			var unblock chan struct{}
+
+			BeforeEach(func() {
+				unblock = make(chan struct{})
+
+				gardenBackend.PingStub = func() error {
+					<-unblock
+					return nil
+				}
+
+				checker = NewHealthChecker(logger, fakeDB, interval, 100*time.Millisecond, 2, fakeClock)
+			})
+
+			AfterEach(func() {
+				close(unblock)
+			})
+
+			It("counts it as a failure", func() {
+				checked := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+
+					checker.Check()
+					checker.Check()
+
+					close(checked)
+				}()
+
+				Eventually(checked).Should(BeClosed())
+				Ω(gardenBackend.PingCallCount()).Should(Equal(2))
+				Ω(checker.Healthy("some-worker")).Should(BeFalse())
+			})
+		})
+
+		Context("when the worker is stalled", func() {
+			BeforeEach(func() {
+				fakeDB.WorkersReturns([]db.WorkerInfo{
+					{
+						Name:  "some-worker",
+						Addr:  gardenAddr,
+						State: atc.WorkerStateStalled,
+					},
+				}, nil)
+			})
+
+			It("does not ping it", func() {
+				checker.Check()
+				Ω(gardenBackend.PingCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when getting the workers fails", func() {
+			BeforeEach(func() {
+				gardenBackend.PingReturns(errors.New("garden is sad"))
+
+				checker.Check()
+				checker.Check()
+
+				fakeDB.WorkersReturns(nil, errors.New("oh no!"))
+			})
+
+			It("keeps the previous health states", func() {
+				checker.Check()
+				Ω(checker.Healthy("some-worker")).Should(BeFalse())
+			})
+		})
+	})
+
+	Describe("running", func() {
+		var process ifrit.Process
+
+		BeforeEach(func() {
+			process = ifrit.Invoke(checker)
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		It("checks immediately and on each interval", func() {
+			Eventually(gardenBackend.PingCallCount).Should(Equal(1))
+
+			fakeClock.Increment(interval)
+			Eventually(gardenBackend.PingCallCount).Should(Equal(2))
+
+			fakeClock.Increment(interval)
+			Eventually(gardenBackend.PingCallCount).Should(Equal(3))
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+//go:generate counterfeiter . ContainerPlacementStrategy
+
+// A ContainerPlacementStrategy picks which of the compatible workers a
+// container should be created on. The given workers are never empty.
+type ContainerPlacementStrategy interface {
+	Choose([]Worker, ContainerSpec) (Worker, error)
+}
+
+const (
+	RandomPlacement           = "random"
+	FewestContainersPlacement = "fewest-containers"
+	InputLocalityPlacement    = "input-locality"
+)
+
+// NewPlacementStrategy returns the strategy with the given name, as used
+// for configuring the ATC.
+func NewPlacementStrategy(name string) (ContainerPlacementStrategy, error) {
+	switch name {
+	case RandomPlacement:
+		return NewRandomPlacementStrategy(), nil
+	case FewestContainersPlacement:
+		return NewFewestContainersPlacementStrategy(), nil
+	case InputLocalityPlacement:
+		return NewInputLocalityPlacementStrategy(NewFewestContainersPlacementStrategy()), nil
+	default:
+		return nil, fmt.Errorf(
+			"unknown container placement strategy: %s (must be %s, %s, or %s)",
+			name,
+			RandomPlacement,
+			FewestContainersPlacement,
+			InputLocalityPlacement,
+		)
+	}
+}
+
+type randomPlacementStrategy struct {
+	rand *rand.Rand
+}
+
+func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+	return &randomPlacementStrategy{
+		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+func (strategy *randomPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	return workers[strategy.rand.Intn(len(workers))], nil
+}
+
+type fewestContainersPlacementStrategy struct {
+	random ContainerPlacementStrategy
+}
+
+func NewFewestContainersPlacementStrategy() ContainerPlacementStrategy {
+	return &fewestContainersPlacementStrategy{
+		random: NewRandomPlacementStrategy(),
+	}
+}
+
+func (strategy *fewestContainersPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	leastBusy := []Worker{}
+
+	for _, worker := range workers {
+		if len(leastBusy) == 0 || worker.ActiveContainers() == leastBusy[0].ActiveContainers() {
+			leastBusy = append(leastBusy, worker)
+		} else if worker.ActiveContainers() < leastBusy[0].ActiveContainers() {
+			leastBusy = []Worker{worker}
+		}
+	}
+
+	// spread out between equally busy workers, as the counts are only
+	// refreshed when the workers heartbeat
+	return strategy.random.Choose(leastBusy, spec)
+}
+
+type inputLocalityPlacementStrategy struct {
+	fallback ContainerPlacementStrategy
+}
+
+// NewInputLocalityPlacementStrategy prefers the worker holding the most
+// artifacts the container will consume, and otherwise leaves it to the
+// fallback strategy.
+func NewInputLocalityPlacementStrategy(fallback ContainerPlacementStrategy) ContainerPlacementStrategy {
+	return &inputLocalityPlacementStrategy{
+		fallback: fallback,
+	}
+}
+
+func (strategy *inputLocalityPlacementStrategy) Choose(workers []Worker, spec ContainerSpec) (Worker, error) {
+	taskSpec, ok := spec.(TaskContainerSpec)
+	if !ok {
+		return strategy.fallback.Choose(workers, spec)
+	}
+
+	artifactCounts := map[string]int{}
+	for _, name := range taskSpec.ArtifactWorkers {
+		artifactCounts[name]++
+	}
+
+	mostLocal := []Worker{}
+	mostArtifacts := 0
+
+	for _, worker := range workers {
+		count := artifactCounts[worker.Name()]
+		if count == 0 {
+			continue
+		}
+
+		if count > mostArtifacts {
+			mostLocal = []Worker{worker}
+			mostArtifacts = count
+		} else if count == mostArtifacts {
+			mostLocal = append(mostLocal, worker)
+		}
+	}
+
+	if len(mostLocal) == 0 {
+		return strategy.fallback.Choose(workers, spec)
+	}
+
+	return strategy.fallback.Choose(mostLocal, spec)
+}
@@ -0,0 +1,157 @@
+package worker_test
+
+import (
+	. "github.com/concourse/atc/worker"
+	"github.com/concourse/atc/worker/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerPlacementStrategy", func() {
+	var (
+		workerA *fakes.FakeWorker
+		workerB *fakes.FakeWorker
+		workerC *fakes.FakeWorker
+
+		workers []Worker
+	)
+
+	BeforeEach(func() {
+		workerA = new(fakes.FakeWorker)
+		workerA.NameReturns("worker-a")
+		workerA.ActiveContainersReturns(3)
+
+		workerB = new(fakes.FakeWorker)
+		workerB.NameReturns("worker-b")
+		workerB.ActiveContainersReturns(1)
+
+		workerC = new(fakes.FakeWorker)
+		workerC.NameReturns("worker-c")
+		workerC.ActiveContainersReturns(2)
+
+		workers = []Worker{workerA, workerB, workerC}
+	})
+
+	Describe("NewPlacementStrategy", func() {
+		It("knows each strategy by name", func() {
+			for _, name := range []string{RandomPlacement, FewestContainersPlacement, InputLocalityPlacement} {
+				strategy, err := NewPlacementStrategy(name)
+				Ω(err).ShouldNot(HaveOccurred())
+				Ω(strategy).ShouldNot(BeNil())
+			}
+		})
+
+		It("fails for unknown strategies", func() {
+			_, err := NewPlacementStrategy("bogus")
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("random", func() {
+		It("spreads containers across the workers", func() {
+			strategy := NewRandomPlacementStrategy()
+
+			chosen := map[Worker]int{}
+			for i := 0; i < 100; i++ {
+				worker, err := strategy.Choose(workers, ResourceTypeContainerSpec{})
+				Ω(err).ShouldNot(HaveOccurred())
+
+				chosen[worker]++
+			}
+
+			Ω(chosen).Should(HaveLen(3))
+		})
+	})
+
+	Describe("fewest-containers", func() {
+		var strategy ContainerPlacementStrategy
+
+		BeforeEach(func() {
+			strategy = NewFewestContainersPlacementStrategy()
+		})
+
+		It("chooses the worker with the fewest active containers", func() {
+			Ω(strategy.Choose(workers, ResourceTypeContainerSpec{})).Should(Equal(workerB))
+		})
+
+		Context("when workers are equally busy", func() {
+			BeforeEach(func() {
+				workerC.ActiveContainersReturns(1)
+			})
+
+			It("spreads containers across them", func() {
+				chosen := map[Worker]int{}
+				for i := 0; i < 100; i++ {
+					worker, err := strategy.Choose(workers, ResourceTypeContainerSpec{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					chosen[worker]++
+				}
+
+				Ω(chosen).Should(HaveLen(2))
+				Ω(chosen).ShouldNot(HaveKey(workerA))
+			})
+		})
+	})
+
+	Describe("input-locality", func() {
+		var (
+			fakeFallback *fakes.FakeContainerPlacementStrategy
+
+			strategy ContainerPlacementStrategy
+		)
+
+		BeforeEach(func() {
+			fakeFallback = new(fakes.FakeContainerPlacementStrategy)
+			fakeFallback.ChooseStub = func(workers []Worker, spec ContainerSpec) (Worker, error) {
+				return workers[0], nil
+			}
+
+			strategy = NewInputLocalityPlacementStrategy(fakeFallback)
+		})
+
+		It("chooses the worker holding the most artifacts", func() {
+			spec := TaskContainerSpec{
+				ArtifactWorkers: []string{"worker-a", "worker-c", "worker-c"},
+			}
+
+			Ω(strategy.Choose(workers, spec)).Should(Equal(workerC))
+
+			localWorkers, _ := fakeFallback.ChooseArgsForCall(0)
+			Ω(localWorkers).Should(Equal([]Worker{workerC}))
+		})
+
+		It("leaves ties between workers to the fallback", func() {
+			spec := TaskContainerSpec{
+				ArtifactWorkers: []string{"worker-b", "worker-c"},
+			}
+
+			Ω(strategy.Choose(workers, spec)).Should(Equal(workerB))
+
+			localWorkers, _ := fakeFallback.ChooseArgsForCall(0)
+			Ω(localWorkers).Should(Equal([]Worker{workerB, workerC}))
+		})
+
+		It("falls back when no compatible worker holds any artifacts", func() {
+			spec := TaskContainerSpec{
+				ArtifactWorkers: []string{"some-other-worker"},
+			}
+
+			Ω(strategy.Choose(workers, spec)).Should(Equal(workerA))
+
+			fallbackWorkers, _ := fakeFallback.ChooseArgsForCall(0)
+			Ω(fallbackWorkers).Should(Equal(workers))
+		})
+
+		It("falls back for containers that do not consume artifacts", func() {
+			spec := ResourceTypeContainerSpec{Type: "some-type"}
+
+			Ω(strategy.Choose(workers, spec)).Should(Equal(workerA))
+
+			fallbackWorkers, fallbackSpec := fakeFallback.ChooseArgsForCall(0)
+			Ω(fallbackWorkers).Should(Equal(workers))
+			Ω(fallbackSpec).Should(Equal(spec))
+		})
+	})
+})
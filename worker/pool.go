@@ -3,9 +3,10 @@ package worker
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
-	"time"
+
+	"github.com/concourse/atc"
+	"github.com/pivotal-golang/lager"
 )
 
 //go:generate counterfeiter . WorkerProvider
@@ -34,16 +35,33 @@ func (err NoCompatibleWorkersError) Error() string {
 	)
 }
 
+// NoCapacityError is returned when there are compatible workers, but all of
+// them are at their container limit. Creating the container may succeed
+// once some of their containers have gone away.
+type NoCapacityError struct {
+	Spec    ContainerSpec
+	Workers []Worker
+}
+
+func (err NoCapacityError) Error() string {
+	return fmt.Sprintf(
+		"all %d workers satisfying %s are at capacity",
+		len(err.Workers),
+		err.Spec.Description(),
+	)
+}
+
 type Pool struct {
+	logger   lager.Logger
 	provider WorkerProvider
-
-	rand *rand.Rand
+	strategy ContainerPlacementStrategy
 }
 
-func NewPool(provider WorkerProvider) Client {
+func NewPool(logger lager.Logger, provider WorkerProvider, strategy ContainerPlacementStrategy) Client {
 	return &Pool{
+		logger:   logger,
 		provider: provider,
-		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+		strategy: strategy,
 	}
 }
 
@@ -59,6 +77,14 @@ func (pool *Pool) CreateContainer(id Identifier, spec ContainerSpec) (Container,
 
 	compatibleWorkers := []Worker{}
 	for _, worker := range workers {
+		if worker.State() != atc.WorkerStateRunning {
+			continue
+		}
+
+		if worker.Team() != "" && worker.Team() != id.TeamName {
+			continue
+		}
+
 		if worker.Satisfies(spec) {
 			compatibleWorkers = append(compatibleWorkers, worker)
 		}
@@ -71,9 +97,54 @@ func (pool *Pool) CreateContainer(id Identifier, spec ContainerSpec) (Container,
 		}
 	}
 
-	randomWorker := compatibleWorkers[pool.rand.Intn(len(compatibleWorkers))]
+	// reusing a worker's earlier fetch needs no new container, so it is
+	// preferred even over workers with capacity to spare
+	if s, ok := spec.(ResourceTypeContainerSpec); ok && s.CacheKey != "" {
+		for _, worker := range compatibleWorkers {
+			container, err := worker.LookupResourceCache(s.CacheKey)
+			if err == nil {
+				return container, nil
+			}
+		}
+	}
+
+	availableWorkers := []Worker{}
+	for _, worker := range compatibleWorkers {
+		if worker.HasCapacity() {
+			availableWorkers = append(availableWorkers, worker)
+		}
+	}
+
+	if len(availableWorkers) == 0 {
+		// idle resource caches only hold on to a worker's containers while
+		// nothing else needs them
+		for _, worker := range compatibleWorkers {
+			evicted, err := worker.EvictResourceCache()
+			if err != nil {
+				pool.logger.Error("failed-to-evict-resource-cache", err, lager.Data{
+					"worker": worker.Name(),
+				})
+
+				continue
+			}
+
+			if evicted {
+				return worker.CreateContainer(id, spec)
+			}
+		}
+
+		return nil, NoCapacityError{
+			Spec:    spec,
+			Workers: compatibleWorkers,
+		}
+	}
+
+	chosenWorker, err := pool.strategy.Choose(availableWorkers, spec)
+	if err != nil {
+		return nil, err
+	}
 
-	return randomWorker.CreateContainer(id, spec)
+	return chosenWorker.CreateContainer(id, spec)
 }
 
 func (pool *Pool) LookupContainer(id Identifier) (Container, error) {
@@ -148,12 +219,53 @@ func (pool *Pool) LookupContainer(id Identifier) (Container, error) {
 	}
 }
 
-type byActiveContainers []Worker
+// FindContainersForIdentifier returns the containers found on every worker
+// that could be reached. Workers that fail are logged and skipped, unless all
+// of them fail.
+func (pool *Pool) FindContainersForIdentifier(id Identifier) ([]Container, error) {
+	logger := pool.logger.Session("find-containers-for-identifier")
 
-func (cs byActiveContainers) Len() int { return len(cs) }
+	workers, err := pool.provider.Workers()
+	if err != nil {
+		return nil, err
+	}
 
-func (cs byActiveContainers) Swap(i, j int) { cs[i], cs[j] = cs[j], cs[i] }
+	wg := new(sync.WaitGroup)
+	wg.Add(len(workers))
+
+	found := make(chan []Container, len(workers))
+	errs := make(chan error, len(workers))
+
+	for _, worker := range workers {
+		go func(worker Worker) {
+			defer wg.Done()
+
+			containers, err := worker.FindContainersForIdentifier(id)
+			if err != nil {
+				logger.Error("failed-to-find-containers", err, lager.Data{
+					"worker": worker.Name(),
+				})
+
+				errs <- err
+			} else {
+				found <- containers
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+
+	close(found)
+	close(errs)
+
+	if len(workers) > 0 && len(errs) == len(workers) {
+		return nil, <-errs
+	}
+
+	allContainers := []Container{}
+	for containers := range found {
+		allContainers = append(allContainers, containers...)
+	}
 
-func (cs byActiveContainers) Less(i, j int) bool {
-	return cs[i].ActiveContainers() < cs[j].ActiveContainers()
+	return allContainers, nil
 }
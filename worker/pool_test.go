@@ -3,11 +3,13 @@ package worker_test
 import (
 	"errors"
 
+	"github.com/concourse/atc"
 	. "github.com/concourse/atc/worker"
 	"github.com/concourse/atc/worker/fakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/lager/lagertest"
 )
 
 var _ = Describe("Pool", func() {
@@ -20,7 +22,7 @@ var _ = Describe("Pool", func() {
 	BeforeEach(func() {
 		fakeProvider = new(fakes.FakeWorkerProvider)
 
-		pool = NewPool(fakeProvider)
+		pool = NewPool(lagertest.NewTestLogger("pool"), fakeProvider, NewRandomPlacementStrategy())
 	})
 
 	Describe("Create", func() {
@@ -58,6 +60,14 @@ var _ = Describe("Pool", func() {
 				workerA.ActiveContainersReturns(3)
 				workerB.ActiveContainersReturns(2)
 
+				workerA.StateReturns(atc.WorkerStateRunning)
+				workerB.StateReturns(atc.WorkerStateRunning)
+				workerC.StateReturns(atc.WorkerStateRunning)
+
+				workerA.HasCapacityReturns(true)
+				workerB.HasCapacityReturns(true)
+				workerC.HasCapacityReturns(true)
+
 				workerA.SatisfiesReturns(true)
 				workerB.SatisfiesReturns(true)
 
@@ -99,6 +109,42 @@ var _ = Describe("Pool", func() {
 				Ω(workerC.CreateContainerCallCount()).Should(BeZero())
 			})
 
+			Context("with a placement strategy", func() {
+				var fakeStrategy *fakes.FakeContainerPlacementStrategy
+
+				BeforeEach(func() {
+					fakeStrategy = new(fakes.FakeContainerPlacementStrategy)
+					fakeStrategy.ChooseReturns(workerB, nil)
+
+					pool = NewPool(lagertest.NewTestLogger("pool"), fakeProvider, fakeStrategy)
+				})
+
+				It("chooses among the compatible workers", func() {
+					Ω(fakeStrategy.ChooseCallCount()).Should(Equal(1))
+
+					workers, chosenSpec := fakeStrategy.ChooseArgsForCall(0)
+					Ω(workers).Should(Equal([]Worker{workerA, workerB}))
+					Ω(chosenSpec).Should(Equal(spec))
+				})
+
+				It("creates the container on the chosen worker", func() {
+					Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+					Ω(workerB.CreateContainerCallCount()).Should(Equal(1))
+				})
+
+				Context("when choosing a worker fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeStrategy.ChooseReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						Ω(createErr).Should(Equal(disaster))
+					})
+				})
+			})
+
 			Context("when creating the container fails", func() {
 				disaster := errors.New("nope")
 
@@ -112,6 +158,178 @@ var _ = Describe("Pool", func() {
 				})
 			})
 
+			Context("when a worker is dedicated to a team", func() {
+				BeforeEach(func() {
+					workerB.TeamReturns("some-team")
+				})
+
+				Context("and the container is for another team", func() {
+					BeforeEach(func() {
+						id.TeamName = "some-other-team"
+					})
+
+					It("does not consider the worker", func() {
+						for i := 1; i < 100; i++ {
+							_, createErr := pool.CreateContainer(id, spec)
+							Ω(createErr).ShouldNot(HaveOccurred())
+						}
+
+						Ω(workerB.SatisfiesCallCount()).Should(BeZero())
+						Ω(workerB.CreateContainerCallCount()).Should(BeZero())
+					})
+				})
+
+				Context("and the container is for the same team", func() {
+					BeforeEach(func() {
+						id.TeamName = "some-team"
+					})
+
+					It("considers the worker", func() {
+						Ω(workerB.SatisfiesCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
+			Context("when a compatible worker is at capacity", func() {
+				BeforeEach(func() {
+					workerA.HasCapacityReturns(false)
+				})
+
+				It("does not create the container on it", func() {
+					for i := 1; i < 100; i++ {
+						_, createErr := pool.CreateContainer(id, spec)
+						Ω(createErr).ShouldNot(HaveOccurred())
+					}
+
+					Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+					Ω(workerB.CreateContainerCallCount()).Should(Equal(100))
+				})
+			})
+
+			Context("when all compatible workers are at capacity", func() {
+				BeforeEach(func() {
+					workerA.HasCapacityReturns(false)
+					workerB.HasCapacityReturns(false)
+				})
+
+				It("returns a NoCapacityError", func() {
+					Ω(createErr).Should(Equal(NoCapacityError{
+						Spec:    spec,
+						Workers: []Worker{workerA, workerB},
+					}))
+				})
+
+				It("does not create the container", func() {
+					Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+					Ω(workerB.CreateContainerCallCount()).Should(BeZero())
+				})
+
+				It("tries to make room on each of them", func() {
+					Ω(workerA.EvictResourceCacheCallCount()).Should(Equal(1))
+					Ω(workerB.EvictResourceCacheCallCount()).Should(Equal(1))
+				})
+
+				Context("when a worker has an idle resource cache to evict", func() {
+					var fakeContainer *fakes.FakeContainer
+
+					BeforeEach(func() {
+						workerA.EvictResourceCacheReturns(false, errors.New("nope"))
+						workerB.EvictResourceCacheReturns(true, nil)
+
+						fakeContainer = new(fakes.FakeContainer)
+						workerB.CreateContainerReturns(fakeContainer, nil)
+					})
+
+					It("creates the container on that worker", func() {
+						Ω(createErr).ShouldNot(HaveOccurred())
+						Ω(createdContainer).Should(Equal(fakeContainer))
+
+						Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+						Ω(workerB.CreateContainerCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
+			Context("when the container may reuse an earlier fetch", func() {
+				var cachedContainer *fakes.FakeContainer
+
+				BeforeEach(func() {
+					spec = ResourceTypeContainerSpec{
+						Type:     "some-type",
+						CacheKey: "some-cache-key",
+					}
+
+					cachedContainer = new(fakes.FakeContainer)
+
+					workerA.LookupResourceCacheReturns(nil, ErrContainerNotFound)
+					workerB.LookupResourceCacheReturns(nil, ErrContainerNotFound)
+					workerC.LookupResourceCacheReturns(nil, ErrContainerNotFound)
+				})
+
+				Context("when a compatible worker has fetched it", func() {
+					BeforeEach(func() {
+						workerB.LookupResourceCacheReturns(cachedContainer, nil)
+					})
+
+					It("returns its container without creating one", func() {
+						Ω(createErr).ShouldNot(HaveOccurred())
+						Ω(createdContainer).Should(Equal(cachedContainer))
+
+						Ω(workerB.LookupResourceCacheArgsForCall(0)).Should(Equal("some-cache-key"))
+
+						Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+						Ω(workerB.CreateContainerCallCount()).Should(BeZero())
+					})
+
+					Context("even when it is at capacity", func() {
+						BeforeEach(func() {
+							workerB.HasCapacityReturns(false)
+						})
+
+						It("returns its container", func() {
+							Ω(createdContainer).Should(Equal(cachedContainer))
+						})
+					})
+				})
+
+				Context("when only an incompatible worker has fetched it", func() {
+					BeforeEach(func() {
+						workerC.LookupResourceCacheReturns(cachedContainer, nil)
+					})
+
+					It("creates a container on a compatible worker", func() {
+						Ω(createdContainer).Should(Equal(fakeContainer))
+						Ω(workerC.LookupResourceCacheCallCount()).Should(BeZero())
+					})
+				})
+
+				Context("when no worker has fetched it", func() {
+					It("creates a container on a compatible worker", func() {
+						Ω(createdContainer).Should(Equal(fakeContainer))
+						Ω(workerA.CreateContainerCallCount() + workerB.CreateContainerCallCount()).Should(Equal(1))
+					})
+				})
+			})
+
+			Context("when a worker is landing or retiring", func() {
+				BeforeEach(func() {
+					workerA.StateReturns(atc.WorkerStateLanding)
+					workerB.StateReturns(atc.WorkerStateRetiring)
+				})
+
+				It("does not consider the worker", func() {
+					Ω(workerA.SatisfiesCallCount()).Should(BeZero())
+					Ω(workerB.SatisfiesCallCount()).Should(BeZero())
+
+					Ω(workerA.CreateContainerCallCount()).Should(BeZero())
+					Ω(workerB.CreateContainerCallCount()).Should(BeZero())
+				})
+
+				It("returns a NoCompatibleWorkersError", func() {
+					Ω(createErr).Should(BeAssignableToTypeOf(NoCompatibleWorkersError{}))
+				})
+			})
+
 			Context("when no workers satisfy the spec", func() {
 				BeforeEach(func() {
 					workerA.SatisfiesReturns(false)
@@ -296,4 +514,118 @@ var _ = Describe("Pool", func() {
 			})
 		})
 	})
+
+	Describe("FindContainersForIdentifier", func() {
+		var (
+			id Identifier
+
+			foundContainers []Container
+			findErr         error
+		)
+
+		BeforeEach(func() {
+			id = Identifier{PipelineName: "some-pipeline"}
+		})
+
+		JustBeforeEach(func() {
+			foundContainers, findErr = pool.FindContainersForIdentifier(id)
+		})
+
+		Context("with multiple workers", func() {
+			var (
+				workerA *fakes.FakeWorker
+				workerB *fakes.FakeWorker
+
+				fakeContainerA *fakes.FakeContainer
+				fakeContainerB *fakes.FakeContainer
+			)
+
+			BeforeEach(func() {
+				workerA = new(fakes.FakeWorker)
+				workerB = new(fakes.FakeWorker)
+
+				fakeContainerA = new(fakes.FakeContainer)
+				fakeContainerA.HandleReturns("fake-container-a")
+
+				fakeContainerB = new(fakes.FakeContainer)
+				fakeContainerB.HandleReturns("fake-container-b")
+
+				fakeProvider.WorkersReturns([]Worker{workerA, workerB}, nil)
+			})
+
+			Context("when the workers find containers", func() {
+				BeforeEach(func() {
+					workerA.FindContainersForIdentifierReturns([]Container{fakeContainerA}, nil)
+					workerB.FindContainersForIdentifierReturns([]Container{fakeContainerB}, nil)
+				})
+
+				It("returns the containers from all of them", func() {
+					Ω(findErr).ShouldNot(HaveOccurred())
+					Ω(foundContainers).Should(ConsistOf(fakeContainerA, fakeContainerB))
+				})
+
+				It("finds by the given identifier", func() {
+					Ω(workerA.FindContainersForIdentifierCallCount()).Should(Equal(1))
+					Ω(workerB.FindContainersForIdentifierCallCount()).Should(Equal(1))
+
+					Ω(workerA.FindContainersForIdentifierArgsForCall(0)).Should(Equal(id))
+					Ω(workerB.FindContainersForIdentifierArgsForCall(0)).Should(Equal(id))
+				})
+			})
+
+			Context("when a worker fails to find containers", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					workerA.FindContainersForIdentifierReturns([]Container{fakeContainerA}, nil)
+					workerB.FindContainersForIdentifierReturns(nil, disaster)
+				})
+
+				It("returns the containers from the other workers", func() {
+					Ω(findErr).ShouldNot(HaveOccurred())
+					Ω(foundContainers).Should(ConsistOf(fakeContainerA))
+				})
+
+				It("does not release them", func() {
+					Ω(fakeContainerA.ReleaseCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when every worker fails to find containers", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					workerA.FindContainersForIdentifierReturns(nil, disaster)
+					workerB.FindContainersForIdentifierReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(findErr).Should(Equal(disaster))
+				})
+			})
+		})
+
+		Context("with no workers", func() {
+			BeforeEach(func() {
+				fakeProvider.WorkersReturns([]Worker{}, nil)
+			})
+
+			It("returns no containers", func() {
+				Ω(findErr).ShouldNot(HaveOccurred())
+				Ω(foundContainers).Should(BeEmpty())
+			})
+		})
+
+		Context("when getting the workers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeProvider.WorkersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(findErr).Should(Equal(disaster))
+			})
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"expvar"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,20 @@ var ErrUnsupportedResourceType = errors.New("unsupported resource type")
 
 const containerKeepalive = 30 * time.Second
 
+// containerTTL is how long a container lives for after it was last kept
+// alive, e.g. after the ATC that was heartbeating it goes away.
+const containerTTL = 5 * time.Minute
+
+// resourceCacheTTL is how long a container that fetched a resource lives for
+// after it was last used, so that later fetches can reuse it.
+const resourceCacheTTL = 1 * time.Hour
+
+const keepalivePropertyName = "keepalive"
+
+// ResourceCachePropertyName is set to a container's cache key once it has
+// fetched what the key identifies.
+const ResourceCachePropertyName = "concourse:resource-cache"
+
 const ephemeralPropertyName = "concourse:ephemeral"
 
 var trackedContainers = expvar.NewInt("TrackedContainers")
@@ -27,9 +42,34 @@ var trackedContainers = expvar.NewInt("TrackedContainers")
 type Worker interface {
 	Client
 
+	// Name identifies the worker, e.g. for placing containers near each
+	// other.
+	Name() string
+
 	ActiveContainers() int
 	Satisfies(ContainerSpec) bool
 
+	// HasCapacity returns false if the worker already has as many containers
+	// as it was registered to allow.
+	HasCapacity() bool
+
+	// LookupResourceCache returns a container that has fetched what the cache
+	// key identifies, or ErrContainerNotFound.
+	LookupResourceCache(cacheKey string) (Container, error)
+
+	// EvictResourceCache destroys the least recently used resource cache
+	// container that nothing is using, to make room for another container.
+	// It returns false if there was none to evict.
+	EvictResourceCache() (bool, error)
+
+	// Team returns the name of the team the worker is dedicated to, or an
+	// empty string if it is shared by all teams.
+	Team() string
+
+	// State returns the worker's lifecycle state. Only running workers are
+	// given new containers.
+	State() atc.WorkerState
+
 	Description() string
 }
 
@@ -37,33 +77,101 @@ type gardenWorker struct {
 	gardenClient garden.Client
 	clock        clock.Clock
 
+	name string
+	addr string
+
 	activeContainers int
+	maxContainers    int
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
+	team             string
+	state            atc.WorkerState
+
+	// containers created since activeContainers was heartbeated
+	created     *createdContainers
+	heartbeatAt time.Time
 }
 
 func NewGardenWorker(
 	gardenClient garden.Client,
 	clock clock.Clock,
+	name string,
+	addr string,
 	activeContainers int,
+	maxContainers int,
 	resourceTypes []atc.WorkerResourceType,
 	platform string,
 	tags []string,
+	team string,
+	state atc.WorkerState,
 ) Worker {
+	return newGardenWorker(
+		gardenClient,
+		clock,
+		name,
+		addr,
+		activeContainers,
+		maxContainers,
+		resourceTypes,
+		platform,
+		tags,
+		team,
+		state,
+		newCreatedContainers(),
+		time.Time{},
+	)
+}
+
+func newGardenWorker(
+	gardenClient garden.Client,
+	clock clock.Clock,
+	name string,
+	addr string,
+	activeContainers int,
+	maxContainers int,
+	resourceTypes []atc.WorkerResourceType,
+	platform string,
+	tags []string,
+	team string,
+	state atc.WorkerState,
+	created *createdContainers,
+	heartbeatAt time.Time,
+) *gardenWorker {
 	return &gardenWorker{
 		gardenClient: gardenClient,
 		clock:        clock,
 
+		name: name,
+		addr: addr,
+
 		activeContainers: activeContainers,
+		maxContainers:    maxContainers,
 		resourceTypes:    resourceTypes,
 		platform:         platform,
 		tags:             tags,
+		team:             team,
+		state:            state,
+
+		created:     created,
+		heartbeatAt: heartbeatAt,
 	}
 }
 
 func (worker *gardenWorker) CreateContainer(id Identifier, spec ContainerSpec) (Container, error) {
+	if s, ok := spec.(ResourceTypeContainerSpec); ok && s.CacheKey != "" {
+		container, err := worker.LookupResourceCache(s.CacheKey)
+		if err == nil {
+			return container, nil
+		}
+
+		if err != ErrContainerNotFound {
+			return nil, err
+		}
+	}
+
 	gardenSpec := garden.ContainerSpec{
+		GraceTime:  containerTTL,
 		Properties: id.gardenProperties(),
 	}
 
@@ -76,6 +184,13 @@ dance:
 			gardenSpec.Properties[ephemeralPropertyName] = "true"
 		}
 
+		if s.CacheKey != "" {
+			gardenSpec.GraceTime = resourceCacheTTL
+
+			// may be evicted once idle, which is judged by its keepalive
+			gardenSpec.Properties[keepalivePropertyName] = worker.keepalive()
+		}
+
 		for _, t := range worker.resourceTypes {
 			if t.Type == s.Type {
 				gardenSpec.RootFSPath = t.Image
@@ -93,12 +208,27 @@ dance:
 		return nil, fmt.Errorf("unknown container spec type: %T (%#v)", s, s)
 	}
 
+	if worker.maxContainers != 0 {
+		// builds placed at the same time all saw room for their container, so
+		// only as many as fit are let through
+		if !worker.created.Reserve(worker.name, worker.heartbeatAt, worker.activeContainers, worker.maxContainers) {
+			return nil, NoCapacityError{
+				Spec:    spec,
+				Workers: []Worker{worker},
+			}
+		}
+	}
+
 	gardenContainer, err := worker.gardenClient.Create(gardenSpec)
 	if err != nil {
+		if worker.maxContainers != 0 {
+			worker.created.Unreserve(worker.name, worker.heartbeatAt)
+		}
+
 		return nil, err
 	}
 
-	return newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock), nil
+	return newGardenWorkerContainer(gardenContainer, worker.gardenClient, worker.clock, worker.name, worker.addr), nil
 }
 
 func (worker *gardenWorker) LookupContainer(id Identifier) (Container, error) {
@@ -111,7 +241,7 @@ func (worker *gardenWorker) LookupContainer(id Identifier) (Container, error) {
 	case 0:
 		return nil, ErrContainerNotFound
 	case 1:
-		return newGardenWorkerContainer(containers[0], worker.gardenClient, worker.clock), nil
+		return newGardenWorkerContainer(containers[0], worker.gardenClient, worker.clock, worker.name, worker.addr), nil
 	default:
 		handles := []string{}
 
@@ -125,10 +255,127 @@ func (worker *gardenWorker) LookupContainer(id Identifier) (Container, error) {
 	}
 }
 
+func (worker *gardenWorker) FindContainersForIdentifier(id Identifier) ([]Container, error) {
+	gardenContainers, err := worker.gardenClient.Containers(id.gardenProperties())
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]Container, len(gardenContainers))
+	for i, c := range gardenContainers {
+		containers[i] = newGardenWorkerContainer(c, worker.gardenClient, worker.clock, worker.name, worker.addr)
+	}
+
+	return containers, nil
+}
+
+func (worker *gardenWorker) LookupResourceCache(cacheKey string) (Container, error) {
+	containers, err := worker.gardenClient.Containers(garden.Properties{
+		ResourceCachePropertyName: cacheKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(containers) == 0 {
+		return nil, ErrContainerNotFound
+	}
+
+	// concurrent fetches may have cached it more than once; any will do
+	container := containers[0]
+
+	// mark it as in use straight away, so that it isn't evicted before its
+	// first heartbeat
+	err = container.SetProperty(keepalivePropertyName, worker.keepalive())
+	if err != nil {
+		return nil, err
+	}
+
+	return newGardenWorkerContainer(container, worker.gardenClient, worker.clock, worker.name, worker.addr), nil
+}
+
+func (worker *gardenWorker) EvictResourceCache() (bool, error) {
+	containers, err := worker.gardenClient.Containers(garden.Properties{})
+	if err != nil {
+		return false, err
+	}
+
+	now := worker.clock.Now()
+
+	var lru garden.Container
+	var lruKeepalive int64
+
+	for _, container := range containers {
+		props, err := container.Properties()
+		if err != nil {
+			continue
+		}
+
+		if props[ResourceCachePropertyName] == "" {
+			continue
+		}
+
+		keepalive, err := strconv.ParseInt(props[keepalivePropertyName], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// containers that are in use are heartbeated every containerKeepalive
+		if now.Sub(time.Unix(keepalive, 0)) < 2*containerKeepalive {
+			continue
+		}
+
+		if lru == nil || keepalive < lruKeepalive {
+			lru = container
+			lruKeepalive = keepalive
+		}
+	}
+
+	if lru == nil {
+		return false, nil
+	}
+
+	err = worker.gardenClient.Destroy(lru.Handle())
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (worker *gardenWorker) keepalive() string {
+	return fmt.Sprintf("%d", worker.clock.Now().Unix())
+}
+
+func (worker *gardenWorker) Name() string {
+	return worker.name
+}
+
 func (worker *gardenWorker) ActiveContainers() int {
 	return worker.activeContainers
 }
 
+func (worker *gardenWorker) HasCapacity() bool {
+	if worker.maxContainers == 0 {
+		return true
+	}
+
+	// go by the heartbeated count rather than asking garden; placement checks
+	// every worker, and one that is slow to respond would hold up every build.
+	// the count may be a heartbeat behind, so add what was created since.
+	created := worker.created.Count(worker.name, worker.heartbeatAt)
+
+	return worker.activeContainers+created < worker.maxContainers
+}
+
+func (worker *gardenWorker) Team() string {
+	return worker.team
+}
+
+func (worker *gardenWorker) State() atc.WorkerState {
+	return worker.state
+}
+
 func (worker *gardenWorker) Satisfies(spec ContainerSpec) bool {
 	switch s := spec.(type) {
 	case ResourceTypeContainerSpec:
@@ -145,6 +392,10 @@ func (worker *gardenWorker) Satisfies(spec ContainerSpec) bool {
 			return false
 		}
 
+		if s.ImageWorker != "" && s.ImageWorker != worker.name {
+			return false
+		}
+
 		return worker.tagsMatch(s.Tags)
 	}
 
@@ -189,13 +440,16 @@ type gardenWorkerContainer struct {
 
 	clock clock.Clock
 
+	workerName string
+	workerAddr string
+
 	stopHeartbeating chan struct{}
 	heartbeating     *sync.WaitGroup
 
 	releaseOnce sync.Once
 }
 
-func newGardenWorkerContainer(container garden.Container, gardenClient garden.Client, clock clock.Clock) Container {
+func newGardenWorkerContainer(container garden.Container, gardenClient garden.Client, clock clock.Clock, workerName string, workerAddr string) Container {
 	workerContainer := &gardenWorkerContainer{
 		Container: container,
 
@@ -203,6 +457,9 @@ func newGardenWorkerContainer(container garden.Container, gardenClient garden.Cl
 
 		clock: clock,
 
+		workerName: workerName,
+		workerAddr: workerAddr,
+
 		heartbeating:     new(sync.WaitGroup),
 		stopHeartbeating: make(chan struct{}),
 	}
@@ -215,6 +472,57 @@ func newGardenWorkerContainer(container garden.Container, gardenClient garden.Cl
 	return workerContainer
 }
 
+func (container *gardenWorkerContainer) WorkerName() string {
+	return container.workerName
+}
+
+func (container *gardenWorkerContainer) WorkerAddr() string {
+	return container.workerAddr
+}
+
+func (container *gardenWorkerContainer) IdentifierFromProperties() (Identifier, error) {
+	props, err := container.Properties()
+	if err != nil {
+		return Identifier{}, err
+	}
+
+	return identifierFromProperties(props), nil
+}
+
+func (container *gardenWorkerContainer) TTL() (time.Duration, error) {
+	props, err := container.Properties()
+	if err != nil {
+		return 0, err
+	}
+
+	maxTTL := containerTTL
+	if props[ResourceCachePropertyName] != "" {
+		maxTTL = resourceCacheTTL
+	}
+
+	keepalive, err := strconv.ParseInt(props[keepalivePropertyName], 10, 64)
+	if err != nil {
+		// not heartbeated yet
+		return maxTTL, nil
+	}
+
+	ttl := maxTTL - container.clock.Now().Sub(time.Unix(keepalive, 0))
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
+
+func (container *gardenWorkerContainer) KeptAlive() (bool, error) {
+	props, err := container.Properties()
+	if err != nil {
+		return false, err
+	}
+
+	return props[keepalivePropertyName] != "", nil
+}
+
 func (container *gardenWorkerContainer) Destroy() error {
 	container.Release()
 	return container.gardenClient.Destroy(container.Handle())
@@ -235,7 +543,7 @@ func (container *gardenWorkerContainer) heartbeat(pacemaker clock.Ticker) {
 	for {
 		select {
 		case <-pacemaker.C():
-			container.SetProperty("keepalive", fmt.Sprintf("%d", container.clock.Now().Unix()))
+			container.SetProperty(keepalivePropertyName, fmt.Sprintf("%d", container.clock.Now().Unix()))
 		case <-container.stopHeartbeating:
 			return
 		}
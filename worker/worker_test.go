@@ -18,9 +18,11 @@ var _ = Describe("Worker", func() {
 		fakeGardenClient *gfakes.FakeClient
 		fakeClock        *fakeclock.FakeClock
 		activeContainers int
+		maxContainers    int
 		resourceTypes    []atc.WorkerResourceType
 		platform         string
 		tags             []string
+		team             string
 
 		worker Worker
 	)
@@ -29,21 +31,28 @@ var _ = Describe("Worker", func() {
 		fakeGardenClient = new(gfakes.FakeClient)
 		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 456))
 		activeContainers = 42
+		maxContainers = 0
 		resourceTypes = []atc.WorkerResourceType{
 			{Type: "some-resource", Image: "some-resource-image"},
 		}
 		platform = "some-platform"
 		tags = []string{"some", "tags"}
+		team = ""
 	})
 
 	JustBeforeEach(func() {
 		worker = NewGardenWorker(
 			fakeGardenClient,
 			fakeClock,
+			"some-worker",
+			"1.2.3.4:7777",
 			activeContainers,
+			maxContainers,
 			resourceTypes,
 			platform,
 			tags,
+			team,
+			atc.WorkerStateRunning,
 		)
 	})
 
@@ -59,7 +68,9 @@ var _ = Describe("Worker", func() {
 		BeforeEach(func() {
 			id = Identifier{
 				Name:         "some-name",
+				TeamName:     "some-team",
 				PipelineName: "some-pipeline",
+				JobName:      "some-job",
 				BuildID:      42,
 				Type:         ContainerTypeGet,
 				StepLocation: 3,
@@ -97,11 +108,14 @@ var _ = Describe("Worker", func() {
 					It("creates the container with the Garden client", func() {
 						Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
 						Ω(fakeGardenClient.CreateArgsForCall(0)).Should(Equal(garden.ContainerSpec{
+							GraceTime:  5 * time.Minute,
 							RootFSPath: "some-resource-image",
 							Privileged: true,
 							Properties: garden.Properties{
 								"concourse:type":          "get",
 								"concourse:pipeline-name": "some-pipeline",
+								"concourse:job-name":      "some-job",
+								"concourse:team-name":     "some-team",
 								"concourse:location":      "3",
 								"concourse:check-type":    "some-check-type",
 								"concourse:check-source":  "{\"some\":\"source\"}",
@@ -122,11 +136,14 @@ var _ = Describe("Worker", func() {
 						It("adds an 'ephemeral' property to the container", func() {
 							Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
 							Ω(fakeGardenClient.CreateArgsForCall(0)).Should(Equal(garden.ContainerSpec{
+								GraceTime:  5 * time.Minute,
 								RootFSPath: "some-resource-image",
 								Privileged: true,
 								Properties: garden.Properties{
 									"concourse:type":          "get",
 									"concourse:pipeline-name": "some-pipeline",
+									"concourse:job-name":      "some-job",
+									"concourse:team-name":     "some-team",
 									"concourse:location":      "3",
 									"concourse:check-type":    "some-check-type",
 									"concourse:check-source":  "{\"some\":\"source\"}",
@@ -138,7 +155,75 @@ var _ = Describe("Worker", func() {
 						})
 					})
 
+					Context("if the container may be reused by later fetches", func() {
+						BeforeEach(func() {
+							spec = ResourceTypeContainerSpec{
+								Type:     "some-resource",
+								CacheKey: "some-cache-key",
+							}
+						})
+
+						Context("when no container has fetched it yet", func() {
+							BeforeEach(func() {
+								fakeGardenClient.ContainersReturns([]garden.Container{}, nil)
+							})
+
+							It("looks for one by its cache key", func() {
+								Ω(fakeGardenClient.ContainersCallCount()).Should(Equal(1))
+								Ω(fakeGardenClient.ContainersArgsForCall(0)).Should(Equal(garden.Properties{
+									"concourse:resource-cache": "some-cache-key",
+								}))
+							})
+
+							It("creates a container that outlives its users for longer", func() {
+								Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
+								Ω(fakeGardenClient.CreateArgsForCall(0).GraceTime).Should(Equal(1 * time.Hour))
+							})
+
+							It("marks the container as in use so that it is not evicted", func() {
+								Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
+								Ω(fakeGardenClient.CreateArgsForCall(0).Properties).Should(HaveKeyWithValue("keepalive", "123"))
+							})
+						})
+
+						Context("when a container has already fetched it", func() {
+							var cachedContainer *gfakes.FakeContainer
+
+							BeforeEach(func() {
+								cachedContainer = new(gfakes.FakeContainer)
+								cachedContainer.HandleReturns("cached-handle")
+
+								fakeGardenClient.ContainersReturns([]garden.Container{cachedContainer}, nil)
+							})
+
+							It("returns it instead of creating one", func() {
+								Ω(createErr).ShouldNot(HaveOccurred())
+								Ω(createdContainer.Handle()).Should(Equal("cached-handle"))
+
+								Ω(fakeGardenClient.CreateCallCount()).Should(BeZero())
+							})
+						})
+
+						Context("when looking for one fails", func() {
+							disaster := errors.New("nope")
+
+							BeforeEach(func() {
+								fakeGardenClient.ContainersReturns(nil, disaster)
+							})
+
+							It("returns the error without creating one", func() {
+								Ω(createErr).Should(Equal(disaster))
+								Ω(fakeGardenClient.CreateCallCount()).Should(BeZero())
+							})
+						})
+					})
+
 					Describe("the created container", func() {
+						It("knows which worker it lives on", func() {
+							Ω(createdContainer.WorkerName()).Should(Equal("some-worker"))
+							Ω(createdContainer.WorkerAddr()).Should(Equal("1.2.3.4:7777"))
+						})
+
 						It("can be destroyed", func() {
 							err := createdContainer.Destroy()
 							Ω(err).ShouldNot(HaveOccurred())
@@ -229,11 +314,14 @@ var _ = Describe("Worker", func() {
 				It("creates the container with the Garden client", func() {
 					Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
 					Ω(fakeGardenClient.CreateArgsForCall(0)).Should(Equal(garden.ContainerSpec{
+						GraceTime:  5 * time.Minute,
 						RootFSPath: "some-image",
 						Privileged: true,
 						Properties: garden.Properties{
 							"concourse:type":          "get",
 							"concourse:pipeline-name": "some-pipeline",
+							"concourse:job-name":      "some-job",
+							"concourse:team-name":     "some-team",
 							"concourse:location":      "3",
 							"concourse:check-type":    "some-check-type",
 							"concourse:check-source":  "{\"some\":\"source\"}",
@@ -335,6 +423,11 @@ var _ = Describe("Worker", func() {
 			})
 
 			Describe("the found container", func() {
+				It("knows which worker it lives on", func() {
+					Ω(foundContainer.WorkerName()).Should(Equal("some-worker"))
+					Ω(foundContainer.WorkerAddr()).Should(Equal("1.2.3.4:7777"))
+				})
+
 				It("can be destroyed", func() {
 					err := foundContainer.Destroy()
 					Ω(err).ShouldNot(HaveOccurred())
@@ -423,6 +516,436 @@ var _ = Describe("Worker", func() {
 		})
 	})
 
+	Describe("FindContainersForIdentifier", func() {
+		var (
+			id Identifier
+
+			foundContainers []Container
+			findErr         error
+		)
+
+		BeforeEach(func() {
+			id = Identifier{PipelineName: "some-pipeline"}
+		})
+
+		JustBeforeEach(func() {
+			foundContainers, findErr = worker.FindContainersForIdentifier(id)
+		})
+
+		Context("when containers are found", func() {
+			var (
+				fakeContainer  *gfakes.FakeContainer
+				bonusContainer *gfakes.FakeContainer
+			)
+
+			BeforeEach(func() {
+				fakeContainer = new(gfakes.FakeContainer)
+				fakeContainer.HandleReturns("some-handle")
+
+				bonusContainer = new(gfakes.FakeContainer)
+				bonusContainer.HandleReturns("some-other-handle")
+
+				fakeGardenClient.ContainersReturns([]garden.Container{fakeContainer, bonusContainer}, nil)
+			})
+
+			AfterEach(func() {
+				for _, c := range foundContainers {
+					c.Release()
+				}
+			})
+
+			It("succeeds", func() {
+				Ω(findErr).ShouldNot(HaveOccurred())
+			})
+
+			It("looks for containers with matching properties via the Garden client", func() {
+				Ω(fakeGardenClient.ContainersCallCount()).Should(Equal(1))
+				Ω(fakeGardenClient.ContainersArgsForCall(0)).Should(Equal(garden.Properties{
+					"concourse:pipeline-name": "some-pipeline",
+				}))
+			})
+
+			It("returns all of them", func() {
+				Ω(foundContainers).Should(HaveLen(2))
+				Ω(foundContainers[0].Handle()).Should(Equal("some-handle"))
+				Ω(foundContainers[1].Handle()).Should(Equal("some-other-handle"))
+				Ω(foundContainers[0].WorkerName()).Should(Equal("some-worker"))
+			})
+
+			Describe("a found container", func() {
+				BeforeEach(func() {
+					fakeContainer.PropertiesReturns(garden.Properties{
+						"concourse:type":          "get",
+						"concourse:pipeline-name": "some-pipeline",
+						"concourse:job-name":      "some-job",
+						"concourse:team-name":     "some-team",
+						"concourse:location":      "3",
+						"concourse:check-type":    "some-check-type",
+						"concourse:check-source":  "{\"some\":\"source\"}",
+						"concourse:name":          "some-name",
+						"concourse:build-id":      "42",
+						"keepalive":               "63", // a minute ago
+					}, nil)
+				})
+
+				It("decodes its identifier from its properties", func() {
+					Ω(foundContainers[0].IdentifierFromProperties()).Should(Equal(Identifier{
+						Name:         "some-name",
+						TeamName:     "some-team",
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						BuildID:      42,
+						Type:         ContainerTypeGet,
+						StepLocation: 3,
+						CheckType:    "some-check-type",
+						CheckSource:  atc.Source{"some": "source"},
+					}))
+				})
+
+				It("has a TTL counting down from when it was last kept alive", func() {
+					Ω(foundContainers[0].TTL()).Should(BeNumerically("~", 4*time.Minute, time.Second))
+				})
+
+				It("has been kept alive", func() {
+					Ω(foundContainers[0].KeptAlive()).Should(BeTrue())
+				})
+
+				Context("when it holds a fetch that may be reused", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertiesReturns(garden.Properties{
+							"concourse:resource-cache": "some-cache-key",
+							"keepalive":                "63", // a minute ago
+						}, nil)
+					})
+
+					It("has a longer TTL", func() {
+						Ω(foundContainers[0].TTL()).Should(BeNumerically("~", 59*time.Minute, time.Second))
+					})
+				})
+
+				Context("when it has not been kept alive yet", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertiesReturns(garden.Properties{}, nil)
+					})
+
+					It("has the full TTL", func() {
+						Ω(foundContainers[0].TTL()).Should(Equal(5 * time.Minute))
+					})
+
+					It("has not been kept alive", func() {
+						Ω(foundContainers[0].KeptAlive()).Should(BeFalse())
+					})
+				})
+
+				Context("when it has not been kept alive for longer than its TTL", func() {
+					BeforeEach(func() {
+						fakeContainer.PropertiesReturns(garden.Properties{
+							"keepalive": "0",
+						}, nil)
+
+						fakeClock.Increment(10 * time.Minute)
+					})
+
+					It("has no TTL left", func() {
+						Ω(foundContainers[0].TTL()).Should(BeZero())
+					})
+				})
+
+				Context("when getting its properties fails", func() {
+					disaster := errors.New("nope")
+
+					BeforeEach(func() {
+						fakeContainer.PropertiesReturns(nil, disaster)
+					})
+
+					It("returns the error", func() {
+						_, err := foundContainers[0].IdentifierFromProperties()
+						Ω(err).Should(Equal(disaster))
+
+						_, err = foundContainers[0].TTL()
+						Ω(err).Should(Equal(disaster))
+
+						_, err = foundContainers[0].KeptAlive()
+						Ω(err).Should(Equal(disaster))
+					})
+				})
+			})
+		})
+
+		Context("when no containers are found", func() {
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns([]garden.Container{}, nil)
+			})
+
+			It("returns no containers", func() {
+				Ω(findErr).ShouldNot(HaveOccurred())
+				Ω(foundContainers).Should(BeEmpty())
+			})
+		})
+
+		Context("when finding the containers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(findErr).Should(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("LookupResourceCache", func() {
+		var (
+			foundContainer Container
+			lookupErr      error
+		)
+
+		JustBeforeEach(func() {
+			foundContainer, lookupErr = worker.LookupResourceCache("some-cache-key")
+		})
+
+		It("looks for containers by their cache key", func() {
+			Ω(fakeGardenClient.ContainersCallCount()).Should(Equal(1))
+			Ω(fakeGardenClient.ContainersArgsForCall(0)).Should(Equal(garden.Properties{
+				"concourse:resource-cache": "some-cache-key",
+			}))
+		})
+
+		Context("when containers are found", func() {
+			var fakeContainer *gfakes.FakeContainer
+
+			BeforeEach(func() {
+				fakeContainer = new(gfakes.FakeContainer)
+				fakeContainer.HandleReturns("some-handle")
+
+				fakeOtherContainer := new(gfakes.FakeContainer)
+				fakeOtherContainer.HandleReturns("some-other-handle")
+
+				fakeGardenClient.ContainersReturns([]garden.Container{fakeContainer, fakeOtherContainer}, nil)
+			})
+
+			It("returns one of them", func() {
+				Ω(lookupErr).ShouldNot(HaveOccurred())
+				Ω(foundContainer.Handle()).Should(Equal("some-handle"))
+				Ω(foundContainer.WorkerName()).Should(Equal("some-worker"))
+			})
+
+			It("marks it as in use so that it is not evicted", func() {
+				Ω(fakeContainer.SetPropertyCallCount()).Should(Equal(1))
+
+				name, value := fakeContainer.SetPropertyArgsForCall(0)
+				Ω(name).Should(Equal("keepalive"))
+				Ω(value).Should(Equal("123"))
+			})
+
+			Context("when marking it fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeContainer.SetPropertyReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(lookupErr).Should(Equal(disaster))
+				})
+			})
+		})
+
+		Context("when no containers are found", func() {
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns([]garden.Container{}, nil)
+			})
+
+			It("returns ErrContainerNotFound", func() {
+				Ω(lookupErr).Should(Equal(ErrContainerNotFound))
+			})
+		})
+
+		Context("when listing containers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(lookupErr).Should(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("EvictResourceCache", func() {
+		var (
+			evicted  bool
+			evictErr error
+		)
+
+		newContainer := func(handle string, props garden.Properties) *gfakes.FakeContainer {
+			container := new(gfakes.FakeContainer)
+			container.HandleReturns(handle)
+			container.PropertiesReturns(props, nil)
+			return container
+		}
+
+		JustBeforeEach(func() {
+			evicted, evictErr = worker.EvictResourceCache()
+		})
+
+		Context("when the worker has idle resource caches", func() {
+			BeforeEach(func() {
+				unreadable := new(gfakes.FakeContainer)
+				unreadable.PropertiesReturns(nil, errors.New("nope"))
+
+				// the clock is at 123
+				fakeGardenClient.ContainersReturns([]garden.Container{
+					newContainer("not-a-cache", garden.Properties{"keepalive": "1"}),
+					newContainer("in-use-cache", garden.Properties{"concourse:resource-cache": "a", "keepalive": "100"}),
+					newContainer("idle-cache", garden.Properties{"concourse:resource-cache": "b", "keepalive": "20"}),
+					newContainer("least-recently-used-cache", garden.Properties{"concourse:resource-cache": "c", "keepalive": "10"}),
+					newContainer("unmarked-cache", garden.Properties{"concourse:resource-cache": "d"}),
+					unreadable,
+				}, nil)
+			})
+
+			It("destroys the least recently used one", func() {
+				Ω(evictErr).ShouldNot(HaveOccurred())
+				Ω(evicted).Should(BeTrue())
+
+				Ω(fakeGardenClient.DestroyCallCount()).Should(Equal(1))
+				Ω(fakeGardenClient.DestroyArgsForCall(0)).Should(Equal("least-recently-used-cache"))
+			})
+
+			Context("when destroying it fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeGardenClient.DestroyReturns(disaster)
+				})
+
+				It("returns the error", func() {
+					Ω(evictErr).Should(Equal(disaster))
+					Ω(evicted).Should(BeFalse())
+				})
+			})
+		})
+
+		Context("when all of the worker's resource caches are in use", func() {
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns([]garden.Container{
+					newContainer("not-a-cache", garden.Properties{"keepalive": "1"}),
+					newContainer("in-use-cache", garden.Properties{"concourse:resource-cache": "a", "keepalive": "100"}),
+				}, nil)
+			})
+
+			It("does not destroy anything", func() {
+				Ω(evictErr).ShouldNot(HaveOccurred())
+				Ω(evicted).Should(BeFalse())
+
+				Ω(fakeGardenClient.DestroyCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when listing containers fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeGardenClient.ContainersReturns(nil, disaster)
+			})
+
+			It("returns the error", func() {
+				Ω(evictErr).Should(Equal(disaster))
+			})
+		})
+	})
+
+	Describe("HasCapacity", func() {
+		Context("when the worker has no container limit", func() {
+			BeforeEach(func() {
+				maxContainers = 0
+			})
+
+			It("returns true", func() {
+				Ω(worker.HasCapacity()).Should(BeTrue())
+			})
+
+			It("does not count the worker's containers", func() {
+				worker.HasCapacity()
+				Ω(fakeGardenClient.ContainersCallCount()).Should(BeZero())
+			})
+		})
+
+		Context("when the worker has a container limit", func() {
+			BeforeEach(func() {
+				maxContainers = 2
+			})
+
+			Context("when the worker registered with fewer containers", func() {
+				BeforeEach(func() {
+					activeContainers = 1
+				})
+
+				It("returns true", func() {
+					Ω(worker.HasCapacity()).Should(BeTrue())
+				})
+
+				It("does not ask garden for the worker's containers", func() {
+					worker.HasCapacity()
+					Ω(fakeGardenClient.ContainersCallCount()).Should(BeZero())
+				})
+			})
+
+			Context("when the worker registered with as many containers", func() {
+				BeforeEach(func() {
+					activeContainers = 2
+				})
+
+				It("returns false", func() {
+					Ω(worker.HasCapacity()).Should(BeFalse())
+				})
+			})
+
+			Context("when containers have been created since the worker registered", func() {
+				BeforeEach(func() {
+					activeContainers = 1
+
+					fakeGardenClient.CreateReturns(new(gfakes.FakeContainer), nil)
+				})
+
+				It("counts them towards the limit", func() {
+					_, err := worker.CreateContainer(Identifier{}, TaskContainerSpec{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					Ω(worker.HasCapacity()).Should(BeFalse())
+				})
+
+				It("does not create any past the limit", func() {
+					_, err := worker.CreateContainer(Identifier{}, TaskContainerSpec{})
+					Ω(err).ShouldNot(HaveOccurred())
+
+					_, err = worker.CreateContainer(Identifier{}, TaskContainerSpec{})
+					Ω(err).Should(BeAssignableToTypeOf(NoCapacityError{}))
+
+					Ω(fakeGardenClient.CreateCallCount()).Should(Equal(1))
+				})
+
+				Context("when creating one fails", func() {
+					BeforeEach(func() {
+						fakeGardenClient.CreateReturns(nil, errors.New("nope"))
+					})
+
+					It("does not count it", func() {
+						_, err := worker.CreateContainer(Identifier{}, TaskContainerSpec{})
+						Ω(err).Should(HaveOccurred())
+
+						Ω(worker.HasCapacity()).Should(BeTrue())
+					})
+				})
+			})
+		})
+	})
+
 	Describe("Satisfies", func() {
 		Context("with a TaskContainerSpec", func() {
 			var (
@@ -473,6 +996,28 @@ var _ = Describe("Worker", func() {
 					})
 				})
 
+				Context("when the image was fetched onto the worker", func() {
+					BeforeEach(func() {
+						spec.Tags = []string{"some", "tags"}
+						spec.ImageWorker = "some-worker"
+					})
+
+					It("returns true", func() {
+						Ω(satisfies).Should(BeTrue())
+					})
+				})
+
+				Context("when the image was fetched onto another worker", func() {
+					BeforeEach(func() {
+						spec.Tags = []string{"some", "tags"}
+						spec.ImageWorker = "some-other-worker"
+					})
+
+					It("returns false", func() {
+						Ω(satisfies).Should(BeFalse())
+					})
+				})
+
 				Context("when some of the requested tags are present", func() {
 					BeforeEach(func() {
 						spec.Tags = []string{"some"}